@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-htlc-coordinator-besu
+package htlccoordinatorbesu
 
 import (
 	"net/http"
@@ -7,7 +7,7 @@ Testing DefaultApiService
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech);
 
-package cactus-plugin-htlc-coordinator-besu
+package htlccoordinatorbesu
 
 import (
 	"context"
@@ -17,7 +17,7 @@ import (
 	openapiclient "github.com/hyperledger/cactus-plugin-htlc-coordinator-besu/src/main/go/generated/openapi/go-client"
 )
 
-func Test_cactus-plugin-htlc-coordinator-besu_DefaultApiService(t *testing.T) {
+func Test_htlccoordinatorbesu_DefaultApiService(t *testing.T) {
 
 	configuration := openapiclient.NewConfiguration()
 	apiClient := openapiclient.NewAPIClient(configuration)
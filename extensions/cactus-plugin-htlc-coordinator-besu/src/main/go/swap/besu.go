@@ -0,0 +1,211 @@
+package swap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Error is an error response of the API server
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("API server responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API server responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// besuLedger runs the operations of a Besu leg through the HTLC and coordinator plugins
+type besuLedger struct {
+	leg    *BesuLeg
+	own    bool
+	expiry int64
+	client *http.Client
+}
+
+// id returns the ID the HTLC contract gives the lock: the hash of its sender, receiver, amount, hash and expiration
+func (b *besuLedger) id(h *hashLock) string {
+	words := [][]byte{
+		addressWord(b.leg.sender(b.own)),
+		addressWord(b.leg.receiver(b.own)),
+		uintWord(b.leg.Amount),
+		h.keccak[:],
+		uintWord(uint64(b.expiry)),
+	}
+	if b.leg.HtlcPackage == BesuERC20 {
+		words = append(words, addressWord(b.leg.TokenAddress))
+	}
+	hash := sha3.NewLegacyKeccak256()
+	for _, word := range words {
+		hash.Write(word)
+	}
+	return "0x" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// lock locks the Own asset, deploying an HTLC contract with OwnHtlcV1 if no contract address is set
+func (b *besuLedger) lock(ctx context.Context, h *hashLock) (string, error) {
+	response := &InvokeContractV1Response{}
+	var err error
+	if b.leg.ContractAddress == "" {
+		err = b.post(ctx, OwnHtlcPath, &OwnHTLCRequest{
+			HtlcPackage:           b.leg.HtlcPackage,
+			ConnectorInstanceId:   b.leg.ConnectorInstanceID,
+			KeychainId:            b.leg.KeychainID,
+			ConstructorArgs:       []interface{}{},
+			Web3SigningCredential: b.leg.SigningCredential,
+			InputAmount:           b.leg.Amount,
+			OutputAmount:          b.leg.OutputAmount,
+			Expiration:            b.expiry,
+			HashLock:              h.besuHash(),
+			TokenAddress:          b.leg.TokenAddress,
+			Receiver:              b.leg.receiver(true),
+			OutputNetwork:         b.leg.OutputNetwork,
+			OutputAddress:         b.leg.OutputAddress,
+			Gas:                   b.leg.Gas,
+		}, response)
+	} else {
+		err = b.post(ctx, htlcPath(b.leg.HtlcPackage, "new-contract"), &NewContractRequest{
+			ContractAddress:       b.leg.ContractAddress,
+			InputAmount:           b.leg.Amount,
+			OutputAmount:          b.leg.OutputAmount,
+			Expiration:            b.expiry,
+			HashLock:              h.besuHash(),
+			TokenAddress:          b.leg.TokenAddress,
+			Receiver:              b.leg.receiver(true),
+			OutputNetwork:         b.leg.OutputNetwork,
+			OutputAddress:         b.leg.OutputAddress,
+			ConnectorId:           b.leg.ConnectorInstanceID,
+			KeychainId:            b.leg.KeychainID,
+			Web3SigningCredential: b.leg.SigningCredential,
+			Gas:                   b.leg.Gas,
+		}, response)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !response.Success {
+		return "", fmt.Errorf("lock transaction failed")
+	}
+	return b.id(h), nil
+}
+
+// status returns the status of a lock with CounterpartyHtlcV1
+func (b *besuLedger) status(ctx context.Context, id string) (HTLCStatus, error) {
+	response := &InvokeContractV1Response{}
+	err := b.post(ctx, CounterpartyHtlcPath, &CounterpartyHTLCRequest{
+		HtlcPackage:           b.leg.HtlcPackage,
+		ConnectorInstanceId:   b.leg.ConnectorInstanceID,
+		KeychainId:            b.leg.KeychainID,
+		HtlcId:                id,
+		Web3SigningCredential: b.leg.SigningCredential,
+	}, response)
+	if err != nil {
+		return 0, err
+	}
+	return parseStatus(response.CallOutput)
+}
+
+// verify has nothing to check, as the ID of a lock is the hash of its hash lock and expiration, among others
+func (b *besuLedger) verify(ctx context.Context, id string, h *hashLock) error {
+	return nil
+}
+
+// claim withdraws the Counterparty asset with WithdrawCounterpartyV1
+func (b *besuLedger) claim(ctx context.Context, id string, h *hashLock) error {
+	response := &InvokeContractV1Response{}
+	err := b.post(ctx, WithdrawCounterpartyPath, &WithdrawCounterpartyRequest{
+		HtlcPackage:           b.leg.HtlcPackage,
+		ConnectorInstanceId:   b.leg.ConnectorInstanceID,
+		KeychainId:            b.leg.KeychainID,
+		Web3SigningCredential: b.leg.SigningCredential,
+		HtlcId:                id,
+		Secret:                h.besuSecret(),
+		Gas:                   b.leg.Gas,
+	}, response)
+	if err == nil && !response.Success {
+		err = fmt.Errorf("withdraw transaction failed")
+	}
+	return err
+}
+
+// refund refunds the expired Own asset with RefundV1
+func (b *besuLedger) refund(ctx context.Context, id string) error {
+	response := &InvokeContractV1Response{}
+	err := b.post(ctx, htlcPath(b.leg.HtlcPackage, "refund"), &RefundRequest{
+		Id:                    id,
+		Web3SigningCredential: b.leg.SigningCredential,
+		ConnectorId:           b.leg.ConnectorInstanceID,
+		KeychainId:            b.leg.KeychainID,
+		Gas:                   b.leg.Gas,
+	}, response)
+	if err == nil && !response.Success {
+		err = fmt.Errorf("refund transaction failed")
+	}
+	return err
+}
+
+// post posts a request to an endpoint of the API server and decodes the response
+func (b *besuLedger) post(ctx context.Context, path string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(b.leg.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := b.client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		apiError := &Error{}
+		if json.Unmarshal(responseBody, apiError) != nil || apiError.Message == "" {
+			apiError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		apiError.StatusCode = httpResponse.StatusCode
+		return apiError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
+
+// addressWord ABI encodes a hex address
+func addressWord(address string) []byte {
+	word := make([]byte, 32)
+	decoded, _ := hex.DecodeString(strings.TrimPrefix(strings.ToLower(address), "0x"))
+	if len(decoded) <= 32 {
+		copy(word[32-len(decoded):], decoded)
+	}
+	return word
+}
+
+// uintWord ABI encodes an unsigned integer
+func uintWord(value uint64) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], value)
+	return word
+}
@@ -0,0 +1,101 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	assetmanager "github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/asset-manager"
+)
+
+// fabricLedger runs the operations of a Fabric leg with the asset-manager of the Weaver SDK
+type fabricLedger struct {
+	leg      *FabricLeg
+	expiry   int64
+	contract assetmanager.GatewayContract
+}
+
+// id returns the ID of the lock of a fungible Counterparty asset; the locks of non-fungible assets are found by asset
+func (f *fabricLedger) id(h *hashLock) string {
+	return f.leg.ContractID
+}
+
+// lock locks the Own asset, returning the contract ID of the lock
+func (f *fabricLedger) lock(ctx context.Context, h *hashLock) (string, error) {
+	if f.leg.AssetID == "" {
+		return assetmanager.CreateFungibleHTLC(f.contract, f.leg.AssetType, f.leg.NumUnits, f.leg.RecipientECert,
+			h.fabricHash(), uint64(f.expiry))
+	}
+	return assetmanager.CreateHTLC(f.contract, f.leg.AssetType, f.leg.AssetID, f.leg.RecipientECert, h.fabricHash(),
+		uint64(f.expiry))
+}
+
+/*
+status tells whether the asset is locked. The asset chaincode only tells whether an asset is locked and not expired, so
+the status is HTLCActive or HTLCInvalid.
+*/
+func (f *fabricLedger) status(ctx context.Context, id string) (HTLCStatus, error) {
+	var locked string
+	var err error
+	if f.leg.AssetID == "" {
+		if id == "" {
+			return HTLCInvalid, nil
+		}
+		locked, err = assetmanager.IsFungibleAssetLockedInHTLC(f.contract, id)
+	} else {
+		locked, err = assetmanager.IsAssetLockedInHTLC(f.contract, f.leg.AssetType, f.leg.AssetID, f.leg.RecipientECert,
+			f.leg.LockerECert)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(locked) == "true" {
+		return HTLCActive, nil
+	}
+	return HTLCInvalid, nil
+}
+
+// verify checks the hash the Counterparty asset is locked with, as its lock may be for any hash
+func (f *fabricLedger) verify(ctx context.Context, id string, h *hashLock) error {
+	var hash string
+	var err error
+	if f.leg.AssetID == "" {
+		hash, err = assetmanager.GetHTLCHashByContractId(f.contract, id)
+	} else {
+		hash, err = assetmanager.GetHTLCHash(f.contract, f.leg.AssetType, f.leg.AssetID, f.leg.RecipientECert,
+			f.leg.LockerECert)
+	}
+	if err != nil {
+		return err
+	}
+	if hash != h.fabricHash() {
+		return errHashMismatch
+	}
+	return nil
+}
+
+// claim claims the Counterparty asset
+func (f *fabricLedger) claim(ctx context.Context, id string, h *hashLock) error {
+	var err error
+	if f.leg.AssetID == "" {
+		_, err = assetmanager.ClaimFungibleAssetInHTLC(f.contract, id, h.fabricPreimage())
+	} else {
+		_, err = assetmanager.ClaimAssetInHTLC(f.contract, f.leg.AssetType, f.leg.AssetID, f.leg.LockerECert,
+			h.fabricPreimage())
+	}
+	return err
+}
+
+// refund reclaims the expired Own asset
+func (f *fabricLedger) refund(ctx context.Context, id string) error {
+	var err error
+	if f.leg.AssetID == "" {
+		if id == "" {
+			return errors.New("contract ID of the lock unknown")
+		}
+		_, err = assetmanager.ReclaimFungibleAssetInHTLC(f.contract, id)
+	} else {
+		_, err = assetmanager.ReclaimAssetInHTLC(f.contract, f.leg.AssetType, f.leg.AssetID, f.leg.RecipientECert)
+	}
+	return err
+}
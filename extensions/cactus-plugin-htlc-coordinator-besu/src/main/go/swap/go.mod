@@ -0,0 +1,23 @@
+module github.com/hyperledger/cactus-plugin-htlc-coordinator-besu/src/main/go/swap
+
+go 1.26
+
+require (
+	github.com/golang/protobuf v1.5.4
+	github.com/hyperledger/cactus-plugin-htlc-coordinator-besu/src/main/go/generated/openapi/go-client v0.0.0
+	github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3 v3.0.1
+	github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3 v3.0.0
+	golang.org/x/crypto v0.54.0
+)
+
+require (
+	github.com/sirupsen/logrus v1.9.4 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+replace (
+	github.com/hyperledger/cactus-plugin-htlc-coordinator-besu/src/main/go/generated/openapi/go-client => ../generated/openapi/go-client
+	github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3 => ../../../../../../weaver/common/protos-go
+	github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3 => ../../../../../../weaver/sdks/fabric/go-sdk
+)
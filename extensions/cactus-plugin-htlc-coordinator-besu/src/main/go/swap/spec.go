@@ -0,0 +1,152 @@
+package swap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/*
+Spec declares a swap. The orchestrator runs it as the initiator: it locks the Own asset for the counterparty, waits for
+the counterparty to lock the Counterparty asset with the same hash, and claims it, revealing the secret the
+counterparty then claims the Own asset with.
+*/
+type Spec struct {
+	// Identifies the swap, e.g. in the Store
+	ID string `json:"id"`
+	// Asset locked by the orchestrator, to be claimed by the counterparty
+	Own Leg `json:"own"`
+	// Asset locked by the counterparty, to be claimed by the orchestrator
+	Counterparty Leg `json:"counterparty"`
+}
+
+// Leg is an asset locked on a network until an expiration. Exactly one of Besu and Fabric is set.
+type Leg struct {
+	Besu   *BesuLeg   `json:"besu,omitempty"`
+	Fabric *FabricLeg `json:"fabric,omitempty"`
+	// Time the lock expires at. The lock of the counterparty must expire first, so that it can claim the Own asset.
+	Expiration time.Time `json:"expiration"`
+}
+
+// BesuLeg is an amount locked in an HTLC contract of a Besu network, through the HTLC and coordinator plugins
+type BesuLeg struct {
+	// URL of the API server hosting the plugins, e.g. http://localhost:4000
+	URL         string      `json:"url"`
+	HtlcPackage HtlcPackage `json:"htlcPackage"`
+	// Instance ID of the Besu connector
+	ConnectorInstanceID string `json:"connectorInstanceId"`
+	// ID of the keychain holding the HTLC contract
+	KeychainID string `json:"keychainId"`
+	// Account of the orchestrator on the network, locking the Own asset or withdrawing the Counterparty one
+	SigningCredential Web3SigningCredential `json:"signingCredential"`
+	/*
+		Address of the HTLC contract the Own asset is locked in; a contract is deployed with OwnHtlcV1 if empty. Not used
+		for the Counterparty asset.
+	*/
+	ContractAddress string `json:"contractAddress,omitempty"`
+	// Account locking the asset; defaults to the account of the signing credential for the Own asset
+	Sender string `json:"sender,omitempty"`
+	// Account the asset is locked for; defaults to the account of the signing credential for the Counterparty asset
+	Receiver string `json:"receiver,omitempty"`
+	// Amount locked, in wei or in tokens
+	Amount uint64 `json:"amount"`
+	// Address of the ERC20 token, for the BESU_ERC20 package
+	TokenAddress string `json:"tokenAddress,omitempty"`
+	// Informative outputAmount, outputNetwork and outputAddress of the HTLC
+	OutputAmount  uint64   `json:"outputAmount,omitempty"`
+	OutputNetwork string   `json:"outputNetwork,omitempty"`
+	OutputAddress string   `json:"outputAddress,omitempty"`
+	Gas           *float64 `json:"gas,omitempty"`
+}
+
+// FabricLeg is an asset locked by the asset chaincode of a Fabric network, with the Weaver asset-manager
+type FabricLeg struct {
+	// Name of the network, the key of the contract of its asset chaincode in the Fabric networks of the Orchestrator
+	Network   string `json:"network"`
+	AssetType string `json:"assetType"`
+	// ID of a non-fungible asset; NumUnits are locked if empty
+	AssetID  string `json:"assetId,omitempty"`
+	NumUnits uint64 `json:"numUnits,omitempty"`
+	// Base64 encoded certificates of the locker and of the recipient of the asset
+	LockerECert    string `json:"lockerECert"`
+	RecipientECert string `json:"recipientECert"`
+	/*
+		ID of the lock of fungible Counterparty assets, which only the counterparty learns when locking. Not used for
+		the Own asset.
+	*/
+	ContractID string `json:"contractId,omitempty"`
+}
+
+// maxAmount is the largest amount the plugins take, as they pass the amounts as JavaScript numbers
+const maxAmount = 1<<53 - 1
+
+// Validate checks that a swap is complete and its expirations consistent
+func (s *Spec) Validate() error {
+	if s.ID == "" {
+		return errors.New("swap ID expected")
+	}
+	for _, leg := range []struct {
+		name string
+		*Leg
+	}{{"own", &s.Own}, {"counterparty", &s.Counterparty}} {
+		err := leg.validate(leg.Leg == &s.Own)
+		if err != nil {
+			return fmt.Errorf("%s asset: %w", leg.name, err)
+		}
+	}
+	if !s.Own.Expiration.After(s.Counterparty.Expiration) {
+		return errors.New("the own asset must be locked longer than the counterparty asset")
+	}
+	return nil
+}
+
+func (l *Leg) validate(own bool) error {
+	if (l.Besu == nil) == (l.Fabric == nil) {
+		return errors.New("either a Besu or a Fabric asset expected")
+	}
+	if l.Expiration.IsZero() {
+		return errors.New("expiration expected")
+	}
+	if l.Besu != nil {
+		b := l.Besu
+		switch {
+		case b.URL == "" || b.ConnectorInstanceID == "" || b.KeychainID == "":
+			return errors.New("URL, connector instance ID and keychain ID expected")
+		case b.HtlcPackage != Besu && b.HtlcPackage != BesuERC20:
+			return fmt.Errorf("unknown HTLC package %q", b.HtlcPackage)
+		case b.HtlcPackage == BesuERC20 && b.TokenAddress == "":
+			return errors.New("token address expected")
+		case b.Amount == 0 || b.Amount > maxAmount:
+			return fmt.Errorf("amount must be between 1 and %d", uint64(maxAmount))
+		case b.sender(own) == "" || b.receiver(own) == "":
+			return errors.New("sender and receiver expected")
+		}
+		return nil
+	}
+	f := l.Fabric
+	switch {
+	case f.Network == "" || f.AssetType == "":
+		return errors.New("network and asset type expected")
+	case f.AssetID == "" && f.NumUnits == 0:
+		return errors.New("asset ID or number of units expected")
+	case f.LockerECert == "" || f.RecipientECert == "":
+		return errors.New("locker and recipient certificates expected")
+	case !own && f.AssetID == "" && f.ContractID == "":
+		return errors.New("contract ID of the fungible asset lock expected")
+	}
+	return nil
+}
+
+func (b *BesuLeg) sender(own bool) string {
+	if b.Sender == "" && own {
+		return b.SigningCredential.EthAccount
+	}
+	return b.Sender
+}
+
+func (b *BesuLeg) receiver(own bool) string {
+	if b.Receiver == "" && !own {
+		return b.SigningCredential.EthAccount
+	}
+	return b.Receiver
+}
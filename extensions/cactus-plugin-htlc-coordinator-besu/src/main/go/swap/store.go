@@ -0,0 +1,126 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Step of a swap
+type Step string
+
+const (
+	// Initiated swaps have a secret, and no lock yet
+	Initiated Step = "INITIATED"
+	// OwnLocked swaps wait for the counterparty to lock its asset
+	OwnLocked Step = "OWN_LOCKED"
+	// CounterpartyLocked swaps have both assets locked, and claim the Counterparty asset
+	CounterpartyLocked Step = "COUNTERPARTY_LOCKED"
+	// Refunding swaps wait for the Own lock to expire to reclaim the asset, as the counterparty did not lock in time,
+	// or locked with another hash
+	Refunding Step = "REFUNDING"
+	// Completed swaps claimed the Counterparty asset
+	Completed Step = "COMPLETED"
+	// Refunded swaps reclaimed the Own asset, or never locked it
+	Refunded Step = "REFUNDED"
+)
+
+// Final tells whether a swap at the step is over
+func (s Step) Final() bool {
+	return s == Completed || s == Refunded
+}
+
+// State is the progress of a swap, saved to the Store after each step
+type State struct {
+	Spec Spec `json:"spec"`
+	Step Step `json:"step"`
+	// Hex encoded secret the assets are locked with, revealed when claiming the Counterparty asset
+	Secret string `json:"secret"`
+	// IDs of the locks: HTLC IDs on Besu, contract IDs on Fabric
+	OwnLockID          string `json:"ownLockId,omitempty"`
+	CounterpartyLockID string `json:"counterpartyLockId,omitempty"`
+	// Why the swap is refunded
+	Reason    string    `json:"reason,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+/*
+HashLocks returns the hashes of the secret the assets are locked with: the keccak256 of the HTLC contracts on Besu, and
+the base64 SHA-256 of the asset chaincodes on Fabric. The counterparty of a swap between Besu and Fabric cannot derive
+one from the other, and must be given the hash of its network before locking.
+*/
+func (s *State) HashLocks() (besu string, fabric string, err error) {
+	h, err := newHashLock(s.Secret)
+	if err != nil {
+		return "", "", err
+	}
+	return h.besuHash(), h.fabricHash(), nil
+}
+
+// ErrNotFound is returned by Store.Load for unknown swaps
+var ErrNotFound = errors.New("swap not found")
+
+// Store persists the progress of swaps, so that they are resumed after a restart
+type Store interface {
+	// Load returns the state of a swap, or ErrNotFound
+	Load(id string) (*State, error)
+	Save(state *State) error
+}
+
+/*
+FileStore stores each swap in a JSON file of a directory. The files hold the secrets of the swaps, and are only
+readable by their owner.
+*/
+type FileStore struct {
+	Dir string
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.Dir, filepath.Base(id)+".json")
+}
+
+func (s *FileStore) Load(id string) (*State, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save writes the state to a temporary file that replaces the file of the swap, so that it is never half written
+func (s *FileStore) Save(state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(s.Dir, 0o700)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(s.Dir, ".swap-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path(state.Spec.ID))
+}
@@ -0,0 +1,294 @@
+/*
+Package swap runs atomic swaps of assets between two Besu networks, or between Besu and Fabric networks, with hash
+time locks: the HTLC contracts of the cactus-plugin-htlc-eth-besu(-erc20) plugins and the coordinator on Besu, and the
+asset chaincodes of Weaver, through the asset-manager of its Go SDK, on Fabric.
+
+The Orchestrator runs a Spec as the initiator of the swap, the party holding the secret. Its progress is saved to a
+Store after each step, so that a swap is resumed where it stopped when it is run again. When the counterparty does
+not lock its asset in time to claim it before its lock expires, or locks it with another hash, the Own asset is
+refunded once its own lock expires.
+*/
+package swap
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	assetmanager "github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/asset-manager"
+	"golang.org/x/crypto/sha3"
+)
+
+// Orchestrator runs swaps
+type Orchestrator struct {
+	Store Store
+	// Contracts of the asset chaincodes of the Fabric networks, by network name
+	Fabric map[string]assetmanager.GatewayContract
+	// Client of the requests to the API servers; defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Interval between the checks of the locks, and between the retries of failed operations; defaults to 5s
+	PollInterval time.Duration
+	/*
+		Time before the expiration of the Counterparty lock from which the orchestrator no longer locks the Own asset
+		nor claims the Counterparty one, so that the claim is committed before the lock expires; defaults to 1m
+	*/
+	ClaimMargin time.Duration
+	// OnError is called with the failed operations, which are retried; it may be nil
+	OnError func(state *State, err error)
+}
+
+// hashLock is the secret of a swap and its hashes: keccak256 on Besu and SHA-256 on Fabric
+type hashLock struct {
+	secret []byte
+	keccak [32]byte
+}
+
+func newHashLock(secretHex string) (*hashLock, error) {
+	secret, err := hex.DecodeString(secretHex)
+	if err != nil || len(secret) != 32 {
+		return nil, errors.New("32 bytes hex encoded secret expected")
+	}
+	h := &hashLock{secret: secret}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(secret)
+	hash.Sum(h.keccak[:0])
+	return h, nil
+}
+
+// besuHash is the hashLock of the HTLC contracts, the keccak256 of the ABI encoded bytes32 secret
+func (h *hashLock) besuHash() string {
+	return "0x" + hex.EncodeToString(h.keccak[:])
+}
+
+func (h *hashLock) besuSecret() string {
+	return "0x" + hex.EncodeToString(h.secret)
+}
+
+func (h *hashLock) fabricHash() string {
+	return assetmanager.GenerateSHA256HashInBase64Form(string(h.secret))
+}
+
+func (h *hashLock) fabricPreimage() string {
+	return base64.StdEncoding.EncodeToString(h.secret)
+}
+
+// ledger runs the operations of a leg
+type ledger interface {
+	// id returns the ID of the lock of the asset, if it is known before locking
+	id(h *hashLock) string
+	lock(ctx context.Context, h *hashLock) (string, error)
+	status(ctx context.Context, id string) (HTLCStatus, error)
+	// verify checks that the Counterparty lock is for the hash of the swap, returning errHashMismatch if it is not
+	verify(ctx context.Context, id string, h *hashLock) error
+	claim(ctx context.Context, id string, h *hashLock) error
+	refund(ctx context.Context, id string) error
+}
+
+// errHashMismatch is returned by verify for a Counterparty lock with another hash, which the secret cannot claim
+var errHashMismatch = errors.New("the counterparty asset is locked with another hash")
+
+func (o *Orchestrator) ledger(leg *Leg, own bool) (ledger, error) {
+	if leg.Besu != nil {
+		client := o.HTTPClient
+		if client == nil {
+			client = http.DefaultClient
+		}
+		return &besuLedger{leg: leg.Besu, own: own, expiry: leg.Expiration.Unix(), client: client}, nil
+	}
+	contract, ok := o.Fabric[leg.Fabric.Network]
+	if !ok {
+		return nil, fmt.Errorf("no contract for Fabric network %q", leg.Fabric.Network)
+	}
+	return &fabricLedger{leg: leg.Fabric, expiry: leg.Expiration.Unix(), contract: contract}, nil
+}
+
+/*
+Run runs a swap until it is completed or refunded, returning its final state; a refund is not an error. A swap found
+in the Store is resumed, and must have the same spec. Run returns early only if the context is done or the Store
+fails, and can then be called again.
+*/
+func (o *Orchestrator) Run(ctx context.Context, spec Spec) (*State, error) {
+	err := spec.Validate()
+	if err != nil {
+		return nil, err
+	}
+	state, err := o.Store.Load(spec.ID)
+	if errors.Is(err, ErrNotFound) {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		state = &State{Spec: spec, Step: Initiated, Secret: hex.EncodeToString(secret)}
+		err = o.save(state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !sameSpec(&state.Spec, &spec) {
+		return nil, fmt.Errorf("swap %s was started with another spec", spec.ID)
+	}
+	h, err := newHashLock(state.Secret)
+	if err != nil {
+		return nil, err
+	}
+	own, err := o.ledger(&state.Spec.Own, true)
+	if err != nil {
+		return nil, err
+	}
+	counterparty, err := o.ledger(&state.Spec.Counterparty, false)
+	if err != nil {
+		return nil, err
+	}
+	interval := o.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	margin := o.ClaimMargin
+	if margin <= 0 {
+		margin = time.Minute
+	}
+
+	for !state.Step.Final() {
+		next, err := o.step(ctx, state, h, own, counterparty, margin)
+		if ctx.Err() != nil {
+			return state, ctx.Err()
+		}
+		if err != nil && o.OnError != nil {
+			o.OnError(state, err)
+		}
+		if next != nil {
+			err = o.save(next)
+			if err != nil {
+				return state, err
+			}
+			state = next
+			continue
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return state, ctx.Err()
+		}
+	}
+	return state, nil
+}
+
+// step attempts to move a swap to its next step, returning the new state if it did
+func (o *Orchestrator) step(ctx context.Context, state *State, h *hashLock, own ledger, counterparty ledger,
+	margin time.Duration) (*State, error) {
+	next := *state
+	now := time.Now()
+	// the Counterparty asset is not claimed within the margin of its expiration
+	counterpartyExpired := !now.Add(margin).Before(state.Spec.Counterparty.Expiration)
+	switch state.Step {
+	case Initiated:
+		// the asset may have been locked before a restart
+		id := own.id(h)
+		status, err := own.status(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status == HTLCActive {
+			next.Step, next.OwnLockID = OwnLocked, id
+			return &next, nil
+		}
+		if counterpartyExpired {
+			next.Step, next.Reason = Refunded, "the own asset was not locked before the counterparty lock expiration"
+			return &next, nil
+		}
+		id, err = own.lock(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock the own asset: %w", err)
+		}
+		next.Step, next.OwnLockID = OwnLocked, id
+		return &next, nil
+
+	case OwnLocked:
+		if counterpartyExpired {
+			next.Step, next.Reason = Refunding, "the counterparty did not lock its asset before its expiration"
+			return &next, nil
+		}
+		id := counterparty.id(h)
+		status, err := counterparty.status(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if status != HTLCActive {
+			return nil, nil
+		}
+		next.Step, next.CounterpartyLockID = CounterpartyLocked, id
+		return &next, nil
+
+	case CounterpartyLocked:
+		if counterpartyExpired {
+			// the asset may have been claimed before a restart
+			status, err := counterparty.status(ctx, state.CounterpartyLockID)
+			if err == nil && status == HTLCWithdrawn {
+				next.Step = Completed
+				return &next, nil
+			}
+			next.Step, next.Reason = Refunding, "the counterparty asset was not claimed before its expiration"
+			return &next, nil
+		}
+		// the secret is only revealed to a lock it can claim
+		err := counterparty.verify(ctx, state.CounterpartyLockID, h)
+		if errors.Is(err, errHashMismatch) {
+			next.Step, next.Reason = Refunding, err.Error()
+			return &next, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the counterparty lock: %w", err)
+		}
+		err = counterparty.claim(ctx, state.CounterpartyLockID, h)
+		if err != nil {
+			status, statusErr := counterparty.status(ctx, state.CounterpartyLockID)
+			if statusErr != nil || status != HTLCWithdrawn {
+				return nil, fmt.Errorf("failed to claim the counterparty asset: %w", err)
+			}
+		}
+		next.Step = Completed
+		return &next, nil
+
+	case Refunding:
+		if !now.After(state.Spec.Own.Expiration) {
+			return nil, nil
+		}
+		status, err := own.status(ctx, state.OwnLockID)
+		if err != nil {
+			return nil, err
+		}
+		switch status {
+		case HTLCWithdrawn:
+			next.Step, next.Reason = Completed, ""
+			return &next, nil
+		case HTLCRefunded:
+			next.Step = Refunded
+			return &next, nil
+		}
+		err = own.refund(ctx, state.OwnLockID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund the own asset: %w", err)
+		}
+		next.Step = Refunded
+		return &next, nil
+	}
+	return nil, fmt.Errorf("unknown step %q", state.Step)
+}
+
+func (o *Orchestrator) save(state *State) error {
+	state.UpdatedAt = time.Now()
+	return o.Store.Save(state)
+}
+
+func sameSpec(a *Spec, b *Spec) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
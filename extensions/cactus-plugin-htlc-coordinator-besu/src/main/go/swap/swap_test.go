@@ -0,0 +1,461 @@
+package swap
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	assetmanager "github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/asset-manager"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	alice = "0x" + strings.Repeat("a1", 20)
+	bob   = "0x" + strings.Repeat("b0", 20)
+	token = "0x" + strings.Repeat("70", 20)
+)
+
+// htlc is a lock of an HTLC contract
+type htlc struct {
+	sender     string
+	receiver   string
+	amount     uint64
+	hashLock   string
+	expiration int64
+	token      string
+	status     HTLCStatus
+}
+
+func (h *htlc) id() string {
+	hashLock, _ := hex.DecodeString(strings.TrimPrefix(h.hashLock, "0x"))
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(addressWord(h.sender))
+	hash.Write(addressWord(h.receiver))
+	hash.Write(uintWord(h.amount))
+	hash.Write(hashLock)
+	hash.Write(uintWord(uint64(h.expiration)))
+	if h.token != "" {
+		hash.Write(addressWord(h.token))
+	}
+	return "0x" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// besuNetwork stands in for an API server hosting the HTLC and coordinator plugins
+type besuNetwork struct {
+	mutex    sync.Mutex
+	htlcs    map[string]*htlc
+	ownLocks int
+	// onLock is called with the locks of the orchestrator, under the mutex
+	onLock func(h *htlc)
+}
+
+func (n *besuNetwork) add(h *htlc) string {
+	id := h.id()
+	h.status = HTLCActive
+	n.htlcs[id] = h
+	return id
+}
+
+func (n *besuNetwork) status(id string) HTLCStatus {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if h, ok := n.htlcs[id]; ok {
+		return h.status
+	}
+	return HTLCInvalid
+}
+
+func startBesuNetwork(t *testing.T, n *besuNetwork) string {
+	n.htlcs = map[string]*htlc{}
+	mux := http.NewServeMux()
+	handle := func(path string, handler func(body json.RawMessage) (*InvokeContractV1Response, error)) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			var body json.RawMessage
+			json.NewDecoder(r.Body).Decode(&body)
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+			response, err := handler(body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(&Error{Message: "Internal Server Error", Exception: err.Error()})
+				return
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+	}
+	lock := func(h *htlc) (*InvokeContractV1Response, error) {
+		if h.expiration <= time.Now().Unix() {
+			return nil, errors.New("expiration in the past")
+		}
+		n.add(h)
+		n.ownLocks++
+		if n.onLock != nil {
+			n.onLock(h)
+		}
+		return &InvokeContractV1Response{Success: true}, nil
+	}
+	handle(OwnHtlcPath, func(body json.RawMessage) (*InvokeContractV1Response, error) {
+		var request OwnHTLCRequest
+		json.Unmarshal(body, &request)
+		h := &htlc{sender: request.Web3SigningCredential.EthAccount, receiver: request.Receiver,
+			amount: request.InputAmount, hashLock: request.HashLock, expiration: request.Expiration}
+		if request.HtlcPackage == BesuERC20 {
+			h.token = request.TokenAddress
+		}
+		return lock(h)
+	})
+	for _, p := range []HtlcPackage{Besu, BesuERC20} {
+		p := p
+		handle(htlcPath(p, "new-contract"), func(body json.RawMessage) (*InvokeContractV1Response, error) {
+			var request NewContractRequest
+			json.Unmarshal(body, &request)
+			h := &htlc{sender: request.Web3SigningCredential.EthAccount, receiver: request.Receiver,
+				amount: request.InputAmount, hashLock: request.HashLock, expiration: request.Expiration}
+			if p == BesuERC20 {
+				h.token = request.TokenAddress
+			}
+			return lock(h)
+		})
+		handle(htlcPath(p, "refund"), func(body json.RawMessage) (*InvokeContractV1Response, error) {
+			var request RefundRequest
+			json.Unmarshal(body, &request)
+			h, ok := n.htlcs[request.Id]
+			if !ok || h.status != HTLCActive || h.expiration > time.Now().Unix() {
+				return nil, errors.New("refund not allowed")
+			}
+			h.status = HTLCRefunded
+			return &InvokeContractV1Response{Success: true}, nil
+		})
+	}
+	handle(CounterpartyHtlcPath, func(body json.RawMessage) (*InvokeContractV1Response, error) {
+		var request CounterpartyHTLCRequest
+		json.Unmarshal(body, &request)
+		status := HTLCInvalid
+		if h, ok := n.htlcs[request.HtlcId]; ok {
+			status = h.status
+			if status == HTLCActive && h.expiration <= time.Now().Unix() {
+				status = HTLCExpired
+			}
+		}
+		return &InvokeContractV1Response{Success: true, CallOutput: json.RawMessage(fmt.Sprintf(`"%d"`, status))}, nil
+	})
+	handle(WithdrawCounterpartyPath, func(body json.RawMessage) (*InvokeContractV1Response, error) {
+		var request WithdrawCounterpartyRequest
+		json.Unmarshal(body, &request)
+		h, ok := n.htlcs[request.HtlcId]
+		if !ok || h.status != HTLCActive || h.expiration <= time.Now().Unix() {
+			return nil, errors.New("withdraw not allowed")
+		}
+		secret, _ := hex.DecodeString(strings.TrimPrefix(request.Secret, "0x"))
+		hash := sha3.NewLegacyKeccak256()
+		hash.Write(secret)
+		if "0x"+hex.EncodeToString(hash.Sum(nil)) != h.hashLock {
+			return nil, errors.New("invalid secret")
+		}
+		h.status = HTLCWithdrawn
+		return &InvokeContractV1Response{Success: true}, nil
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// fabricLock is a lock of a non-fungible asset by the asset chaincode
+type fabricLock struct {
+	recipient  string
+	locker     string
+	hashBase64 string
+	expiry     int64
+	claimed    bool
+}
+
+// assetChaincode stands in for the contract of an asset chaincode of a Fabric network
+type assetChaincode struct {
+	mutex sync.Mutex
+	locks map[string]*fabricLock
+}
+
+func (c *assetChaincode) lock(assetType string, assetID string, l *fabricLock) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.locks[assetType+":"+assetID] = l
+}
+
+func (c *assetChaincode) agreement(arg string) (*common.AssetExchangeAgreement, *fabricLock, error) {
+	data, _ := base64.StdEncoding.DecodeString(arg)
+	agreement := &common.AssetExchangeAgreement{}
+	err := proto.Unmarshal(data, agreement)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, ok := c.locks[agreement.AssetType+":"+agreement.Id]
+	if !ok || l.claimed || l.expiry <= time.Now().Unix() {
+		return agreement, nil, nil
+	}
+	return agreement, l, nil
+}
+
+func (c *assetChaincode) SubmitTransaction(name string, args ...string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if name != "ClaimAsset" {
+		return nil, fmt.Errorf("unexpected transaction %s", name)
+	}
+	agreement, l, err := c.agreement(args[0])
+	if err != nil || l == nil || l.locker != agreement.Locker {
+		return nil, errors.New("asset not locked")
+	}
+	data, _ := base64.StdEncoding.DecodeString(args[1])
+	claim, claimHTLC := &common.AssetClaim{}, &common.AssetClaimHTLC{}
+	proto.Unmarshal(data, claim)
+	proto.Unmarshal(claim.ClaimInfo, claimHTLC)
+	preimage, _ := base64.StdEncoding.DecodeString(string(claimHTLC.HashPreimageBase64))
+	if assetmanager.GenerateSHA256HashInBase64Form(string(preimage)) != l.hashBase64 {
+		return nil, errors.New("invalid hash preimage")
+	}
+	l.claimed = true
+	return []byte("true"), nil
+}
+
+func (c *assetChaincode) EvaluateTransaction(name string, args ...string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if name != "IsAssetLocked" && name != "GetHTLCHash" {
+		return nil, fmt.Errorf("unexpected transaction %s", name)
+	}
+	agreement, l, err := c.agreement(args[0])
+	if err != nil {
+		return nil, err
+	}
+	locked := l != nil && l.recipient == agreement.Recipient && l.locker == agreement.Locker
+	if name == "GetHTLCHash" {
+		if !locked {
+			return nil, errors.New("asset not locked")
+		}
+		return json.Marshal(map[string]interface{}{"hashMechanism": common.HashMechanism_SHA256, "hashBase64": l.hashBase64})
+	}
+	return []byte(fmt.Sprint(locked)), nil
+}
+
+func (c *assetChaincode) claimed(assetType string, assetID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	l, ok := c.locks[assetType+":"+assetID]
+	return ok && l.claimed
+}
+
+// besuSpec swaps 10 wei of alice on a network for 20 tokens of bob on another
+func besuSpec(id string, ownURL string, counterpartyURL string, counterpartyLock time.Duration) Spec {
+	now := time.Now().Truncate(time.Second)
+	credential := Web3SigningCredential{Type: CactusKeychainRef, EthAccount: alice, KeychainEntryKey: "alice",
+		KeychainId: "keychain"}
+	return Spec{
+		ID: id,
+		Own: Leg{
+			Besu: &BesuLeg{URL: ownURL, HtlcPackage: Besu, ConnectorInstanceID: "connector", KeychainID: "keychain",
+				SigningCredential: credential, Receiver: bob, Amount: 10},
+			Expiration: now.Add(2 * counterpartyLock),
+		},
+		Counterparty: Leg{
+			Besu: &BesuLeg{URL: counterpartyURL, HtlcPackage: BesuERC20, ConnectorInstanceID: "connector",
+				KeychainID: "keychain", SigningCredential: credential, Sender: bob, Amount: 20, TokenAddress: token},
+			Expiration: now.Add(counterpartyLock),
+		},
+	}
+}
+
+func run(t *testing.T, o *Orchestrator, spec Spec) *State {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	state, err := o.Run(ctx, spec)
+	if err != nil {
+		t.Fatalf("swap failed: %v", err)
+	}
+	return state
+}
+
+func TestSwapBesu(t *testing.T) {
+	own, counterparty := &besuNetwork{}, &besuNetwork{}
+	ownURL, counterpartyURL := startBesuNetwork(t, own), startBesuNetwork(t, counterparty)
+	spec := besuSpec("besu", ownURL, counterpartyURL, 3*time.Second)
+	var counterpartyID string
+	own.onLock = func(h *htlc) {
+		counterparty.mutex.Lock()
+		defer counterparty.mutex.Unlock()
+		counterpartyID = counterparty.add(&htlc{sender: bob, receiver: alice, amount: 20, hashLock: h.hashLock,
+			expiration: spec.Counterparty.Expiration.Unix(), token: token})
+	}
+
+	var errs []error
+	o := &Orchestrator{Store: &FileStore{Dir: t.TempDir()}, PollInterval: 10 * time.Millisecond, ClaimMargin: 10 * time.Millisecond,
+		OnError: func(state *State, err error) { errs = append(errs, err) }}
+	state := run(t, o, spec)
+	if state.Step != Completed || len(errs) != 0 {
+		t.Fatalf("swap not completed: %+v %v", state, errs)
+	}
+	if state.CounterpartyLockID != counterpartyID || counterparty.status(counterpartyID) != HTLCWithdrawn {
+		t.Errorf("counterparty asset not withdrawn")
+	}
+	if own.status(state.OwnLockID) != HTLCActive {
+		t.Errorf("own asset not left for the counterparty")
+	}
+
+	// a completed swap is not run again, and its spec cannot change
+	state = run(t, o, spec)
+	if state.Step != Completed || own.ownLocks != 1 {
+		t.Errorf("completed swap run again")
+	}
+	spec.Own.Besu.Amount = 11
+	if _, err := o.Run(context.Background(), spec); err == nil {
+		t.Errorf("swap run with another spec")
+	}
+}
+
+func TestSwapRefund(t *testing.T) {
+	network := &besuNetwork{}
+	url := startBesuNetwork(t, network)
+	spec := besuSpec("refund", url, url, time.Second)
+	o := &Orchestrator{Store: &FileStore{Dir: t.TempDir()}, PollInterval: 10 * time.Millisecond, ClaimMargin: 10 * time.Millisecond}
+	state := run(t, o, spec)
+	if state.Step != Refunded || state.Reason == "" {
+		t.Fatalf("swap not refunded: %+v", state)
+	}
+	if network.status(state.OwnLockID) != HTLCRefunded || state.CounterpartyLockID != "" {
+		t.Errorf("own asset not refunded")
+	}
+	if time.Now().Before(spec.Own.Expiration) {
+		t.Errorf("own asset refunded before its expiration")
+	}
+}
+
+func TestSwapFabric(t *testing.T) {
+	network := &besuNetwork{}
+	url := startBesuNetwork(t, network)
+	chaincode := &assetChaincode{locks: map[string]*fabricLock{}}
+	store := &FileStore{Dir: t.TempDir()}
+	spec := besuSpec("fabric", url, url, 3*time.Second)
+	spec.Counterparty.Besu = nil
+	spec.Counterparty.Fabric = &FabricLeg{Network: "network1", AssetType: "bond", AssetID: "a01",
+		LockerECert: "bob", RecipientECert: "alice"}
+	// the counterparty is given the Fabric hash
+	network.onLock = func(h *htlc) {
+		state, err := store.Load(spec.ID)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, hash, _ := state.HashLocks()
+		chaincode.lock("bond", "a01", &fabricLock{recipient: "alice", locker: "bob", hashBase64: hash,
+			expiry: spec.Counterparty.Expiration.Unix()})
+	}
+
+	o := &Orchestrator{Store: store, PollInterval: 10 * time.Millisecond, ClaimMargin: 10 * time.Millisecond,
+		Fabric: map[string]assetmanager.GatewayContract{"network1": chaincode}}
+	state := run(t, o, spec)
+	if state.Step != Completed || !chaincode.claimed("bond", "a01") {
+		t.Fatalf("fabric asset not claimed: %+v", state)
+	}
+
+	spec.ID = "unknown network"
+	spec.Counterparty.Fabric.Network = "network2"
+	if _, err := o.Run(context.Background(), spec); err == nil {
+		t.Errorf("swap run on an unknown network")
+	}
+}
+
+func TestSwapFabricHashMismatch(t *testing.T) {
+	network := &besuNetwork{}
+	url := startBesuNetwork(t, network)
+	chaincode := &assetChaincode{locks: map[string]*fabricLock{}}
+	spec := besuSpec("fabric hash", url, url, 2*time.Second)
+	spec.Counterparty.Besu = nil
+	spec.Counterparty.Fabric = &FabricLeg{Network: "network1", AssetType: "bond", AssetID: "a01",
+		LockerECert: "bob", RecipientECert: "alice"}
+	// the counterparty locks its asset with a hash the secret does not match
+	network.onLock = func(h *htlc) {
+		chaincode.lock("bond", "a01", &fabricLock{recipient: "alice", locker: "bob",
+			hashBase64: assetmanager.GenerateSHA256HashInBase64Form("another secret"),
+			expiry:     spec.Counterparty.Expiration.Unix()})
+	}
+
+	o := &Orchestrator{Store: &FileStore{Dir: t.TempDir()}, PollInterval: 10 * time.Millisecond,
+		ClaimMargin: 10 * time.Millisecond, Fabric: map[string]assetmanager.GatewayContract{"network1": chaincode}}
+	state := run(t, o, spec)
+	if state.Step != Refunded || state.Reason != errHashMismatch.Error() {
+		t.Fatalf("swap not refunded: %+v", state)
+	}
+	if chaincode.claimed("bond", "a01") || network.status(state.OwnLockID) != HTLCRefunded {
+		t.Errorf("counterparty asset claimed instead of refunding the own asset")
+	}
+}
+
+func TestSwapResume(t *testing.T) {
+	own, counterparty := &besuNetwork{}, &besuNetwork{}
+	ownURL, counterpartyURL := startBesuNetwork(t, own), startBesuNetwork(t, counterparty)
+	spec := besuSpec("resume", ownURL, counterpartyURL, 3*time.Second)
+	store := &FileStore{Dir: t.TempDir()}
+
+	// stopped while waiting for the counterparty
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := (&Orchestrator{Store: store, PollInterval: 10 * time.Millisecond, ClaimMargin: 10 * time.Millisecond}).Run(ctx, spec)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("swap not stopped: %v", err)
+	}
+	state, err := store.Load(spec.ID)
+	if err != nil || state.Step != OwnLocked {
+		t.Fatalf("progress not saved: %+v %v", state, err)
+	}
+	info, err := os.Stat(store.path(spec.ID))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("swap file readable by others: %v", err)
+	}
+
+	hash, _, _ := state.HashLocks()
+	counterparty.mutex.Lock()
+	counterparty.add(&htlc{sender: bob, receiver: alice, amount: 20, hashLock: hash,
+		expiration: spec.Counterparty.Expiration.Unix(), token: token})
+	counterparty.mutex.Unlock()
+	state = run(t, &Orchestrator{Store: store, PollInterval: 10 * time.Millisecond, ClaimMargin: 10 * time.Millisecond}, spec)
+	if state.Step != Completed || own.ownLocks != 1 {
+		t.Errorf("swap not resumed: %+v, %d own locks", state, own.ownLocks)
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	for name, change := range map[string]func(s *Spec){
+		"no ID":           func(s *Spec) { s.ID = "" },
+		"no ledger":       func(s *Spec) { s.Own.Besu = nil },
+		"no token":        func(s *Spec) { s.Counterparty.Besu.TokenAddress = "" },
+		"large amount":    func(s *Spec) { s.Own.Besu.Amount = 1 << 53 },
+		"no receiver":     func(s *Spec) { s.Own.Besu.Receiver = "" },
+		"expirations":     func(s *Spec) { s.Own.Expiration = s.Counterparty.Expiration },
+		"unknown package": func(s *Spec) { s.Own.Besu.HtlcPackage = "BESU_ERC721" },
+		"no fungible lock ID": func(s *Spec) {
+			s.Counterparty.Besu = nil
+			s.Counterparty.Fabric = &FabricLeg{Network: "n", AssetType: "t", NumUnits: 5, LockerECert: "b",
+				RecipientECert: "a"}
+		},
+	} {
+		spec := besuSpec("spec", "http://localhost", "http://localhost", time.Second)
+		if err := spec.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		change(&spec)
+		if spec.Validate() == nil {
+			t.Errorf("%s: invalid spec accepted", name)
+		}
+	}
+}
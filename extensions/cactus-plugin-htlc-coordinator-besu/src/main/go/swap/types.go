@@ -0,0 +1,176 @@
+package swap
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	htlccoordinatorbesu "github.com/hyperledger/cactus-plugin-htlc-coordinator-besu/src/main/go/generated/openapi/go-client"
+)
+
+/*
+The requests mirror the models of the generated clients of the coordinator and of the HTLC plugins, whose amounts and
+expiration are float32: the amounts of tokens and the Unix times of expirations are not exact as float32 beyond 2^24.
+The enums are those of the generated client of the coordinator.
+*/
+
+// HtlcPackage is the HTLC plugin a Besu leg goes through
+type HtlcPackage = htlccoordinatorbesu.HtlcPackage
+
+const (
+	Besu      = htlccoordinatorbesu.BESU
+	BesuERC20 = htlccoordinatorbesu.BESU_ERC20
+)
+
+// htlcPath returns the path of an endpoint of the HTLC plugin of a package
+func htlcPath(p HtlcPackage, endpoint string) string {
+	if p == BesuERC20 {
+		return "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-htlc-eth-besu-erc20/" + endpoint
+	}
+	return "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-htlc-eth-besu/" + endpoint
+}
+
+// Paths of the endpoints of the coordinator
+const (
+	OwnHtlcPath              = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-htlc-coordinator-besu/own-htlc"
+	CounterpartyHtlcPath     = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-htlc-coordinator-besu/counterparty-htlc"
+	WithdrawCounterpartyPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-htlc-coordinator-besu/withdraw-counterparty"
+)
+
+type Web3SigningCredentialType = htlccoordinatorbesu.Web3SigningCredentialType
+
+const (
+	CactusKeychainRef    = htlccoordinatorbesu.CACTUS_KEYCHAIN_REF
+	GethKeychainPassword = htlccoordinatorbesu.GETH_KEYCHAIN_PASSWORD
+	PrivateKeyHex        = htlccoordinatorbesu.PRIVATE_KEY_HEX
+	None                 = htlccoordinatorbesu.NONE
+)
+
+/*
+Web3SigningCredential is the account transactions are signed with (Web3SigningCredential in the OpenAPI spec, a oneOf
+of the types of credentials, flattened here)
+*/
+type Web3SigningCredential struct {
+	Type       Web3SigningCredentialType `json:"type"`
+	EthAccount string                    `json:"ethAccount,omitempty"`
+	// Private key, for PRIVATE_KEY_HEX credentials
+	Secret string `json:"secret,omitempty"`
+	// Keychain entry of the private key, for CACTUS_KEYCHAIN_REF credentials
+	KeychainEntryKey string `json:"keychainEntryKey,omitempty"`
+	KeychainId       string `json:"keychainId,omitempty"`
+}
+
+// OwnHTLCRequest deploys an HTLC contract and locks funds in it (OwnHTLCRequest in the OpenAPI spec)
+type OwnHTLCRequest struct {
+	HtlcPackage           HtlcPackage           `json:"htlcPackage"`
+	ConnectorInstanceId   string                `json:"connectorInstanceId"`
+	KeychainId            string                `json:"keychainId"`
+	ConstructorArgs       []interface{}         `json:"constructorArgs"`
+	Web3SigningCredential Web3SigningCredential `json:"web3SigningCredential"`
+	InputAmount           uint64                `json:"inputAmount"`
+	OutputAmount          uint64                `json:"outputAmount"`
+	// Unix time the lock expires at
+	Expiration    int64    `json:"expiration"`
+	HashLock      string   `json:"hashLock"`
+	TokenAddress  string   `json:"tokenAddress"`
+	Receiver      string   `json:"receiver"`
+	OutputNetwork string   `json:"outputNetwork"`
+	OutputAddress string   `json:"outputAddress"`
+	Gas           *float64 `json:"gas,omitempty"`
+}
+
+/*
+NewContractRequest locks funds in a deployed HTLC contract (NewContractObj of the HTLC plugin and NewContractRequest of
+its ERC20 variant in the OpenAPI specs, which differ by the token address)
+*/
+type NewContractRequest struct {
+	ContractAddress       string                `json:"contractAddress"`
+	InputAmount           uint64                `json:"inputAmount"`
+	OutputAmount          uint64                `json:"outputAmount"`
+	Expiration            int64                 `json:"expiration"`
+	HashLock              string                `json:"hashLock"`
+	TokenAddress          string                `json:"tokenAddress,omitempty"`
+	Receiver              string                `json:"receiver"`
+	OutputNetwork         string                `json:"outputNetwork"`
+	OutputAddress         string                `json:"outputAddress"`
+	ConnectorId           string                `json:"connectorId"`
+	KeychainId            string                `json:"keychainId"`
+	Web3SigningCredential Web3SigningCredential `json:"web3SigningCredential"`
+	Gas                   *float64              `json:"gas,omitempty"`
+}
+
+// CounterpartyHTLCRequest gets the status of an HTLC (CounterpartyHTLCRequest in the OpenAPI spec)
+type CounterpartyHTLCRequest struct {
+	HtlcPackage           HtlcPackage           `json:"htlcPackage"`
+	ConnectorInstanceId   string                `json:"connectorInstanceId"`
+	KeychainId            string                `json:"keychainId"`
+	HtlcId                string                `json:"htlcId"`
+	Web3SigningCredential Web3SigningCredential `json:"web3SigningCredential"`
+	Gas                   *float64              `json:"gas,omitempty"`
+}
+
+// WithdrawCounterpartyRequest withdraws the funds of an HTLC (WithdrawCounterpartyRequest in the OpenAPI spec)
+type WithdrawCounterpartyRequest struct {
+	HtlcPackage           HtlcPackage           `json:"htlcPackage"`
+	ConnectorInstanceId   string                `json:"connectorInstanceId"`
+	KeychainId            string                `json:"keychainId"`
+	Web3SigningCredential Web3SigningCredential `json:"web3SigningCredential"`
+	HtlcId                string                `json:"htlcId"`
+	Secret                string                `json:"secret"`
+	Gas                   *float64              `json:"gas,omitempty"`
+}
+
+// RefundRequest refunds the funds of an expired HTLC (RefundReq and RefundRequest in the OpenAPI specs)
+type RefundRequest struct {
+	Id                    string                `json:"id"`
+	Web3SigningCredential Web3SigningCredential `json:"web3SigningCredential"`
+	ConnectorId           string                `json:"connectorId"`
+	KeychainId            string                `json:"keychainId"`
+	Gas                   *float64              `json:"gas,omitempty"`
+}
+
+// InvokeContractV1Response (InvokeContractV1Response in the OpenAPI spec)
+type InvokeContractV1Response struct {
+	TransactionReceipt json.RawMessage `json:"transactionReceipt,omitempty"`
+	CallOutput         json.RawMessage `json:"callOutput,omitempty"`
+	Success            bool            `json:"success"`
+}
+
+// HTLCStatus is the status of an HTLC, as returned by getSingleStatus of the HTLC contracts
+type HTLCStatus int
+
+const (
+	// HTLCInvalid is the status of the IDs of no HTLC
+	HTLCInvalid HTLCStatus = iota
+	HTLCActive
+	HTLCRefunded
+	HTLCWithdrawn
+	// HTLCExpired is the status of active HTLCs past their expiration
+	HTLCExpired
+)
+
+func (s HTLCStatus) String() string {
+	switch s {
+	case HTLCInvalid:
+		return "INVALID"
+	case HTLCActive:
+		return "ACTIVE"
+	case HTLCRefunded:
+		return "REFUNDED"
+	case HTLCWithdrawn:
+		return "WITHDRAWN"
+	case HTLCExpired:
+		return "EXPIRED"
+	}
+	return fmt.Sprintf("HTLCStatus(%d)", int(s))
+}
+
+// parseStatus decodes the call output of getSingleStatus, a uint256 serialized as a number or a string
+func parseStatus(callOutput json.RawMessage) (HTLCStatus, error) {
+	status, err := strconv.Atoi(strings.Trim(string(callOutput), `"`))
+	if err != nil || status < 0 || status > int(HTLCExpired) {
+		return 0, fmt.Errorf("unexpected HTLC status %s", callOutput)
+	}
+	return HTLCStatus(status), nil
+}
@@ -7,7 +7,7 @@ Testing DefaultApiService
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech);
 
-package cactus-plugin-object-store-ipfs
+package objectstoreipfs
 
 import (
 	"context"
@@ -17,7 +17,7 @@ import (
 	openapiclient "github.com/hyperledger/cactus-plugin-object-store-ipfs/src/main/go/generated/openapi/go-client"
 )
 
-func Test_cactus-plugin-object-store-ipfs_DefaultApiService(t *testing.T) {
+func Test_objectstoreipfs_DefaultApiService(t *testing.T) {
 
 	configuration := openapiclient.NewConfiguration()
 	apiClient := openapiclient.NewAPIClient(configuration)
@@ -0,0 +1,323 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// Size of the chunks of the objects, unless configured
+const DefaultChunkSize = 1 << 20
+
+// MaxChunkSize is the largest chunk size whose encrypted and base64 encoded chunks the plugin takes
+const MaxChunkSize = 7 << 20
+
+// Key prefixes of the chunks and manifests
+const (
+	chunkPrefix    = "chunks/"
+	manifestPrefix = "manifests/"
+)
+
+// Manifest lists the chunks of an object
+type Manifest struct {
+	Version int `json:"version"`
+	// Size and hex encoded SHA-256 of the object
+	Size   int64   `json:"size"`
+	SHA256 string  `json:"sha256"`
+	Chunks []Chunk `json:"chunks"`
+}
+
+// Chunk is a part of an object
+type Chunk struct {
+	// Hex encoded SHA-256 of the stored chunk, encrypted if the object is, and the key of the chunk
+	Hash string `json:"hash"`
+	// Size of the chunk, decrypted
+	Size int `json:"size"`
+}
+
+/*
+Objects stores objects in chunks in a Store. The reference of an object is the hex encoded SHA-256 of its stored
+manifest; the same content is stored under the same reference, unless encrypted, as every encryption has a new nonce.
+*/
+type Objects struct {
+	Store Store
+	// Size of the chunks of new objects, at most MaxChunkSize; defaults to DefaultChunkSize
+	ChunkSize int
+	/*
+		AES-256 key the chunks and manifests are encrypted with, if set. Objects are read with the key they were
+		written with.
+	*/
+	Key []byte
+}
+
+func (o *Objects) aead() (cipher.AEAD, error) {
+	if o.Key == nil {
+		return nil, nil
+	}
+	if len(o.Key) != 32 {
+		return nil, errors.New("32 bytes AES-256 key expected")
+	}
+	block, err := aes.NewCipher(o.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts a chunk or manifest, bound to its position, into the nonce followed by the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte, position uint64) ([]byte, error) {
+	if aead == nil {
+		return plaintext, nil
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, binary.BigEndian.AppendUint64(nil, position)), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte, position uint64) ([]byte, error) {
+	if aead == nil {
+		return sealed, nil
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrIntegrity
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():],
+		binary.BigEndian.AppendUint64(nil, position))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decrypt", ErrIntegrity)
+	}
+	return plaintext, nil
+}
+
+// manifestPosition is the position the manifests are encrypted at, which no chunk has
+const manifestPosition = ^uint64(0)
+
+// Put stores an object read from r until EOF, one chunk at a time, and returns its reference
+func (o *Objects) Put(ctx context.Context, r io.Reader) (string, error) {
+	chunkSize := o.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize > MaxChunkSize {
+		return "", fmt.Errorf("chunk size must be at most %d", MaxChunkSize)
+	}
+	aead, err := o.aead()
+	if err != nil {
+		return "", err
+	}
+	manifest := &Manifest{Version: 1, Chunks: []Chunk{}}
+	objectHash := sha256.New()
+	buffer := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(r, buffer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		objectHash.Write(buffer[:n])
+		manifest.Size += int64(n)
+		sealed, err := seal(aead, buffer[:n], uint64(len(manifest.Chunks)))
+		if err != nil {
+			return "", err
+		}
+		chunkHash := sha256.Sum256(sealed)
+		chunk := Chunk{Hash: hex.EncodeToString(chunkHash[:]), Size: n}
+		// plain chunks are deduplicated
+		present := false
+		if aead == nil {
+			present, err = o.Store.Has(ctx, chunkPrefix+chunk.Hash)
+			if err != nil {
+				return "", err
+			}
+		}
+		if !present {
+			err = o.Store.Set(ctx, chunkPrefix+chunk.Hash, sealed)
+			if err != nil {
+				return "", fmt.Errorf("failed to store chunk %d: %w", len(manifest.Chunks), err)
+			}
+		}
+		manifest.Chunks = append(manifest.Chunks, chunk)
+	}
+	manifest.SHA256 = hex.EncodeToString(objectHash.Sum(nil))
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, data, manifestPosition)
+	if err != nil {
+		return "", err
+	}
+	manifestHash := sha256.Sum256(sealed)
+	ref := hex.EncodeToString(manifestHash[:])
+	err = o.Store.Set(ctx, manifestPrefix+ref, sealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to store manifest: %w", err)
+	}
+	return ref, nil
+}
+
+// PutBytes stores an object held in memory, e.g. a marshalled view, and returns its reference
+func (o *Objects) PutBytes(ctx context.Context, object []byte) (string, error) {
+	return o.Put(ctx, bytes.NewReader(object))
+}
+
+// Has tells whether the manifest of an object is stored
+func (o *Objects) Has(ctx context.Context, ref string) (bool, error) {
+	if _, err := parseRef(ref); err != nil {
+		return false, err
+	}
+	return o.Store.Has(ctx, manifestPrefix+ref)
+}
+
+// Stat returns the verified manifest of an object
+func (o *Objects) Stat(ctx context.Context, ref string) (*Manifest, error) {
+	expected, err := parseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := o.aead()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := o.Store.Get(ctx, manifestPrefix+ref)
+	if err != nil {
+		return nil, err
+	}
+	if sha256.Sum256(sealed) != expected {
+		return nil, fmt.Errorf("%w: manifest of %s altered", ErrIntegrity, ref)
+	}
+	data, err := open(aead, sealed, manifestPosition)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil || manifest.Version != 1 {
+		return nil, fmt.Errorf("manifest of %s unreadable, it may be encrypted", ref)
+	}
+	return manifest, nil
+}
+
+/*
+Get returns a reader of an object, which fetches and verifies its chunks as it is read. A read fails with ErrIntegrity
+as soon as a chunk does not match, or at the end of the object if the whole object does not.
+*/
+func (o *Objects) Get(ctx context.Context, ref string) (*Object, error) {
+	manifest, err := o.Stat(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := o.aead()
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Manifest: manifest, ctx: ctx, store: o.Store, aead: aead, hash: sha256.New()}, nil
+}
+
+// GetBytes reads a whole object in memory
+func (o *Objects) GetBytes(ctx context.Context, ref string) ([]byte, error) {
+	object, err := o.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	return io.ReadAll(object)
+}
+
+// Object is a reader of a stored object
+type Object struct {
+	Manifest *Manifest
+	ctx      context.Context
+	store    Store
+	aead     cipher.AEAD
+	// index of the next chunk, and the rest of the current one
+	next    int
+	current []byte
+	hash    hash.Hash
+	read    int64
+	err     error
+}
+
+func (o *Object) Read(p []byte) (int, error) {
+	for len(o.current) == 0 && o.err == nil {
+		o.err = o.fetch()
+	}
+	if len(o.current) == 0 {
+		return 0, o.err
+	}
+	n := copy(p, o.current)
+	o.current = o.current[n:]
+	return n, nil
+}
+
+// fetch verifies and decrypts the next chunk, or verifies the whole object after the last chunk
+func (o *Object) fetch() error {
+	if o.next == len(o.Manifest.Chunks) {
+		if o.read != o.Manifest.Size || hex.EncodeToString(o.hash.Sum(nil)) != o.Manifest.SHA256 {
+			return fmt.Errorf("%w: object does not match its manifest", ErrIntegrity)
+		}
+		return io.EOF
+	}
+	chunk := o.Manifest.Chunks[o.next]
+	expected, err := parseRef(chunk.Hash)
+	if err != nil {
+		return fmt.Errorf("%w: invalid chunk hash", ErrIntegrity)
+	}
+	sealed, err := o.store.Get(o.ctx, chunkPrefix+chunk.Hash)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: chunk %d missing", ErrIntegrity, o.next)
+	}
+	if err != nil {
+		return err
+	}
+	if sha256.Sum256(sealed) != expected {
+		return fmt.Errorf("%w: chunk %d altered", ErrIntegrity, o.next)
+	}
+	plaintext, err := open(o.aead, sealed, uint64(o.next))
+	if err != nil {
+		return err
+	}
+	if len(plaintext) != chunk.Size {
+		return fmt.Errorf("%w: chunk %d has %d bytes instead of %d", ErrIntegrity, o.next, len(plaintext), chunk.Size)
+	}
+	o.hash.Write(plaintext)
+	o.read += int64(len(plaintext))
+	o.current = plaintext
+	o.next++
+	return nil
+}
+
+// Close stops the reading of the object; later reads fail
+func (o *Object) Close() error {
+	if o.err == nil {
+		o.err = errors.New("object closed")
+	}
+	o.current = nil
+	return nil
+}
+
+// parseRef decodes the hex encoded SHA-256 of a reference or chunk
+func parseRef(ref string) ([sha256.Size]byte, error) {
+	var hash [sha256.Size]byte
+	decoded, err := hex.DecodeString(ref)
+	if err != nil || len(decoded) != sha256.Size {
+		return hash, fmt.Errorf("invalid object reference %q", ref)
+	}
+	copy(hash[:], decoded)
+	return hash, nil
+}
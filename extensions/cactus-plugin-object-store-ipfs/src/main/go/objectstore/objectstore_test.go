@@ -0,0 +1,199 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ipfsPlugin stands in for an API server hosting the IPFS object store plugin
+func ipfsPlugin(t *testing.T) *httptest.Server {
+	var mutex sync.Mutex
+	objects := map[string]string{}
+	mux := http.NewServeMux()
+	handle := func(path string, handler func(request *SetObjectRequestV1) interface{}) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			request := &SetObjectRequestV1{}
+			json.NewDecoder(r.Body).Decode(request)
+			mutex.Lock()
+			defer mutex.Unlock()
+			response := handler(request)
+			if err, ok := response.(*Error); ok {
+				w.WriteHeader(err.StatusCode)
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+	}
+	handle(SetObjectPath, func(request *SetObjectRequestV1) interface{} {
+		objects[request.Key] = request.Value
+		return &SetObjectResponseV1{Key: request.Key}
+	})
+	handle(GetObjectPath, func(request *SetObjectRequestV1) interface{} {
+		value, ok := objects[request.Key]
+		if !ok {
+			return &Error{StatusCode: 500, Message: "Internal Server Error", Exception: "file does not exist"}
+		}
+		return &GetObjectResponseV1{Key: request.Key, Value: value}
+	})
+	handle(HasObjectPath, func(request *SetObjectRequestV1) interface{} {
+		_, ok := objects[request.Key]
+		return &HasObjectResponseV1{Key: request.Key, IsPresent: ok, CheckedAt: time.Now().Format(time.RFC3339)}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// testObjects stores and reads back objects of several sizes
+func testObjects(t *testing.T, o *Objects) {
+	ctx := context.Background()
+	for _, size := range []int{0, 1, 1024, 3*1024 + 512} {
+		data := randomBytes(t, size)
+		ref, err := o.Put(ctx, bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if present, err := o.Has(ctx, ref); err != nil || !present {
+			t.Fatalf("object of %d bytes not stored: %v", size, err)
+		}
+		manifest, err := o.Stat(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if manifest.Size != int64(size) || len(manifest.Chunks) != (size+1023)/1024 {
+			t.Errorf("unexpected manifest %+v", manifest)
+		}
+
+		// read in small pieces
+		object, err := o.Get(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var read bytes.Buffer
+		_, err = io.CopyBuffer(&read, struct{ io.Reader }{object}, make([]byte, 100))
+		if err != nil || !bytes.Equal(read.Bytes(), data) {
+			t.Errorf("object of %d bytes not read back: %v", size, err)
+		}
+	}
+	if _, err := o.Stat(ctx, strings.Repeat("00", 32)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing object found: %v", err)
+	}
+	if _, err := o.Stat(ctx, "../objects"); err == nil {
+		t.Errorf("invalid reference accepted")
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := &Dir{Path: t.TempDir()}
+	o := &Objects{Store: dir, ChunkSize: 1024}
+	testObjects(t, o)
+
+	ctx := context.Background()
+	data := randomBytes(t, 2048)
+	ref, _ := o.PutBytes(ctx, data)
+	again, _ := o.PutBytes(ctx, data)
+	if again != ref {
+		t.Errorf("same content stored under another reference")
+	}
+	if err := dir.Set(ctx, "../outside", nil); err == nil {
+		t.Errorf("key outside the directory accepted")
+	}
+
+	// a chunk is altered
+	manifest, _ := o.Stat(ctx, ref)
+	chunkPath := filepath.Join(dir.Path, "chunks", manifest.Chunks[1].Hash)
+	chunk, _ := os.ReadFile(chunkPath)
+	chunk[0] ^= 1
+	os.WriteFile(chunkPath, chunk, 0o644)
+	object, err := o.Get(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := make([]byte, 1024)
+	if _, err := io.ReadFull(object, first); err != nil || !bytes.Equal(first, data[:1024]) {
+		t.Errorf("first chunk not read: %v", err)
+	}
+	if _, err := io.ReadAll(object); !errors.Is(err, ErrIntegrity) {
+		t.Errorf("altered chunk read: %v", err)
+	}
+
+	// a manifest is altered
+	manifestPath := filepath.Join(dir.Path, "manifests", ref)
+	os.WriteFile(manifestPath, []byte(`{"version":1,"size":0,"sha256":"","chunks":[]}`), 0o644)
+	if _, err := o.Stat(ctx, ref); !errors.Is(err, ErrIntegrity) {
+		t.Errorf("altered manifest read: %v", err)
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	dir := &Dir{Path: t.TempDir()}
+	o := &Objects{Store: dir, ChunkSize: 1024, Key: randomBytes(t, 32)}
+	testObjects(t, o)
+
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("interop proof "), 200)
+	ref, err := o.PutBytes(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filepath.WalkDir(dir.Path, func(path string, entry os.DirEntry, err error) error {
+		if !entry.IsDir() {
+			content, _ := os.ReadFile(path)
+			if bytes.Contains(content, []byte("interop proof")) {
+				t.Errorf("%s stored in clear", path)
+			}
+		}
+		return nil
+	})
+
+	for name, key := range map[string][]byte{"no key": nil, "wrong key": randomBytes(t, 32)} {
+		other := &Objects{Store: dir, Key: key}
+		if _, err := other.GetBytes(ctx, ref); err == nil {
+			t.Errorf("%s: encrypted object read", name)
+		}
+	}
+	if _, err := (&Objects{Store: dir, Key: []byte("short")}).PutBytes(ctx, data); err == nil {
+		t.Errorf("short key accepted")
+	}
+}
+
+func TestRemote(t *testing.T) {
+	server := ipfsPlugin(t)
+	remote := NewRemote(Config{URL: server.URL})
+	testObjects(t, &Objects{Store: remote, ChunkSize: 1024})
+
+	ctx := context.Background()
+	if _, err := remote.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing key found: %v", err)
+	}
+	if err := remote.Set(ctx, "large", make([]byte, MaxValueLength)); err == nil {
+		t.Errorf("value too large for the plugin accepted")
+	}
+	value := base64.StdEncoding.EncodeToString([]byte("value"))
+	if err := remote.Set(ctx, "key", []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := remote.Get(ctx, "key"); err != nil || base64.StdEncoding.EncodeToString(got) != value {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,154 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	objectstoreipfs "github.com/hyperledger/cactus-plugin-object-store-ipfs/src/main/go/generated/openapi/go-client"
+)
+
+// Paths of the endpoints of the IPFS object store plugin
+const (
+	GetObjectPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-object-store-ipfs/get-object"
+	SetObjectPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-object-store-ipfs/set-object"
+	HasObjectPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-object-store-ipfs/has-object"
+)
+
+// The requests and responses are the models of the generated client. The values are base64 encoded.
+
+type GetObjectRequestV1 = objectstoreipfs.GetObjectRequestV1
+
+type GetObjectResponseV1 = objectstoreipfs.GetObjectResponseV1
+
+type SetObjectRequestV1 = objectstoreipfs.SetObjectRequestV1
+
+type SetObjectResponseV1 = objectstoreipfs.SetObjectResponseV1
+
+type HasObjectRequestV1 = objectstoreipfs.HasObjectRequestV1
+
+// HasObjectResponseV1 has the date and time of the check, encoded as JSON, in CheckedAt
+type HasObjectResponseV1 = objectstoreipfs.HasObjectResponseV1
+
+// MaxValueLength is the longest base64 encoded value the plugin takes
+const MaxValueLength = 10485760
+
+// Error is an error response of the API server
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("API server responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API server responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// Config configures a Remote store
+type Config struct {
+	// URL of the API server hosting the plugin, e.g. http://localhost:4000
+	URL string
+	// Defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers set on every request, e.g. Authorization
+	Header http.Header
+}
+
+// Remote is the Store of an IPFS object store plugin
+type Remote struct {
+	config Config
+}
+
+func NewRemote(config Config) *Remote {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Remote{config: config}
+}
+
+func (r *Remote) Set(ctx context.Context, key string, value []byte) error {
+	if base64.StdEncoding.EncodedLen(len(value)) > MaxValueLength {
+		return fmt.Errorf("value of %d bytes too large for the plugin", len(value))
+	}
+	request := &SetObjectRequestV1{Key: key, Value: base64.StdEncoding.EncodeToString(value)}
+	return r.post(ctx, SetObjectPath, request, &SetObjectResponseV1{})
+}
+
+/*
+Get returns the value under a key. The plugin fails the same way on missing keys and on IPFS errors, so a failed Get is
+followed by a presence check to return ErrNotFound.
+*/
+func (r *Remote) Get(ctx context.Context, key string) ([]byte, error) {
+	response := &GetObjectResponseV1{}
+	err := r.post(ctx, GetObjectPath, &GetObjectRequestV1{Key: key}, response)
+	if _, ok := err.(*Error); ok {
+		if present, hasErr := r.Has(ctx, key); hasErr == nil && !present {
+			return nil, ErrNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, err := base64.StdEncoding.DecodeString(response.Value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed value of %s: %v", key, err)
+	}
+	return value, nil
+}
+
+func (r *Remote) Has(ctx context.Context, key string) (bool, error) {
+	response := &HasObjectResponseV1{}
+	err := r.post(ctx, HasObjectPath, &HasObjectRequestV1{Key: key}, response)
+	if err != nil {
+		return false, err
+	}
+	return response.IsPresent, nil
+}
+
+// post posts a request to an endpoint of the plugin and decodes the response
+func (r *Remote) post(ctx context.Context, path string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range r.config.Header {
+		httpRequest.Header[name] = values
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := r.config.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		apiError := &Error{}
+		if json.Unmarshal(responseBody, apiError) != nil || apiError.Message == "" {
+			apiError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		apiError.StatusCode = httpResponse.StatusCode
+		return apiError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
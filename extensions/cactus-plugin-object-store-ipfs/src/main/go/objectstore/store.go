@@ -0,0 +1,96 @@
+/*
+Package objectstore stores objects larger than memory, such as interop proofs and SATP evidence, in an object store
+of Cactus: the cactus-plugin-object-store-ipfs plugin, through Remote, or a local directory, through Dir.
+
+Objects splits the objects into chunks, stored under their SHA-256, and a manifest listing them, whose SHA-256 is the
+reference of the object. Objects are written and read as streams, one chunk at a time, and every chunk is verified
+on read, as is the whole object once read. The chunks and the manifest are optionally encrypted with AES-256-GCM
+before leaving the client.
+*/
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Store is an object store of the plugins: values under keys, that are set, read and checked for presence
+type Store interface {
+	Set(ctx context.Context, key string, value []byte) error
+	// Get returns the value under a key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+	Has(ctx context.Context, key string) (bool, error)
+}
+
+// ErrNotFound is returned for missing keys and objects
+var ErrNotFound = errors.New("object not found")
+
+// ErrIntegrity is returned when an object read does not match its reference
+var ErrIntegrity = errors.New("object integrity check failed")
+
+// MaxKeyLength is the longest key the plugins take
+const MaxKeyLength = 1024
+
+// Dir is a Store of a local directory, each value in a file; keys may contain slashes, which create subdirectories
+type Dir struct {
+	Path string
+}
+
+func (d *Dir) path(key string) (string, error) {
+	if key == "" || len(key) > MaxKeyLength || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(d.Path, filepath.FromSlash(key)), nil
+}
+
+// Set writes the value to a temporary file that replaces the file of the key, so that it is never half written
+func (d *Dir) Set(ctx context.Context, key string, value []byte) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".object-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(value)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (d *Dir) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (d *Dir) Has(ctx context.Context, key string) (bool, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,59 @@
+package consortium
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Path of the GetConsortiumJwsV1 endpoint of the static consortium plugin
+const ConsortiumJwsPath = "/api/v1/plugins/@hyperledger-cacti/cacti-plugin-consortium-static/consortium/jws"
+
+/*
+Fetch retrieves the consortium JWS from an API server hosting a consortium plugin, e.g. http://localhost:4000.
+The JWS is not verified.
+*/
+func Fetch(ctx context.Context, apiHost string, client *http.Client) (*JWSGeneral, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(apiHost, "/")+ConsortiumJwsPath, bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GetConsortiumJwsV1 failed with status %s: %s", response.Status, body)
+	}
+	var jwsResponse struct {
+		Jws *JWSGeneral `json:"jws"`
+	}
+	err = json.Unmarshal(body, &jwsResponse)
+	if err != nil || jwsResponse.Jws == nil {
+		return nil, errors.New("GetConsortiumJwsV1 returned no JWS")
+	}
+	return jwsResponse.Jws, nil
+}
+
+// FetchAndVerify retrieves the consortium JWS from an API server and verifies it
+func FetchAndVerify(ctx context.Context, apiHost string, client *http.Client, options VerifyOptions) (*Verified, error) {
+	jws, err := Fetch(ctx, apiHost, client)
+	if err != nil {
+		return nil, err
+	}
+	return Verify(jws, options)
+}
@@ -0,0 +1,200 @@
+package consortium
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// loadNodeJws loads a JWS signed by a node with ES256K, as generated by Node.js, and the node's private key
+func loadNodeJws(t *testing.T) (*JWSGeneral, string) {
+	data, err := os.ReadFile("testdata/consortium-jws.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jws := &JWSGeneral{}
+	err = json.Unmarshal(data, jws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := os.ReadFile("testdata/node-key.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jws, string(key)
+}
+
+func TestVerifyNodeJws(t *testing.T) {
+	jws, keyPem := loadNodeJws(t)
+	signer, err := NewSigner(keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verified, err := Verify(jws, VerifyOptions{TrustedKeys: []string{signer.PublicKeyPEM()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &verified.Database
+	if len(verified.Signers) != 1 || verified.Signers[0] != "n1" {
+		t.Fatalf("unexpected signers %v", verified.Signers)
+	}
+	if db.Node("n1").PublicKeyPem != signer.PublicKeyPEM() {
+		t.Fatal("public key of the signer differs from that of the node")
+	}
+	if ledgers := db.NodeLedgers("n1"); len(ledgers) != 1 || ledgers[0].LedgerType != "BESU_2X" {
+		t.Fatalf("unexpected ledgers %v", ledgers)
+	}
+	if plugins := db.NodePlugins("n1"); len(plugins) != 1 || plugins[0].PackageName != "@hyperledger-cacti/cactus-plugin-ledger-connector-besu" {
+		t.Fatalf("unexpected plugins %v", plugins)
+	}
+	if len(db.MemberNodes("m1")) != 1 || len(db.LedgerNodes("l1")) != 1 || db.Node("n2") != nil || db.NodeLedgers("n2") != nil {
+		t.Fatal("unexpected topology")
+	}
+
+	// a key that did not sign is not trusted
+	other, _ := GenerateSigner()
+	if _, err := Verify(jws, VerifyOptions{TrustedKeys: []string{other.PublicKeyPEM()}}); err == nil {
+		t.Fatal("JWS verified without a signature by a trusted key")
+	}
+	// the payload cannot be altered
+	payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+	tampered := *jws
+	tampered.Payload = base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(payload), "node1:4000", "evil:4000", 1)))
+	if _, err := Verify(&tampered, VerifyOptions{}); err == nil {
+		t.Fatal("tampered JWS verified")
+	}
+}
+
+func pkcs8PEM(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// newConsortium returns the database of a consortium with a node per signer, and the signers
+func newConsortium(t *testing.T) (*ConsortiumDatabase, []*Signer) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	secpSigner, err := GenerateSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecSigner, err := NewSigner(pkcs8PEM(t, ecKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edSigner, err := NewSigner(pkcs8PEM(t, edKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := []*Signer{secpSigner, ecSigner, edSigner}
+	db := &ConsortiumDatabase{
+		Consortium:       []Consortium{{Id: "c1", Name: "Consortium", MemberIds: []string{"m1", "m2"}}},
+		ConsortiumMember: []ConsortiumMember{{Id: "m1", NodeIds: []string{"n1", "n2"}}, {Id: "m2", NodeIds: []string{"n3"}}},
+		Ledger:           []Ledger{{Id: "l1", LedgerType: "FABRIC_2"}},
+	}
+	for i, signer := range signers {
+		memberId := "m1"
+		if i == 2 {
+			memberId = "m2"
+		}
+		db.CactusNode = append(db.CactusNode, CactusNode{
+			Id:           []string{"n1", "n2", "n3"}[i],
+			MemberId:     memberId,
+			ConsortiumId: "c1",
+			PublicKeyPem: signer.PublicKeyPEM(),
+			LedgerIds:    []string{"l1"},
+		})
+	}
+	return db, signers
+}
+
+func TestSign(t *testing.T) {
+	db, signers := newConsortium(t)
+	jws, err := Sign(db, signers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, err := Verify(jws, VerifyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(verified.Signers, ",") != "n1,n2,n3" || len(verified.Database.LedgerNodes("l1")) != 3 || len(verified.Database.MemberNodes("m1")) != 2 {
+		t.Fatalf("unexpected verification %+v", verified)
+	}
+
+	// every node must sign, unless partial agreement is accepted
+	partial := *jws
+	partial.Signatures = append([]JWSRecipient{}, jws.Signatures[:2]...)
+	if _, err := Verify(&partial, VerifyOptions{}); err == nil {
+		t.Fatal("JWS verified without the signature of every node")
+	}
+	if _, err := Verify(&partial, VerifyOptions{AllowPartial: true}); err != nil {
+		t.Fatal(err)
+	}
+	// signatures by keys outside of the consortium are rejected
+	outsider, _ := GenerateSigner()
+	foreign, _ := Sign(db, outsider)
+	partial.Signatures = append(partial.Signatures, foreign.Signatures[0])
+	if _, err := Verify(&partial, VerifyOptions{AllowPartial: true}); err == nil {
+		t.Fatal("JWS verified with the signature of a key outside of the consortium")
+	}
+	// the algorithm must be that of the key
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256"}`))
+	partial.Signatures = []JWSRecipient{{Protected: &protected, Signature: jws.Signatures[0].Signature}}
+	if _, err := Verify(&partial, VerifyOptions{AllowPartial: true}); err == nil {
+		t.Fatal("JWS verified with an algorithm other than that of the key")
+	}
+
+	// detached JWS
+	detached, payload, err := Detach(jws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(detached, VerifyOptions{Payload: payload}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(detached, VerifyOptions{}); err == nil {
+		t.Fatal("detached JWS verified without its payload")
+	}
+	if _, err := Verify(detached, VerifyOptions{Payload: []byte(`{"consortiumDatabase":{}}`)}); err == nil {
+		t.Fatal("detached JWS verified with another payload")
+	}
+}
+
+func TestFetchAndVerify(t *testing.T) {
+	jws, _ := loadNodeJws(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != ConsortiumJwsPath {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jws": jws})
+	}))
+	defer server.Close()
+
+	verified, err := FetchAndVerify(context.Background(), server.URL+"/", nil, VerifyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified.Database.Consortium[0].Name != "Test Consortium" {
+		t.Fatalf("unexpected consortium %+v", verified.Database.Consortium)
+	}
+	if _, err := Fetch(context.Background(), server.URL+"/api", nil); err == nil {
+		t.Fatal("fetched JWS from a wrong path")
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/hyperledger/cactus-core-api/src/main/go/consortium
+
+go 1.21
+
+require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
+	github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client v0.0.0
+)
+
+replace github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client => ../generated/openapi/go-client
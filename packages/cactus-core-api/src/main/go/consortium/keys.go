@@ -0,0 +1,218 @@
+package consortium
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+/*
+The nodes sign with ES256K (ECDSA on secp256k1) by default, a curve the x509 package does not support, so the
+PKIX and PKCS #8 encodings of secp256k1 keys are handled here; other keys are left to the x509 package.
+*/
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// Signature algorithms supported
+const (
+	ES256K = "ES256K"
+	ES256  = "ES256"
+	ES384  = "ES384"
+	EdDSA  = "EdDSA"
+)
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type pkcs8 struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+func decodePEM(keyPem string, blockType string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(keyPem))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("PEM block of type %s expected, found %s", blockType, block.Type)
+	}
+	return block.Bytes, nil
+}
+
+// isSecp256k1 tells whether an algorithm identifier is that of an EC key on secp256k1
+func isSecp256k1(algorithm pkix.AlgorithmIdentifier) bool {
+	var curve asn1.ObjectIdentifier
+	if !algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return false
+	}
+	_, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &curve)
+	return err == nil && curve.Equal(oidSecp256k1)
+}
+
+/*
+ParsePublicKeyPEM parses a PEM encoded PKIX public key, as found in CactusNode.PublicKeyPem. The key is returned as a
+*secp256k1.PublicKey, an *ecdsa.PublicKey or an ed25519.PublicKey.
+*/
+func ParsePublicKeyPEM(publicKeyPem string) (crypto.PublicKey, error) {
+	der, err := decodePEM(publicKeyPem, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	var info subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) > 0 {
+		return nil, errors.New("malformed public key")
+	}
+	if isSecp256k1(info.Algorithm) {
+		return secp256k1.ParsePubKey(info.PublicKey.Bytes)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+/*
+ParsePrivateKeyPEM parses a PEM encoded PKCS #8 private key, such as the keyPairPem of the consortium plugin. The key
+is returned as a *secp256k1.PrivateKey, an *ecdsa.PrivateKey or an ed25519.PrivateKey.
+*/
+func ParsePrivateKeyPEM(privateKeyPem string) (crypto.PrivateKey, error) {
+	der, err := decodePEM(privateKeyPem, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	var info pkcs8
+	rest, err := asn1.Unmarshal(der, &info)
+	if err != nil || len(rest) > 0 {
+		return nil, errors.New("malformed private key")
+	}
+	if isSecp256k1(info.Algorithm) {
+		var ecKey ecPrivateKey
+		_, err = asn1.Unmarshal(info.PrivateKey, &ecKey)
+		if err != nil || len(ecKey.PrivateKey) != 32 {
+			return nil, errors.New("malformed secp256k1 private key")
+		}
+		return secp256k1.PrivKeyFromBytes(ecKey.PrivateKey), nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// marshalPublicKeyPEM encodes a public key returned by ParsePublicKeyPEM in PEM
+func marshalPublicKeyPEM(key crypto.PublicKey) (string, error) {
+	var der []byte
+	var err error
+	if secpKey, ok := key.(*secp256k1.PublicKey); ok {
+		var curve []byte
+		curve, err = asn1.Marshal(oidSecp256k1)
+		if err != nil {
+			return "", err
+		}
+		point := secpKey.SerializeUncompressed()
+		der, err = asn1.Marshal(subjectPublicKeyInfo{
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}},
+			PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+		})
+	} else {
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// algorithm returns the JWS algorithm a key signs with
+func algorithm(key crypto.PublicKey) (string, error) {
+	switch key := key.(type) {
+	case *secp256k1.PublicKey:
+		return ES256K, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return ES256, nil
+		case elliptic.P384():
+			return ES384, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return EdDSA, nil
+	}
+	return "", fmt.Errorf("unsupported public key type %T", key)
+}
+
+// verifySignature verifies a JWS signature, in the format of RFC 7518, made with a key
+func verifySignature(alg string, key crypto.PublicKey, input []byte, signature []byte) bool {
+	keyAlg, err := algorithm(key)
+	if err != nil || keyAlg != alg {
+		return false
+	}
+	switch key := key.(type) {
+	case *secp256k1.PublicKey:
+		if len(signature) != 64 {
+			return false
+		}
+		var r, s secp256k1.ModNScalar
+		if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) || r.IsZero() || s.IsZero() {
+			return false
+		}
+		hash := sha256.Sum256(input)
+		return secp256k1ecdsa.NewSignature(&r, &s).Verify(hash[:], key)
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if alg == ES384 {
+			hash := sha512.Sum384(input)
+			return ecdsa.Verify(key, hash[:], r, s)
+		}
+		hash := sha256.Sum256(input)
+		return ecdsa.Verify(key, hash[:], r, s)
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, input, signature)
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package consortium
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+/*
+Signer signs consortium databases the way a node of the consortium does. It is meant for tests and tools that stand
+in for Cactus nodes.
+*/
+type Signer struct {
+	key       crypto.PrivateKey
+	publicKey crypto.PublicKey
+	alg       string
+}
+
+// GenerateSigner generates an ES256K key, the type of the keys of Cactus nodes
+func GenerateSigner() (*Signer, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return newSigner(key)
+}
+
+// NewSigner returns a signer with a PEM encoded PKCS #8 private key, such as the keyPairPem of a node
+func NewSigner(privateKeyPem string) (*Signer, error) {
+	key, err := ParsePrivateKeyPEM(privateKeyPem)
+	if err != nil {
+		return nil, err
+	}
+	return newSigner(key)
+}
+
+func newSigner(key crypto.PrivateKey) (*Signer, error) {
+	var publicKey crypto.PublicKey
+	switch key := key.(type) {
+	case *secp256k1.PrivateKey:
+		publicKey = key.PubKey()
+	case *ecdsa.PrivateKey:
+		publicKey = &key.PublicKey
+	case ed25519.PrivateKey:
+		publicKey = key.Public()
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	alg, err := algorithm(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, publicKey: publicKey, alg: alg}, nil
+}
+
+// PublicKeyPEM returns the PEM encoded public key of the signer, to set as the publicKeyPem of its node
+func (s *Signer) PublicKeyPEM() string {
+	keyPem, _ := marshalPublicKeyPEM(s.publicKey)
+	return keyPem
+}
+
+func (s *Signer) sign(input []byte) ([]byte, error) {
+	switch key := s.key.(type) {
+	case *secp256k1.PrivateKey:
+		hash := sha256.Sum256(input)
+		// the compact signature is the recovery code followed by R and S
+		return secp256k1ecdsa.SignCompact(key, hash[:], false)[1:], nil
+	case *ecdsa.PrivateKey:
+		var hash []byte
+		if s.alg == ES384 {
+			sum := sha512.Sum384(input)
+			hash = sum[:]
+		} else {
+			sum := sha256.Sum256(input)
+			hash = sum[:]
+		}
+		r, sig, err := ecdsa.Sign(rand.Reader, key, hash)
+		if err != nil {
+			return nil, err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		sig.FillBytes(signature[size:])
+		return signature, nil
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, input), nil
+	}
+	return nil, errors.New("unsupported key")
+}
+
+/*
+Sign signs a consortium database with the keys of nodes, producing a consortium JWS. Like the nodes, it signs the
+database serialized with sorted keys, with the protected header {"alg": ..., "_protected": {"iat", "jti", "iss"}}.
+*/
+func Sign(db *ConsortiumDatabase, signers ...*Signer) (*JWSGeneral, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	// round-trip through a map to sort the keys of the objects, like safe-stable-stringify
+	var sorted interface{}
+	dbJson, err := json.Marshal(db)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(dbJson, &sorted)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(map[string]interface{}{"consortiumDatabase": sorted})
+	if err != nil {
+		return nil, err
+	}
+	jws := &JWSGeneral{Payload: base64.RawURLEncoding.EncodeToString(payload)}
+	for _, signer := range signers {
+		jti := make([]byte, 16)
+		_, err = rand.Read(jti)
+		if err != nil {
+			return nil, err
+		}
+		// random UUID, like the jti set by the nodes
+		jti[6], jti[8] = jti[6]&0x0f|0x40, jti[8]&0x3f|0x80
+		protectedJson, err := json.Marshal(map[string]interface{}{
+			"alg": signer.alg,
+			"_protected": map[string]interface{}{
+				"iat": time.Now().UnixMilli(),
+				"jti": fmt.Sprintf("%x-%x-%x-%x-%x", jti[0:4], jti[4:6], jti[6:8], jti[8:10], jti[10:]),
+				"iss": "Hyperledger Cactus",
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		protected := base64.RawURLEncoding.EncodeToString(protectedJson)
+		signature, err := signer.sign([]byte(protected + "." + jws.Payload))
+		if err != nil {
+			return nil, err
+		}
+		jws.Signatures = append(jws.Signatures, JWSRecipient{
+			Signature: base64.RawURLEncoding.EncodeToString(signature),
+			Protected: &protected,
+		})
+	}
+	return jws, nil
+}
+
+// Detach removes the payload of a JWS, returning it decoded to be passed along separately
+func Detach(jws *JWSGeneral) (*JWSGeneral, []byte, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, nil, errors.New("JWS payload is not base64url encoded")
+	}
+	detached := *jws
+	detached.Payload = ""
+	return &detached, payload, nil
+}
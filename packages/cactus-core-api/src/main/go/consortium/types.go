@@ -0,0 +1,102 @@
+package consortium
+
+import (
+	coreapi "github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client"
+)
+
+// JWSGeneral is a JWS in the general JSON serialization; the payload is empty for a detached JWS
+type JWSGeneral = coreapi.JWSGeneral
+
+// JWSRecipient is a signature of a JWS
+type JWSRecipient = coreapi.JWSRecipient
+
+type Consortium = coreapi.Consortium
+
+// ConsortiumMember is an organization of the consortium
+type ConsortiumMember = coreapi.ConsortiumMember
+
+// CactusNode is an API server run by a consortium member, signing the consortium database with the key of PublicKeyPem
+type CactusNode = coreapi.CactusNode
+
+// LedgerType is the type of a ledger, e.g. BESU_2X or FABRIC_2
+type LedgerType = coreapi.LedgerType
+
+// Ledger reachable through the nodes of the consortium
+type Ledger = coreapi.Ledger
+
+// PluginInstance is a plugin hosted by a node
+type PluginInstance = coreapi.PluginInstance
+
+// ConsortiumDatabase is the metadata of a consortium
+type ConsortiumDatabase coreapi.ConsortiumDatabase
+
+// Node returns the node with the given ID, or nil
+func (db *ConsortiumDatabase) Node(id string) *CactusNode {
+	for i := range db.CactusNode {
+		if db.CactusNode[i].Id == id {
+			return &db.CactusNode[i]
+		}
+	}
+	return nil
+}
+
+// MemberNodes returns the nodes run by a consortium member
+func (db *ConsortiumDatabase) MemberNodes(memberId string) []CactusNode {
+	var nodes []CactusNode
+	for _, node := range db.CactusNode {
+		if node.MemberId == memberId {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// LedgerNodes returns the nodes a ledger is reachable through
+func (db *ConsortiumDatabase) LedgerNodes(ledgerId string) []CactusNode {
+	var nodes []CactusNode
+	for _, node := range db.CactusNode {
+		if contains(node.LedgerIds, ledgerId) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// NodeLedgers returns the ledgers reachable through a node
+func (db *ConsortiumDatabase) NodeLedgers(nodeId string) []Ledger {
+	node := db.Node(nodeId)
+	if node == nil {
+		return nil
+	}
+	var ledgers []Ledger
+	for _, ledger := range db.Ledger {
+		if contains(node.LedgerIds, ledger.Id) {
+			ledgers = append(ledgers, ledger)
+		}
+	}
+	return ledgers
+}
+
+// NodePlugins returns the plugin instances hosted by a node
+func (db *ConsortiumDatabase) NodePlugins(nodeId string) []PluginInstance {
+	node := db.Node(nodeId)
+	if node == nil {
+		return nil
+	}
+	var plugins []PluginInstance
+	for _, plugin := range db.PluginInstance {
+		if contains(node.PluginInstanceIds, plugin.Id) {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
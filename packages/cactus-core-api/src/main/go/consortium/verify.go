@@ -0,0 +1,184 @@
+/*
+Package consortium verifies the consortium JWS served by the consortium plugins of the Cactus API server, and exposes
+the topology of the consortium it asserts: members, nodes, ledgers and plugin instances.
+
+The consortium JWS is a JWS in the general JSON serialization whose payload is {"consortiumDatabase": ...}, signed by
+each node of the consortium with the key published as its publicKeyPem.
+*/
+package consortium
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// VerifyOptions of the verification of a consortium JWS
+type VerifyOptions struct {
+	/*
+		PEM encoded public keys trusted beforehand, e.g. that of the node the JWS is fetched from. If set, one of them at
+		least must have signed; otherwise the JWS only proves that its nodes agree on the consortium database.
+	*/
+	TrustedKeys []string
+	// Accept the signatures of part of the nodes; by default every node of the consortium database must have signed
+	AllowPartial bool
+	// Payload of a detached JWS, whose payload member is empty
+	Payload []byte
+}
+
+// Verified is the content of a verified consortium JWS
+type Verified struct {
+	Database ConsortiumDatabase
+	// IDs of the nodes that signed, in the order of the signatures
+	Signers []string
+}
+
+// header is the part of the JOSE header relevant to the verification
+type header struct {
+	Alg  string   `json:"alg"`
+	Crit []string `json:"crit"`
+	B64  *bool    `json:"b64"`
+}
+
+/*
+Verify verifies a consortium JWS: every signature must be valid and made by the key of a node of the consortium
+database, and every node must have signed unless options.AllowPartial is set.
+*/
+func Verify(jws *JWSGeneral, options VerifyOptions) (*Verified, error) {
+	if jws == nil || len(jws.Signatures) == 0 {
+		return nil, errors.New("JWS has no signatures")
+	}
+	payload, encodedPayload, err := jwsPayload(jws, options.Payload)
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		ConsortiumDatabase *ConsortiumDatabase `json:"consortiumDatabase"`
+	}
+	err = json.Unmarshal(payload, &body)
+	if err != nil || body.ConsortiumDatabase == nil {
+		return nil, errors.New("JWS payload is not a consortium database")
+	}
+	db := body.ConsortiumDatabase
+
+	nodeKeys := make([]crypto.PublicKey, len(db.CactusNode))
+	for i, node := range db.CactusNode {
+		nodeKeys[i], err = ParsePublicKeyPEM(node.PublicKeyPem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of node %s: %v", node.Id, err)
+		}
+	}
+	trustedKeys := make([]string, len(options.TrustedKeys))
+	for i, keyPem := range options.TrustedKeys {
+		key, err := ParsePublicKeyPEM(keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted key: %v", err)
+		}
+		// compare keys by their canonical encoding
+		trustedKeys[i], err = marshalPublicKeyPEM(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verified := &Verified{Database: *db}
+	signed := make([]bool, len(db.CactusNode))
+	trusted := false
+	for i, recipient := range jws.Signatures {
+		alg, protected, err := recipientHeader(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %v", i, err)
+		}
+		signature, err := base64.RawURLEncoding.DecodeString(recipient.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d is not base64url encoded", i)
+		}
+		input := []byte(protected + "." + encodedPayload)
+		signer := -1
+		for j, key := range nodeKeys {
+			if verifySignature(alg, key, input, signature) {
+				signer = j
+				break
+			}
+		}
+		if signer < 0 {
+			return nil, fmt.Errorf("signature %d is not valid for any node of the consortium", i)
+		}
+		if signed[signer] {
+			continue
+		}
+		signed[signer] = true
+		verified.Signers = append(verified.Signers, db.CactusNode[signer].Id)
+		keyPem, err := marshalPublicKeyPEM(nodeKeys[signer])
+		if err != nil {
+			return nil, err
+		}
+		trusted = trusted || contains(trustedKeys, keyPem)
+	}
+
+	if !options.AllowPartial {
+		for i, node := range db.CactusNode {
+			if !signed[i] {
+				return nil, fmt.Errorf("node %s did not sign", node.Id)
+			}
+		}
+	}
+	if len(trustedKeys) > 0 && !trusted {
+		return nil, errors.New("no signature by a trusted key")
+	}
+	return verified, nil
+}
+
+// jwsPayload returns the decoded and encoded payload of a JWS, which is detachedPayload for a detached JWS
+func jwsPayload(jws *JWSGeneral, detachedPayload []byte) ([]byte, string, error) {
+	if jws.Payload == "" {
+		if detachedPayload == nil {
+			return nil, "", errors.New("JWS is detached but no payload is given")
+		}
+		return detachedPayload, base64.RawURLEncoding.EncodeToString(detachedPayload), nil
+	}
+	if detachedPayload != nil {
+		return nil, "", errors.New("payload given for a JWS that is not detached")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, "", errors.New("JWS payload is not base64url encoded")
+	}
+	return payload, jws.Payload, nil
+}
+
+// recipientHeader returns the algorithm and the encoded protected header of a signature
+func recipientHeader(recipient JWSRecipient) (string, string, error) {
+	var protected header
+	encoded := ""
+	if recipient.Protected != nil {
+		encoded = *recipient.Protected
+		decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", "", errors.New("protected header is not base64url encoded")
+		}
+		err = json.Unmarshal(decoded, &protected)
+		if err != nil {
+			return "", "", errors.New("malformed protected header")
+		}
+	}
+	// the header parameters understood are not extensions, so none can be critical
+	if len(protected.Crit) > 0 {
+		return "", "", fmt.Errorf("unsupported critical header parameters %s", strings.Join(protected.Crit, ", "))
+	}
+	if protected.B64 != nil && !*protected.B64 {
+		return "", "", errors.New("unencoded payloads are not supported")
+	}
+	alg := protected.Alg
+	if alg == "" {
+		// the algorithm may be in the unprotected header instead
+		alg, _ = recipient.Header["alg"].(string)
+	}
+	if alg == "" {
+		return "", "", errors.New("no algorithm in header")
+	}
+	return alg, encoded, nil
+}
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,7 +20,7 @@ type ConsensusAlgorithmFamiliesWithOutTxFinality string
 
 // List of ConsensusAlgorithmFamiliesWithOutTxFinality
 const (
-	WORK ConsensusAlgorithmFamiliesWithOutTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_WORK"
+	CONSENSUSALGORITHMFAMILIESWITHOUTTXFINALITY_WORK ConsensusAlgorithmFamiliesWithOutTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_WORK"
 )
 
 // All allowed values of ConsensusAlgorithmFamiliesWithOutTxFinality enum
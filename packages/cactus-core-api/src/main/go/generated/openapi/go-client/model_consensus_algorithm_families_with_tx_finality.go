@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,8 +20,8 @@ type ConsensusAlgorithmFamiliesWithTxFinality string
 
 // List of ConsensusAlgorithmFamiliesWithTxFinality
 const (
-	AUTHORITY ConsensusAlgorithmFamiliesWithTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_AUTHORITY"
-	STAKE ConsensusAlgorithmFamiliesWithTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_STAKE"
+	CONSENSUSALGORITHMFAMILIESWITHTXFINALITY_AUTHORITY ConsensusAlgorithmFamiliesWithTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_AUTHORITY"
+	CONSENSUSALGORITHMFAMILIESWITHTXFINALITY_STAKE ConsensusAlgorithmFamiliesWithTxFinality = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_STAKE"
 )
 
 // All allowed values of ConsensusAlgorithmFamiliesWithTxFinality enum
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,9 +20,9 @@ type ConsensusAlgorithmFamily string
 
 // List of ConsensusAlgorithmFamily
 const (
-	AUTHORITY ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_AUTHORITY"
-	STAKE ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_STAKE"
-	WORK ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_WORK"
+	CONSENSUSALGORITHMFAMILY_AUTHORITY ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_AUTHORITY"
+	CONSENSUSALGORITHMFAMILY_STAKE ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_STAKE"
+	CONSENSUSALGORITHMFAMILY_WORK ConsensusAlgorithmFamily = "org.hyperledger.cactus.consensusalgorithm.PROOF_OF_WORK"
 )
 
 // All allowed values of ConsensusAlgorithmFamily enum
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,7 +20,7 @@ type Constants string
 
 // List of Constants
 const (
-	SocketIoConnectionPathV1 Constants = "/api/v1/async/socket-io/connect"
+	CONSTANTS_SocketIoConnectionPathV1 Constants = "/api/v1/async/socket-io/connect"
 )
 
 // All allowed values of Constants enum
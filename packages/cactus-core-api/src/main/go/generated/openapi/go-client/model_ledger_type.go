@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,13 +20,13 @@ type LedgerType string
 
 // List of LedgerType
 const (
-	BESU_1_X LedgerType = "BESU_1X"
-	BESU_2_X LedgerType = "BESU_2X"
-	BURROW_0_X LedgerType = "BURROW_0X"
-	CORDA_4_X LedgerType = "CORDA_4X"
-	ETHEREUM LedgerType = "ETHEREUM"
-	FABRIC_2 LedgerType = "FABRIC_2"
-	SAWTOOTH_1_X LedgerType = "SAWTOOTH_1X"
+	LEDGERTYPE_BESU_1_X LedgerType = "BESU_1X"
+	LEDGERTYPE_BESU_2_X LedgerType = "BESU_2X"
+	LEDGERTYPE_BURROW_0_X LedgerType = "BURROW_0X"
+	LEDGERTYPE_CORDA_4_X LedgerType = "CORDA_4X"
+	LEDGERTYPE_ETHEREUM LedgerType = "ETHEREUM"
+	LEDGERTYPE_FABRIC_2 LedgerType = "FABRIC_2"
+	LEDGERTYPE_SAWTOOTH_1_X LedgerType = "SAWTOOTH_1X"
 )
 
 // All allowed values of LedgerType enum
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,8 +20,8 @@ type PluginImportAction string
 
 // List of PluginImportAction
 const (
-	INSTANTIATE PluginImportAction = "org.hyperledger.cactus.plugin_import_action.INSTANTIATE"
-	INSTALL PluginImportAction = "org.hyperledger.cactus.plugin_import_action.INSTALL"
+	PLUGINIMPORTACTION_INSTANTIATE PluginImportAction = "org.hyperledger.cactus.plugin_import_action.INSTANTIATE"
+	PLUGINIMPORTACTION_INSTALL PluginImportAction = "org.hyperledger.cactus.plugin_import_action.INSTALL"
 )
 
 // All allowed values of PluginImportAction enum
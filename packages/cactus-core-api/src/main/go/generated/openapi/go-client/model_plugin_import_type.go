@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-core-api
+package coreapi
 
 import (
 	"encoding/json"
@@ -20,8 +20,8 @@ type PluginImportType string
 
 // List of PluginImportType
 const (
-	LOCAL PluginImportType = "org.hyperledger.cactus.plugin_import_type.LOCAL"
-	REMOTE PluginImportType = "org.hyperledger.cactus.plugin_import_type.REMOTE"
+	PLUGINIMPORTTYPE_LOCAL PluginImportType = "org.hyperledger.cactus.plugin_import_type.LOCAL"
+	PLUGINIMPORTTYPE_REMOTE PluginImportType = "org.hyperledger.cactus.plugin_import_type.REMOTE"
 )
 
 // All allowed values of PluginImportType enum
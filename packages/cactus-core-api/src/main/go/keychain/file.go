@@ -0,0 +1,224 @@
+package keychain
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Iterations of PBKDF2-SHA256 deriving the key of new File keychains from their passphrase
+const FileIterations = 600000
+
+// ErrPassphrase is returned when a File keychain cannot be decrypted
+var ErrPassphrase = errors.New("wrong passphrase, or corrupted keychain file")
+
+// fileFormat is the content of the file of a File keychain: the entries, as a JSON object, sealed with AES-256-GCM
+type fileFormat struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// additionalData binds the ciphertext to the parameters of the key derivation
+func (f *fileFormat) additionalData() []byte {
+	return []byte(fmt.Sprintf("cactus-keychain:%d:%s:%d:%x", f.Version, f.KDF, f.Iterations, f.Salt))
+}
+
+/*
+File is a keychain encrypted in a local file, with a key derived from a passphrase. The file is read on every
+operation and replaced on every change, so that a keychain can be shared by processes; concurrent changes from
+different processes are not merged, the last one wins.
+*/
+type File struct {
+	path       string
+	passphrase string
+	mutex      sync.Mutex
+	// key derived with the salt and iterations of the file
+	salt       []byte
+	iterations int
+	aead       cipher.AEAD
+}
+
+/*
+OpenFile opens the keychain of a file, created on the first Set if it does not exist. The passphrase is checked against
+an existing file.
+*/
+func OpenFile(path string, passphrase string) (*File, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase expected")
+	}
+	f := &File{path: path, passphrase: passphrase}
+	_, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) Get(ctx context.Context, key string) (string, error) {
+	if err := checkEntry(key, nil); err != nil {
+		return "", err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	value, ok := entries[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (f *File) Set(ctx context.Context, key string, value string) error {
+	if err := checkEntry(key, &value); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	entries[key] = value
+	return f.write(entries)
+}
+
+func (f *File) Has(ctx context.Context, key string) (bool, error) {
+	if err := checkEntry(key, nil); err != nil {
+		return false, err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return false, err
+	}
+	_, ok := entries[key]
+	return ok, nil
+}
+
+func (f *File) Delete(ctx context.Context, key string) error {
+	if err := checkEntry(key, nil); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	entries, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[key]; !ok {
+		return nil
+	}
+	delete(entries, key)
+	return f.write(entries)
+}
+
+// read decrypts the entries of the file, deriving the key again only if the file has another salt
+func (f *File) read() (map[string]string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	content := &fileFormat{}
+	err = json.Unmarshal(data, content)
+	if err != nil || content.Version != 1 || content.KDF != "pbkdf2-sha256" || content.Iterations <= 0 {
+		return nil, fmt.Errorf("%s is not a keychain file", f.path)
+	}
+	err = f.derive(content.Salt, content.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := f.aead.Open(nil, content.Nonce, content.Ciphertext, content.additionalData())
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	entries := map[string]string{}
+	err = json.Unmarshal(plaintext, &entries)
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	return entries, nil
+}
+
+// write encrypts the entries with a new nonce to a temporary file that replaces the file
+func (f *File) write(entries map[string]string) error {
+	if f.aead == nil {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		if err := f.derive(salt, FileIterations); err != nil {
+			return err
+		}
+	}
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	content := &fileFormat{Version: 1, KDF: "pbkdf2-sha256", Iterations: f.iterations, Salt: f.salt,
+		Nonce: make([]byte, f.aead.NonceSize())}
+	if _, err := rand.Read(content.Nonce); err != nil {
+		return err
+	}
+	content.Ciphertext = f.aead.Seal(nil, content.Nonce, plaintext, content.additionalData())
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(f.path), ".keychain-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), f.path)
+}
+
+func (f *File) derive(salt []byte, iterations int) error {
+	if f.aead != nil && string(salt) == string(f.salt) && iterations == f.iterations {
+		return nil
+	}
+	key, err := pbkdf2.Key(sha256.New, f.passphrase, salt, iterations, 32)
+	if err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	f.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	f.salt, f.iterations = salt, iterations
+	return nil
+}
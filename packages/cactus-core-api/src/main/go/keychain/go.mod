@@ -0,0 +1,7 @@
+module github.com/hyperledger/cactus-core-api/src/main/go/keychain
+
+go 1.24
+
+require github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client v0.0.0
+
+replace github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client => ../generated/openapi/go-client
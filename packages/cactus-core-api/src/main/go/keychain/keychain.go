@@ -0,0 +1,66 @@
+/*
+Package keychain stores secrets, such as certificates and private keys, in keychains with the semantics of the keychain
+plugins of Cactus: entries are strings under keys, that are set, read, checked for presence and deleted.
+
+Remote is a keychain hosted by an API server, through the endpoints of the keychain plugins, e.g. the
+cactus-plugin-keychain-memory one. File is a keychain encrypted with a passphrase in a local file.
+*/
+package keychain
+
+import (
+	"context"
+	"errors"
+
+	coreapi "github.com/hyperledger/cactus-core-api/src/main/go/generated/openapi/go-client"
+)
+
+// Keychain is a store of secrets
+type Keychain interface {
+	// Get returns the value of an entry, or ErrNotFound
+	Get(ctx context.Context, key string) (string, error)
+	// Set creates or replaces an entry
+	Set(ctx context.Context, key string, value string) error
+	Has(ctx context.Context, key string) (bool, error)
+	// Delete removes an entry; deleting a missing entry is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// ErrNotFound is returned by Get for missing entries
+var ErrNotFound = errors.New("keychain entry not found")
+
+// Limits of the keys and values of the keychain plugins
+const (
+	MaxKeyLength   = 1024
+	MaxValueLength = 10485760
+)
+
+// checkEntry checks a key, and a value if set, against the limits of the keychain plugins
+func checkEntry(key string, value *string) error {
+	if key == "" || len(key) > MaxKeyLength {
+		return errors.New("keychain entry key must be 1 to 1024 characters long")
+	}
+	if value != nil && len(*value) > MaxValueLength {
+		return errors.New("keychain entry value must be at most 10485760 characters long")
+	}
+	return nil
+}
+
+// The requests and responses of the keychain endpoints are the models of cactus-core-api, which the generated clients
+// of the keychain plugins share
+
+type GetKeychainEntryRequestV1 = coreapi.GetKeychainEntryRequestV1
+
+type GetKeychainEntryResponseV1 = coreapi.GetKeychainEntryResponseV1
+
+type SetKeychainEntryRequestV1 = coreapi.SetKeychainEntryRequestV1
+
+type SetKeychainEntryResponseV1 = coreapi.SetKeychainEntryResponseV1
+
+type HasKeychainEntryRequestV1 = coreapi.HasKeychainEntryRequestV1
+
+// HasKeychainEntryResponseV1 has the date and time of the check, encoded as JSON, in CheckedAt
+type HasKeychainEntryResponseV1 = coreapi.HasKeychainEntryResponseV1
+
+type DeleteKeychainEntryRequestV1 = coreapi.DeleteKeychainEntryRequestV1
+
+type DeleteKeychainEntryResponseV1 = coreapi.DeleteKeychainEntryResponseV1
@@ -0,0 +1,170 @@
+package keychain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryPlugin stands in for an API server hosting the cactus-plugin-keychain-memory plugin
+func memoryPlugin(t *testing.T) *httptest.Server {
+	var mutex sync.Mutex
+	entries := map[string]string{}
+	mux := http.NewServeMux()
+	handle := func(path string, handler func(key string, value string) (interface{}, error)) {
+		mux.HandleFunc(MemoryPluginPath+path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var request SetKeychainEntryRequestV1
+			json.NewDecoder(r.Body).Decode(&request)
+			mutex.Lock()
+			defer mutex.Unlock()
+			response, err := handler(request.Key, request.Value)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(&Error{Message: "Internal Server Error", Exception: err.Error()})
+				return
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+	}
+	handle(GetKeychainEntryPath, func(key string, value string) (interface{}, error) {
+		// like the plugin, empty values are missing
+		if entries[key] == "" {
+			return nil, errors.New(`Keychain entry for "` + key + `" not found.`)
+		}
+		return &GetKeychainEntryResponseV1{Key: key, Value: entries[key]}, nil
+	})
+	handle(SetKeychainEntryPath, func(key string, value string) (interface{}, error) {
+		if key == "broken" {
+			return nil, errors.New("backend down")
+		}
+		entries[key] = value
+		return &SetKeychainEntryResponseV1{Key: key}, nil
+	})
+	handle(HasKeychainEntryPath, func(key string, value string) (interface{}, error) {
+		_, ok := entries[key]
+		return &HasKeychainEntryResponseV1{Key: key, IsPresent: ok, CheckedAt: time.Now().Format(time.RFC3339)}, nil
+	})
+	handle(DeleteKeychainEntryPath, func(key string, value string) (interface{}, error) {
+		delete(entries, key)
+		return &DeleteKeychainEntryResponseV1{Key: key}, nil
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testKeychain runs the operations every keychain supports
+func testKeychain(t *testing.T, k Keychain) {
+	ctx := context.Background()
+	if _, err := k.Get(ctx, "cert"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing entry found: %v", err)
+	}
+	if present, err := k.Has(ctx, "cert"); err != nil || present {
+		t.Fatalf("missing entry present: %v", err)
+	}
+	pem := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	if err := k.Set(ctx, "cert", pem); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := k.Get(ctx, "cert"); err != nil || value != pem {
+		t.Fatalf("got %q, %v", value, err)
+	}
+	if present, err := k.Has(ctx, "cert"); err != nil || !present {
+		t.Fatalf("entry not present: %v", err)
+	}
+	if err := k.Set(ctx, "cert", "replaced"); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := k.Get(ctx, "cert"); value != "replaced" {
+		t.Errorf("entry not replaced: %q", value)
+	}
+	if err := k.Delete(ctx, "cert"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Get(ctx, "cert"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted entry found: %v", err)
+	}
+	if err := k.Delete(ctx, "cert"); err != nil {
+		t.Errorf("missing entry not deleted: %v", err)
+	}
+	if err := k.Set(ctx, "", "value"); err == nil {
+		t.Errorf("empty key accepted")
+	}
+	if err := k.Set(ctx, strings.Repeat("k", MaxKeyLength+1), "value"); err == nil {
+		t.Errorf("long key accepted")
+	}
+}
+
+func TestRemote(t *testing.T) {
+	server := memoryPlugin(t)
+	k := NewRemote(RemoteConfig{URL: server.URL + "/", Header: http.Header{"Authorization": {"Bearer token"}}})
+	testKeychain(t, k)
+
+	var apiError *Error
+	if err := k.Set(context.Background(), "broken", "value"); !errors.As(err, &apiError) ||
+		apiError.StatusCode != http.StatusInternalServerError || apiError.Exception != "backend down" {
+		t.Errorf("unexpected error %v", err)
+	}
+	unauthorized := NewRemote(RemoteConfig{URL: server.URL})
+	if _, err := unauthorized.Get(context.Background(), "cert"); !errors.As(err, &apiError) ||
+		apiError.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keychain.json")
+	k, err := OpenFile(path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testKeychain(t, k)
+	if err := k.Set(context.Background(), "key", "secret value"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret value") {
+		t.Errorf("entry stored in clear")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("keychain file readable by others: %v", info.Mode())
+	}
+
+	// another process shares the file
+	other, err := OpenFile(path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := other.Get(context.Background(), "key"); err != nil || value != "secret value" {
+		t.Errorf("got %q, %v", value, err)
+	}
+	if _, err := OpenFile(path, "wrong"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("wrong passphrase accepted: %v", err)
+	}
+
+	// the ciphertext is bound to the key derivation parameters
+	content := &fileFormat{}
+	json.Unmarshal(data, content)
+	content.Iterations++
+	data, _ = json.Marshal(content)
+	os.WriteFile(path, data, 0o600)
+	if _, err := OpenFile(path, "passphrase"); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("tampered file accepted: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package keychain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Base path of the endpoints of the cactus-plugin-keychain-memory plugin
+const MemoryPluginPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-keychain-memory"
+
+// Paths of the endpoints of a keychain plugin, relative to its base path
+const (
+	GetKeychainEntryPath    = "/get-keychain-entry"
+	SetKeychainEntryPath    = "/set-keychain-entry"
+	HasKeychainEntryPath    = "/has-keychain-entry"
+	DeleteKeychainEntryPath = "/delete-keychain-entry"
+)
+
+// Error is an error response of the API server
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("API server responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API server responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// RemoteConfig configures a Remote keychain
+type RemoteConfig struct {
+	// URL of the API server, e.g. http://localhost:4000
+	URL string
+	// Base path of the endpoints of the keychain plugin; defaults to MemoryPluginPath
+	PluginPath string
+	// Defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers set on every request, e.g. Authorization
+	Header http.Header
+}
+
+// Remote is a keychain hosted by an API server
+type Remote struct {
+	config RemoteConfig
+}
+
+func NewRemote(config RemoteConfig) *Remote {
+	if config.PluginPath == "" {
+		config.PluginPath = MemoryPluginPath
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	config.PluginPath = "/" + strings.Trim(config.PluginPath, "/")
+	return &Remote{config: config}
+}
+
+/*
+Get returns the value of an entry. The plugins fail the same way on missing entries and on backend errors, so a failed
+Get is followed by a presence check to return ErrNotFound.
+*/
+func (r *Remote) Get(ctx context.Context, key string) (string, error) {
+	if err := checkEntry(key, nil); err != nil {
+		return "", err
+	}
+	response := &GetKeychainEntryResponseV1{}
+	err := r.post(ctx, GetKeychainEntryPath, &GetKeychainEntryRequestV1{Key: key}, response)
+	if _, ok := err.(*Error); ok {
+		if present, hasErr := r.Has(ctx, key); hasErr == nil && !present {
+			return "", ErrNotFound
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return response.Value, nil
+}
+
+func (r *Remote) Set(ctx context.Context, key string, value string) error {
+	if err := checkEntry(key, &value); err != nil {
+		return err
+	}
+	return r.post(ctx, SetKeychainEntryPath, &SetKeychainEntryRequestV1{Key: key, Value: value},
+		&SetKeychainEntryResponseV1{})
+}
+
+func (r *Remote) Has(ctx context.Context, key string) (bool, error) {
+	if err := checkEntry(key, nil); err != nil {
+		return false, err
+	}
+	response := &HasKeychainEntryResponseV1{}
+	err := r.post(ctx, HasKeychainEntryPath, &HasKeychainEntryRequestV1{Key: key}, response)
+	if err != nil {
+		return false, err
+	}
+	return response.IsPresent, nil
+}
+
+func (r *Remote) Delete(ctx context.Context, key string) error {
+	if err := checkEntry(key, nil); err != nil {
+		return err
+	}
+	return r.post(ctx, DeleteKeychainEntryPath, &DeleteKeychainEntryRequestV1{Key: key},
+		&DeleteKeychainEntryResponseV1{})
+}
+
+// post posts a request to an endpoint of the plugin and decodes the response
+func (r *Remote) post(ctx context.Context, path string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.URL+r.config.PluginPath+path,
+		bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range r.config.Header {
+		httpRequest.Header[name] = values
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := r.config.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		apiError := &Error{}
+		if json.Unmarshal(responseBody, apiError) != nil || apiError.Message == "" {
+			apiError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		apiError.StatusCode = httpResponse.StatusCode
+		return apiError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package socketio
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff is an exponential backoff between reconnection attempts
+type Backoff struct {
+	// Delay before the first attempt; defaults to 1 second
+	Min time.Duration
+	// Longest delay between attempts; defaults to 30 seconds
+	Max  time.Duration
+	next time.Duration
+}
+
+// Wait waits before the next attempt, returning false if the context is done first
+func (b *Backoff) Wait(ctx context.Context) bool {
+	if b.next == 0 {
+		b.next = b.Min
+		if b.next <= 0 {
+			b.next = time.Second
+		}
+	}
+	timer := time.NewTimer(b.next)
+	defer timer.Stop()
+	max := b.Max
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	b.next *= 2
+	if b.next > max {
+		b.next = max
+	}
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Reset makes the next attempt wait the minimum delay again, e.g. once connected
+func (b *Backoff) Reset() {
+	b.next = 0
+}
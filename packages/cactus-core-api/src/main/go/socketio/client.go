@@ -0,0 +1,147 @@
+/*
+Package socketio is a minimal Socket.IO v4 client over the websocket transport.
+
+The async APIs of the connectors, such as WatchBlocksV1, are served over Socket.IO by the API server and are
+not covered by the OpenAPI generated Go clients. This package only supports what these APIs use: events with
+JSON arguments on the main namespace. Binary packets, acknowledgements and HTTP long-polling are not supported.
+*/
+package socketio
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultPath is the path the API server serves Socket.IO on (SocketIoConnectionPathV1 of the core API)
+const DefaultPath = "/api/v1/async/socket-io/connect"
+
+// Options of a connection
+type Options struct {
+	// Path Socket.IO is served on; defaults to DefaultPath
+	Path string
+	// Headers of the handshake request, e.g. for authorization
+	Header http.Header
+	// TLS configuration for https and wss URLs
+	TLSConfig *tls.Config
+}
+
+// Client is a Socket.IO connection to a server
+type Client struct {
+	*conn
+	// Session ID given by the server
+	ID string
+}
+
+func socketURL(serverURL string, path string) (*url.URL, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return nil, fmt.Errorf("socket.io: unsupported URL scheme %q", u.Scheme)
+	}
+	if path == "" {
+		path = DefaultPath
+	}
+	// the server matches the path with a trailing slash
+	u.Path = strings.TrimSuffix(path, "/") + "/"
+	u.RawQuery = url.Values{"EIO": {"4"}, "transport": {"websocket"}}.Encode()
+	return u, nil
+}
+
+// Dial connects to the Socket.IO server at serverURL (e.g. http://localhost:4000) and joins the main namespace
+func Dial(ctx context.Context, serverURL string, options Options) (*Client, error) {
+	u, err := socketURL(serverURL, options.Path)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := dialWebSocket(ctx, u, options.Header, options.TLSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("socket.io: failed to connect to %s: %v", u.Redacted(), err)
+	}
+	c := &Client{conn: newConn(ws, 0)}
+
+	// the handshake must complete before the context is done
+	stop := context.AfterFunc(ctx, func() { ws.setReadDeadline(time.Now()) })
+	defer stop()
+	fail := func(err error) (*Client, error) {
+		ws.close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	data, err := c.expectPacket(string(engineOpen))
+	if err != nil {
+		return fail(err)
+	}
+	var open openPacket
+	err = json.Unmarshal([]byte(data), &open)
+	if err != nil {
+		return fail(fmt.Errorf("socket.io: malformed open packet: %v", err))
+	}
+	err = ws.writeText(string([]byte{engineMessage, socketConnect}))
+	if err != nil {
+		return fail(err)
+	}
+	data, err = c.expectPacket(string([]byte{engineMessage, socketConnect}))
+	if err != nil {
+		return fail(err)
+	}
+	var connect struct {
+		Sid string `json:"sid"`
+	}
+	json.Unmarshal([]byte(data), &connect)
+	c.ID = connect.Sid
+	if !stop() {
+		return fail(ctx.Err())
+	}
+
+	// the server pings every pingInterval, and gives up on a client that takes longer than pingTimeout to answer
+	if open.PingInterval > 0 {
+		c.readTimeout = time.Duration(open.PingInterval+open.PingTimeout) * time.Millisecond
+	}
+	go c.run()
+	return c, nil
+}
+
+// Emit sends an event with arguments, each of which is serialized to JSON
+func (c *Client) Emit(event string, args ...interface{}) error {
+	return c.emit(event, args)
+}
+
+// Events returns the events sent by the server. It must be drained, or the connection stalls.
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Done is closed when the connection ends
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection ended: nil if closed by the client, ErrDisconnected if closed by the server
+func (c *Client) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// Close leaves the namespace and closes the connection
+func (c *Client) Close() error {
+	c.ws.writeText(string([]byte{engineMessage, socketDisconnect}))
+	c.finish(nil)
+	return nil
+}
@@ -0,0 +1,142 @@
+package socketio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startServer(t *testing.T, server *Server) string {
+	mux := http.NewServeMux()
+	mux.Handle(DefaultPath+"/", server)
+	httpServer := httptest.NewServer(mux)
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	sockets := make(chan *Socket, 1)
+	url := startServer(t, &Server{
+		OnConnect:    func(s *Socket) { sockets <- s },
+		PingInterval: 20 * time.Millisecond,
+		PingTimeout:  50 * time.Millisecond,
+	})
+	client, err := Dial(context.Background(), url, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	socket := <-sockets
+	if client.ID != socket.ID {
+		t.Fatalf("client has session %q, server %q", client.ID, socket.ID)
+	}
+
+	err = client.Emit("Subscribe", map[string]string{"channelName": "mychannel"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := receive(t, socket.Events())
+	if event.Name != "Subscribe" || len(event.Args) != 1 || string(event.Args[0]) != `{"channelName":"mychannel"}` {
+		t.Fatalf("unexpected event %s %s", event.Name, event.Args)
+	}
+
+	// the connection outlives several ping intervals, and carries messages longer than 64 KiB
+	time.Sleep(200 * time.Millisecond)
+	large := strings.Repeat("a", 100000)
+	err = socket.Emit("Next", large, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event = receive(t, client.Events())
+	var data string
+	json.Unmarshal(event.Args[0], &data)
+	if event.Name != "Next" || data != large || string(event.Args[1]) != "2" {
+		t.Fatalf("unexpected event %s", event.Name)
+	}
+
+	socket.Disconnect()
+	<-client.Done()
+	if !errors.Is(client.Err(), ErrDisconnected) {
+		t.Fatalf("unexpected error %v", client.Err())
+	}
+	if client.Emit("Next") == nil {
+		t.Fatal("emitted on a closed connection")
+	}
+}
+
+func TestClose(t *testing.T) {
+	sockets := make(chan *Socket, 1)
+	url := startServer(t, &Server{OnConnect: func(s *Socket) { sockets <- s }})
+	client, err := Dial(context.Background(), url, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := <-sockets
+	client.Close()
+	<-socket.Done()
+	if client.Err() != nil {
+		t.Fatalf("unexpected error %v", client.Err())
+	}
+}
+
+func TestDialErrors(t *testing.T) {
+	url := startServer(t, &Server{})
+	_, err := Dial(context.Background(), url, Options{Path: "/socket.io"})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_, err = Dial(context.Background(), "ftp://localhost", Options{})
+	if err == nil {
+		t.Fatal("dialed an unsupported scheme")
+	}
+
+	// a server that never answers the handshake
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := acceptWebSocket(w, r)
+		if err == nil {
+			<-r.Context().Done()
+			conn.close()
+		}
+	}))
+	defer stalled.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = Dial(ctx, stalled.URL, Options{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	backoff := Backoff{Min: time.Millisecond, Max: 4 * time.Millisecond}
+	for _, expected := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond} {
+		if backoff.next != 0 && backoff.next != expected {
+			t.Fatalf("next delay is %v, expected %v", backoff.next, expected)
+		}
+		if !backoff.Wait(context.Background()) {
+			t.Fatal("wait was interrupted")
+		}
+	}
+	backoff.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if backoff.Wait(ctx) {
+		t.Fatal("waited with a done context")
+	}
+}
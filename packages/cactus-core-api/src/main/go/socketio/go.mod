@@ -0,0 +1,3 @@
+module github.com/hyperledger/cactus-core-api/src/main/go/socketio
+
+go 1.21
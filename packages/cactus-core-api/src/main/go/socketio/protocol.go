@@ -0,0 +1,214 @@
+package socketio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Engine.IO v4 packet types
+const (
+	engineOpen    = '0'
+	engineClose   = '1'
+	enginePing    = '2'
+	enginePong    = '3'
+	engineMessage = '4'
+)
+
+// Socket.IO v5 protocol packet types, carried in Engine.IO messages
+const (
+	socketConnect      = '0'
+	socketDisconnect   = '1'
+	socketEvent        = '2'
+	socketAck          = '3'
+	socketConnectError = '4'
+	socketBinaryEvent  = '5'
+	socketBinaryAck    = '6'
+)
+
+// ErrDisconnected is the error of connections the server disconnected
+var ErrDisconnected = errors.New("socket.io: disconnected by server")
+
+// Event is a Socket.IO event with its JSON arguments
+type Event struct {
+	Name string
+	Args []json.RawMessage
+}
+
+// openPacket is the payload of the Engine.IO open packet
+type openPacket struct {
+	Sid          string   `json:"sid"`
+	Upgrades     []string `json:"upgrades"`
+	PingInterval int      `json:"pingInterval"`
+	PingTimeout  int      `json:"pingTimeout"`
+	MaxPayload   int      `json:"maxPayload"`
+}
+
+func encodeEvent(name string, args []interface{}) (string, error) {
+	packet, err := json.Marshal(append([]interface{}{name}, args...))
+	if err != nil {
+		return "", err
+	}
+	return string([]byte{engineMessage, socketEvent}) + string(packet), nil
+}
+
+// decodeEvent decodes the data of an event packet for the main namespace: an optional ack ID and a JSON array
+func decodeEvent(data string) (*Event, error) {
+	data = strings.TrimLeft(data, "0123456789")
+	var items []json.RawMessage
+	err := json.Unmarshal([]byte(data), &items)
+	if err != nil {
+		return nil, fmt.Errorf("socket.io: malformed event: %v", err)
+	}
+	if len(items) == 0 {
+		return nil, errors.New("socket.io: event without a name")
+	}
+	event := &Event{Args: items[1:]}
+	err = json.Unmarshal(items[0], &event.Name)
+	if err != nil {
+		return nil, fmt.Errorf("socket.io: malformed event name: %v", err)
+	}
+	return event, nil
+}
+
+// conn is the event stream of a Socket.IO connection on the main namespace, shared by clients and servers
+type conn struct {
+	ws        *wsConn
+	events    chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+	mutex     sync.Mutex
+	err       error
+	// time allowed without receiving anything from the peer; zero for no limit
+	readTimeout time.Duration
+}
+
+func newConn(ws *wsConn, readTimeout time.Duration) *conn {
+	return &conn{
+		ws:          ws,
+		events:      make(chan Event, 64),
+		done:        make(chan struct{}),
+		readTimeout: readTimeout,
+	}
+}
+
+// finish closes the connection, recording why
+func (c *conn) finish(err error) {
+	c.closeOnce.Do(func() {
+		c.mutex.Lock()
+		c.err = err
+		c.mutex.Unlock()
+		c.ws.close()
+		close(c.done)
+	})
+}
+
+func (c *conn) emit(name string, args []interface{}) error {
+	packet, err := encodeEvent(name, args)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-c.done:
+		return c.closedError()
+	default:
+	}
+	return c.ws.writeText(packet)
+}
+
+func (c *conn) closedError() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	return errors.New("socket.io: connection closed")
+}
+
+func (c *conn) readPacket() (string, error) {
+	for {
+		if c.readTimeout > 0 {
+			c.ws.setReadDeadline(time.Now().Add(c.readTimeout))
+		}
+		opcode, message, err := c.ws.readMessage()
+		if err != nil {
+			return "", err
+		}
+		// binary frames only carry the attachments of binary packets, which are not supported
+		if opcode == opText && len(message) > 0 {
+			return string(message), nil
+		}
+	}
+}
+
+// run reads packets until the connection ends, answering pings and queueing events
+func (c *conn) run() {
+	for {
+		packet, err := c.readPacket()
+		if err != nil {
+			select {
+			case <-c.done:
+			default:
+				if err == io.EOF {
+					err = ErrDisconnected
+				}
+				c.finish(err)
+			}
+			return
+		}
+		switch packet[0] {
+		case enginePing:
+			c.ws.writeText(string(enginePong) + packet[1:])
+		case engineClose:
+			c.finish(ErrDisconnected)
+			return
+		case engineMessage:
+			if len(packet) < 2 {
+				continue
+			}
+			switch packet[1] {
+			case socketEvent:
+				event, err := decodeEvent(packet[2:])
+				if err != nil {
+					c.finish(err)
+					return
+				}
+				select {
+				case c.events <- *event:
+				case <-c.done:
+					return
+				}
+			case socketDisconnect:
+				c.finish(ErrDisconnected)
+				return
+			case socketBinaryEvent, socketBinaryAck:
+				c.finish(errors.New("socket.io: binary packets are not supported"))
+				return
+			}
+		}
+	}
+}
+
+// expectPacket reads the next packet, which must start with the given prefix, and returns the rest of it
+func (c *conn) expectPacket(prefix string) (string, error) {
+	packet, err := c.readPacket()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(packet, prefix) {
+		if strings.HasPrefix(packet, string([]byte{engineMessage, socketConnectError})) {
+			var connectError struct {
+				Message string `json:"message"`
+			}
+			json.Unmarshal([]byte(packet[2:]), &connectError)
+			return "", fmt.Errorf("socket.io: connection refused: %s", connectError.Message)
+		}
+		return "", fmt.Errorf("socket.io: unexpected packet %s", strconv.Quote(packet))
+	}
+	return packet[len(prefix):], nil
+}
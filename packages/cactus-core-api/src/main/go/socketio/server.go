@@ -0,0 +1,114 @@
+package socketio
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+/*
+Server is a minimal Socket.IO server over the websocket transport, meant to stand in for the API server in the
+tests of clients of the async APIs. OnConnect is called with each socket that joins the main namespace.
+*/
+type Server struct {
+	OnConnect func(*Socket)
+	// Interval between pings; defaults to 25 seconds as in Socket.IO
+	PingInterval time.Duration
+	// Time allowed for the client to answer a ping; defaults to 20 seconds as in Socket.IO
+	PingTimeout time.Duration
+}
+
+// Socket is the server side of a connection
+type Socket struct {
+	*conn
+	ID string
+}
+
+func newID() string {
+	id := make([]byte, 10)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := acceptWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	pingInterval, pingTimeout := s.PingInterval, s.PingTimeout
+	if pingInterval == 0 {
+		pingInterval = 25 * time.Second
+	}
+	if pingTimeout == 0 {
+		pingTimeout = 20 * time.Second
+	}
+	socket := &Socket{conn: newConn(ws, pingInterval+pingTimeout), ID: newID()}
+
+	open, _ := json.Marshal(openPacket{
+		Sid:          newID(),
+		Upgrades:     []string{},
+		PingInterval: int(pingInterval / time.Millisecond),
+		PingTimeout:  int(pingTimeout / time.Millisecond),
+		MaxPayload:   maxMessageSize,
+	})
+	err = ws.writeText(string(engineOpen) + string(open))
+	if err == nil {
+		_, err = socket.expectPacket(string([]byte{engineMessage, socketConnect}))
+	}
+	if err == nil {
+		connect, _ := json.Marshal(map[string]string{"sid": socket.ID})
+		err = ws.writeText(string([]byte{engineMessage, socketConnect}) + string(connect))
+	}
+	if err != nil {
+		ws.close()
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ws.writeText(string(enginePing))
+			case <-socket.done:
+				return
+			}
+		}
+	}()
+	if s.OnConnect != nil {
+		go s.OnConnect(socket)
+	}
+	socket.run()
+}
+
+// Emit sends an event with arguments, each of which is serialized to JSON
+func (s *Socket) Emit(event string, args ...interface{}) error {
+	return s.emit(event, args)
+}
+
+// Events returns the events sent by the client. It must be drained, or the connection stalls.
+func (s *Socket) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed when the connection ends
+func (s *Socket) Done() <-chan struct{} {
+	return s.done
+}
+
+// Disconnect disconnects the client from the namespace, as the disconnect method of Socket.IO sockets does
+func (s *Socket) Disconnect() {
+	s.ws.writeText(string([]byte{engineMessage, socketDisconnect}))
+	s.finish(nil)
+}
+
+// Drop closes the connection without notice, as a network failure would
+func (s *Socket) Drop() {
+	s.closeOnce.Do(func() {
+		s.ws.conn.Close()
+		close(s.done)
+	})
+}
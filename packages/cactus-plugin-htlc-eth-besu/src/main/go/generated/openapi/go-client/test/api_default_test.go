@@ -7,7 +7,7 @@ Testing DefaultApiService
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech);
 
-package cactus-plugin-htlc-eth-besu
+package htlcethbesu
 
 import (
 	"context"
@@ -17,7 +17,7 @@ import (
 	openapiclient "github.com/hyperledger/cactus-plugin-htlc-eth-besu/src/main/go/generated/openapi/go-client"
 )
 
-func Test_cactus-plugin-htlc-eth-besu_DefaultApiService(t *testing.T) {
+func Test_htlcethbesu_DefaultApiService(t *testing.T) {
 
 	configuration := openapiclient.NewConfiguration()
 	apiClient := openapiclient.NewAPIClient(configuration)
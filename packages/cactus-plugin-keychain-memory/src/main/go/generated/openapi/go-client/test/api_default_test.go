@@ -7,7 +7,7 @@ Testing DefaultApiService
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech);
 
-package cactus-plugin-keychain-memory
+package keychainmemory
 
 import (
 	"context"
@@ -17,7 +17,7 @@ import (
 	openapiclient "github.com/hyperledger/cactus-plugin-keychain-memory/src/main/go/generated/openapi/go-client"
 )
 
-func Test_cactus-plugin-keychain-memory_DefaultApiService(t *testing.T) {
+func Test_keychainmemory_DefaultApiService(t *testing.T) {
 
 	configuration := openapiclient.NewConfiguration()
 	apiClient := openapiclient.NewAPIClient(configuration)
@@ -0,0 +1,62 @@
+package watchblocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	connectorbesu "github.com/hyperledger/cactus-plugin-ledger-connector-besu/src/main/go/generated/openapi/go-client"
+)
+
+// Path of the GetBlockV1 endpoint of the connector
+const getBlockPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-besu/get-block"
+
+// getBlock fetches a block with GetBlockV1, returning it as sent by the connector along with its header
+func (w *Watcher) getBlock(ctx context.Context, number uint64) (json.RawMessage, *BlockHeader, error) {
+	body, err := json.Marshal(connectorbesu.NewGetBlockV1Request(number))
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(w.config.URL, "/")+getBlockPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, values := range w.config.Header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	client := w.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("GetBlockV1 of block %d failed with status %s: %s", number, response.Status, responseBody)
+	}
+	var getBlockResponse struct {
+		Block json.RawMessage `json:"block"`
+	}
+	err = json.Unmarshal(responseBody, &getBlockResponse)
+	if err != nil || len(getBlockResponse.Block) == 0 || string(getBlockResponse.Block) == "null" {
+		return nil, nil, fmt.Errorf("GetBlockV1 of block %d returned no block", number)
+	}
+	var block evmBlock
+	err = json.Unmarshal(getBlockResponse.Block, &block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("GetBlockV1 of block %d returned a malformed block: %v", number, err)
+	}
+	header := block.header()
+	return getBlockResponse.Block, &header, nil
+}
@@ -0,0 +1,150 @@
+package watchblocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	connectorbesu "github.com/hyperledger/cactus-plugin-ledger-connector-besu/src/main/go/generated/openapi/go-client"
+)
+
+// Socket.IO events of the WatchBlocksV1 async API
+var (
+	eventSubscribe   = string(connectorbesu.Subscribe)
+	eventNext        = string(connectorbesu.Next)
+	eventUnsubscribe = string(connectorbesu.Unsubscribe)
+	eventError       = string(connectorbesu.Error)
+	eventComplete    = string(connectorbesu.Complete)
+)
+
+// Quantity is an integer sent by the connector either as a JSON number or as a decimal or 0x-prefixed hex string
+type Quantity uint64
+
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		text = s
+	}
+	var value uint64
+	var err error
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		value, err = strconv.ParseUint(text[2:], 16, 64)
+	} else {
+		value, err = strconv.ParseUint(text, 10, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid quantity %s", data)
+	}
+	*q = Quantity(value)
+	return nil
+}
+
+/*
+BlockHeader is the header of a new block (Web3BlockHeader in the OpenAPI spec). It is decoded by hand rather than
+with the Web3BlockHeader and EvmBlock models of the generated client, as their quantities are float32 and int32
+fields, which are not exact for block numbers beyond 2^24 and do not accept the hex strings sent by web3.
+*/
+type BlockHeader struct {
+	Number          Quantity `json:"number"`
+	Hash            string   `json:"hash"`
+	ParentHash      string   `json:"parentHash"`
+	Nonce           string   `json:"nonce"`
+	Sha3Uncles      string   `json:"sha3Uncles"`
+	LogsBloom       string   `json:"logsBloom"`
+	TransactionRoot string   `json:"transactionRoot"`
+	StateRoot       string   `json:"stateRoot"`
+	ReceiptRoot     string   `json:"receiptRoot"`
+	Miner           string   `json:"miner"`
+	ExtraData       string   `json:"extraData"`
+	GasLimit        Quantity `json:"gasLimit"`
+	GasUsed         Quantity `json:"gasUsed"`
+	Timestamp       Quantity `json:"timestamp"`
+}
+
+// evmBlock holds the header fields of a block returned by GetBlockV1 (EvmBlock in the OpenAPI spec)
+type evmBlock struct {
+	Number           Quantity `json:"number"`
+	Hash             string   `json:"hash"`
+	ParentHash       string   `json:"parentHash"`
+	Nonce            string   `json:"nonce"`
+	Sha3Uncles       string   `json:"sha3Uncles"`
+	LogsBloom        string   `json:"logsBloom"`
+	TransactionsRoot string   `json:"transactionsRoot"`
+	StateRoot        string   `json:"stateRoot"`
+	ReceiptsRoot     string   `json:"receiptsRoot"`
+	Miner            string   `json:"miner"`
+	ExtraData        string   `json:"extraData"`
+	GasLimit         Quantity `json:"gasLimit"`
+	GasUsed          Quantity `json:"gasUsed"`
+	Timestamp        Quantity `json:"timestamp"`
+}
+
+func (b *evmBlock) header() BlockHeader {
+	return BlockHeader{
+		Number:          b.Number,
+		Hash:            b.Hash,
+		ParentHash:      b.ParentHash,
+		Nonce:           b.Nonce,
+		Sha3Uncles:      b.Sha3Uncles,
+		LogsBloom:       b.LogsBloom,
+		TransactionRoot: b.TransactionsRoot,
+		StateRoot:       b.StateRoot,
+		ReceiptRoot:     b.ReceiptsRoot,
+		Miner:           b.Miner,
+		ExtraData:       b.ExtraData,
+		GasLimit:        b.GasLimit,
+		GasUsed:         b.GasUsed,
+		Timestamp:       b.Timestamp,
+	}
+}
+
+// Block is a new block
+type Block struct {
+	Header BlockHeader
+	// The block as returned by GetBlockV1 (EvmBlock in the OpenAPI spec), if full blocks are requested
+	Full json.RawMessage
+}
+
+// GapError reports blocks that were skipped, e.g. while the connection was lost
+type GapError struct {
+	// First and last numbers of the missing blocks
+	From, To uint64
+	// Why the missing blocks could not be fetched, if they were to be
+	Err error
+}
+
+func (e *GapError) Error() string {
+	message := fmt.Sprintf("missed blocks %d to %d", e.From, e.To)
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *GapError) Unwrap() error {
+	return e.Err
+}
+
+// Error is an error reported by the connector with an Error event
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "watch blocks error: " + e.Message
+}
+
+func decodeError(args []json.RawMessage) *Error {
+	if len(args) == 0 {
+		return &Error{Message: "no details"}
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(args[0], &body) == nil && body.Message != "" {
+		return &Error{Message: body.Message}
+	}
+	return &Error{Message: string(args[0])}
+}
@@ -0,0 +1,278 @@
+/*
+Package watchblocks is a client of the WatchBlocksV1 async API of the Besu connector, which streams the headers
+of new blocks over Socket.IO.
+
+It is hand-written, as the OpenAPI generator does not cover async APIs; the events and the GetBlockV1 requests are
+those of the generated client. A Watcher delivers blocks on a channel in ascending order. When the connection is lost it
+reconnects with backoff, and the blocks committed meanwhile are reported as a gap or, if requested, fetched with
+GetBlockV1 and delivered.
+*/
+package watchblocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hyperledger/cactus-core-api/src/main/go/socketio"
+)
+
+// Config of the connection to the API server hosting the connector
+type Config struct {
+	// URL of the API server, e.g. http://localhost:4000
+	URL string
+	socketio.Options
+	// Client for the GetBlockV1 requests; defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Fetch each new block with GetBlockV1, to deliver it in full along with its header
+	FullBlocks bool
+	// Fetch the missed blocks with GetBlockV1 and deliver them, rather than only reporting the gap
+	FillGaps bool
+	// Backoff between reconnection attempts
+	Backoff socketio.Backoff
+}
+
+// Watcher streams new blocks
+type Watcher struct {
+	config Config
+	blocks chan *Block
+	errors chan error
+	done   chan struct{}
+	stop   context.CancelFunc
+	// number of the last block delivered, if any
+	last         uint64
+	delivered    bool
+	mutex        sync.Mutex
+	client       *socketio.Client
+	unsubscribed bool
+	err          error
+}
+
+// Subscribe watches new blocks
+func Subscribe(ctx context.Context, config Config) (*Watcher, error) {
+	ctx, stop := context.WithCancel(ctx)
+	w := &Watcher{
+		config: config,
+		blocks: make(chan *Block),
+		errors: make(chan error, 16),
+		done:   make(chan struct{}),
+		stop:   stop,
+	}
+	client, err := w.connect(ctx)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	go w.run(ctx, client)
+	return w, nil
+}
+
+// Blocks returns the new blocks, in ascending order. It is closed when the watcher stops.
+func (w *Watcher) Blocks() <-chan *Block {
+	return w.blocks
+}
+
+/*
+Errors returns the errors reported by the connector with Error events, gaps in the blocks (as *GapError) and lost
+connections. The watcher keeps going after these errors; those not read are dropped once 16 are pending.
+It is closed when the watcher stops.
+*/
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Done is closed when the watcher stops
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Err returns why the watcher stopped: nil after a Complete event or Unsubscribe, or the context's error if it is done
+func (w *Watcher) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+// Unsubscribe ends the subscription and stops the watcher
+func (w *Watcher) Unsubscribe() error {
+	w.mutex.Lock()
+	client := w.client
+	w.unsubscribed = true
+	w.mutex.Unlock()
+	err := client.Emit(eventUnsubscribe)
+	w.stop()
+	<-w.done
+	return err
+}
+
+func (w *Watcher) connect(ctx context.Context) (*socketio.Client, error) {
+	client, err := socketio.Dial(ctx, w.config.URL, w.config.Options)
+	if err != nil {
+		return nil, err
+	}
+	err = client.Emit(eventSubscribe)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	w.mutex.Lock()
+	w.client = client
+	w.mutex.Unlock()
+	return client, nil
+}
+
+func (w *Watcher) report(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
+
+func (w *Watcher) run(ctx context.Context, client *socketio.Client) {
+	err := func() error {
+		for {
+			completed, err := w.watch(ctx, client)
+			client.Close()
+			if completed || ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.report(err)
+			for {
+				if !w.config.Backoff.Wait(ctx) {
+					return ctx.Err()
+				}
+				client, err = w.connect(ctx)
+				if err == nil {
+					w.config.Backoff.Reset()
+					break
+				}
+				w.report(err)
+			}
+		}
+	}()
+	w.stop()
+	w.mutex.Lock()
+	// stopping through Unsubscribe is not an error
+	if w.unsubscribed {
+		err = nil
+	}
+	w.err = err
+	w.mutex.Unlock()
+	close(w.blocks)
+	close(w.errors)
+	close(w.done)
+}
+
+// watch handles the events of a connection until it ends, returning whether the subscription completed
+func (w *Watcher) watch(ctx context.Context, client *socketio.Client) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event := <-client.Events():
+			completed, err := w.handle(ctx, event)
+			if completed || err != nil {
+				return completed, err
+			}
+		case <-client.Done():
+			// handle the events received before the connection was lost
+			for {
+				select {
+				case event := <-client.Events():
+					completed, err := w.handle(ctx, event)
+					if completed || err != nil {
+						return completed, err
+					}
+					continue
+				default:
+				}
+				break
+			}
+			err := client.Err()
+			if err == nil {
+				err = socketio.ErrDisconnected
+			}
+			return false, fmt.Errorf("connection lost: %w", err)
+		}
+	}
+}
+
+// handle handles an event, returning whether the subscription completed
+func (w *Watcher) handle(ctx context.Context, event socketio.Event) (bool, error) {
+	switch event.Name {
+	case eventNext:
+		var progress struct {
+			BlockHeader *BlockHeader `json:"blockHeader"`
+		}
+		if len(event.Args) == 0 || json.Unmarshal(event.Args[0], &progress) != nil || progress.BlockHeader == nil {
+			w.report(errors.New("block header expected in Next event"))
+			return false, nil
+		}
+		return false, w.next(ctx, progress.BlockHeader)
+	case eventError:
+		w.report(decodeError(event.Args))
+	case eventComplete:
+		return true, nil
+	}
+	return false, nil
+}
+
+// next delivers a new block, after the blocks missed since the last one
+func (w *Watcher) next(ctx context.Context, header *BlockHeader) error {
+	number := uint64(header.Number)
+	// only the first block seen at a height is delivered
+	if w.delivered && number <= w.last {
+		return nil
+	}
+	if w.delivered && number > w.last+1 {
+		gap := &GapError{From: w.last + 1, To: number - 1}
+		if !w.config.FillGaps {
+			w.report(gap)
+		} else {
+			for missing := gap.From; missing <= gap.To; missing++ {
+				full, missingHeader, err := w.getBlock(ctx, missing)
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					gap.From, gap.Err = missing, err
+					w.report(gap)
+					break
+				}
+				if !w.config.FullBlocks {
+					full = nil
+				}
+				err = w.deliver(ctx, &Block{Header: *missingHeader, Full: full})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	block := &Block{Header: *header}
+	if w.config.FullBlocks {
+		full, _, err := w.getBlock(ctx, number)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			w.report(fmt.Errorf("failed to fetch block %d: %w", number, err))
+		}
+		block.Full = full
+	}
+	return w.deliver(ctx, block)
+}
+
+func (w *Watcher) deliver(ctx context.Context, block *Block) error {
+	select {
+	case w.blocks <- block:
+		w.last, w.delivered = uint64(block.Header.Number), true
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,209 @@
+package watchblocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/cactus-core-api/src/main/go/socketio"
+)
+
+// connector stands in for the Besu connector, serving WatchBlocksV1 and GetBlockV1
+type connector struct {
+	url     string
+	sockets chan *socketio.Socket
+	mutex   sync.Mutex
+	// blocks served by GetBlockV1, and the numbers requested
+	blocks    map[uint64]bool
+	requested []uint64
+}
+
+func startConnector(t *testing.T) *connector {
+	c := &connector{sockets: make(chan *socketio.Socket, 4), blocks: map[uint64]bool{}}
+	mux := http.NewServeMux()
+	mux.Handle(socketio.DefaultPath+"/", &socketio.Server{OnConnect: func(s *socketio.Socket) { c.sockets <- s }})
+	mux.HandleFunc(getBlockPath, func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			BlockHashOrBlockNumber uint64 `json:"blockHashOrBlockNumber"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		number := request.BlockHashOrBlockNumber
+		c.requested = append(c.requested, number)
+		if !c.blocks[number] {
+			http.Error(w, "block not found", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"block": map[string]interface{}{
+			"number":           number,
+			"hash":             fmt.Sprintf("0x%x", number),
+			"transactionsRoot": "0xroot",
+			"timestamp":        "1700000000",
+			"transactions":     []string{},
+		}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	c.url = server.URL
+	return c
+}
+
+func (c *connector) serve(numbers ...uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, number := range numbers {
+		c.blocks[number] = true
+	}
+}
+
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-c:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+		var zero T
+		return zero
+	}
+}
+
+func header(number uint64) map[string]interface{} {
+	return map[string]interface{}{"blockHeader": map[string]interface{}{
+		"number":          number,
+		"hash":            fmt.Sprintf("0x%x", number),
+		"transactionRoot": "0xroot",
+		"timestamp":       1700000000,
+	}}
+}
+
+func expectBlocks(t *testing.T, watcher *Watcher, full bool, numbers ...uint64) {
+	t.Helper()
+	for _, expected := range numbers {
+		block := receive(t, watcher.Blocks())
+		if uint64(block.Header.Number) != expected || block.Header.Hash != fmt.Sprintf("0x%x", expected) ||
+			block.Header.TransactionRoot != "0xroot" || block.Header.Timestamp != 1700000000 || (block.Full != nil) != full {
+			t.Fatalf("expected block %d, got %+v", expected, block)
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	c := startConnector(t)
+	watcher, err := Subscribe(context.Background(), Config{URL: c.url, Backoff: socketio.Backoff{Min: time.Millisecond}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socket := receive(t, c.sockets)
+	if event := receive(t, socket.Events()); event.Name != eventSubscribe {
+		t.Fatalf("unexpected subscription %s", event.Name)
+	}
+	socket.Emit(eventNext, header(5))
+	socket.Emit(eventNext, header(6))
+	// blocks already delivered are skipped
+	socket.Emit(eventNext, header(6))
+	socket.Emit(eventNext, header(7))
+	expectBlocks(t, watcher, false, 5, 6, 7)
+
+	// the watcher reconnects once the connection is lost, and reports the blocks missed meanwhile
+	socket.Drop()
+	if err := receive(t, watcher.Errors()); err == nil {
+		t.Fatal("lost connection not reported")
+	}
+	socket = receive(t, c.sockets)
+	receive(t, socket.Events())
+	socket.Emit(eventNext, header(10))
+	expectBlocks(t, watcher, false, 10)
+	var gap *GapError
+	if err := receive(t, watcher.Errors()); !errors.As(err, &gap) || gap.From != 8 || gap.To != 9 || gap.Err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// errors are reported, and the subscription goes on until completed
+	socket.Emit(eventError, map[string]interface{}{"message": "node unavailable"})
+	var watchError *Error
+	if err := receive(t, watcher.Errors()); !errors.As(err, &watchError) || watchError.Message != "node unavailable" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	socket.Emit(eventNext, map[string]interface{}{})
+	if err := receive(t, watcher.Errors()); err == nil {
+		t.Fatal("missing block header not reported")
+	}
+	socket.Emit(eventNext, header(11))
+	socket.Emit(eventComplete)
+	expectBlocks(t, watcher, false, 11)
+	<-watcher.Done()
+	if watcher.Err() != nil {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+	if _, open := <-watcher.Blocks(); open {
+		t.Fatal("blocks channel not closed")
+	}
+}
+
+func TestFillGaps(t *testing.T) {
+	c := startConnector(t)
+	c.serve(1, 2, 3, 4, 6)
+	watcher, err := Subscribe(context.Background(), Config{URL: c.url, FillGaps: true, FullBlocks: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := receive(t, c.sockets)
+	receive(t, socket.Events())
+
+	// the missed blocks are fetched and delivered in order, in full
+	socket.Emit(eventNext, header(1))
+	socket.Emit(eventNext, header(4))
+	expectBlocks(t, watcher, true, 1, 2, 3, 4)
+
+	// blocks that cannot be fetched are reported as a gap
+	socket.Emit(eventNext, header(8))
+	expectBlocks(t, watcher, false, 8)
+	var gap *GapError
+	if err := receive(t, watcher.Errors()); !errors.As(err, &gap) || gap.From != 5 || gap.To != 7 || gap.Err == nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := receive(t, watcher.Errors()); err == nil {
+		t.Fatal("failure to fetch the new block not reported")
+	}
+
+	if err := watcher.Unsubscribe(); err != nil {
+		t.Fatal(err)
+	}
+	if watcher.Err() != nil {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if fmt.Sprint(c.requested) != "[1 2 3 4 5 8]" {
+		t.Fatalf("unexpected requests %v", c.requested)
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	for _, test := range []struct {
+		data     string
+		expected Quantity
+	}{
+		{`42`, 42},
+		{`"42"`, 42},
+		{`"0x2a"`, 42},
+	} {
+		var q Quantity
+		if err := json.Unmarshal([]byte(test.data), &q); err != nil || q != test.expected {
+			t.Fatalf("unexpected quantity %d for %s: %v", q, test.data, err)
+		}
+	}
+	var q Quantity
+	if err := json.Unmarshal([]byte(`"block"`), &q); err == nil {
+		t.Fatal("invalid quantity accepted")
+	}
+}
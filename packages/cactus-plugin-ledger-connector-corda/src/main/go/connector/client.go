@@ -0,0 +1,205 @@
+/*
+Package connector is a client of the Corda connector of the Cactus API server, on top of its REST API: it runs Corda 5
+flows to completion, lists flows and CPIs, queries vaults with typed pages and turns the state monitor endpoints into
+a channel of acknowledged state changes.
+
+The requests and responses are the models of the generated client, except for starting flows and their statuses.
+*/
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Paths of the endpoints of the connector
+const (
+	StartFlowPath                = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/start-flow"
+	GetFlowPath                  = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/get-flow-cid"
+	ListFlowPath                 = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/list-flow"
+	ListCpiPath                  = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/list-cpi"
+	VaultQueryPath               = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/vault-query"
+	StartMonitorPath             = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/start-monitor"
+	GetMonitorTransactionsPath   = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/get-monitor-transactions"
+	ClearMonitorTransactionsPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/clear-monitor-transactions"
+	StopMonitorPath              = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-corda/stop-monitor"
+)
+
+// Config of a client
+type Config struct {
+	// URL of the API server, e.g. http://localhost:4000
+	URL string
+	// Client of the requests; defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers added to the requests, e.g. Authorization
+	Header http.Header
+	// Credentials of the Corda 5 REST API, passed on by the connector
+	Username           string
+	Password           string
+	RejectUnauthorized bool
+	// Virtual node the flows run on, if not set on the requests
+	HoldingIDShortHash string
+	// Interval between the status requests of a running flow, and between the polls of a monitor; defaults to 5s
+	PollInterval time.Duration
+	// Time a flow is given to complete in RunFlow; defaults to 2 minutes
+	FlowTimeout time.Duration
+}
+
+// Client of the Corda connector
+type Client struct {
+	config Config
+}
+
+// New returns a client of the connector of an API server
+func New(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.FlowTimeout <= 0 {
+		config.FlowTimeout = 2 * time.Minute
+	}
+	return &Client{config: config}
+}
+
+/*
+StartFlow starts a flow with StartFlowV1. The connector waits a while for the flow to complete, returning an error if
+it does not; RunFlow keeps waiting. The credentials and virtual node of the client are used if those of the request
+are not set, and a client request ID is generated if it is empty.
+*/
+func (c *Client) StartFlow(ctx context.Context, request *StartFlowV1Request) (*FlowStatusResponse, error) {
+	withDefaults := *request
+	if withDefaults.Username == "" {
+		withDefaults.Username, withDefaults.Password = c.config.Username, c.config.Password
+		withDefaults.RejectUnauthorized = c.config.RejectUnauthorized
+	}
+	if withDefaults.HoldingIDShortHash == "" {
+		withDefaults.HoldingIDShortHash = c.config.HoldingIDShortHash
+	}
+	if withDefaults.ClientRequestId == "" {
+		withDefaults.ClientRequestId = newClientRequestID()
+		request.ClientRequestId = withDefaults.ClientRequestId
+	}
+	if withDefaults.RequestBody == nil {
+		withDefaults.RequestBody = struct{}{}
+	}
+	response := &FlowStatusResponse{}
+	err := c.do(ctx, http.MethodPost, StartFlowPath, &withDefaults, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetFlow returns the status of a flow run with GetFlowV1, or ErrFlowNotFound
+func (c *Client) GetFlow(ctx context.Context, holdingIDShortHash string, clientRequestID string) (*FlowStatusResponse, error) {
+	request := c.flowRequest(holdingIDShortHash)
+	request.ClientRequestId = &clientRequestID
+	var response *FlowStatusResponse
+	err := c.do(ctx, http.MethodGet, GetFlowPath, &request, &response)
+	if err != nil {
+		return nil, err
+	}
+	// the connector responds with no status when Corda does not
+	if response == nil || response.FlowStatus == "" {
+		return nil, ErrFlowNotFound
+	}
+	return response, nil
+}
+
+// ListFlows returns the flow runs of a virtual node with ListFlowV1
+func (c *Client) ListFlows(ctx context.Context, holdingIDShortHash string) ([]FlowStatusResponse, error) {
+	request := c.flowRequest(holdingIDShortHash)
+	var response *FlowStatusV1Responses
+	err := c.do(ctx, http.MethodGet, ListFlowPath, &request, &response)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return response.FlowStatusResponses, nil
+}
+
+// ListCPIs returns the CPIs uploaded to the cluster with ListCpiV1
+func (c *Client) ListCPIs(ctx context.Context) ([]CPI, error) {
+	request := ListCpiV1Request{
+		Username:           c.config.Username,
+		Password:           c.config.Password,
+		RejectUnauthorized: c.config.RejectUnauthorized,
+	}
+	var response *ListCpiV1Response
+	err := c.do(ctx, http.MethodGet, ListCpiPath, &request, &response)
+	if err != nil || response == nil {
+		return nil, err
+	}
+	return response.Cpis, nil
+}
+
+func (c *Client) flowRequest(holdingIDShortHash string) GetFlowCidV1Request {
+	if holdingIDShortHash == "" {
+		holdingIDShortHash = c.config.HoldingIDShortHash
+	}
+	return GetFlowCidV1Request{
+		Username:           c.config.Username,
+		Password:           c.config.Password,
+		RejectUnauthorized: c.config.RejectUnauthorized,
+		HoldingIDShortHash: &holdingIDShortHash,
+	}
+}
+
+// newClientRequestID returns a random UUID
+func newClientRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+}
+
+// do sends a request to an endpoint and decodes the response
+func (c *Client) do(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.config.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range c.config.Header {
+		httpRequest.Header[name] = values
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := c.config.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		connectorError := &Error{}
+		if json.Unmarshal(responseBody, connectorError) != nil || connectorError.Message == "" {
+			connectorError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		connectorError.StatusCode = httpResponse.StatusCode
+		return connectorError
+	}
+	if len(bytes.TrimSpace(responseBody)) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	connectorcorda "github.com/hyperledger/cactus-plugin-ledger-connector-corda/src/main/go/generated/openapi/go-client"
+)
+
+// cordaConnector stands in for the Corda connector
+type cordaConnector struct {
+	mutex sync.Mutex
+	// statuses a flow goes through, one per GetFlowV1 request
+	statuses []FlowStatusResponse
+	// whether StartFlowV1 fails as when the connector gives up waiting
+	startFails bool
+	// changes kept by the monitor, and the number of changes seen so far
+	monitoring bool
+	changes    []MonitorV1TxItem
+	count      int
+	cleared    []string
+}
+
+func (c *cordaConnector) addChanges(data ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, d := range data {
+		c.changes = append(c.changes, MonitorV1TxItem{Index: connectorcorda.PtrString(strconv.Itoa(c.count)), Data: connectorcorda.PtrString(d)})
+		c.count++
+	}
+}
+
+func startConnector(t *testing.T, c *cordaConnector) *Client {
+	mux := http.NewServeMux()
+	handle := func(path string, method string, handler func(body json.RawMessage) interface{}) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != method {
+				http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+				return
+			}
+			var body json.RawMessage
+			json.NewDecoder(r.Body).Decode(&body)
+			c.mutex.Lock()
+			defer c.mutex.Unlock()
+			response := handler(body)
+			if err, ok := response.(*Error); ok {
+				w.WriteHeader(err.StatusCode)
+			}
+			if response != nil {
+				json.NewEncoder(w).Encode(response)
+			}
+		})
+	}
+	handle(StartFlowPath, http.MethodPost, func(body json.RawMessage) interface{} {
+		if c.startFails {
+			return &Error{StatusCode: 500, Message: "Internal Server Error", Exception: "Max attempts (10) reached"}
+		}
+		var request StartFlowV1Request
+		json.Unmarshal(body, &request)
+		return &FlowStatusResponse{ClientRequestId: request.ClientRequestId, FlowStatus: StartRequested}
+	})
+	handle(GetFlowPath, http.MethodGet, func(body json.RawMessage) interface{} {
+		if len(c.statuses) == 0 {
+			return nil
+		}
+		status := c.statuses[0]
+		if len(c.statuses) > 1 {
+			c.statuses = c.statuses[1:]
+		}
+		return &status
+	})
+	handle(ListFlowPath, http.MethodGet, func(body json.RawMessage) interface{} {
+		return &FlowStatusV1Responses{FlowStatusResponses: c.statuses}
+	})
+	handle(ListCpiPath, http.MethodGet, func(body json.RawMessage) interface{} {
+		return &ListCpiV1Response{Cpis: []CPI{{Id: &CPIIDV1{Name: "chat", Version: "1.0"}}}}
+	})
+	handle(VaultQueryPath, http.MethodPost, func(body json.RawMessage) interface{} {
+		return json.RawMessage(`{"states":[{"state":{"data":{"value":7},"contract":"IOUContract"},"ref":{"txhash":"AB12","index":0}}],
+			"statesMetadata":[],"totalStatesAvailable":1,"stateTypes":"UNCONSUMED","otherResults":[]}`)
+	})
+	handle(StartMonitorPath, http.MethodPost, func(body json.RawMessage) interface{} {
+		c.monitoring = true
+		return &MonitorV1Response{Success: true, Msg: "OK"}
+	})
+	handle(GetMonitorTransactionsPath, http.MethodGet, func(body json.RawMessage) interface{} {
+		if !c.monitoring {
+			return &GetMonitorTransactionsV1Response{Msg: "No monitor running"}
+		}
+		return &GetMonitorTransactionsV1Response{Success: true, Msg: "OK", Tx: append([]MonitorV1TxItem{}, c.changes...)}
+	})
+	handle(ClearMonitorTransactionsPath, http.MethodDelete, func(body json.RawMessage) interface{} {
+		var request ClearMonitorTransactionsV1Request
+		json.Unmarshal(body, &request)
+		c.cleared = append(c.cleared, request.TxIndexes...)
+		kept := c.changes[:0]
+		for _, change := range c.changes {
+			cleared := false
+			for _, index := range request.TxIndexes {
+				cleared = cleared || index == change.GetIndex()
+			}
+			if !cleared {
+				kept = append(kept, change)
+			}
+		}
+		c.changes = kept
+		return &MonitorV1Response{Success: true, Msg: "OK"}
+	})
+	handle(StopMonitorPath, http.MethodDelete, func(body json.RawMessage) interface{} {
+		c.monitoring, c.changes = false, nil
+		return &MonitorV1Response{Success: true, Msg: "OK"}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return New(Config{URL: server.URL, HoldingIDShortHash: "0123456789AB", PollInterval: 10 * time.Millisecond})
+}
+
+func TestRunFlow(t *testing.T) {
+	c := &cordaConnector{statuses: []FlowStatusResponse{
+		{FlowStatus: Running},
+		{FlowStatus: Completed, FlowResult: `{"messages":2}`},
+	}}
+	client := startConnector(t, c)
+	status, err := client.RunFlow(context.Background(), Flow{FlowClassName: "com.r3.developers.ListChatsFlow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct{ Messages int }
+	if err := status.Unmarshal(&result); err != nil || result.Messages != 2 {
+		t.Fatalf("unexpected result %+v, %v", result, err)
+	}
+
+	// the connector gives up waiting for the flow, which then fails
+	c.startFails = true
+	c.statuses = []FlowStatusResponse{
+		{ClientRequestId: "run-2", FlowStatus: Running},
+		{ClientRequestId: "run-2", FlowStatus: Failed, FlowError: &FlowV1Error{Type: "FLOW_FAILED", Message: "boom"}},
+	}
+	_, err = client.RunFlow(context.Background(), Flow{ClientRequestID: "run-2", FlowClassName: "Flow"})
+	var flowError *FlowError
+	if !errors.As(err, &flowError) || flowError.Status.FlowError.Message != "boom" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// a flow Corda does not know of is not waited for
+	c.statuses = nil
+	_, err = client.RunFlow(context.Background(), Flow{FlowClassName: "Flow"})
+	var connectorError *Error
+	if !errors.As(err, &connectorError) || connectorError.StatusCode != 500 {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// nor past the flow timeout
+	c.statuses = []FlowStatusResponse{{FlowStatus: Running}}
+	client.config.FlowTimeout = 50 * time.Millisecond
+	_, err = client.RunFlow(context.Background(), Flow{FlowClassName: "Flow"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestListsAndVaultQuery(t *testing.T) {
+	c := &cordaConnector{statuses: []FlowStatusResponse{{FlowStatus: Completed}, {FlowStatus: Running}}}
+	client := startConnector(t, c)
+	flows, err := client.ListFlows(context.Background(), "")
+	if err != nil || len(flows) != 2 {
+		t.Fatalf("unexpected flows %v, %v", flows, err)
+	}
+	cpis, err := client.ListCPIs(context.Background())
+	if err != nil || len(cpis) != 1 || cpis[0].Id.Name != "chat" {
+		t.Fatalf("unexpected CPIs %v, %v", cpis, err)
+	}
+
+	type iou struct{ Value int }
+	page, err := VaultQuery[iou](context.Background(), client, "net.corda.samples.example.states.IOUState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page.Complete() || page.Data()[0].Value != 7 || page.States[0].Ref.Index != 0 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+}
+
+// receive returns the next value of a channel, failing after a second
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("nothing received")
+		panic("unreachable")
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	c := &cordaConnector{}
+	client := startConnector(t, c)
+	monitor, err := client.Monitor(context.Background(), "app", "IOUState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.addChanges("iou-0", "iou-1")
+	first := receive(t, monitor.Changes())
+	receive(t, monitor.Changes())
+	first.Ack()
+	// the change not acknowledged is not delivered again by the monitor
+	c.addChanges("iou-2")
+	if change := receive(t, monitor.Changes()); change.Data != "iou-2" {
+		t.Fatalf("unexpected change %+v", change)
+	}
+	if err := monitor.Close(context.Background()); err != nil || monitor.Err() != nil {
+		t.Fatal(err, monitor.Err())
+	}
+	c.mutex.Lock()
+	if len(c.cleared) != 1 || c.cleared[0] != "0" {
+		t.Fatalf("unexpected cleared changes %v", c.cleared)
+	}
+	c.mutex.Unlock()
+
+	// but by the next monitor of the application
+	monitor, err = client.Monitor(context.Background(), "app", "IOUState")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range []string{"iou-1", "iou-2"} {
+		if change := receive(t, monitor.Changes()); change.Data != data {
+			t.Fatalf("unexpected change %+v", change)
+		}
+	}
+
+	// a monitor dropped by the connector is started again
+	c.mutex.Lock()
+	c.monitoring, c.changes = false, nil
+	c.mutex.Unlock()
+	var monitorError *MonitorError
+	if err := receive(t, monitor.Errors()); !errors.As(err, &monitorError) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	c.addChanges("iou-3")
+	if change := receive(t, monitor.Changes()); change.Data != "iou-3" {
+		t.Fatalf("unexpected change %+v", change)
+	}
+	if err := monitor.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-monitor.Changes(); ok {
+		t.Fatal("changes not closed")
+	}
+}
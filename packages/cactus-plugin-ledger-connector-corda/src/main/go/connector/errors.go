@@ -0,0 +1,52 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+Error is an error response of the connector: the body is an ErrorExceptionResponseV1 for the endpoints served by the
+plugin, and a Spring error for those served by the connector server (the vault query and monitor endpoints).
+*/
+type Error struct {
+	StatusCode int
+	// Name of the status, or the message of the Spring error
+	Message string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("connector responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("connector responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// ErrFlowNotFound is returned for flow runs Corda does not know of, or not yet
+var ErrFlowNotFound = errors.New("flow not found")
+
+// FlowError is returned for flow runs that failed or were killed
+type FlowError struct {
+	Status *FlowStatusResponse
+}
+
+func (e *FlowError) Error() string {
+	if e.Status.FlowError == nil {
+		return fmt.Sprintf("flow %s %s", e.Status.ClientRequestId, e.Status.FlowStatus)
+	}
+	return fmt.Sprintf("flow %s %s: %s: %s", e.Status.ClientRequestId, e.Status.FlowStatus, e.Status.FlowError.Type,
+		e.Status.FlowError.Message)
+}
+
+// MonitorError is a monitor request the connector answered without success
+type MonitorError struct {
+	// Operation refused, e.g. getMonitorTransactionsV1
+	Operation string
+	Msg       string
+}
+
+func (e *MonitorError) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Operation, e.Msg)
+}
@@ -0,0 +1,75 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Flow to run on a virtual node
+type Flow struct {
+	// Virtual node to run the flow on; defaults to that of the client
+	HoldingIDShortHash string
+	// Identifies the run; generated if empty. Reusing the ID of a previous run resumes waiting for it.
+	ClientRequestID string
+	// Fully qualified class name of the flow
+	FlowClassName string
+	// Start arguments of the flow, serialized to JSON
+	RequestBody interface{}
+}
+
+/*
+RunFlow starts a flow and waits for it to complete, polling its status with GetFlowV1 until it is final or the flow
+timeout of the client elapses. It returns the completed status, with the result of the flow, or a *FlowError if the
+flow failed or was killed.
+
+Starting is not retried, but the ID of the run makes it safe to call RunFlow again with the same ClientRequestID
+after an error: Corda refuses to start a second run with the ID, and RunFlow then waits for the first one.
+*/
+func (c *Client) RunFlow(ctx context.Context, flow Flow) (*FlowStatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.FlowTimeout)
+	defer cancel()
+	request := &StartFlowV1Request{
+		HoldingIDShortHash: flow.HoldingIDShortHash,
+		ClientRequestId:    flow.ClientRequestID,
+		FlowClassName:      flow.FlowClassName,
+		RequestBody:        flow.RequestBody,
+	}
+	status, startErr := c.StartFlow(ctx, request)
+	if startErr != nil {
+		// the connector gives up waiting after a while, or the run was started before: wait for it if Corda knows it
+		var connectorError *Error
+		if !errors.As(startErr, &connectorError) {
+			return nil, startErr
+		}
+		var err error
+		status, err = c.GetFlow(ctx, flow.HoldingIDShortHash, request.ClientRequestId)
+		if err != nil {
+			return nil, startErr
+		}
+	}
+	for !status.FlowStatus.Final() {
+		select {
+		case <-time.After(c.config.PollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("flow %s still %s: %w", request.ClientRequestId, status.FlowStatus, ctx.Err())
+		}
+		next, err := c.GetFlow(ctx, flow.HoldingIDShortHash, request.ClientRequestId)
+		// the connector also responds with no status when it fails to reach Corda
+		if errors.Is(err, ErrFlowNotFound) {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("flow %s still %s: %w", request.ClientRequestId, status.FlowStatus, ctx.Err())
+			}
+			return nil, err
+		}
+		status = next
+	}
+	if status.FlowStatus != Completed {
+		return nil, &FlowError{Status: status}
+	}
+	return status, nil
+}
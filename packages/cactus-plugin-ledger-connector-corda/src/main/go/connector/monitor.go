@@ -0,0 +1,269 @@
+package connector
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StateChange is a state produced by a transaction, seen by a Monitor
+type StateChange struct {
+	// Index of the change in the monitor of the connector
+	Index string
+	// The produced StateAndRef, as formatted by its toString
+	Data       string
+	monitor    *Monitor
+	generation int
+}
+
+/*
+Ack acknowledges that the change was processed, so that the connector drops it; the changes not acknowledged are
+delivered again by a later Monitor of the same client application. Acknowledgements are sent before the next poll,
+and by Close.
+*/
+func (s *StateChange) Ack() {
+	s.monitor.mutex.Lock()
+	defer s.monitor.mutex.Unlock()
+	// the indexes of a restarted monitor of the connector start again from 0
+	if s.generation == s.monitor.generation {
+		s.monitor.acks = append(s.monitor.acks, s.Index)
+	}
+}
+
+/*
+Monitor delivers the changes of a Corda state, polling the state monitor of the connector
+(GetMonitorTransactionsV1). Delivery is at least once: a change is dropped by the connector
+(ClearMonitorTransactionsV1) only once it is acknowledged, and the connector keeps the changes of a client
+application, identified by its ID, across monitors.
+*/
+type Monitor struct {
+	client  *Client
+	request StartMonitorV1Request
+	changes chan *StateChange
+	errors  chan error
+	done    chan struct{}
+	stop    context.CancelFunc
+	// indexes of the changes delivered and not yet dropped by the connector
+	delivered map[string]bool
+	mutex     sync.Mutex
+	acks      []string
+	// number of times the monitor of the connector was started again
+	generation int
+	closed     bool
+	err        error
+}
+
+/*
+Monitor starts monitoring a state with StartMonitorV1 and returns a Monitor delivering its changes. The monitor of the
+connector is started if it is not already running for the client application.
+*/
+func (c *Client) Monitor(ctx context.Context, clientAppID string, stateFullClassName string) (*Monitor, error) {
+	ctx, stop := context.WithCancel(ctx)
+	m := &Monitor{
+		client:    c,
+		request:   StartMonitorV1Request{ClientAppId: clientAppID, StateFullClassName: stateFullClassName},
+		changes:   make(chan *StateChange),
+		errors:    make(chan error, 16),
+		done:      make(chan struct{}),
+		stop:      stop,
+		delivered: map[string]bool{},
+	}
+	err := m.call(ctx, http.MethodPost, StartMonitorPath, "startMonitorV1", &m.request)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	go m.run(ctx)
+	return m, nil
+}
+
+// Changes returns the changes of the state, in the order the connector saw them. It is closed when the monitor stops.
+func (m *Monitor) Changes() <-chan *StateChange {
+	return m.changes
+}
+
+/*
+Errors returns the failed polls and acknowledgements. The monitor keeps going after these errors; those not read are
+dropped once 16 are pending. It is closed when the monitor stops.
+*/
+func (m *Monitor) Errors() <-chan error {
+	return m.errors
+}
+
+// Done is closed when the monitor stops
+func (m *Monitor) Done() <-chan struct{} {
+	return m.done
+}
+
+// Err returns why the monitor stopped: nil after Close or Stop, or the context's error if it is done
+func (m *Monitor) Err() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.err
+}
+
+/*
+Close stops polling and sends the pending acknowledgements. The monitor of the connector keeps running, keeping the
+changes for the next Monitor of the client application.
+*/
+func (m *Monitor) Close(ctx context.Context) error {
+	m.mutex.Lock()
+	m.closed = true
+	m.mutex.Unlock()
+	m.stop()
+	<-m.done
+	return m.ack(ctx)
+}
+
+/*
+Stop closes the monitor and stops the monitor of the connector with StopMonitorV1, which drops the changes not yet
+acknowledged.
+*/
+func (m *Monitor) Stop(ctx context.Context) error {
+	err := m.Close(ctx)
+	if err != nil {
+		return err
+	}
+	return m.call(ctx, http.MethodDelete, StopMonitorPath, "stopMonitorV1", &m.request)
+}
+
+func (m *Monitor) report(err error) {
+	select {
+	case m.errors <- err:
+	default:
+	}
+}
+
+func (m *Monitor) run(ctx context.Context) {
+	err := func() error {
+		for {
+			err := m.ack(ctx)
+			if err != nil && ctx.Err() == nil {
+				m.report(err)
+			}
+			err = m.poll(ctx)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				m.report(err)
+			}
+			select {
+			case <-time.After(m.client.config.PollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}()
+	m.mutex.Lock()
+	// stopping through Close is not an error
+	if m.closed {
+		err = nil
+	}
+	m.err = err
+	m.mutex.Unlock()
+	close(m.changes)
+	close(m.errors)
+	close(m.done)
+}
+
+// poll delivers the changes the connector has that were not delivered yet
+func (m *Monitor) poll(ctx context.Context) error {
+	response := &GetMonitorTransactionsV1Response{}
+	err := m.client.do(ctx, http.MethodGet, GetMonitorTransactionsPath, &m.request, response)
+	if err != nil {
+		return err
+	}
+	if !response.Success {
+		// the connector drops the monitors of the client applications that stop polling: start it again
+		restartErr := m.call(ctx, http.MethodPost, StartMonitorPath, "startMonitorV1", &m.request)
+		if restartErr != nil {
+			return restartErr
+		}
+		// the changes of the dropped monitor are lost, and the new one counts its changes from 0
+		m.delivered = map[string]bool{}
+		m.mutex.Lock()
+		m.generation++
+		m.acks = nil
+		m.mutex.Unlock()
+		return &MonitorError{Operation: "getMonitorTransactionsV1", Msg: response.Msg}
+	}
+	sort.Slice(response.Tx, func(i, j int) bool {
+		return indexLess(response.Tx[i].GetIndex(), response.Tx[j].GetIndex())
+	})
+	pending := make(map[string]bool, len(response.Tx))
+	for _, tx := range response.Tx {
+		pending[tx.GetIndex()] = true
+	}
+	// forget the changes the connector dropped
+	for index := range m.delivered {
+		if !pending[index] {
+			delete(m.delivered, index)
+		}
+	}
+	m.mutex.Lock()
+	generation := m.generation
+	m.mutex.Unlock()
+	for _, tx := range response.Tx {
+		if m.delivered[tx.GetIndex()] {
+			continue
+		}
+		select {
+		case m.changes <- &StateChange{Index: tx.GetIndex(), Data: tx.GetData(), monitor: m, generation: generation}:
+			m.delivered[tx.GetIndex()] = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// ack sends the pending acknowledgements
+func (m *Monitor) ack(ctx context.Context) error {
+	m.mutex.Lock()
+	acks := m.acks
+	m.acks = nil
+	m.mutex.Unlock()
+	if len(acks) == 0 {
+		return nil
+	}
+	err := m.call(ctx, http.MethodDelete, ClearMonitorTransactionsPath, "clearMonitorTransactionsV1",
+		&ClearMonitorTransactionsV1Request{
+			ClientAppId:        m.request.ClientAppId,
+			StateFullClassName: m.request.StateFullClassName,
+			TxIndexes:          acks,
+		})
+	if err != nil {
+		// send them again with the next ones
+		m.mutex.Lock()
+		m.acks = append(acks, m.acks...)
+		m.mutex.Unlock()
+	}
+	return err
+}
+
+// call sends a request to a monitor endpoint, returning a *MonitorError if it does not succeed
+func (m *Monitor) call(ctx context.Context, method string, path string, operation string, request interface{}) error {
+	response := &MonitorV1Response{}
+	err := m.client.do(ctx, method, path, request, response)
+	if err != nil {
+		return err
+	}
+	if !response.Success {
+		return &MonitorError{Operation: operation, Msg: response.Msg}
+	}
+	return nil
+}
+
+// indexLess compares the decimal indexes of changes
+func indexLess(a string, b string) bool {
+	x, okX := new(big.Int).SetString(a, 10)
+	y, okY := new(big.Int).SetString(b, 10)
+	if !okX || !okY {
+		return a < b
+	}
+	return x.Cmp(y) < 0
+}
@@ -0,0 +1,166 @@
+package connector
+
+import (
+	"encoding/json"
+	"time"
+
+	connectorcorda "github.com/hyperledger/cactus-plugin-ledger-connector-corda/src/main/go/generated/openapi/go-client"
+)
+
+// The types are the models of the generated client of the endpoints wrapped by the Client, except where noted.
+
+/*
+StartFlowV1Request starts a Corda 5 flow. Unlike the model of the generated client, whose request body has the
+arguments of the chat sample listed by the spec, it takes any request body, which the connector forwards to Corda.
+*/
+type StartFlowV1Request struct {
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	RejectUnauthorized bool   `json:"rejectUnauthorized"`
+	HoldingIDShortHash string `json:"holdingIDShortHash,omitempty"`
+	// Identifies the flow run, see GetFlowV1
+	ClientRequestId string `json:"clientRequestId"`
+	FlowClassName   string `json:"flowClassName"`
+	// Start arguments of the flow
+	RequestBody interface{} `json:"requestBody"`
+}
+
+// GetFlowCidV1Request identifies a flow run, or the virtual node of the flows to list
+type GetFlowCidV1Request = connectorcorda.GetFlowCidV1Request
+
+type ListCpiV1Request = connectorcorda.ListCpiV1Request
+
+// FlowStatus of a flow run
+type FlowStatus string
+
+// Statuses of the flow runs of Corda 5
+const (
+	StartRequested FlowStatus = "START_REQUESTED"
+	Running        FlowStatus = "RUNNING"
+	Retrying       FlowStatus = "RETRYING"
+	Completed      FlowStatus = "COMPLETED"
+	Failed         FlowStatus = "FAILED"
+	Killed         FlowStatus = "KILLED"
+)
+
+// Final tells whether a flow run with the status is over
+func (s FlowStatus) Final() bool {
+	return s == Completed || s == Failed || s == Killed
+}
+
+type FlowV1Error = connectorcorda.FlowV1Error
+
+/*
+FlowStatusResponse is the status of a flow run. StartFlowV1Response, GetFlowCidV1Response and the items of
+FlowStatusV1Responses are the same object, but three models of the generated client: it is a single type here, with
+the status typed.
+*/
+type FlowStatusResponse struct {
+	ClientRequestId    string       `json:"clientRequestId,omitempty"`
+	FlowError          *FlowV1Error `json:"flowError,omitempty"`
+	FlowId             string       `json:"flowId,omitempty"`
+	FlowResult         string       `json:"flowResult,omitempty"`
+	FlowStatus         FlowStatus   `json:"flowStatus"`
+	HoldingIDShortHash string       `json:"holdingIDShortHash"`
+	Timestamp          time.Time    `json:"timestamp"`
+}
+
+// Unmarshal decodes the result of the flow, for flows returning JSON
+func (r *FlowStatusResponse) Unmarshal(v interface{}) error {
+	return json.Unmarshal([]byte(r.FlowResult), v)
+}
+
+// FlowStatusV1Responses (FlowStatusV1Responses in the OpenAPI spec)
+type FlowStatusV1Responses struct {
+	FlowStatusResponses []FlowStatusResponse `json:"flowStatusResponses,omitempty"`
+}
+
+type CPIIDV1 = connectorcorda.CPIIDV1
+
+// CPK of a CPI
+type CPK = connectorcorda.ListCpiV1ResponseCpisInnerCpksInner
+
+// CPI uploaded to the cluster
+type CPI = connectorcorda.ListCpiV1ResponseCpisInner
+
+type ListCpiV1Response = connectorcorda.ListCpiV1Response
+
+type VaultQueryV1Request = connectorcorda.VaultQueryV1Request
+
+/*
+VaultPage is a page of the states of a vault query, as serialized by the connector from Vault.Page of Corda 4, with the
+data of the states decoded as T. The spec leaves the response untyped.
+*/
+type VaultPage[T any] struct {
+	States               []StateAndRef[T] `json:"states"`
+	StatesMetadata       []StateMetadata  `json:"statesMetadata"`
+	TotalStatesAvailable int64            `json:"totalStatesAvailable"`
+	// UNCONSUMED, CONSUMED or ALL
+	StateTypes   string            `json:"stateTypes"`
+	OtherResults []json.RawMessage `json:"otherResults"`
+}
+
+// Data returns the data of the states of the page
+func (p *VaultPage[T]) Data() []T {
+	data := make([]T, len(p.States))
+	for i, state := range p.States {
+		data[i] = state.State.Data
+	}
+	return data
+}
+
+// Complete tells whether the page holds all the states matching the query
+func (p *VaultPage[T]) Complete() bool {
+	return int64(len(p.States)) >= p.TotalStatesAvailable
+}
+
+// StateAndRef is a state of a vault and the output of the transaction that created it
+type StateAndRef[T any] struct {
+	State TransactionState[T] `json:"state"`
+	Ref   StateRef            `json:"ref"`
+}
+
+// TransactionState is a state and the contract governing it
+type TransactionState[T any] struct {
+	Data        T               `json:"data"`
+	Contract    string          `json:"contract"`
+	Notary      json.RawMessage `json:"notary,omitempty"`
+	Encumbrance *int            `json:"encumbrance,omitempty"`
+	Constraint  json.RawMessage `json:"constraint,omitempty"`
+}
+
+// StateRef is the output of a transaction
+type StateRef struct {
+	// Hash of the transaction, in the form the JVM serializes SecureHash to
+	TxHash json.RawMessage `json:"txhash"`
+	Index  int             `json:"index"`
+}
+
+// StateMetadata is the metadata the vault keeps of a state
+type StateMetadata struct {
+	Ref                    StateRef `json:"ref"`
+	ContractStateClassName string   `json:"contractStateClassName"`
+	// UNCONSUMED or CONSUMED
+	Status string `json:"status"`
+	// RELEVANT or NOT_RELEVANT
+	RelevancyStatus string `json:"relevancyStatus,omitempty"`
+}
+
+type StartMonitorV1Request = connectorcorda.StartMonitorV1Request
+
+type GetMonitorTransactionsV1Request = connectorcorda.GetMonitorTransactionsV1Request
+
+type StopMonitorV1Request = connectorcorda.StopMonitorV1Request
+
+type ClearMonitorTransactionsV1Request = connectorcorda.ClearMonitorTransactionsV1Request
+
+/*
+MonitorV1Response is the response of the monitor endpoints (StartMonitorV1Response, ClearMonitorTransactionsV1Response
+and StopMonitorV1Response in the OpenAPI spec, which are the same)
+*/
+type MonitorV1Response = connectorcorda.StartMonitorV1Response
+
+type GetMonitorTransactionsV1Response = connectorcorda.GetMonitorTransactionsV1Response
+
+// MonitorV1TxItem is a state change seen by a monitor, whose index counts from 0
+type MonitorV1TxItem = connectorcorda.GetMonitorTransactionsV1ResponseTxInner
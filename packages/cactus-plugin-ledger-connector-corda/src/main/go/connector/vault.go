@@ -0,0 +1,20 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+)
+
+/*
+VaultQuery queries the unconsumed states of a type in the vault of the Corda 4 node of the connector with
+VaultQueryV1, decoding the data of the states as T. The connector does not pass a page specification, so Corda
+returns a single page, and fails the query if more states than its default page size (200) match.
+*/
+func VaultQuery[T any](ctx context.Context, c *Client, contractStateType string) (*VaultPage[T], error) {
+	page := &VaultPage[T]{}
+	err := c.do(ctx, http.MethodPost, VaultQueryPath, &VaultQueryV1Request{ContractStateType: &contractStateType}, page)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
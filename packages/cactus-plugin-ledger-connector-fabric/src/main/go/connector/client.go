@@ -0,0 +1,218 @@
+/*
+Package connector is a client of the Fabric connector of the Cactus API server, on top of its REST API: it submits and
+evaluates transactions, fetches transaction receipts and deploys Go chaincodes.
+
+The requests and responses are the models of the generated client.
+*/
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Paths of the endpoints of the connector
+const (
+	RunTransactionPath        = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-fabric/run-transaction"
+	GetTransactionReceiptPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-fabric/get-transaction-receipt-by-txid"
+	DeployContractPath        = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-ledger-connector-fabric/deploy-contract"
+)
+
+// Config of a client
+type Config struct {
+	// URL of the API server, e.g. http://localhost:4000
+	URL string
+	// Client of the requests; defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers added to the requests, e.g. Authorization
+	Header http.Header
+	// Identity the transactions are signed with
+	SigningCredential FabricSigningCredential
+	// Options of the gateway of the connector, if not configured on the connector
+	GatewayOptions *GatewayOptions
+	/*
+		Times a request is retried after failing to connect to the connector and, for requests that do not change the
+		ledger, after a transient error (see Error.Transient) or failing to get a response. Requests that change the
+		ledger are only retried after a 503 response, as the connector may have received those answered with a 502
+		or 504 by a proxy.
+	*/
+	MaxRetries int
+	// Delay before the first retry, doubling after each one; defaults to 500ms
+	RetryDelay time.Duration
+}
+
+// Client of the Fabric connector
+type Client struct {
+	config Config
+}
+
+// Result of a transaction
+type Result struct {
+	TransactionID string
+	// Output of the chaincode function
+	Output string
+}
+
+// Unmarshal decodes the output of the transaction, for chaincode functions returning JSON
+func (r *Result) Unmarshal(v interface{}) error {
+	return json.Unmarshal([]byte(r.Output), v)
+}
+
+// New returns a client of the connector of an API server
+func New(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 500 * time.Millisecond
+	}
+	return &Client{config: config}
+}
+
+// Submit submits a transaction invoking a chaincode function, and waits for it to be committed
+func (c *Client) Submit(ctx context.Context, channel string, contract string, method string, args ...string) (*Result, error) {
+	return c.transact(ctx, Send, channel, contract, method, args)
+}
+
+// Evaluate evaluates a chaincode function on a peer, without submitting a transaction
+func (c *Client) Evaluate(ctx context.Context, channel string, contract string, method string, args ...string) (*Result, error) {
+	return c.transact(ctx, Call, channel, contract, method, args)
+}
+
+func (c *Client) transact(ctx context.Context, invocationType InvocationType, channel string, contract string,
+	method string, args []string) (*Result, error) {
+	response, err := c.RunTransaction(ctx, &RunTransactionRequest{
+		ChannelName:    channel,
+		ContractName:   contract,
+		InvocationType: invocationType,
+		MethodName:     method,
+		Params:         params(args),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Result{TransactionID: response.TransactionId, Output: response.FunctionOutput}, nil
+}
+
+/*
+RunTransaction runs a transaction with RunTransactionV1, for the options Submit and Evaluate do not cover, e.g.
+endorsing organizations or transient data. The signing credential and gateway options of the client are used if
+those of the request are not set.
+*/
+func (c *Client) RunTransaction(ctx context.Context, request *RunTransactionRequest) (*RunTransactionResponse, error) {
+	withDefaults := c.withDefaults(*request)
+	response := &RunTransactionResponse{}
+	err := c.post(ctx, RunTransactionPath, &withDefaults, response, request.InvocationType == Call)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// TransactionReceipt fetches the receipt of a committed transaction with GetTransactionReceiptByTxIDV1
+func (c *Client) TransactionReceipt(ctx context.Context, channel string, transactionID string) (*TransactionReceipt, error) {
+	request := c.withDefaults(RunTransactionRequest{
+		ChannelName:    channel,
+		ContractName:   "qscc",
+		InvocationType: Call,
+		MethodName:     "GetBlockByTxID",
+		Params:         params([]string{channel, transactionID}),
+	})
+	receipt := &TransactionReceipt{}
+	err := c.post(ctx, GetTransactionReceiptPath, &request, receipt, true)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (c *Client) withDefaults(request RunTransactionRequest) RunTransactionRequest {
+	if request.SigningCredential == (FabricSigningCredential{}) {
+		request.SigningCredential = c.config.SigningCredential
+	}
+	if request.GatewayOptions == nil {
+		request.GatewayOptions = c.config.GatewayOptions
+	}
+	if request.Params == nil {
+		request.Params = []*string{}
+	}
+	return request
+}
+
+// post posts a request to an endpoint and decodes the response, retrying failed requests as configured
+func (c *Client) post(ctx context.Context, path string, request interface{}, response interface{}, idempotent bool) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	delay := c.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		connected, answered, err := c.postOnce(ctx, path, body, response)
+		var connectorError *Error
+		var retry bool
+		if errors.As(err, &connectorError) {
+			retry = connectorError.StatusCode == http.StatusServiceUnavailable || (idempotent && connectorError.Transient())
+		} else {
+			retry = !connected || (!answered && idempotent)
+		}
+		if err == nil || attempt >= c.config.MaxRetries || ctx.Err() != nil || !retry {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+/*
+postOnce posts a request to an endpoint, returning whether a connection to the connector was obtained, i.e. whether
+the request may have been sent, and whether a response was received
+*/
+func (c *Client) postOnce(ctx context.Context, path string, body []byte, response interface{}) (bool, bool, error) {
+	var connected atomic.Bool
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { connected.Store(true) },
+	})
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.config.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return false, false, err
+	}
+	for name, values := range c.config.Header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	httpResponse, err := c.config.HTTPClient.Do(request)
+	if err != nil {
+		return connected.Load(), false, err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return true, false, err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		connectorError := &Error{}
+		if json.Unmarshal(responseBody, connectorError) != nil || connectorError.Message == "" {
+			connectorError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		connectorError.StatusCode = httpResponse.StatusCode
+		return true, true, connectorError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return true, true, fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return true, true, nil
+}
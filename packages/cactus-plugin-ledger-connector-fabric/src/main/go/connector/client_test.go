@@ -0,0 +1,267 @@
+package connector
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// connector stands in for the connector, answering with the responses queued for each path
+type connector struct {
+	mutex     sync.Mutex
+	responses map[string][]func(w http.ResponseWriter)
+	requests  map[string][]json.RawMessage
+}
+
+func startConnector(t *testing.T) (*connector, *Client) {
+	c := &connector{responses: map[string][]func(w http.ResponseWriter){}, requests: map[string][]json.RawMessage{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		c.mutex.Lock()
+		c.requests[r.URL.Path] = append(c.requests[r.URL.Path], body)
+		responses := c.responses[r.URL.Path]
+		if len(responses) == 0 {
+			c.mutex.Unlock()
+			http.NotFound(w, r)
+			return
+		}
+		c.responses[r.URL.Path] = responses[1:]
+		c.mutex.Unlock()
+		responses[0](w)
+	}))
+	t.Cleanup(server.Close)
+	credentialType := X509
+	client := New(Config{
+		URL:               server.URL,
+		SigningCredential: FabricSigningCredential{KeychainId: "keychain", KeychainRef: "user1", Type: &credentialType},
+		MaxRetries:        2,
+		RetryDelay:        time.Millisecond,
+	})
+	return c, client
+}
+
+func (c *connector) respond(path string, status int, body interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.responses[path] = append(c.responses[path], func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+// drop closes the connection of a request without responding
+func (c *connector) drop(path string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.responses[path] = append(c.responses[path], func(w http.ResponseWriter) {
+		connection, _, _ := w.(http.Hijacker).Hijack()
+		connection.Close()
+	})
+}
+
+func (c *connector) received(path string) []json.RawMessage {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.requests[path]
+}
+
+func TestSubmitAndEvaluate(t *testing.T) {
+	c, client := startConnector(t)
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{FunctionOutput: "", TransactionId: "tx1"})
+	result, err := client.Submit(context.Background(), "mychannel", "basic", "CreateAsset", "asset1", "blue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TransactionID != "tx1" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	var request RunTransactionRequest
+	json.Unmarshal(c.received(RunTransactionPath)[0], &request)
+	if request.InvocationType != Send || request.ChannelName != "mychannel" || request.ContractName != "basic" ||
+		request.MethodName != "CreateAsset" || len(request.Params) != 2 || *request.Params[1] != "blue" ||
+		request.SigningCredential.KeychainRef != "user1" || *request.SigningCredential.Type != X509 {
+		t.Fatalf("unexpected request %+v", request)
+	}
+
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{FunctionOutput: `{"ID":"asset1","Color":"blue"}`})
+	result, err = client.Evaluate(context.Background(), "mychannel", "basic", "ReadAsset", "asset1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var asset struct{ ID, Color string }
+	if err := result.Unmarshal(&asset); err != nil || asset.Color != "blue" {
+		t.Fatalf("unexpected output %s", result.Output)
+	}
+	json.Unmarshal(c.received(RunTransactionPath)[1], &request)
+	if request.InvocationType != Call || *request.Params[0] != "asset1" {
+		t.Fatalf("unexpected request %+v", request)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	c, client := startConnector(t)
+
+	// errors of the connector are typed, and not retried
+	c.respond(RunTransactionPath, http.StatusInternalServerError, map[string]string{
+		"message": "Internal Server Error",
+		"error":   "asset asset1 does not exist",
+	})
+	_, err := client.Evaluate(context.Background(), "mychannel", "basic", "ReadAsset", "asset1")
+	var connectorError *Error
+	if !errors.As(err, &connectorError) || connectorError.StatusCode != 500 || connectorError.Exception != "asset asset1 does not exist" || connectorError.Transient() {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(c.received(RunTransactionPath)) != 1 {
+		t.Fatal("connector error retried")
+	}
+
+	// transient errors are retried
+	c.respond(RunTransactionPath, http.StatusServiceUnavailable, "unavailable")
+	c.respond(RunTransactionPath, http.StatusBadGateway, "bad gateway")
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{FunctionOutput: "true"})
+	result, err := client.Evaluate(context.Background(), "mychannel", "basic", "AssetExists", "asset1")
+	if err != nil || result.Output != "true" {
+		t.Fatalf("unexpected result %+v %v", result, err)
+	}
+	// until retries run out
+	for i := 0; i < 3; i++ {
+		c.respond(RunTransactionPath, http.StatusGatewayTimeout, "timeout")
+	}
+	_, err = client.Evaluate(context.Background(), "mychannel", "basic", "AssetExists", "asset1")
+	if !errors.As(err, &connectorError) || connectorError.StatusCode != http.StatusGatewayTimeout || connectorError.Message != "Gateway Timeout" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// submissions are retried on 503, but not on 502 or 504 as the connector may have received them
+	c.respond(RunTransactionPath, http.StatusServiceUnavailable, "unavailable")
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{TransactionId: "tx2"})
+	result, err = client.Submit(context.Background(), "mychannel", "basic", "DeleteAsset", "asset1")
+	if err != nil || result.TransactionID != "tx2" {
+		t.Fatalf("unexpected result %+v %v", result, err)
+	}
+	for _, status := range []int{http.StatusBadGateway, http.StatusGatewayTimeout} {
+		c.respond(RunTransactionPath, status, "proxy error")
+		before := len(c.received(RunTransactionPath))
+		_, err = client.Submit(context.Background(), "mychannel", "basic", "DeleteAsset", "asset1")
+		if !errors.As(err, &connectorError) || connectorError.StatusCode != status {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if len(c.received(RunTransactionPath)) != before+1 {
+			t.Fatalf("submission retried after status %d", status)
+		}
+	}
+
+	// requests without a response are retried only if they do not change the ledger
+	c.drop(RunTransactionPath)
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{FunctionOutput: "true"})
+	if result, err := client.Evaluate(context.Background(), "mychannel", "basic", "AssetExists", "asset1"); err != nil || result.Output != "true" {
+		t.Fatalf("unexpected result %+v %v", result, err)
+	}
+	c.drop(RunTransactionPath)
+	before := len(c.received(RunTransactionPath))
+	if _, err := client.Submit(context.Background(), "mychannel", "basic", "DeleteAsset", "asset1"); err == nil || errors.As(err, &connectorError) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(c.received(RunTransactionPath)) != before+1 {
+		t.Fatal("submission retried without a response")
+	}
+}
+
+func TestRetryConnectionFailure(t *testing.T) {
+	c, client := startConnector(t)
+	dialer := &net.Dialer{}
+	var dials atomic.Int32
+	client.config.HTTPClient = &http.Client{Transport: &http.Transport{
+		// the first connection attempt fails, before the request is sent
+		DialContext: func(ctx context.Context, network string, address string) (net.Conn, error) {
+			if dials.Add(1) == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}}
+
+	// requests that were not sent are retried even if they change the ledger
+	c.respond(RunTransactionPath, http.StatusOK, RunTransactionResponse{TransactionId: "tx1"})
+	result, err := client.Submit(context.Background(), "mychannel", "basic", "DeleteAsset", "asset1")
+	if err != nil || result.TransactionID != "tx1" {
+		t.Fatalf("unexpected result %+v %v", result, err)
+	}
+	if dials.Load() != 2 || len(c.received(RunTransactionPath)) != 1 {
+		t.Fatalf("unexpected dials %d and requests %d", dials.Load(), len(c.received(RunTransactionPath)))
+	}
+}
+
+func TestTransactionReceipt(t *testing.T) {
+	c, client := startConnector(t)
+	blockNumber := "5"
+	c.respond(GetTransactionReceiptPath, http.StatusOK, TransactionReceipt{BlockNumber: &blockNumber})
+	receipt, err := client.TransactionReceipt(context.Background(), "mychannel", "tx1")
+	if err != nil || *receipt.BlockNumber != "5" {
+		t.Fatalf("unexpected receipt %+v %v", receipt, err)
+	}
+	var request RunTransactionRequest
+	json.Unmarshal(c.received(GetTransactionReceiptPath)[0], &request)
+	if request.ContractName != "qscc" || request.MethodName != "GetBlockByTxID" || len(request.Params) != 2 || *request.Params[1] != "tx1" {
+		t.Fatalf("unexpected request %+v", request)
+	}
+	if _, err := client.TransactionReceipt(context.Background(), "mychannel", "tx2"); !IsNotFound(err) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestDeployGoChaincode(t *testing.T) {
+	c, client := startConnector(t)
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "chaincode"), 0o755)
+	os.MkdirAll(filepath.Join(dir, "vendor", "example.com"), 0o755)
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module basic\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "chaincode", "contract.go"), []byte("package chaincode\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "vendor", "modules.txt"), []byte(""), 0o644)
+	os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(""), 0o644)
+
+	c.respond(DeployContractPath, http.StatusOK, DeployContractV1Response{Success: true, PackageIds: []string{"basic_1.0:123"}})
+	response, err := client.DeployGoChaincode(context.Background(), dir, DeployContractV1Request{
+		ChannelId: "mychannel",
+		CcName:    "basic",
+		CcVersion: "1.0",
+	})
+	if err != nil || response.PackageIds[0] != "basic_1.0:123" {
+		t.Fatalf("unexpected response %+v %v", response, err)
+	}
+	var request DeployContractV1Request
+	json.Unmarshal(c.received(DeployContractPath)[0], &request)
+	if request.CcLang != "golang" || request.CcLabel != "basic_1.0" || request.CcSequence != 1 || len(request.SourceFiles) != 3 {
+		t.Fatalf("unexpected request %+v", request)
+	}
+	files := map[string]string{}
+	for _, file := range request.SourceFiles {
+		name := file.Filename
+		if file.Filepath != nil {
+			name = *file.Filepath + "/" + name
+		}
+		content, _ := base64.StdEncoding.DecodeString(file.Body)
+		files[name] = string(content)
+	}
+	if files["go.mod"] != "module basic\n" || files["chaincode/contract.go"] != "package chaincode\n" || files["main.go"] == "" {
+		t.Fatalf("unexpected source files %v", files)
+	}
+
+	c.respond(DeployContractPath, http.StatusOK, DeployContractV1Response{Success: false})
+	if _, err := client.DeployGoChaincode(context.Background(), dir, DeployContractV1Request{CcName: "basic"}); err == nil {
+		t.Fatal("failed deployment not reported")
+	}
+}
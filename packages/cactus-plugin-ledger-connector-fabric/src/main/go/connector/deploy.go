@@ -0,0 +1,92 @@
+package connector
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	connectorfabric "github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client"
+)
+
+/*
+DeployGoChaincode deploys the Go chaincode module in a directory with DeployContractV1: its files are sent as the
+source files of the request, which holds the settings of the deployment. The label defaults to <name>_<version> and
+the sequence to 1.
+
+The deployment installs, approves and commits the chaincode with the peer CLI, so it changes the ledger and is not
+retried on lack of response, nor after a 502 or 504 response.
+*/
+func (c *Client) DeployGoChaincode(ctx context.Context, dir string, request DeployContractV1Request) (*DeployContractV1Response, error) {
+	sourceFiles, err := ReadSourceFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	request.CcLang = connectorfabric.CHAINCODEPROGRAMMINGLANGUAGE_GOLANG
+	request.SourceFiles = append(sourceFiles, request.SourceFiles...)
+	if request.CcLabel == "" {
+		request.CcLabel = request.CcName + "_" + request.CcVersion
+	}
+	if request.CcSequence == 0 {
+		request.CcSequence = 1
+	}
+	response := &DeployContractV1Response{}
+	err = c.post(ctx, DeployContractPath, &request, response, false)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return response, errors.New("chaincode deployment failed")
+	}
+	return response, nil
+}
+
+/*
+ReadSourceFiles reads the files of a chaincode module, such as its go.mod, go.sum and .go files, skipping hidden
+files and the vendor directory: the connector vendors the dependencies itself.
+*/
+func ReadSourceFiles(dir string) ([]FileBase64, error) {
+	var files []FileBase64
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		if relativePath == "." {
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") || (entry.IsDir() && relativePath == "vendor") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		file := FileBase64{Body: base64.StdEncoding.EncodeToString(content), Filename: entry.Name()}
+		if fileDir := path.Dir(filepath.ToSlash(relativePath)); fileDir != "." {
+			file.Filepath = &fileDir
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no chaincode source files in " + dir)
+	}
+	return files, nil
+}
@@ -0,0 +1,46 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+/*
+Error is an error response of the connector. The body of 5xx responses is an ErrorExceptionResponseV1, whose error
+member is the serialized exception, e.g. that of a chaincode.
+*/
+type Error struct {
+	StatusCode int
+	// Name of the status, e.g. Internal Server Error
+	Message string `json:"message"`
+	// The exception that caused the error, or the body of the response if it is not an ErrorExceptionResponseV1
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("connector responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("connector responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+/*
+Transient tells whether the request can be expected to succeed if retried: the connector, or a proxy in front of it,
+is unavailable or timed out. Errors of the connector itself (500) are not transient, as a transaction may have been
+submitted. A 502 or 504 response of a proxy may also come after the connector received the request, so the Client
+only retries requests that change the ledger after a 503 response.
+*/
+func (e *Error) Transient() bool {
+	switch e.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsNotFound tells whether an error is a 404 response, e.g. for the receipt of an unknown transaction
+func IsNotFound(err error) bool {
+	var connectorError *Error
+	return errors.As(err, &connectorError) && connectorError.StatusCode == http.StatusNotFound
+}
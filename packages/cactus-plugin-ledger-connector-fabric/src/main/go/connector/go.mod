@@ -0,0 +1,7 @@
+module github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/connector
+
+go 1.21
+
+require github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client v0.0.0
+
+replace github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client => ../generated/openapi/go-client
@@ -0,0 +1,88 @@
+package connector
+
+import (
+	connectorfabric "github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// The types are the models of the generated client of the endpoints wrapped by the Client.
+
+type FabricSigningCredentialType = connectorfabric.FabricSigningCredentialType
+
+const (
+	X509      = connectorfabric.FABRICSIGNINGCREDENTIALTYPE_X_509
+	VaultX509 = connectorfabric.FABRICSIGNINGCREDENTIALTYPE_VAULT_X_509
+	WSX509    = connectorfabric.FABRICSIGNINGCREDENTIALTYPE_WS_X_509
+)
+
+type VaultTransitKey = connectorfabric.VaultTransitKey
+
+type WebSocketKey = connectorfabric.WebSocketKey
+
+// FabricSigningCredential identifies the identity transactions are signed with
+type FabricSigningCredential = connectorfabric.FabricSigningCredential
+
+type ConnectionProfile = connectorfabric.ConnectionProfile
+
+type GatewayDiscoveryOptions = connectorfabric.GatewayDiscoveryOptions
+
+type GatewayEventHandlerOptions = connectorfabric.GatewayEventHandlerOptions
+
+type GatewayOptionsWallet = connectorfabric.GatewayOptionsWallet
+
+// GatewayOptions of the gateway the connector transacts through
+type GatewayOptions = connectorfabric.GatewayOptions
+
+// InvocationType (FabricContractInvocationType in the OpenAPI spec)
+type InvocationType = connectorfabric.FabricContractInvocationType
+
+const (
+	Send        = connectorfabric.FABRICCONTRACTINVOCATIONTYPE_SEND
+	Call        = connectorfabric.FABRICCONTRACTINVOCATIONTYPE_CALL
+	SendPrivate = connectorfabric.FABRICCONTRACTINVOCATIONTYPE_SENDPRIVATE
+)
+
+// ResponseType is the encoding of the output of a transaction (RunTransactionResponseType in the OpenAPI spec)
+type ResponseType = connectorfabric.RunTransactionResponseType
+
+const (
+	ResponseJSON = connectorfabric.RUNTRANSACTIONRESPONSETYPE_JSON
+	ResponseUTF8 = connectorfabric.RUNTRANSACTIONRESPONSETYPE_UTF8
+)
+
+type RunTransactionRequest = connectorfabric.RunTransactionRequest
+
+type RunTransactionResponse = connectorfabric.RunTransactionResponse
+
+type TransactReceiptTransactionCreator = connectorfabric.TransactReceiptTransactionCreator
+
+type TransactReceiptTransactionEndorsement = connectorfabric.TransactReceiptTransactionEndorsement
+
+type TransactReceiptBlockMetaData = connectorfabric.TransactReceiptBlockMetaData
+
+// TransactionReceipt is the receipt of a committed transaction (GetTransactionReceiptResponse in the OpenAPI spec)
+type TransactionReceipt = connectorfabric.GetTransactionReceiptResponse
+
+type FileBase64 = connectorfabric.FileBase64
+
+// DeploymentTargetOrganization is an organization whose peers the chaincode is installed on
+type DeploymentTargetOrganization = connectorfabric.DeploymentTargetOrganization
+
+// ConstructorArgs (DeployContractV1RequestConstructorArgs in the OpenAPI spec)
+type ConstructorArgs = connectorfabric.DeployContractV1RequestConstructorArgs
+
+type DeployContractV1Request = connectorfabric.DeployContractV1Request
+
+type SSHExecCommandResponse = connectorfabric.SSHExecCommandResponse
+
+type ChainCodeLifeCycleCommandResponses = connectorfabric.ChainCodeLifeCycleCommandResponses
+
+type DeployContractV1Response = connectorfabric.DeployContractV1Response
+
+// params returns the arguments of a chaincode function as the params of a RunTransactionRequest
+func params(args []string) []*string {
+	params := make([]*string, len(args))
+	for i := range args {
+		params[i] = &args[i]
+	}
+	return params
+}
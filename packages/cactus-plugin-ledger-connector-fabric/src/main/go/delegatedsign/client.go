@@ -0,0 +1,68 @@
+package delegatedsign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+/*
+RemoteSigner requests signatures from a Server, as the sign callback of the connector does. It is meant for Go hosts
+of the signing flow and for tests standing in for the connector.
+*/
+type RemoteSigner struct {
+	// URL the Server is served at
+	URL string
+	// Client of the requests; defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// Sign requests the signature of a payload, passing the uniqueTransactionData of the connector request
+func (s *RemoteSigner) Sign(ctx context.Context, payload []byte, txData interface{}) ([]byte, error) {
+	request := Request{Payload: payload}
+	if txData != nil {
+		data, err := json.Marshal(txData)
+		if err != nil {
+			return nil, err
+		}
+		request.TxData = data
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		var errorResponse ErrorResponse
+		if json.Unmarshal(responseBody, &errorResponse) != nil || errorResponse.Error == "" {
+			errorResponse.Error = string(responseBody)
+		}
+		return nil, fmt.Errorf("signature refused with status %d: %s", httpResponse.StatusCode, errorResponse.Error)
+	}
+	var response Response
+	err = json.Unmarshal(responseBody, &response)
+	if err != nil || len(response.Signature) == 0 {
+		return nil, fmt.Errorf("malformed signature response")
+	}
+	return response.Signature, nil
+}
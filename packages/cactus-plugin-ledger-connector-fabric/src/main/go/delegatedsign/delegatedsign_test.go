@@ -0,0 +1,143 @@
+package delegatedsign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newIdentity returns an EC key and a certificate of its public key
+func newIdentity(t *testing.T, curve elliptic.Curve) (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1", Organization: []string{"Org1MSP"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestServer(t *testing.T) {
+	key, certificate := newIdentity(t, elliptic.P256())
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyFile := filepath.Join(t.TempDir(), "priv_sk")
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	signer, err := NewFileSigner(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(&Server{
+		Signer: signer,
+		Authorize: func(ctx context.Context, request *Request) error {
+			var txData struct{ Token string }
+			if json.Unmarshal(request.TxData, &txData) != nil || txData.Token != "secret" {
+				return errors.New("invalid token")
+			}
+			return nil
+		},
+	})
+	defer server.Close()
+	connector := &RemoteSigner{URL: server.URL}
+
+	for _, payload := range [][]byte{[]byte("proposal"), []byte("transaction")} {
+		signature, err := connector.Sign(context.Background(), payload, map[string]string{"Token": "secret"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Verify(certificate, payload, signature); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = connector.Sign(context.Background(), []byte("proposal"), map[string]string{"Token": "guess"})
+	if err == nil || !strings.Contains(err.Error(), "status 403: invalid token") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_, err = connector.Sign(context.Background(), nil, map[string]string{"Token": "secret"})
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// a server without an Authorize function signs nothing
+	unauthorized := httptest.NewServer(&Server{Signer: signer})
+	defer unauthorized.Close()
+	_, err = (&RemoteSigner{URL: unauthorized.URL}).Sign(context.Background(), []byte("proposal"), nil)
+	if err == nil || !strings.Contains(err.Error(), "status 500: no Authorize function configured") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+// highSSigner signs with an ECDSA key, returning signatures with a high S
+type highSSigner struct {
+	*ecdsa.PrivateKey
+}
+
+func (s highSSigner) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	r, sig, err := ecdsa.Sign(random, s.PrivateKey, digest)
+	if err != nil {
+		return nil, err
+	}
+	order := s.Curve.Params().N
+	if sig.Cmp(new(big.Int).Rsh(order, 1)) <= 0 {
+		sig.Sub(order, sig)
+	}
+	return asn1.Marshal(ecdsaSignature{r, sig})
+}
+
+func TestCryptoSigner(t *testing.T) {
+	key, certificate := newIdentity(t, elliptic.P384())
+	// signatures with a high S are rejected by the peers
+	highS, _ := highSSigner{key}.Sign(rand.Reader, Digest([]byte("proposal")), crypto.SHA256)
+	if err := Verify(certificate, []byte("proposal"), highS); err == nil {
+		t.Fatal("signature with a high S verified")
+	}
+
+	signer, err := NewCryptoSigner(highSSigner{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := signer.Sign(context.Background(), Digest([]byte("proposal")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(certificate, []byte("proposal"), signature); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(certificate, []byte("other proposal"), signature); err == nil {
+		t.Fatal("signature of another payload verified")
+	}
+
+	// SEC 1 keys are accepted too, but only ECDSA keys
+	der, _ := x509.MarshalECPrivateKey(key)
+	if _, err := NewPEMSigner(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})); err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := NewCryptoSigner(rsaKey); err == nil {
+		t.Fatal("RSA key accepted")
+	}
+}
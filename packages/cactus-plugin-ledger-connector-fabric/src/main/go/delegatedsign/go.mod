@@ -0,0 +1,3 @@
+module github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/delegatedsign
+
+go 1.21
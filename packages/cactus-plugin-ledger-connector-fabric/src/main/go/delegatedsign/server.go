@@ -0,0 +1,78 @@
+/*
+Package delegatedsign signs Fabric proposals and transactions for the delegated-sign methods of the Fabric connector
+(RunDelegatedSignTransactionV1 and WatchBlocksDelegatedSignV1), so that the signing keys never leave the process.
+
+The connector is given a signCallback that posts each payload to sign to a Server, e.g. the one made by
+createRemoteSignCallback of the connector package. The server hashes the payload, checks that the request is
+authorized and returns the signature made by its Signer: a key file, or any crypto.Signer such as a PKCS #11 or KMS key.
+*/
+package delegatedsign
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Request to sign a payload, as posted by the sign callback of the connector
+type Request struct {
+	// Proposal or transaction to sign, base64 encoded in JSON
+	Payload []byte `json:"payload"`
+	// uniqueTransactionData of the connector request the payload belongs to
+	TxData json.RawMessage `json:"txData,omitempty"`
+}
+
+// Response to a Request
+type Response struct {
+	// DER encoded signature, base64 encoded in JSON
+	Signature []byte `json:"signature"`
+}
+
+// ErrorResponse is returned when a payload is not signed
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server signs the payloads posted by the connector
+type Server struct {
+	Signer Signer
+	/*
+		Authorize decides whether to sign a payload, e.g. by checking a token passed by the caller of the connector in
+		the uniqueTransactionData. It is required: a Server without it signs nothing.
+	*/
+	Authorize func(ctx context.Context, request *Request) error
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{Error: "POST expected"})
+		return
+	}
+	request := &Request{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<20)).Decode(request)
+	if err != nil || len(request.Payload) == 0 {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: "payload expected"})
+		return
+	}
+	if s.Authorize == nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "no Authorize function configured"})
+		return
+	}
+	err = s.Authorize(r.Context(), request)
+	if err != nil {
+		writeJSON(w, http.StatusForbidden, ErrorResponse{Error: err.Error()})
+		return
+	}
+	signature, err := s.Signer.Sign(r.Context(), Digest(request.Payload))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, Response{Signature: signature})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
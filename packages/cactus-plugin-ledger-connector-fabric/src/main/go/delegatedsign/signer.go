@@ -0,0 +1,144 @@
+package delegatedsign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+/*
+Signer signs the SHA-256 digests of Fabric proposals and transactions. The signature is ASN.1 DER encoded ECDSA, with
+a low S as Fabric requires.
+*/
+type Signer interface {
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// CryptoSigner adapts a crypto.Signer with an ECDSA key, such as a PKCS #11 or KMS key, to a Signer
+type CryptoSigner struct {
+	signer crypto.Signer
+	curve  elliptic.Curve
+}
+
+// NewCryptoSigner adapts a crypto.Signer to a Signer. The key must be an ECDSA key on P-256 or P-384.
+func NewCryptoSigner(signer crypto.Signer) (*CryptoSigner, error) {
+	publicKey, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("ECDSA key expected, found %T", signer.Public())
+	}
+	if publicKey.Curve != elliptic.P256() && publicKey.Curve != elliptic.P384() {
+		return nil, fmt.Errorf("unsupported curve %s", publicKey.Curve.Params().Name)
+	}
+	return &CryptoSigner{signer: signer, curve: publicKey.Curve}, nil
+}
+
+// NewFileSigner returns a signer with the PEM encoded EC private key in a file, e.g. the key of an MSP keystore
+func NewFileSigner(keyFile string) (*CryptoSigner, error) {
+	keyPem, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewPEMSigner(keyPem)
+}
+
+// NewPEMSigner returns a signer with a PEM encoded EC private key, in PKCS #8 or SEC 1 form
+func NewPEMSigner(keyPem []byte) (*CryptoSigner, error) {
+	block, _ := pem.Decode(keyPem)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.New("private key is neither a PKCS #8 nor an EC private key")
+		}
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("ECDSA key expected, found %T", key)
+	}
+	return NewCryptoSigner(ecKey)
+}
+
+// Public returns the public key of the signer
+func (s *CryptoSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+func (s *CryptoSigner) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	signature, err := s.signer.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return lowS(signature, s.curve)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// lowS rewrites a DER encoded ECDSA signature with S in the lower half of the order of the curve, if it is not
+func lowS(signature []byte, curve elliptic.Curve) ([]byte, error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+		return nil, errors.New("signer returned a malformed ECDSA signature")
+	}
+	order := curve.Params().N
+	if sig.S.Cmp(new(big.Int).Rsh(order, 1)) <= 0 {
+		return signature, nil
+	}
+	sig.S.Sub(order, sig.S)
+	return asn1.Marshal(sig)
+}
+
+// Digest returns the digest of a payload that is signed
+func Digest(payload []byte) []byte {
+	digest := sha256.Sum256(payload)
+	return digest[:]
+}
+
+/*
+Verify verifies the signature of a payload with the PEM encoded certificate of the signer, as a peer would, rejecting
+signatures with a high S.
+*/
+func Verify(certificatePem []byte, payload []byte, signature []byte) error {
+	block, _ := pem.Decode(certificatePem)
+	if block == nil {
+		return errors.New("no PEM data found")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	publicKey, ok := certificate.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("certificate has no ECDSA key")
+	}
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+		return errors.New("malformed signature")
+	}
+	if sig.S.Cmp(new(big.Int).Rsh(publicKey.Curve.Params().N, 1)) > 0 {
+		return errors.New("signature has a high S")
+	}
+	if !ecdsa.Verify(publicKey, Digest(payload), sig.R, sig.S) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
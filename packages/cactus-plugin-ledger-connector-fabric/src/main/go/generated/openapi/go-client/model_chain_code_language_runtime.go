@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,9 +20,9 @@ type ChainCodeLanguageRuntime string
 
 // List of ChainCodeLanguageRuntime
 const (
-	GOLANG ChainCodeLanguageRuntime = "golang"
-	NODE ChainCodeLanguageRuntime = "node"
-	JAVA ChainCodeLanguageRuntime = "java"
+	CHAINCODELANGUAGERUNTIME_GOLANG ChainCodeLanguageRuntime = "golang"
+	CHAINCODELANGUAGERUNTIME_NODE ChainCodeLanguageRuntime = "node"
+	CHAINCODELANGUAGERUNTIME_JAVA ChainCodeLanguageRuntime = "java"
 )
 
 // All allowed values of ChainCodeLanguageRuntime enum
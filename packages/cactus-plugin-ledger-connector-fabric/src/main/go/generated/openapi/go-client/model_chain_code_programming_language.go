@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,10 +20,10 @@ type ChainCodeProgrammingLanguage string
 
 // List of ChainCodeProgrammingLanguage
 const (
-	GOLANG ChainCodeProgrammingLanguage = "golang"
-	JAVASCRIPT ChainCodeProgrammingLanguage = "javascript"
-	TYPESCRIPT ChainCodeProgrammingLanguage = "typescript"
-	JAVA ChainCodeProgrammingLanguage = "java"
+	CHAINCODEPROGRAMMINGLANGUAGE_GOLANG ChainCodeProgrammingLanguage = "golang"
+	CHAINCODEPROGRAMMINGLANGUAGE_JAVASCRIPT ChainCodeProgrammingLanguage = "javascript"
+	CHAINCODEPROGRAMMINGLANGUAGE_TYPESCRIPT ChainCodeProgrammingLanguage = "typescript"
+	CHAINCODEPROGRAMMINGLANGUAGE_JAVA ChainCodeProgrammingLanguage = "java"
 )
 
 // All allowed values of ChainCodeProgrammingLanguage enum
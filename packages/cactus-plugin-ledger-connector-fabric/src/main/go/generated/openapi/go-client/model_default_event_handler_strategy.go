@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,10 +20,10 @@ type DefaultEventHandlerStrategy string
 
 // List of DefaultEventHandlerStrategy
 const (
-	MSPID_SCOPE_ALLFORTX DefaultEventHandlerStrategy = "MSPID_SCOPE_ALLFORTX"
-	MSPID_SCOPE_ANYFORTX DefaultEventHandlerStrategy = "MSPID_SCOPE_ANYFORTX"
-	NETWORK_SCOPE_ALLFORTX DefaultEventHandlerStrategy = "NETWORK_SCOPE_ALLFORTX"
-	NETWORK_SCOPE_ANYFORTX DefaultEventHandlerStrategy = "NETWORK_SCOPE_ANYFORTX"
+	DEFAULTEVENTHANDLERSTRATEGY_MSPID_SCOPE_ALLFORTX DefaultEventHandlerStrategy = "MSPID_SCOPE_ALLFORTX"
+	DEFAULTEVENTHANDLERSTRATEGY_MSPID_SCOPE_ANYFORTX DefaultEventHandlerStrategy = "MSPID_SCOPE_ANYFORTX"
+	DEFAULTEVENTHANDLERSTRATEGY_NETWORK_SCOPE_ALLFORTX DefaultEventHandlerStrategy = "NETWORK_SCOPE_ALLFORTX"
+	DEFAULTEVENTHANDLERSTRATEGY_NETWORK_SCOPE_ANYFORTX DefaultEventHandlerStrategy = "NETWORK_SCOPE_ANYFORTX"
 )
 
 // All allowed values of DefaultEventHandlerStrategy enum
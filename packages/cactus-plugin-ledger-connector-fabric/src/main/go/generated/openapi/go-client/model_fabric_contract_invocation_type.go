@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,9 +20,9 @@ type FabricContractInvocationType string
 
 // List of FabricContractInvocationType
 const (
-	SEND FabricContractInvocationType = "FabricContractInvocationType.SEND"
-	CALL FabricContractInvocationType = "FabricContractInvocationType.CALL"
-	SENDPRIVATE FabricContractInvocationType = "FabricContractInvocationType.SENDPRIVATE"
+	FABRICCONTRACTINVOCATIONTYPE_SEND FabricContractInvocationType = "FabricContractInvocationType.SEND"
+	FABRICCONTRACTINVOCATIONTYPE_CALL FabricContractInvocationType = "FabricContractInvocationType.CALL"
+	FABRICCONTRACTINVOCATIONTYPE_SENDPRIVATE FabricContractInvocationType = "FabricContractInvocationType.SENDPRIVATE"
 )
 
 // All allowed values of FabricContractInvocationType enum
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,9 +20,9 @@ type FabricSigningCredentialType string
 
 // List of FabricSigningCredentialType
 const (
-	X_509 FabricSigningCredentialType = "X.509"
-	VAULT_X_509 FabricSigningCredentialType = "Vault-X.509"
-	WS_X_509 FabricSigningCredentialType = "WS-X.509"
+	FABRICSIGNINGCREDENTIALTYPE_X_509 FabricSigningCredentialType = "X.509"
+	FABRICSIGNINGCREDENTIALTYPE_VAULT_X_509 FabricSigningCredentialType = "Vault-X.509"
+	FABRICSIGNINGCREDENTIALTYPE_WS_X_509 FabricSigningCredentialType = "WS-X.509"
 )
 
 // All allowed values of FabricSigningCredentialType enum
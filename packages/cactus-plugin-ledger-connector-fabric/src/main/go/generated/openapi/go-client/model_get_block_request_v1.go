@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -37,7 +37,7 @@ func NewGetBlockRequestV1(channelName string, gatewayOptions GatewayOptions, que
 	this.ChannelName = channelName
 	this.GatewayOptions = gatewayOptions
 	this.Query = query
-	var responseType GetBlockResponseTypeV1 = Full
+	var responseType GetBlockResponseTypeV1 = GETBLOCKRESPONSETYPEV1_Full
 	this.ResponseType = &responseType
 	return &this
 }
@@ -47,7 +47,7 @@ func NewGetBlockRequestV1(channelName string, gatewayOptions GatewayOptions, que
 // but it doesn't guarantee that properties required by API are set
 func NewGetBlockRequestV1WithDefaults() *GetBlockRequestV1 {
 	this := GetBlockRequestV1{}
-	var responseType GetBlockResponseTypeV1 = Full
+	var responseType GetBlockResponseTypeV1 = GETBLOCKRESPONSETYPEV1_Full
 	this.ResponseType = &responseType
 	return &this
 }
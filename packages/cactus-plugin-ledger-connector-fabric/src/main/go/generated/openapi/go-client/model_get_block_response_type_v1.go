@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,10 +20,10 @@ type GetBlockResponseTypeV1 string
 
 // List of GetBlockResponseTypeV1
 const (
-	Full GetBlockResponseTypeV1 = "full"
-	Encoded GetBlockResponseTypeV1 = "encoded"
-	CactiTransactions GetBlockResponseTypeV1 = "cacti:transactions"
-	CactiFullBlock GetBlockResponseTypeV1 = "cacti:full-block"
+	GETBLOCKRESPONSETYPEV1_Full GetBlockResponseTypeV1 = "full"
+	GETBLOCKRESPONSETYPEV1_Encoded GetBlockResponseTypeV1 = "encoded"
+	GETBLOCKRESPONSETYPEV1_CactiTransactions GetBlockResponseTypeV1 = "cacti:transactions"
+	GETBLOCKRESPONSETYPEV1_CactiFullBlock GetBlockResponseTypeV1 = "cacti:full-block"
 )
 
 // All allowed values of GetBlockResponseTypeV1 enum
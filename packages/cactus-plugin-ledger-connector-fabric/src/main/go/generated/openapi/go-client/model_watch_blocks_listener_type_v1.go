@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,11 +20,11 @@ type WatchBlocksListenerTypeV1 string
 
 // List of WatchBlocksListenerTypeV1
 const (
-	Filtered WatchBlocksListenerTypeV1 = "filtered"
-	Full WatchBlocksListenerTypeV1 = "full"
-	Private WatchBlocksListenerTypeV1 = "private"
-	CactiTransactions WatchBlocksListenerTypeV1 = "cacti:transactions"
-	CactiFullBlock WatchBlocksListenerTypeV1 = "cacti:full-block"
+	WATCHBLOCKSLISTENERTYPEV1_Filtered WatchBlocksListenerTypeV1 = "filtered"
+	WATCHBLOCKSLISTENERTYPEV1_Full WatchBlocksListenerTypeV1 = "full"
+	WATCHBLOCKSLISTENERTYPEV1_Private WatchBlocksListenerTypeV1 = "private"
+	WATCHBLOCKSLISTENERTYPEV1_CactiTransactions WatchBlocksListenerTypeV1 = "cacti:transactions"
+	WATCHBLOCKSLISTENERTYPEV1_CactiFullBlock WatchBlocksListenerTypeV1 = "cacti:full-block"
 )
 
 // All allowed values of WatchBlocksListenerTypeV1 enum
@@ -8,7 +8,7 @@ API version: 3.0.1
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech); DO NOT EDIT.
 
-package cactus-plugin-ledger-connector-fabric
+package connectorfabric
 
 import (
 	"encoding/json"
@@ -20,12 +20,12 @@ type WatchBlocksV1 string
 
 // List of WatchBlocksV1
 const (
-	Subscribe WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Subscribe"
-	SubscribeDelegatedSign WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.SubscribeDelegatedSign"
-	Next WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Next"
-	Unsubscribe WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Unsubscribe"
-	Error WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Error"
-	Complete WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Complete"
+	WATCHBLOCKSV1_Subscribe WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Subscribe"
+	WATCHBLOCKSV1_SubscribeDelegatedSign WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.SubscribeDelegatedSign"
+	WATCHBLOCKSV1_Next WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Next"
+	WATCHBLOCKSV1_Unsubscribe WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Unsubscribe"
+	WATCHBLOCKSV1_Error WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Error"
+	WATCHBLOCKSV1_Complete WatchBlocksV1 = "org.hyperledger.cactus.api.async.hlfabric.WatchBlocksV1.Complete"
 )
 
 // All allowed values of WatchBlocksV1 enum
@@ -0,0 +1,13 @@
+module github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/watchblocks
+
+go 1.21
+
+require (
+	github.com/hyperledger/cactus-core-api/src/main/go/socketio v0.0.0
+	github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client v0.0.0
+)
+
+replace (
+	github.com/hyperledger/cactus-core-api/src/main/go/socketio => ../../../../../cactus-core-api/src/main/go/socketio
+	github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client => ../generated/openapi/go-client
+)
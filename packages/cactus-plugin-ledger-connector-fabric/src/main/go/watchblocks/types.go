@@ -0,0 +1,128 @@
+package watchblocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	connectorfabric "github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// Socket.IO events of the WatchBlocksV1 async API
+var (
+	eventSubscribe              = string(connectorfabric.WATCHBLOCKSV1_Subscribe)
+	eventSubscribeDelegatedSign = string(connectorfabric.WATCHBLOCKSV1_SubscribeDelegatedSign)
+	eventNext                   = string(connectorfabric.WATCHBLOCKSV1_Next)
+	eventUnsubscribe            = string(connectorfabric.WATCHBLOCKSV1_Unsubscribe)
+	eventError                  = string(connectorfabric.WATCHBLOCKSV1_Error)
+	eventComplete               = string(connectorfabric.WATCHBLOCKSV1_Complete)
+)
+
+// Block is a block sent by the connector. Only the field of the watched listener type is set.
+type Block struct {
+	// Number of the block; nil for cacti:transactions blocks, which do not carry it
+	Number *uint64
+	// Block event of the Fabric Node SDK, for full, filtered and private blocks
+	FullBlock     json.RawMessage
+	FilteredBlock json.RawMessage
+	PrivateBlock  json.RawMessage
+	// Transactions of the block, for cacti:transactions blocks
+	CactiTransactions []connectorfabric.CactiBlockTransactionEventV1
+	// Summary of the block, for cacti:full-block blocks
+	CactiFullBlock *connectorfabric.CactiBlockFullEventV1
+}
+
+// Error is an error reported by the connector
+type Error connectorfabric.WatchBlocksCactusErrorResponseV1
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("watch blocks error %v: %s", e.Code, e.ErrorMessage)
+}
+
+/*
+blockNumber decodes the blockNumber of a block event. For block events of the Fabric Node SDK, it is a Long, which
+serializes to {"low": ..., "high": ..., "unsigned": ...}, though a number or a string is accepted as well.
+*/
+func blockNumber(blockEvent json.RawMessage) (*uint64, error) {
+	var event struct {
+		BlockNumber json.RawMessage `json:"blockNumber"`
+	}
+	err := json.Unmarshal(blockEvent, &event)
+	if err != nil {
+		return nil, err
+	}
+	if len(event.BlockNumber) == 0 {
+		return nil, fmt.Errorf("block event has no block number")
+	}
+	var long struct {
+		Low  *int32 `json:"low"`
+		High *int32 `json:"high"`
+	}
+	if json.Unmarshal(event.BlockNumber, &long) == nil && long.Low != nil && long.High != nil {
+		number := uint64(uint32(*long.High))<<32 | uint64(uint32(*long.Low))
+		return &number, nil
+	}
+	var text string
+	if json.Unmarshal(event.BlockNumber, &text) != nil {
+		text = string(event.BlockNumber)
+	}
+	number, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number %s", event.BlockNumber)
+	}
+	return &number, nil
+}
+
+// decodeBlock decodes the payload of a Next event (WatchBlocksResponseV1 in the OpenAPI spec)
+func decodeBlock(listenerType connectorfabric.WatchBlocksListenerTypeV1, data json.RawMessage) (*Block, error) {
+	var response struct {
+		FullBlock               json.RawMessage                                `json:"fullBlock"`
+		FilteredBlock           json.RawMessage                                `json:"filteredBlock"`
+		PrivateBlock            json.RawMessage                                `json:"privateBlock"`
+		CactiTransactionsEvents []connectorfabric.CactiBlockTransactionEventV1 `json:"cactiTransactionsEvents"`
+		CactiFullEvents         json.RawMessage                                `json:"cactiFullEvents"`
+	}
+	err := json.Unmarshal(data, &response)
+	if err != nil {
+		return nil, fmt.Errorf("malformed block: %v", err)
+	}
+	block := &Block{}
+	switch listenerType {
+	case connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Full, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Filtered, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Private:
+		blockEvent := map[connectorfabric.WatchBlocksListenerTypeV1]json.RawMessage{
+			connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Full:     response.FullBlock,
+			connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Filtered: response.FilteredBlock,
+			connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Private:  response.PrivateBlock,
+		}[listenerType]
+		if len(blockEvent) == 0 {
+			return nil, fmt.Errorf("%s block expected", listenerType)
+		}
+		block.Number, err = blockNumber(blockEvent)
+		if err != nil {
+			return nil, err
+		}
+		block.FullBlock, block.FilteredBlock, block.PrivateBlock = response.FullBlock, response.FilteredBlock, response.PrivateBlock
+	case connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiTransactions:
+		if response.CactiTransactionsEvents == nil {
+			return nil, fmt.Errorf("%s block expected", listenerType)
+		}
+		block.CactiTransactions = response.CactiTransactionsEvents
+	case connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiFullBlock:
+		if len(response.CactiFullEvents) == 0 || string(response.CactiFullEvents) == "null" {
+			return nil, fmt.Errorf("%s block expected", listenerType)
+		}
+		block.CactiFullBlock = &connectorfabric.CactiBlockFullEventV1{}
+		err = json.Unmarshal(response.CactiFullEvents, block.CactiFullBlock)
+		if err != nil {
+			return nil, fmt.Errorf("malformed block: %v", err)
+		}
+		// the blockNumber of the model is a float32, which is not exact beyond 2^24
+		block.Number, err = blockNumber(response.CactiFullEvents)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown listener type %s", listenerType)
+	}
+	return block, nil
+}
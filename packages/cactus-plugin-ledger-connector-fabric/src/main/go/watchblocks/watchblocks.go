@@ -0,0 +1,281 @@
+/*
+Package watchblocks is a client of the WatchBlocksV1 async API of the Fabric connector, which streams the blocks
+committed on a channel over Socket.IO.
+
+It is hand-written, as the OpenAPI generator does not cover async APIs; the subscriptions and blocks are the
+WatchBlocks models of the generated client. A Watcher delivers blocks on a channel and, when the connection is lost,
+reconnects and resubscribes from the block after the last one received. Blocks without a number, such as those of
+cacti:transactions subscriptions, do not move this position: the subscription resumes from the last known start
+block, which may deliver some blocks again, and the watcher stops with ErrUnknownPosition if there is none.
+*/
+package watchblocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hyperledger/cactus-core-api/src/main/go/socketio"
+	connectorfabric "github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// ErrUnknownPosition is returned by Watcher.Err when the connection is lost after blocks without a number were
+// received on a subscription without a start block, as it cannot be resumed without skipping blocks.
+var ErrUnknownPosition = errors.New("position of the subscription is unknown")
+
+// Config of the connection to the API server hosting the connector
+type Config struct {
+	// URL of the API server, e.g. http://localhost:4000
+	URL string
+	socketio.Options
+	// Do not reconnect when the connection is lost; the watcher stops instead
+	DisableReconnect bool
+	// Backoff between reconnection attempts
+	Backoff socketio.Backoff
+}
+
+// Watcher streams the blocks of a subscription
+type Watcher struct {
+	config    Config
+	subscribe func(startBlock string) (string, interface{})
+	// type of the blocks, and block to start from when connecting
+	listenerType connectorfabric.WatchBlocksListenerTypeV1
+	startBlock   string
+	// blocks without a number were received and there is no start block to resume from
+	positionUnknown bool
+	blocks          chan *Block
+	errors          chan error
+	done            chan struct{}
+	stop            context.CancelFunc
+	mutex           sync.Mutex
+	client          *socketio.Client
+	unsubscribed    bool
+	err             error
+}
+
+// Subscribe watches blocks with a subscription signed by the connector's identity
+func Subscribe(ctx context.Context, config Config, options connectorfabric.WatchBlocksOptionsV1) (*Watcher, error) {
+	return start(ctx, config, options.Type, options.GetStartBlock(), func(startBlock string) (string, interface{}) {
+		options.StartBlock = optionalStartBlock(startBlock)
+		return eventSubscribe, options
+	})
+}
+
+// SubscribeDelegatedSign watches blocks with a subscription signed by the connector's sign callback
+func SubscribeDelegatedSign(ctx context.Context, config Config, options connectorfabric.WatchBlocksDelegatedSignOptionsV1) (*Watcher, error) {
+	return start(ctx, config, options.Type, options.GetStartBlock(), func(startBlock string) (string, interface{}) {
+		options.StartBlock = optionalStartBlock(startBlock)
+		return eventSubscribeDelegatedSign, options
+	})
+}
+
+// optionalStartBlock leaves out an empty start block, so that the connector starts from the latest block
+func optionalStartBlock(startBlock string) *string {
+	if startBlock == "" {
+		return nil
+	}
+	return &startBlock
+}
+
+func start(ctx context.Context, config Config, listenerType connectorfabric.WatchBlocksListenerTypeV1, startBlock string,
+	subscribe func(startBlock string) (string, interface{})) (*Watcher, error) {
+	ctx, stop := context.WithCancel(ctx)
+	w := &Watcher{
+		config:       config,
+		subscribe:    subscribe,
+		listenerType: listenerType,
+		startBlock:   startBlock,
+		blocks:       make(chan *Block),
+		errors:       make(chan error, 16),
+		done:         make(chan struct{}),
+		stop:         stop,
+	}
+	client, err := w.connect(ctx)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	go w.run(ctx, client)
+	return w, nil
+}
+
+// Blocks returns the blocks, in the order they are committed. It is closed when the watcher stops.
+func (w *Watcher) Blocks() <-chan *Block {
+	return w.blocks
+}
+
+/*
+Errors returns the errors reported by the connector with Error events, blocks that could not be decoded and lost
+connections. The watcher keeps going after these errors; those not read are dropped once 16 are pending.
+It is closed when the watcher stops.
+*/
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Done is closed when the watcher stops
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+/*
+Err returns why the watcher stopped: nil after a Complete event or Unsubscribe, the context's error if it is
+done, the error that lost the connection if reconnection is disabled, or ErrUnknownPosition if the subscription
+cannot be resumed.
+*/
+func (w *Watcher) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+// Unsubscribe ends the subscription and stops the watcher
+func (w *Watcher) Unsubscribe() error {
+	w.mutex.Lock()
+	client := w.client
+	w.unsubscribed = true
+	w.mutex.Unlock()
+	err := client.Emit(eventUnsubscribe)
+	w.stop()
+	<-w.done
+	return err
+}
+
+func (w *Watcher) connect(ctx context.Context) (*socketio.Client, error) {
+	client, err := socketio.Dial(ctx, w.config.URL, w.config.Options)
+	if err != nil {
+		return nil, err
+	}
+	event, options := w.subscribe(w.startBlock)
+	err = client.Emit(event, options)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	w.mutex.Lock()
+	w.client = client
+	w.mutex.Unlock()
+	return client, nil
+}
+
+func (w *Watcher) report(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
+
+func (w *Watcher) run(ctx context.Context, client *socketio.Client) {
+	err := func() error {
+		for {
+			completed, err := w.watch(ctx, client)
+			client.Close()
+			if completed || ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.config.DisableReconnect {
+				return err
+			}
+			if w.positionUnknown {
+				return fmt.Errorf("%w: %w", ErrUnknownPosition, err)
+			}
+			w.report(err)
+			for {
+				if !w.config.Backoff.Wait(ctx) {
+					return ctx.Err()
+				}
+				client, err = w.connect(ctx)
+				if err == nil {
+					break
+				}
+				w.report(err)
+			}
+		}
+	}()
+	w.stop()
+	w.mutex.Lock()
+	// stopping through Unsubscribe is not an error
+	if w.unsubscribed {
+		err = nil
+	}
+	w.err = err
+	w.mutex.Unlock()
+	close(w.blocks)
+	close(w.errors)
+	close(w.done)
+}
+
+// watch handles the events of a connection until it ends, returning whether the subscription completed
+func (w *Watcher) watch(ctx context.Context, client *socketio.Client) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event := <-client.Events():
+			completed, err := w.handle(ctx, event)
+			if completed || err != nil {
+				return completed, err
+			}
+		case <-client.Done():
+			// handle the events received before the connection was lost
+			for {
+				select {
+				case event := <-client.Events():
+					completed, err := w.handle(ctx, event)
+					if completed || err != nil {
+						return completed, err
+					}
+					continue
+				default:
+				}
+				break
+			}
+			err := client.Err()
+			if err == nil {
+				err = socketio.ErrDisconnected
+			}
+			return false, fmt.Errorf("connection lost: %w", err)
+		}
+	}
+}
+
+// handle handles an event, returning whether the subscription completed
+func (w *Watcher) handle(ctx context.Context, event socketio.Event) (bool, error) {
+	switch event.Name {
+	case eventNext:
+		if len(event.Args) == 0 {
+			w.report(errors.New("block expected in Next event"))
+			return false, nil
+		}
+		block, err := decodeBlock(w.listenerType, event.Args[0])
+		if err != nil {
+			w.report(err)
+			return false, nil
+		}
+		// resume after this block on reconnection; blocks without a number keep the last known start block
+		if block.Number != nil {
+			w.startBlock = strconv.FormatUint(*block.Number+1, 10)
+			w.positionUnknown = false
+		} else if w.startBlock == "" {
+			w.positionUnknown = true
+		}
+		w.config.Backoff.Reset()
+		select {
+		case w.blocks <- block:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	case eventError:
+		watchError := &Error{}
+		if len(event.Args) == 0 || json.Unmarshal(event.Args[0], watchError) != nil {
+			watchError.ErrorMessage = "malformed error event"
+		}
+		w.report(watchError)
+	case eventComplete:
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,234 @@
+package watchblocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/cactus-core-api/src/main/go/socketio"
+	connectorfabric "github.com/hyperledger/cactus-plugin-ledger-connector-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// startConnector starts a Socket.IO server standing in for the connector, whose sockets are passed to the test
+func startConnector(t *testing.T) (string, <-chan *socketio.Socket) {
+	sockets := make(chan *socketio.Socket, 4)
+	mux := http.NewServeMux()
+	mux.Handle(socketio.DefaultPath+"/", &socketio.Server{OnConnect: func(s *socketio.Socket) { sockets <- s }})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL, sockets
+}
+
+func receive[T any](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-c:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+		var zero T
+		return zero
+	}
+}
+
+func fullBlock(number int) map[string]interface{} {
+	return map[string]interface{}{
+		"fullBlock": map[string]interface{}{
+			"blockNumber": map[string]interface{}{"low": number, "high": 0, "unsigned": true},
+			"blockData":   map[string]interface{}{"header": map[string]interface{}{}},
+		},
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	url, sockets := startConnector(t)
+	options := connectorfabric.NewWatchBlocksOptionsV1("mychannel", connectorfabric.GatewayOptions{Identity: "user1"}, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Full)
+	options.SetStartBlock("5")
+	watcher, err := Subscribe(context.Background(), Config{URL: url, Backoff: socketio.Backoff{Min: time.Millisecond}}, *options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socket := receive(t, sockets)
+	event := receive(t, socket.Events())
+	options = &connectorfabric.WatchBlocksOptionsV1{}
+	json.Unmarshal(event.Args[0], options)
+	if event.Name != eventSubscribe || options.ChannelName != "mychannel" || options.GatewayOptions.Identity != "user1" ||
+		options.Type != connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Full || options.GetStartBlock() != "5" {
+		t.Fatalf("unexpected subscription %s %s", event.Name, event.Args)
+	}
+	socket.Emit(eventNext, fullBlock(5))
+	socket.Emit(eventNext, fullBlock(6))
+	for _, expected := range []uint64{5, 6} {
+		block := receive(t, watcher.Blocks())
+		if block.Number == nil || *block.Number != expected || block.FullBlock == nil {
+			t.Fatalf("unexpected block %+v", block)
+		}
+	}
+
+	// the watcher resumes after the last block once the connection is lost
+	socket.Drop()
+	if err := receive(t, watcher.Errors()); err == nil {
+		t.Fatal("lost connection not reported")
+	}
+	socket = receive(t, sockets)
+	event = receive(t, socket.Events())
+	json.Unmarshal(event.Args[0], options)
+	if options.GetStartBlock() != "7" {
+		t.Fatalf("resumed from block %q", options.GetStartBlock())
+	}
+
+	// errors are reported, and the subscription goes on until completed
+	socket.Emit(eventError, map[string]interface{}{"code": 500, "errorMessage": "peer unavailable"})
+	var watchError *Error
+	if err := receive(t, watcher.Errors()); !errors.As(err, &watchError) || watchError.Code != 500 || watchError.ErrorMessage != "peer unavailable" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	socket.Emit(eventNext, map[string]interface{}{"filteredBlock": map[string]interface{}{}})
+	if err := receive(t, watcher.Errors()); err == nil {
+		t.Fatal("block of the wrong type not reported")
+	}
+	socket.Emit(eventNext, fullBlock(7))
+	socket.Emit(eventComplete)
+	if block := receive(t, watcher.Blocks()); *block.Number != 7 {
+		t.Fatalf("unexpected block %d", *block.Number)
+	}
+	<-watcher.Done()
+	if watcher.Err() != nil {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+	if _, open := <-watcher.Blocks(); open {
+		t.Fatal("blocks channel not closed")
+	}
+}
+
+func TestSubscribeDelegatedSign(t *testing.T) {
+	url, sockets := startConnector(t)
+	watcher, err := SubscribeDelegatedSign(context.Background(), Config{URL: url},
+		*connectorfabric.NewWatchBlocksDelegatedSignOptionsV1(connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiTransactions, "mychannel", "certificate", "Org1MSP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := receive(t, sockets)
+	event := receive(t, socket.Events())
+	var delegatedSignOptions map[string]interface{}
+	json.Unmarshal(event.Args[0], &delegatedSignOptions)
+	if _, set := delegatedSignOptions["startBlock"]; event.Name != eventSubscribeDelegatedSign || set {
+		t.Fatalf("unexpected subscription %s %s", event.Name, event.Args)
+	}
+	socket.Emit(eventNext, map[string]interface{}{"cactiTransactionsEvents": []connectorfabric.CactiBlockTransactionEventV1{{
+		ChaincodeId: "basic", TransactionId: "tx1", FunctionName: "CreateAsset", FunctionArgs: []string{"asset1"},
+	}}})
+	block := receive(t, watcher.Blocks())
+	if block.Number != nil || len(block.CactiTransactions) != 1 || block.CactiTransactions[0].FunctionName != "CreateAsset" {
+		t.Fatalf("unexpected block %+v", block)
+	}
+
+	err = watcher.Unsubscribe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := receive(t, socket.Events()); event.Name != eventUnsubscribe {
+		t.Fatalf("unexpected event %s", event.Name)
+	}
+	if watcher.Err() != nil {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+}
+
+func TestBlocksWithoutNumber(t *testing.T) {
+	transactions := map[string]interface{}{"cactiTransactionsEvents": []connectorfabric.CactiBlockTransactionEventV1{{
+		ChaincodeId: "basic", TransactionId: "tx1", FunctionName: "CreateAsset",
+	}}}
+	url, sockets := startConnector(t)
+	options := connectorfabric.NewWatchBlocksOptionsV1("mychannel", connectorfabric.GatewayOptions{}, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiTransactions)
+	options.SetStartBlock("5")
+	watcher, err := Subscribe(context.Background(), Config{URL: url, Backoff: socketio.Backoff{Min: time.Millisecond}}, *options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the subscription resumes from the last known start block
+	socket := receive(t, sockets)
+	receive(t, socket.Events())
+	socket.Emit(eventNext, transactions)
+	receive(t, watcher.Blocks())
+	socket.Drop()
+	receive(t, watcher.Errors())
+	socket = receive(t, sockets)
+	event := receive(t, socket.Events())
+	json.Unmarshal(event.Args[0], options)
+	if options.GetStartBlock() != "5" {
+		t.Fatalf("resumed from block %q", options.GetStartBlock())
+	}
+	watcher.Unsubscribe()
+
+	// without a start block, the watcher stops rather than skip blocks
+	watcher, err = Subscribe(context.Background(), Config{URL: url, Backoff: socketio.Backoff{Min: time.Millisecond}},
+		*connectorfabric.NewWatchBlocksOptionsV1("mychannel", connectorfabric.GatewayOptions{}, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiTransactions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket = receive(t, sockets)
+	receive(t, socket.Events())
+	socket.Emit(eventNext, transactions)
+	receive(t, watcher.Blocks())
+	socket.Drop()
+	receive(t, watcher.Done())
+	if !errors.Is(watcher.Err(), ErrUnknownPosition) || !errors.Is(watcher.Err(), socketio.ErrDisconnected) {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+}
+
+func TestDisableReconnect(t *testing.T) {
+	url, sockets := startConnector(t)
+	watcher, err := Subscribe(context.Background(), Config{URL: url, DisableReconnect: true},
+		*connectorfabric.NewWatchBlocksOptionsV1("mychannel", connectorfabric.GatewayOptions{}, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_CactiFullBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := receive(t, sockets)
+	receive(t, socket.Events())
+	// block numbers are exact beyond the precision of the float32 blockNumber of the model
+	socket.Emit(eventNext, map[string]interface{}{"cactiFullEvents": map[string]interface{}{"blockNumber": 1<<24 + 1, "blockHash": "0x01", "transactionCount": 0}})
+	if block := receive(t, watcher.Blocks()); *block.Number != 1<<24+1 || block.CactiFullBlock.BlockHash != "0x01" {
+		t.Fatalf("unexpected block %+v", block)
+	}
+	socket.Disconnect()
+	<-watcher.Done()
+	if !errors.Is(watcher.Err(), socketio.ErrDisconnected) {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher, err = Subscribe(ctx, Config{URL: url},
+		*connectorfabric.NewWatchBlocksOptionsV1("mychannel", connectorfabric.GatewayOptions{}, connectorfabric.WATCHBLOCKSLISTENERTYPEV1_Filtered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	<-watcher.Done()
+	if !errors.Is(watcher.Err(), context.Canceled) {
+		t.Fatalf("unexpected error %v", watcher.Err())
+	}
+}
+
+func TestBlockNumber(t *testing.T) {
+	for blockEvent, expected := range map[string]uint64{
+		`{"blockNumber":{"low":-1,"high":1,"unsigned":true}}`: 1<<33 - 1,
+		`{"blockNumber":"12"}`:                                12,
+		`{"blockNumber":12}`:                                  12,
+	} {
+		number, err := blockNumber(json.RawMessage(blockEvent))
+		if err != nil || *number != expected {
+			t.Fatalf("decoded %s as %v, %v", blockEvent, number, err)
+		}
+	}
+	if _, err := blockNumber(json.RawMessage(`{}`)); err == nil {
+		t.Fatal("decoded a block event without a number")
+	}
+}
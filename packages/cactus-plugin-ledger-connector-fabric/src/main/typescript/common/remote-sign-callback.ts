@@ -0,0 +1,43 @@
+import axios, { AxiosRequestConfig } from "axios";
+import type { SignPayloadCallback } from "../plugin-ledger-connector-fabric";
+
+export interface IRemoteSignCallbackOptions {
+  /**
+   * URL of the signing service.
+   */
+  readonly url: string;
+  /**
+   * Additional request configuration, e.g. authorization headers or timeout.
+   */
+  readonly requestConfig?: AxiosRequestConfig;
+}
+
+/**
+ * Creates a `signCallback` for the connector that posts each payload to sign, along with the
+ * `uniqueTransactionData` of the request, to a remote signing service:
+ * `{ payload: <base64>, txData }` is answered with `{ signature: <base64 DER signature> }`.
+ *
+ * @param options signing service to use.
+ * @returns sign callback to set in the connector options.
+ */
+export function createRemoteSignCallback(
+  options: IRemoteSignCallbackOptions,
+): SignPayloadCallback {
+  const fnTag = "createRemoteSignCallback()";
+  if (!options.url) {
+    throw new Error(`${fnTag} expected options.url to be truthy.`);
+  }
+
+  return async (payload: Buffer, txData: unknown): Promise<Buffer> => {
+    const response = await axios.post(
+      options.url,
+      { payload: payload.toString("base64"), txData },
+      options.requestConfig,
+    );
+    const signature = response.data?.signature;
+    if (typeof signature !== "string" || signature.length === 0) {
+      throw new Error(`${fnTag} signing service returned no signature.`);
+    }
+    return Buffer.from(signature, "base64");
+  };
+}
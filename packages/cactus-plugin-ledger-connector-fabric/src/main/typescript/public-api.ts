@@ -25,3 +25,7 @@ export { IVaultConfig, IWebSocketConfig } from "./identity/identity-provider";
 export { IIdentityData } from "./identity/internal/cert-datastore";
 
 export { signProposal } from "./common/sign-utils";
+export {
+  createRemoteSignCallback,
+  IRemoteSignCallbackOptions,
+} from "./common/remote-sign-callback";
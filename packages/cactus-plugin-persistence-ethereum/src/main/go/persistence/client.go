@@ -0,0 +1,177 @@
+/*
+Package persistence is a client of the cactus-plugin-persistence-ethereum plugin, which indexes the blocks,
+transactions and token balances of an Ethereum ledger in PostgreSQL. It reads the status of the plugin and queries the
+indexed data: blocks by range, transactions by contract and method, and ERC20 and ERC721 token balances. Requests and
+responses are the models of the OpenAPI client generated from the spec of the plugin.
+*/
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	persistenceethereum "github.com/hyperledger/cactus-plugin-persistence-ethereum/src/main/go/generated/openapi/go-client"
+)
+
+// Paths of the endpoints of the plugin
+const (
+	StatusPath        = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/status"
+	BlocksPath        = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/blocks"
+	TransactionsPath  = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/transactions"
+	TokenBalancesPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/token-balances"
+)
+
+// MaxLimit is the largest number of blocks or transactions the plugin returns at once
+const MaxLimit = 1000
+
+// Error is an error response of the API server
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("API server responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API server responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// Config configures a Client
+type Config struct {
+	// URL of the API server hosting the plugin, e.g. http://localhost:4000
+	URL string
+	// Defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers set on every request, e.g. Authorization
+	Header http.Header
+}
+
+// Client of a persistence plugin for Ethereum
+type Client struct {
+	config Config
+}
+
+func New(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Client{config: config}
+}
+
+func (c *Client) GetStatus(ctx context.Context) (*persistenceethereum.StatusResponseV1, error) {
+	response := &persistenceethereum.StatusResponseV1{}
+	return response, c.do(ctx, http.MethodGet, StatusPath, nil, response)
+}
+
+// GetBlocks returns the stored blocks of a range, ordered by number; blocks not yet synchronized are missing
+func (c *Client) GetBlocks(ctx context.Context, request *persistenceethereum.GetBlocksRequestV1) ([]persistenceethereum.BlockV1, error) {
+	response := &persistenceethereum.GetBlocksResponseV1{}
+	err := c.do(ctx, http.MethodPost, BlocksPath, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Blocks, nil
+}
+
+// GetTransactions returns a page of the stored transactions matching the request, ordered by block and index
+func (c *Client) GetTransactions(ctx context.Context, request *persistenceethereum.GetTransactionsRequestV1) ([]persistenceethereum.TransactionV1, error) {
+	response := &persistenceethereum.GetTransactionsResponseV1{}
+	err := c.do(ctx, http.MethodPost, TransactionsPath, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Transactions, nil
+}
+
+/*
+EachTransaction calls fn with every stored transaction matching the request, reading them page by page from the
+request offset, until fn fails or the transactions are exhausted.
+*/
+func (c *Client) EachTransaction(ctx context.Context, request persistenceethereum.GetTransactionsRequestV1, fn func(persistenceethereum.TransactionV1) error) error {
+	if request.GetLimit() <= 0 || request.GetLimit() > MaxLimit {
+		request.SetLimit(MaxLimit)
+	}
+	offset := request.GetOffset()
+	for {
+		request.SetOffset(offset)
+		transactions, err := c.GetTransactions(ctx, &request)
+		if err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			if err := fn(transaction); err != nil {
+				return err
+			}
+		}
+		if len(transactions) < int(request.GetLimit()) {
+			return nil
+		}
+		offset += int32(len(transactions))
+	}
+}
+
+// GetTokenBalances returns the ERC20 balances and the ERC721 tokens matching the request
+func (c *Client) GetTokenBalances(ctx context.Context, request *persistenceethereum.GetTokenBalancesRequestV1) (*persistenceethereum.GetTokenBalancesResponseV1, error) {
+	if request.GetTokenType() == persistenceethereum.ERC1155 {
+		return nil, fmt.Errorf("%s balances are not supported", persistenceethereum.ERC1155)
+	}
+	response := &persistenceethereum.GetTokenBalancesResponseV1{}
+	err := c.do(ctx, http.MethodPost, TokenBalancesPath, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// do sends a request to an endpoint of the plugin, with a JSON body unless request is nil, and decodes the response
+func (c *Client) do(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, c.config.URL+path, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.config.Header {
+		httpRequest.Header[name] = values
+	}
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := c.config.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		apiError := &Error{}
+		if json.Unmarshal(responseBody, apiError) != nil || apiError.Message == "" {
+			apiError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		apiError.StatusCode = httpResponse.StatusCode
+		return apiError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	persistenceethereum "github.com/hyperledger/cactus-plugin-persistence-ethereum/src/main/go/generated/openapi/go-client"
+)
+
+const contract = "0x42EA16C9B9e529dA492909F34f416fEd2bE7c280"
+
+// persistencePlugin stands in for an API server hosting the persistence plugin, with 10 blocks of 3 transactions
+func persistencePlugin(t *testing.T) *httptest.Server {
+	var transactions []persistenceethereum.TransactionV1
+	for block := int64(1); block <= 10; block++ {
+		for index, to := range []string{contract, strings.ToLower(contract), "0x53F6337d308FfB2c52eDa319Be216cC7321D3725"} {
+			transactions = append(transactions, persistenceethereum.TransactionV1{
+				Hash: fmt.Sprintf("0x%d%d", block, index), Index: int32(index), BlockNumber: block, To: to,
+				MethodName: "transfer", EthValue: "0",
+			})
+		}
+	}
+	mux := http.NewServeMux()
+	handle := func(path string, request interface{}, handler func() interface{}) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if request != nil && json.NewDecoder(r.Body).Decode(request) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(&Error{Message: "Bad Request", Exception: "malformed body"})
+				return
+			}
+			json.NewEncoder(w).Encode(handler())
+		})
+	}
+	handle(StatusPath, nil, func() interface{} {
+		return &persistenceethereum.StatusResponseV1{InstanceId: "persistence", Connected: true, LastSeenBlock: 10}
+	})
+	blocksRequest := &persistenceethereum.GetBlocksRequestV1{}
+	handle(BlocksPath, blocksRequest, func() interface{} {
+		response := &persistenceethereum.GetBlocksResponseV1{Blocks: []persistenceethereum.BlockV1{}}
+		for number := blocksRequest.FromBlock; number <= blocksRequest.ToBlock && number <= 10; number++ {
+			response.Blocks = append(response.Blocks, persistenceethereum.BlockV1{Number: number, NumberOfTx: 3})
+		}
+		return response
+	})
+	transactionsRequest := &persistenceethereum.GetTransactionsRequestV1{}
+	handle(TransactionsPath, transactionsRequest, func() interface{} {
+		request := transactionsRequest
+		response := &persistenceethereum.GetTransactionsResponseV1{Transactions: []persistenceethereum.TransactionV1{}}
+		matching := 0
+		for _, transaction := range transactions {
+			if (request.HasContractAddress() && !strings.EqualFold(request.GetContractAddress(), transaction.To)) ||
+				(request.HasFromBlock() && transaction.BlockNumber < request.GetFromBlock()) ||
+				(request.HasToBlock() && transaction.BlockNumber > request.GetToBlock()) {
+				continue
+			}
+			if matching >= int(request.GetOffset()) && len(response.Transactions) < int(request.GetLimit()) {
+				response.Transactions = append(response.Transactions, transaction)
+			}
+			matching++
+		}
+		*transactionsRequest = persistenceethereum.GetTransactionsRequestV1{}
+		return response
+	})
+	balancesRequest := &persistenceethereum.GetTokenBalancesRequestV1{}
+	handle(TokenBalancesPath, balancesRequest, func() interface{} {
+		response := &persistenceethereum.GetTokenBalancesResponseV1{
+			Erc20: []persistenceethereum.TokenBalanceERC20V1{}, Erc721: []persistenceethereum.TokenERC721V1{},
+		}
+		if balancesRequest.GetTokenType() != persistenceethereum.ERC721 {
+			response.Erc20 = append(response.Erc20, persistenceethereum.TokenBalanceERC20V1{
+				AccountAddress: balancesRequest.GetAccountAddress(), TokenAddress: contract, Balance: "100000000000000000000",
+			})
+		}
+		return response
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	server := persistencePlugin(t)
+	c := New(Config{URL: server.URL + "/", Header: http.Header{"Authorization": {"Bearer token"}}})
+	ctx := context.Background()
+
+	status, err := c.GetStatus(ctx)
+	if err != nil || !status.Connected || status.LastSeenBlock != 10 {
+		t.Fatalf("unexpected status %+v, %v", status, err)
+	}
+
+	blocks, err := c.GetBlocks(ctx, &persistenceethereum.GetBlocksRequestV1{FromBlock: 9, ToBlock: 20})
+	if err != nil || len(blocks) != 2 || blocks[1].Number != 10 {
+		t.Errorf("unexpected blocks %+v, %v", blocks, err)
+	}
+
+	request := persistenceethereum.NewGetTransactionsRequestV1()
+	request.SetContractAddress(contract)
+	request.SetFromBlock(3)
+	request.SetLimit(5)
+	transactions, err := c.GetTransactions(ctx, request)
+	if err != nil || len(transactions) != 5 || transactions[0].BlockNumber != 3 {
+		t.Errorf("unexpected transactions %+v, %v", transactions, err)
+	}
+
+	var hashes []string
+	request = persistenceethereum.NewGetTransactionsRequestV1()
+	request.SetContractAddress(contract)
+	request.SetLimit(3)
+	err = c.EachTransaction(ctx, *request, func(transaction persistenceethereum.TransactionV1) error {
+		hashes = append(hashes, transaction.Hash)
+		return nil
+	})
+	if err != nil || len(hashes) != 20 || hashes[0] != "0x10" || hashes[19] != "0x101" {
+		t.Errorf("unexpected transactions %v, %v", hashes, err)
+	}
+	stop := errors.New("stop")
+	if err := c.EachTransaction(ctx, persistenceethereum.GetTransactionsRequestV1{}, func(persistenceethereum.TransactionV1) error { return stop }); err != stop {
+		t.Errorf("error of fn not returned: %v", err)
+	}
+
+	balancesRequest := persistenceethereum.NewGetTokenBalancesRequestV1()
+	balancesRequest.SetAccountAddress(contract)
+	balancesRequest.SetTokenType(persistenceethereum.ERC20)
+	balances, err := c.GetTokenBalances(ctx, balancesRequest)
+	if err != nil || len(balances.Erc20) != 1 || balances.Erc20[0].Balance != "100000000000000000000" {
+		t.Errorf("unexpected balances %+v, %v", balances, err)
+	}
+	if _, err := c.GetTokenBalances(ctx, &persistenceethereum.GetTokenBalancesRequestV1{TokenType: persistenceethereum.ERC1155.Ptr()}); err == nil {
+		t.Errorf("ERC1155 balances requested")
+	}
+
+	var apiError *Error
+	unauthorized := New(Config{URL: server.URL})
+	if _, err := unauthorized.GetStatus(ctx); !errors.As(err, &apiError) || apiError.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -25,6 +25,7 @@ type SchemaTables = DatabaseSchemaType["ethereum"]["Tables"];
 type PluginStatusRowType = SchemaTables["plugin_status"]["Row"];
 type BlockRowType = SchemaTables["block"]["Row"];
 type BlockInsertType = SchemaTables["block"]["Insert"];
+type TransactionRowType = SchemaTables["transaction"]["Row"];
 type TransactionInsertType = SchemaTables["transaction"]["Insert"];
 type TokenTransferInsertType = SchemaTables["token_transfer"]["Insert"];
 type TokenERC72RowType = SchemaTables["token_erc721"]["Row"];
@@ -72,6 +73,20 @@ type InsertBlockDataInput = {
   transactions: BlockDataTransactionInput[];
 };
 
+export type GetTransactionsFilter = {
+  contractAddress?: string;
+  methodName?: string;
+  fromBlock?: number;
+  toBlock?: number;
+  limit: number;
+  offset: number;
+};
+
+export type GetTokenBalancesFilter = {
+  accountAddress?: string;
+  tokenAddress?: string;
+};
+
 export interface PostgresDatabaseClientOptions {
   connectionString: string;
   logLevel: LogLevelDesc;
@@ -514,6 +529,123 @@ export default class PostgresDatabaseClient {
     return queryResponse.rows[0];
   }
 
+  /**
+   * Read blocks stored in the range, ordered by block number.
+   * @param fromBlock block to read from (including)
+   * @param toBlock block to read to (including)
+   * @param limit maximum number of blocks returned
+   * @returns Blocks data.
+   */
+  public async getBlocksInRange(
+    fromBlock: number,
+    toBlock: number,
+    limit: number,
+  ): Promise<BlockRowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query(
+      `SELECT * FROM ethereum.block
+     WHERE number BETWEEN $1 AND $2 ORDER BY number LIMIT $3`,
+      [fromBlock, toBlock, limit],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} blocks between ${fromBlock} and ${toBlock}`,
+    );
+    return queryResponse.rows;
+  }
+
+  /**
+   * Read transactions matching the filter, ordered by block number and index.
+   * Addresses are compared case insensitive.
+   * @param filter transaction filter, every field set must match
+   * @returns Transactions data.
+   */
+  public async getTransactions(
+    filter: GetTransactionsFilter,
+  ): Promise<TransactionRowType[]> {
+    this.assertConnected();
+
+    const conditions: string[] = [];
+    const params: unknown[] = [];
+    const addCondition = (condition: string, param: unknown) => {
+      params.push(param);
+      conditions.push(condition.replace("?", `$${params.length}`));
+    };
+    if (filter.contractAddress !== undefined) {
+      addCondition('lower("to") = lower(?)', filter.contractAddress);
+    }
+    if (filter.methodName !== undefined) {
+      addCondition("method_name = ?", filter.methodName);
+    }
+    if (filter.fromBlock !== undefined) {
+      addCondition("block_number >= ?", filter.fromBlock);
+    }
+    if (filter.toBlock !== undefined) {
+      addCondition("block_number <= ?", filter.toBlock);
+    }
+    const where =
+      conditions.length > 0 ? `WHERE ${conditions.join(" AND ")}` : "";
+    params.push(filter.limit, filter.offset);
+
+    const queryResponse = await this.client.query(
+      `SELECT * FROM ethereum.transaction ${where}
+     ORDER BY block_number, index LIMIT $${params.length - 1} OFFSET $${params.length}`,
+      params,
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} rows from table transaction`,
+    );
+    return queryResponse.rows;
+  }
+
+  /**
+   * Read ERC20 token balances matching the filter.
+   * Addresses are compared case insensitive.
+   * @param filter account and token address filter, every field set must match
+   * @returns ERC20 token balances
+   */
+  public async getTokenBalancesERC20(
+    filter: GetTokenBalancesFilter,
+  ): Promise<TokenERC20RowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query(
+      `SELECT * FROM ethereum.token_erc20
+     WHERE ($1::text IS NULL OR lower(account_address) = lower($1))
+     AND ($2::text IS NULL OR lower(token_address) = lower($2))
+     ORDER BY token_address, account_address`,
+      [filter.accountAddress ?? null, filter.tokenAddress ?? null],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} rows from table token_erc20`,
+    );
+    return queryResponse.rows;
+  }
+
+  /**
+   * Read issued ERC721 tokens matching the filter.
+   * Addresses are compared case insensitive.
+   * @param filter owner and token address filter, every field set must match
+   * @returns ERC721 tokens
+   */
+  public async getTokensERC721(
+    filter: GetTokenBalancesFilter,
+  ): Promise<TokenERC72RowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query(
+      `SELECT * FROM ethereum.token_erc721
+     WHERE ($1::text IS NULL OR lower(account_address) = lower($1))
+     AND ($2::text IS NULL OR lower(token_address) = lower($2))
+     ORDER BY token_address, token_id`,
+      [filter.accountAddress ?? null, filter.tokenAddress ?? null],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} rows from table token_erc721`,
+    );
+    return queryResponse.rows;
+  }
+
   /**
    * Insert entire block data into the database (the block itself, transactions and token transfers if there were any).
    * Everything is committed in single atomic transaction (rollback on error).
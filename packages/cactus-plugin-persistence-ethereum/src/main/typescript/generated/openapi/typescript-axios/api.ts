@@ -23,6 +23,43 @@ import type { RequestArgs } from './base';
 // @ts-ignore
 import { BASE_PATH, COLLECTION_FORMATS, BaseAPI, RequiredError } from './base';
 
+/**
+ * Block stored in the database.
+ * @export
+ * @interface BlockV1
+ */
+export interface BlockV1 {
+    /**
+     * Block number.
+     * @type {number}
+     * @memberof BlockV1
+     */
+    'number': number;
+    /**
+     * Block hash.
+     * @type {string}
+     * @memberof BlockV1
+     */
+    'hash': string;
+    /**
+     * Block timestamp.
+     * @type {string}
+     * @memberof BlockV1
+     */
+    'createdAt': string;
+    /**
+     * Number of transactions in the block.
+     * @type {number}
+     * @memberof BlockV1
+     */
+    'numberOfTx': number;
+    /**
+     * Time the block was stored in the database.
+     * @type {string}
+     * @memberof BlockV1
+     */
+    'syncAt': string;
+}
 /**
  * 
  * @export
@@ -42,6 +79,146 @@ export interface ErrorExceptionResponseV1 {
      */
     'error': string;
 }
+/**
+ * Request for the stored blocks in a range, ordered by number.
+ * @export
+ * @interface GetBlocksRequestV1
+ */
+export interface GetBlocksRequestV1 {
+    /**
+     * First block number of the range (including).
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'fromBlock': number;
+    /**
+     * Last block number of the range (including).
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'toBlock': number;
+    /**
+     * Maximum number of results, 100 by default.
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'limit'?: number;
+}
+/**
+ * Stored blocks in the requested range.
+ * @export
+ * @interface GetBlocksResponseV1
+ */
+export interface GetBlocksResponseV1 {
+    /**
+     * 
+     * @type {Array<BlockV1>}
+     * @memberof GetBlocksResponseV1
+     */
+    'blocks': Array<BlockV1>;
+}
+/**
+ * Request for the token balances matching the filters.
+ * @export
+ * @interface GetTokenBalancesRequestV1
+ */
+export interface GetTokenBalancesRequestV1 {
+    /**
+     * Account address (case insensitive).
+     * @type {string}
+     * @memberof GetTokenBalancesRequestV1
+     */
+    'accountAddress'?: string;
+    /**
+     * Token contract address (case insensitive).
+     * @type {string}
+     * @memberof GetTokenBalancesRequestV1
+     */
+    'tokenAddress'?: string;
+    /**
+     * 
+     * @type {TokenTypeV1}
+     * @memberof GetTokenBalancesRequestV1
+     */
+    'tokenType'?: TokenTypeV1;
+}
+
+
+/**
+ * Token balances matching the filters. Only ERC20 and ERC721 tokens are returned; the list of the other token type is empty when a token type is requested.
+ * @export
+ * @interface GetTokenBalancesResponseV1
+ */
+export interface GetTokenBalancesResponseV1 {
+    /**
+     * 
+     * @type {Array<TokenBalanceERC20V1>}
+     * @memberof GetTokenBalancesResponseV1
+     */
+    'erc20': Array<TokenBalanceERC20V1>;
+    /**
+     * 
+     * @type {Array<TokenERC721V1>}
+     * @memberof GetTokenBalancesResponseV1
+     */
+    'erc721': Array<TokenERC721V1>;
+}
+/**
+ * Request for the stored transactions matching the filters, ordered by block and index.
+ * @export
+ * @interface GetTransactionsRequestV1
+ */
+export interface GetTransactionsRequestV1 {
+    /**
+     * Address of the called contract (case insensitive).
+     * @type {string}
+     * @memberof GetTransactionsRequestV1
+     */
+    'contractAddress'?: string;
+    /**
+     * Name of the called method.
+     * @type {string}
+     * @memberof GetTransactionsRequestV1
+     */
+    'methodName'?: string;
+    /**
+     * First block number (including).
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'fromBlock'?: number;
+    /**
+     * Last block number (including).
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'toBlock'?: number;
+    /**
+     * Maximum number of results, 100 by default.
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'limit'?: number;
+    /**
+     * Number of results to skip.
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'offset'?: number;
+}
+/**
+ * Stored transactions matching the filters.
+ * @export
+ * @interface GetTransactionsResponseV1
+ */
+export interface GetTransactionsResponseV1 {
+    /**
+     * 
+     * @type {Array<TransactionV1>}
+     * @memberof GetTransactionsResponseV1
+     */
+    'transactions': Array<TransactionV1>;
+}
 /**
  * Ethereum tokens that are being monitored by the persistence plugin.
  * @export
@@ -118,6 +295,62 @@ export interface StatusResponseV1 {
      */
     'lastSeenBlock': number;
 }
+/**
+ * ERC20 token balance of an account.
+ * @export
+ * @interface TokenBalanceERC20V1
+ */
+export interface TokenBalanceERC20V1 {
+    /**
+     * Account address.
+     * @type {string}
+     * @memberof TokenBalanceERC20V1
+     */
+    'accountAddress': string;
+    /**
+     * Token contract address.
+     * @type {string}
+     * @memberof TokenBalanceERC20V1
+     */
+    'tokenAddress': string;
+    /**
+     * Token balance, as a decimal string.
+     * @type {string}
+     * @memberof TokenBalanceERC20V1
+     */
+    'balance': string;
+}
+/**
+ * ERC721 token owned by an account.
+ * @export
+ * @interface TokenERC721V1
+ */
+export interface TokenERC721V1 {
+    /**
+     * Owner address.
+     * @type {string}
+     * @memberof TokenERC721V1
+     */
+    'accountAddress': string;
+    /**
+     * Token contract address.
+     * @type {string}
+     * @memberof TokenERC721V1
+     */
+    'tokenAddress': string;
+    /**
+     * Token ID, as a decimal string.
+     * @type {string}
+     * @memberof TokenERC721V1
+     */
+    'tokenId': string;
+    /**
+     * Token URI.
+     * @type {string}
+     * @memberof TokenERC721V1
+     */
+    'uri': string;
+}
 /**
  * 
  * @export
@@ -161,6 +394,61 @@ export interface TrackedOperationV1 {
      */
     'operation': string;
 }
+/**
+ * Transaction stored in the database.
+ * @export
+ * @interface TransactionV1
+ */
+export interface TransactionV1 {
+    /**
+     * Transaction hash.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'hash': string;
+    /**
+     * Index of the transaction in its block.
+     * @type {number}
+     * @memberof TransactionV1
+     */
+    'index': number;
+    /**
+     * Number of the block of the transaction.
+     * @type {number}
+     * @memberof TransactionV1
+     */
+    'blockNumber': number;
+    /**
+     * Sender address.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'from': string;
+    /**
+     * Recipient or contract address.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'to': string;
+    /**
+     * Value transferred, in wei, as a decimal string.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'ethValue': string;
+    /**
+     * Signature of the called method.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'methodSignature': string;
+    /**
+     * Name of the called method, if known.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'methodName': string;
+}
 
 /**
  * DefaultApi - axios parameter creator
@@ -168,6 +456,42 @@ export interface TrackedOperationV1 {
  */
 export const DefaultApiAxiosParamCreator = function (configuration?: Configuration) {
     return {
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getBlocksV1: async (getBlocksRequestV1: GetBlocksRequestV1, options: AxiosRequestConfig = {}): Promise<RequestArgs> => {
+            // verify required parameter 'getBlocksRequestV1' is not null or undefined
+            assertParamExists('getBlocksV1', 'getBlocksRequestV1', getBlocksRequestV1)
+            const localVarPath = `/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/blocks`;
+            // use dummy base URL string because the URL constructor only accepts absolute URLs.
+            const localVarUrlObj = new URL(localVarPath, DUMMY_BASE_URL);
+            let baseOptions;
+            if (configuration) {
+                baseOptions = configuration.baseOptions;
+            }
+
+            const localVarRequestOptions = { method: 'POST', ...baseOptions, ...options};
+            const localVarHeaderParameter = {} as any;
+            const localVarQueryParameter = {} as any;
+
+
+    
+            localVarHeaderParameter['Content-Type'] = 'application/json';
+
+            setSearchParams(localVarUrlObj, localVarQueryParameter);
+            let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
+            localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
+            localVarRequestOptions.data = serializeDataIfNeeded(getBlocksRequestV1, localVarRequestOptions, configuration)
+
+            return {
+                url: toPathString(localVarUrlObj),
+                options: localVarRequestOptions,
+            };
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for ethereum
@@ -193,6 +517,78 @@ export const DefaultApiAxiosParamCreator = function (configuration?: Configurati
             let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
             localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
 
+            return {
+                url: toPathString(localVarUrlObj),
+                options: localVarRequestOptions,
+            };
+        },
+        /**
+         * 
+         * @summary Get the ERC20 and ERC721 token balances
+         * @param {GetTokenBalancesRequestV1} getTokenBalancesRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTokenBalancesV1: async (getTokenBalancesRequestV1: GetTokenBalancesRequestV1, options: AxiosRequestConfig = {}): Promise<RequestArgs> => {
+            // verify required parameter 'getTokenBalancesRequestV1' is not null or undefined
+            assertParamExists('getTokenBalancesV1', 'getTokenBalancesRequestV1', getTokenBalancesRequestV1)
+            const localVarPath = `/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/token-balances`;
+            // use dummy base URL string because the URL constructor only accepts absolute URLs.
+            const localVarUrlObj = new URL(localVarPath, DUMMY_BASE_URL);
+            let baseOptions;
+            if (configuration) {
+                baseOptions = configuration.baseOptions;
+            }
+
+            const localVarRequestOptions = { method: 'POST', ...baseOptions, ...options};
+            const localVarHeaderParameter = {} as any;
+            const localVarQueryParameter = {} as any;
+
+
+    
+            localVarHeaderParameter['Content-Type'] = 'application/json';
+
+            setSearchParams(localVarUrlObj, localVarQueryParameter);
+            let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
+            localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
+            localVarRequestOptions.data = serializeDataIfNeeded(getTokenBalancesRequestV1, localVarRequestOptions, configuration)
+
+            return {
+                url: toPathString(localVarUrlObj),
+                options: localVarRequestOptions,
+            };
+        },
+        /**
+         * 
+         * @summary Get the stored transactions by contract and method
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTransactionsV1: async (getTransactionsRequestV1: GetTransactionsRequestV1, options: AxiosRequestConfig = {}): Promise<RequestArgs> => {
+            // verify required parameter 'getTransactionsRequestV1' is not null or undefined
+            assertParamExists('getTransactionsV1', 'getTransactionsRequestV1', getTransactionsRequestV1)
+            const localVarPath = `/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/transactions`;
+            // use dummy base URL string because the URL constructor only accepts absolute URLs.
+            const localVarUrlObj = new URL(localVarPath, DUMMY_BASE_URL);
+            let baseOptions;
+            if (configuration) {
+                baseOptions = configuration.baseOptions;
+            }
+
+            const localVarRequestOptions = { method: 'POST', ...baseOptions, ...options};
+            const localVarHeaderParameter = {} as any;
+            const localVarQueryParameter = {} as any;
+
+
+    
+            localVarHeaderParameter['Content-Type'] = 'application/json';
+
+            setSearchParams(localVarUrlObj, localVarQueryParameter);
+            let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
+            localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
+            localVarRequestOptions.data = serializeDataIfNeeded(getTransactionsRequestV1, localVarRequestOptions, configuration)
+
             return {
                 url: toPathString(localVarUrlObj),
                 options: localVarRequestOptions,
@@ -208,6 +604,17 @@ export const DefaultApiAxiosParamCreator = function (configuration?: Configurati
 export const DefaultApiFp = function(configuration?: Configuration) {
     const localVarAxiosParamCreator = DefaultApiAxiosParamCreator(configuration)
     return {
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        async getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: AxiosRequestConfig): Promise<(axios?: AxiosInstance, basePath?: string) => AxiosPromise<GetBlocksResponseV1>> {
+            const localVarAxiosArgs = await localVarAxiosParamCreator.getBlocksV1(getBlocksRequestV1, options);
+            return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for ethereum
@@ -218,6 +625,28 @@ export const DefaultApiFp = function(configuration?: Configuration) {
             const localVarAxiosArgs = await localVarAxiosParamCreator.getStatusV1(options);
             return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
         },
+        /**
+         * 
+         * @summary Get the ERC20 and ERC721 token balances
+         * @param {GetTokenBalancesRequestV1} getTokenBalancesRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        async getTokenBalancesV1(getTokenBalancesRequestV1: GetTokenBalancesRequestV1, options?: AxiosRequestConfig): Promise<(axios?: AxiosInstance, basePath?: string) => AxiosPromise<GetTokenBalancesResponseV1>> {
+            const localVarAxiosArgs = await localVarAxiosParamCreator.getTokenBalancesV1(getTokenBalancesRequestV1, options);
+            return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
+        },
+        /**
+         * 
+         * @summary Get the stored transactions by contract and method
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        async getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: AxiosRequestConfig): Promise<(axios?: AxiosInstance, basePath?: string) => AxiosPromise<GetTransactionsResponseV1>> {
+            const localVarAxiosArgs = await localVarAxiosParamCreator.getTransactionsV1(getTransactionsRequestV1, options);
+            return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
+        },
     }
 };
 
@@ -228,6 +657,16 @@ export const DefaultApiFp = function(configuration?: Configuration) {
 export const DefaultApiFactory = function (configuration?: Configuration, basePath?: string, axios?: AxiosInstance) {
     const localVarFp = DefaultApiFp(configuration)
     return {
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: any): AxiosPromise<GetBlocksResponseV1> {
+            return localVarFp.getBlocksV1(getBlocksRequestV1, options).then((request) => request(axios, basePath));
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for ethereum
@@ -237,6 +676,26 @@ export const DefaultApiFactory = function (configuration?: Configuration, basePa
         getStatusV1(options?: any): AxiosPromise<StatusResponseV1> {
             return localVarFp.getStatusV1(options).then((request) => request(axios, basePath));
         },
+        /**
+         * 
+         * @summary Get the ERC20 and ERC721 token balances
+         * @param {GetTokenBalancesRequestV1} getTokenBalancesRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTokenBalancesV1(getTokenBalancesRequestV1: GetTokenBalancesRequestV1, options?: any): AxiosPromise<GetTokenBalancesResponseV1> {
+            return localVarFp.getTokenBalancesV1(getTokenBalancesRequestV1, options).then((request) => request(axios, basePath));
+        },
+        /**
+         * 
+         * @summary Get the stored transactions by contract and method
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: any): AxiosPromise<GetTransactionsResponseV1> {
+            return localVarFp.getTransactionsV1(getTransactionsRequestV1, options).then((request) => request(axios, basePath));
+        },
     };
 };
 
@@ -247,6 +706,18 @@ export const DefaultApiFactory = function (configuration?: Configuration, basePa
  * @extends {BaseAPI}
  */
 export class DefaultApi extends BaseAPI {
+    /**
+     * 
+     * @summary Get the stored blocks in a range
+     * @param {GetBlocksRequestV1} getBlocksRequestV1 
+     * @param {*} [options] Override http request option.
+     * @throws {RequiredError}
+     * @memberof DefaultApi
+     */
+    public getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: AxiosRequestConfig) {
+        return DefaultApiFp(this.configuration).getBlocksV1(getBlocksRequestV1, options).then((request) => request(this.axios, this.basePath));
+    }
+
     /**
      * 
      * @summary Get the status of persistence plugin for ethereum
@@ -257,6 +728,30 @@ export class DefaultApi extends BaseAPI {
     public getStatusV1(options?: AxiosRequestConfig) {
         return DefaultApiFp(this.configuration).getStatusV1(options).then((request) => request(this.axios, this.basePath));
     }
+
+    /**
+     * 
+     * @summary Get the ERC20 and ERC721 token balances
+     * @param {GetTokenBalancesRequestV1} getTokenBalancesRequestV1 
+     * @param {*} [options] Override http request option.
+     * @throws {RequiredError}
+     * @memberof DefaultApi
+     */
+    public getTokenBalancesV1(getTokenBalancesRequestV1: GetTokenBalancesRequestV1, options?: AxiosRequestConfig) {
+        return DefaultApiFp(this.configuration).getTokenBalancesV1(getTokenBalancesRequestV1, options).then((request) => request(this.axios, this.basePath));
+    }
+
+    /**
+     * 
+     * @summary Get the stored transactions by contract and method
+     * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+     * @param {*} [options] Override http request option.
+     * @throws {RequiredError}
+     * @memberof DefaultApi
+     */
+    public getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: AxiosRequestConfig) {
+        return DefaultApiFp(this.configuration).getTransactionsV1(getTransactionsRequestV1, options).then((request) => request(this.axios, this.basePath));
+    }
 }
 
 
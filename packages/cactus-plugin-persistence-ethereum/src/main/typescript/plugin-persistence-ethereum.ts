@@ -26,13 +26,26 @@ import TokenClientERC721 from "./token-client/token-client-erc721";
 import ERC1155 from "../json/contract-abi/ERC1155.json";
 import TokenClientERC1155 from "./token-client/token-client-erc1155";
 import OAS from "../json/openapi.json";
-import { getRuntimeErrorCause, normalizeAddress } from "./utils";
+import {
+  getQueryLimit,
+  getRuntimeErrorCause,
+  normalizeAddress,
+} from "./utils";
 import { StatusEndpointV1 } from "./web-services/status-endpoint-v1";
+import { GetBlocksEndpointV1 } from "./web-services/get-blocks-endpoint-v1";
+import { GetTransactionsEndpointV1 } from "./web-services/get-transactions-endpoint-v1";
+import { GetTokenBalancesEndpointV1 } from "./web-services/get-token-balances-endpoint-v1";
 import PostgresDatabaseClient, {
   BlockDataTransferInput,
   BlockDataTransactionInput,
 } from "./db-client/db-client";
 import {
+  GetBlocksRequestV1,
+  GetBlocksResponseV1,
+  GetTokenBalancesRequestV1,
+  GetTokenBalancesResponseV1,
+  GetTransactionsRequestV1,
+  GetTransactionsResponseV1,
   MonitoredToken,
   StatusResponseV1,
   TokenTypeV1,
@@ -659,6 +672,27 @@ export class PluginPersistenceEthereum
       });
       endpoints.push(endpoint);
     }
+    {
+      const endpoint = new GetBlocksEndpointV1({
+        connector: this,
+        logLevel: this.options.logLevel,
+      });
+      endpoints.push(endpoint);
+    }
+    {
+      const endpoint = new GetTransactionsEndpointV1({
+        connector: this,
+        logLevel: this.options.logLevel,
+      });
+      endpoints.push(endpoint);
+    }
+    {
+      const endpoint = new GetTokenBalancesEndpointV1({
+        connector: this,
+        logLevel: this.options.logLevel,
+      });
+      endpoints.push(endpoint);
+    }
     this.endpoints = endpoints;
 
     log.info(`Instantiated web services for plugin ${pkgName} OK`, {
@@ -683,6 +717,102 @@ export class PluginPersistenceEthereum
     };
   }
 
+  /**
+   * Read blocks stored in the database in the requested range, ordered by block number.
+   * @param request block range and limit (100 by default, 1000 at most).
+   * @returns Stored blocks.
+   */
+  public async getBlocks(
+    request: GetBlocksRequestV1,
+  ): Promise<GetBlocksResponseV1> {
+    Checks.truthy(
+      request.toBlock >= request.fromBlock,
+      `getBlocks fromBlock larger than toBlock`,
+    );
+    const rows = await this.dbClient.getBlocksInRange(
+      request.fromBlock,
+      request.toBlock,
+      getQueryLimit(request.limit),
+    );
+    return {
+      blocks: rows.map((row) => ({
+        number: Number(row.number),
+        hash: row.hash,
+        createdAt: new Date(row.created_at).toISOString(),
+        numberOfTx: Number(row.number_of_tx),
+        syncAt: new Date(row.sync_at).toISOString(),
+      })),
+    };
+  }
+
+  /**
+   * Read transactions stored in the database, filtered by called contract, method and block range.
+   * @param request transaction filter and page (limit is 100 by default, 1000 at most).
+   * @returns Stored transactions ordered by block number and index.
+   */
+  public async getTransactions(
+    request: GetTransactionsRequestV1,
+  ): Promise<GetTransactionsResponseV1> {
+    const rows = await this.dbClient.getTransactions({
+      contractAddress: request.contractAddress,
+      methodName: request.methodName,
+      fromBlock: request.fromBlock,
+      toBlock: request.toBlock,
+      limit: getQueryLimit(request.limit),
+      offset: request.offset ?? 0,
+    });
+    return {
+      transactions: rows.map((row) => ({
+        hash: row.hash,
+        index: Number(row.index),
+        blockNumber: Number(row.block_number),
+        from: row.from,
+        to: row.to,
+        ethValue: String(row.eth_value),
+        methodSignature: row.method_signature,
+        methodName: row.method_name,
+      })),
+    };
+  }
+
+  /**
+   * Read ERC20 token balances and ERC721 tokens owned, filtered by account, token and token type.
+   * @param request token balances filter.
+   * @returns Token balances, empty for the token types not requested.
+   */
+  public async getTokenBalances(
+    request: GetTokenBalancesRequestV1,
+  ): Promise<GetTokenBalancesResponseV1> {
+    Checks.truthy(
+      request.tokenType !== TokenTypeV1.ERC1155,
+      `getTokenBalances ERC1155 tokens are not supported`,
+    );
+    const filter = {
+      accountAddress: request.accountAddress,
+      tokenAddress: request.tokenAddress,
+    };
+    const response: GetTokenBalancesResponseV1 = { erc20: [], erc721: [] };
+
+    if (!request.tokenType || request.tokenType === TokenTypeV1.ERC20) {
+      const rows = await this.dbClient.getTokenBalancesERC20(filter);
+      response.erc20 = rows.map((row) => ({
+        accountAddress: row.account_address,
+        tokenAddress: row.token_address,
+        balance: String(row.balance),
+      }));
+    }
+    if (!request.tokenType || request.tokenType === TokenTypeV1.ERC721) {
+      const rows = await this.dbClient.getTokensERC721(filter);
+      response.erc721 = rows.map((row) => ({
+        accountAddress: row.account_address,
+        tokenAddress: row.token_address,
+        tokenId: String(row.token_id),
+        uri: row.uri,
+      }));
+    }
+    return response;
+  }
+
   /**
    * Fetch the metadata of all tokens to be monitored by this persistence plugin.
    * List is saved internally (in the plugin).
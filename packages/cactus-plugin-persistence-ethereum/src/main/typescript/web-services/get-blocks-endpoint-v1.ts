@@ -0,0 +1,109 @@
+/**
+ * OpenAPI endpoint (POST) for reading stored blocks in a range.
+ */
+
+import {
+  Logger,
+  Checks,
+  LogLevelDesc,
+  LoggerProvider,
+  IAsyncProvider,
+} from "@hyperledger-cacti/cactus-common";
+import type {
+  IEndpointAuthzOptions,
+  IExpressRequestHandler,
+  IWebServiceEndpoint,
+} from "@hyperledger-cacti/cactus-core-api";
+import {
+  handleRestEndpointException,
+  registerWebServiceEndpoint,
+} from "@hyperledger-cacti/cactus-core";
+
+import { PluginPersistenceEthereum } from "../plugin-persistence-ethereum";
+import { GetBlocksRequestV1 } from "../generated/openapi/typescript-axios";
+import OAS from "../../json/openapi.json";
+
+import type { Express, Request, Response } from "express";
+
+export interface IGetBlocksEndpointV1Options {
+  logLevel?: LogLevelDesc;
+  connector: PluginPersistenceEthereum;
+}
+
+/**
+ * OpenAPI endpoint (POST) for reading stored blocks in a range.
+ */
+export class GetBlocksEndpointV1 implements IWebServiceEndpoint {
+  public static readonly CLASS_NAME = "GetBlocksEndpointV1";
+
+  private readonly log: Logger;
+
+  public get className(): string {
+    return GetBlocksEndpointV1.CLASS_NAME;
+  }
+
+  constructor(public readonly options: IGetBlocksEndpointV1Options) {
+    const fnTag = `${this.className}#constructor()`;
+    Checks.truthy(options, `${fnTag} arg options`);
+    Checks.truthy(options.connector, `${fnTag} arg options.connector`);
+
+    const level = this.options.logLevel || "INFO";
+    const label = this.className;
+    this.log = LoggerProvider.getOrCreate({ level, label });
+  }
+
+  public getOasPath(): any {
+    return OAS.paths[
+      "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-ethereum/blocks"
+    ];
+  }
+
+  public getPath(): string {
+    const apiPath = this.getOasPath();
+    return apiPath.post["x-hyperledger-cacti"].http.path;
+  }
+
+  public getVerbLowerCase(): string {
+    const apiPath = this.getOasPath();
+    return apiPath.post["x-hyperledger-cacti"].http.verbLowerCase;
+  }
+
+  public getOperationId(): string {
+    return this.getOasPath().post.operationId;
+  }
+
+  getAuthorizationOptionsProvider(): IAsyncProvider<IEndpointAuthzOptions> {
+    // TODO: make this an injectable dependency in the constructor
+    return {
+      get: async () => ({
+        isProtected: true,
+        requiredRoles: [],
+      }),
+    };
+  }
+
+  public async registerExpress(
+    expressApp: Express,
+  ): Promise<IWebServiceEndpoint> {
+    await registerWebServiceEndpoint(expressApp, this);
+    return this;
+  }
+
+  public getExpressRequestHandler(): IExpressRequestHandler {
+    return this.handleRequest.bind(this);
+  }
+
+  public async handleRequest(req: Request, res: Response): Promise<void> {
+    const reqTag = `${this.getVerbLowerCase()} - ${this.getPath()}`;
+    this.log.debug(reqTag);
+    const reqBody: GetBlocksRequestV1 = req.body;
+
+    try {
+      const resBody = await this.options.connector.getBlocks(reqBody);
+      res.status(200).json(resBody);
+    } catch (ex) {
+      const errorMsg = `Crash while serving ${reqTag}`;
+      handleRestEndpointException({ errorMsg, log: this.log, error: ex, res });
+    }
+  }
+}
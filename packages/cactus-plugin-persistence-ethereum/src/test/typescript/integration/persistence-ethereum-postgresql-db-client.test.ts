@@ -754,4 +754,50 @@ describe("Ethereum persistence PostgreSQL PostgresDatabaseClient tests", () => {
       nft_image: issuedTokenUri,
     });
   });
+
+  test("Blocks and transactions are read by range, contract and method", async () => {
+    const blockTimestamp = new Date(1671702925 * 1000);
+    const contractAddr = "0x42EA16C9B9e529dA492909F34f416fEd2bE7c280";
+    const otherAddr = "0x53F6337d308FfB2c52eDa319Be216cC7321D3725";
+    for (const blockNumber of [10, 11, 12]) {
+      await dbClient.insertBlockData({
+        block: {
+          number: blockNumber,
+          created_at: blockTimestamp.toUTCString(),
+          hash: `0x${blockNumber.toString(16).padStart(64, "0")}`,
+          number_of_tx: 2,
+        },
+        transactions: [0, 1].map((index) => ({
+          index,
+          hash: `0x${(blockNumber * 10 + index).toString(16).padStart(64, "0")}`,
+          from: "0x00a329c0648769A73afAc7F9381E08FB43dBEA72",
+          to: index === 0 ? contractAddr : otherAddr,
+          eth_value: 0,
+          method_signature: "0xa9059cbb",
+          method_name: index === 0 ? "transfer" : "",
+          token_transfers: [],
+        })),
+      });
+    }
+
+    const blocks = await dbClient.getBlocksInRange(11, 20, 100);
+    expect(blocks.map((b) => b.number)).toEqual(["11", "12"]);
+    const limitedBlocks = await dbClient.getBlocksInRange(0, 20, 1);
+    expect(limitedBlocks.map((b) => b.number)).toEqual(["10"]);
+
+    const contractTxs = await dbClient.getTransactions({
+      contractAddress: contractAddr.toLowerCase(),
+      methodName: "transfer",
+      fromBlock: 11,
+      limit: 100,
+      offset: 0,
+    });
+    expect(contractTxs.length).toBe(2);
+    expect(contractTxs.map((tx) => tx.block_number)).toEqual(["11", "12"]);
+    contractTxs.forEach((tx) => expect(tx.to).toEqual(contractAddr));
+
+    const pagedTxs = await dbClient.getTransactions({ limit: 2, offset: 3 });
+    const pagedTxKeys = pagedTxs.map((tx) => `${tx.block_number}:${tx.index}`);
+    expect(pagedTxKeys).toEqual(["11:1", "12:0"]);
+  });
 });
@@ -7,7 +7,7 @@ Testing DefaultApiService
 
 // Code generated by OpenAPI Generator (https://openapi-generator.tech);
 
-package cactus-plugin-persistence-fabric
+package persistencefabric
 
 import (
 	"context"
@@ -17,11 +17,35 @@ import (
 	openapiclient "github.com/hyperledger/cactus-plugin-persistence-fabric/src/main/go/generated/openapi/go-client"
 )
 
-func Test_cactus-plugin-persistence-fabric_DefaultApiService(t *testing.T) {
+func Test_persistencefabric_DefaultApiService(t *testing.T) {
 
 	configuration := openapiclient.NewConfiguration()
 	apiClient := openapiclient.NewAPIClient(configuration)
 
+	t.Run("Test DefaultApiService DiscoverNetworkV1", func(t *testing.T) {
+
+		t.Skip("skip test")  // remove to run test
+
+		resp, httpRes, err := apiClient.DefaultApi.DiscoverNetworkV1(context.Background()).Execute()
+
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 200, httpRes.StatusCode)
+
+	})
+
+	t.Run("Test DefaultApiService GetBlocksV1", func(t *testing.T) {
+
+		t.Skip("skip test")  // remove to run test
+
+		resp, httpRes, err := apiClient.DefaultApi.GetBlocksV1(context.Background()).Execute()
+
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 200, httpRes.StatusCode)
+
+	})
+
 	t.Run("Test DefaultApiService GetStatusV1", func(t *testing.T) {
 
 		t.Skip("skip test")  // remove to run test
@@ -34,4 +58,16 @@ func Test_cactus-plugin-persistence-fabric_DefaultApiService(t *testing.T) {
 
 	})
 
+	t.Run("Test DefaultApiService GetTransactionsV1", func(t *testing.T) {
+
+		t.Skip("skip test")  // remove to run test
+
+		resp, httpRes, err := apiClient.DefaultApi.GetTransactionsV1(context.Background()).Execute()
+
+		require.Nil(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, 200, httpRes.StatusCode)
+
+	})
+
 }
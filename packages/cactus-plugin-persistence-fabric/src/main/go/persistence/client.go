@@ -0,0 +1,187 @@
+/*
+Package persistence is a client of the cactus-plugin-persistence-fabric plugin, which indexes the blocks and
+transactions of a Fabric channel in PostgreSQL. It reads the status of the plugin, refreshes the network structure it
+stores, and queries the indexed data: blocks by range, and transactions by channel, chaincode and function. Requests
+and responses are the models of the OpenAPI client generated from the spec of the plugin.
+*/
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	persistencefabric "github.com/hyperledger/cactus-plugin-persistence-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// Paths of the endpoints of the plugin
+const (
+	StatusPath          = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/status"
+	DiscoverNetworkPath = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/discover-network"
+	BlocksPath          = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/blocks"
+	TransactionsPath    = "/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/transactions"
+)
+
+// MaxLimit is the largest number of blocks or transactions the plugin returns at once
+const MaxLimit = 1000
+
+// Error is an error response of the API server
+type Error struct {
+	StatusCode int
+	Message    string `json:"message"`
+	// The exception that caused the error, or the body of the response if it has no message
+	Exception string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Exception == "" {
+		return fmt.Sprintf("API server responded with status %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API server responded with status %d %s: %s", e.StatusCode, e.Message, e.Exception)
+}
+
+// Config configures a Client
+type Config struct {
+	// URL of the API server hosting the plugin, e.g. http://localhost:4000
+	URL string
+	// Defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// Headers set on every request, e.g. Authorization
+	Header http.Header
+}
+
+// Client of a persistence plugin for Fabric
+type Client struct {
+	config Config
+}
+
+func New(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Client{config: config}
+}
+
+func (c *Client) GetStatus(ctx context.Context) (*persistencefabric.StatusResponseV1, error) {
+	response := &persistencefabric.StatusResponseV1{}
+	return response, c.do(ctx, http.MethodGet, StatusPath, nil, response)
+}
+
+// DiscoverNetwork refreshes the MSPs, orderers and peers of the channel stored by the plugin
+func (c *Client) DiscoverNetwork(ctx context.Context) (*persistencefabric.DiscoverNetworkResponseV1, error) {
+	response := &persistencefabric.DiscoverNetworkResponseV1{}
+	return response, c.do(ctx, http.MethodPost, DiscoverNetworkPath, nil, response)
+}
+
+// GetBlocks returns the stored blocks of a range, ordered by number; blocks not yet synchronized are missing
+func (c *Client) GetBlocks(ctx context.Context, request *persistencefabric.GetBlocksRequestV1) ([]persistencefabric.BlockV1, error) {
+	response := &persistencefabric.GetBlocksResponseV1{}
+	err := c.do(ctx, http.MethodPost, BlocksPath, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Blocks, nil
+}
+
+// GetTransactions returns a page of the stored transactions matching the request, ordered by block and timestamp
+func (c *Client) GetTransactions(ctx context.Context, request *persistencefabric.GetTransactionsRequestV1) ([]persistencefabric.TransactionV1, error) {
+	response := &persistencefabric.GetTransactionsResponseV1{}
+	err := c.do(ctx, http.MethodPost, TransactionsPath, request, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Transactions, nil
+}
+
+/*
+EachTransaction calls fn with every stored transaction matching the request, reading them page by page from the
+request offset, until fn fails or the transactions are exhausted.
+*/
+func (c *Client) EachTransaction(ctx context.Context, request persistencefabric.GetTransactionsRequestV1, fn func(persistencefabric.TransactionV1) error) error {
+	if request.GetLimit() <= 0 || request.GetLimit() > MaxLimit {
+		request.SetLimit(MaxLimit)
+	}
+	offset := request.GetOffset()
+	for {
+		request.SetOffset(offset)
+		transactions, err := c.GetTransactions(ctx, &request)
+		if err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			if err := fn(transaction); err != nil {
+				return err
+			}
+		}
+		if len(transactions) < int(request.GetLimit()) {
+			return nil
+		}
+		offset += int32(len(transactions))
+	}
+}
+
+// ActionArgs decodes the arguments of the call of a transaction action, as stored by the plugin
+func ActionArgs(a *persistencefabric.TransactionActionV1) ([][]byte, error) {
+	if a.FunctionArgs == "" {
+		return nil, nil
+	}
+	var args [][]byte
+	for _, encoded := range strings.Split(a.FunctionArgs, ",") {
+		arg, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("malformed argument %q of %s: %v", encoded, a.FunctionName, err)
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// do sends a request to an endpoint of the plugin, with a JSON body unless request is nil, and decodes the response
+func (c *Client) do(ctx context.Context, method string, path string, request interface{}, response interface{}) error {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, c.config.URL+path, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.config.Header {
+		httpRequest.Header[name] = values
+	}
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := c.config.HTTPClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		apiError := &Error{}
+		if json.Unmarshal(responseBody, apiError) != nil || apiError.Message == "" {
+			apiError = &Error{Message: http.StatusText(httpResponse.StatusCode), Exception: string(responseBody)}
+		}
+		apiError.StatusCode = httpResponse.StatusCode
+		return apiError
+	}
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		return fmt.Errorf("malformed response of %s: %v", path, err)
+	}
+	return nil
+}
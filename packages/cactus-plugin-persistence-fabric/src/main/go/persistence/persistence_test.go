@@ -0,0 +1,138 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	persistencefabric "github.com/hyperledger/cactus-plugin-persistence-fabric/src/main/go/generated/openapi/go-client"
+)
+
+// persistencePlugin stands in for an API server hosting the persistence plugin, with 10 blocks of 2 transactions
+func persistencePlugin(t *testing.T) *httptest.Server {
+	var transactions []persistencefabric.TransactionV1
+	for block := int64(1); block <= 10; block++ {
+		for index, function := range []string{"CreateAsset", "TransferAsset"} {
+			transactions = append(transactions, persistencefabric.TransactionV1{
+				Hash: fmt.Sprintf("%d-%d", block, index), ChannelId: "mychannel", BlockNumber: block,
+				Actions: []persistencefabric.TransactionActionV1{{
+					ChaincodeId: "basic", FunctionName: function, FunctionArgs: "0x61737365743031,0x",
+					CreatorMspId: "Org1MSP",
+				}},
+			})
+		}
+	}
+	mux := http.NewServeMux()
+	handle := func(path string, request interface{}, handler func() interface{}) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if request != nil && json.NewDecoder(r.Body).Decode(request) != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(&Error{Message: "Bad Request", Exception: "malformed body"})
+				return
+			}
+			response := handler()
+			if _, ok := response.(*Error); ok {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			json.NewEncoder(w).Encode(response)
+		})
+	}
+	handle(StatusPath, nil, func() interface{} {
+		return &persistencefabric.StatusResponseV1{InstanceId: "persistence", Connected: true, LastSeenBlock: 10}
+	})
+	handle(DiscoverNetworkPath, nil, func() interface{} {
+		return &persistencefabric.DiscoverNetworkResponseV1{Status: true, Message: "Discovery done."}
+	})
+	blocksRequest := &persistencefabric.GetBlocksRequestV1{}
+	handle(BlocksPath, blocksRequest, func() interface{} {
+		if blocksRequest.FromBlock > blocksRequest.ToBlock {
+			return &Error{Message: "Internal Server Error", Exception: "getBlocks fromBlock larger than toBlock"}
+		}
+		response := &persistencefabric.GetBlocksResponseV1{Blocks: []persistencefabric.BlockV1{}}
+		for number := blocksRequest.FromBlock; number <= blocksRequest.ToBlock && number <= 10; number++ {
+			response.Blocks = append(response.Blocks, persistencefabric.BlockV1{Number: number, TransactionCount: 2})
+		}
+		return response
+	})
+	transactionsRequest := &persistencefabric.GetTransactionsRequestV1{}
+	handle(TransactionsPath, transactionsRequest, func() interface{} {
+		request := transactionsRequest
+		response := &persistencefabric.GetTransactionsResponseV1{Transactions: []persistencefabric.TransactionV1{}}
+		matching := 0
+		for _, transaction := range transactions {
+			if (request.HasChaincodeId() && request.GetChaincodeId() != transaction.Actions[0].ChaincodeId) ||
+				(request.HasFunctionName() && request.GetFunctionName() != transaction.Actions[0].FunctionName) ||
+				(request.HasToBlock() && transaction.BlockNumber > request.GetToBlock()) {
+				continue
+			}
+			if matching >= int(request.GetOffset()) && len(response.Transactions) < int(request.GetLimit()) {
+				response.Transactions = append(response.Transactions, transaction)
+			}
+			matching++
+		}
+		*transactionsRequest = persistencefabric.GetTransactionsRequestV1{}
+		return response
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	server := persistencePlugin(t)
+	c := New(Config{URL: server.URL})
+	ctx := context.Background()
+
+	status, err := c.GetStatus(ctx)
+	if err != nil || !status.Connected || status.LastSeenBlock != 10 {
+		t.Fatalf("unexpected status %+v, %v", status, err)
+	}
+	if discovery, err := c.DiscoverNetwork(ctx); err != nil || !discovery.Status {
+		t.Errorf("unexpected discovery %+v, %v", discovery, err)
+	}
+
+	blocks, err := c.GetBlocks(ctx, &persistencefabric.GetBlocksRequestV1{FromBlock: 9, ToBlock: 20})
+	if err != nil || len(blocks) != 2 || blocks[1].Number != 10 {
+		t.Errorf("unexpected blocks %+v, %v", blocks, err)
+	}
+
+	var apiError *Error
+	if _, err := c.GetBlocks(ctx, &persistencefabric.GetBlocksRequestV1{FromBlock: 2, ToBlock: 1}); !errors.As(err, &apiError) ||
+		apiError.StatusCode != http.StatusInternalServerError {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	request := persistencefabric.NewGetTransactionsRequestV1()
+	request.SetChaincodeId("basic")
+	request.SetFunctionName("TransferAsset")
+	request.SetToBlock(4)
+	request.SetLimit(10)
+	transactions, err := c.GetTransactions(ctx, request)
+	if err != nil || len(transactions) != 4 || transactions[3].Hash != "4-1" {
+		t.Fatalf("unexpected transactions %+v, %v", transactions, err)
+	}
+	args, err := ActionArgs(&transactions[0].Actions[0])
+	if err != nil || len(args) != 2 || string(args[0]) != "asset01" || len(args[1]) != 0 {
+		t.Errorf("unexpected arguments %q, %v", args, err)
+	}
+
+	var hashes []string
+	request = persistencefabric.NewGetTransactionsRequestV1()
+	request.SetFunctionName("CreateAsset")
+	request.SetLimit(3)
+	err = c.EachTransaction(ctx, *request, func(transaction persistencefabric.TransactionV1) error {
+		hashes = append(hashes, transaction.Hash)
+		return nil
+	})
+	if err != nil || len(hashes) != 10 || hashes[9] != "10-0" {
+		t.Errorf("unexpected transactions %v, %v", hashes, err)
+	}
+
+	if _, err := ActionArgs(&persistencefabric.TransactionActionV1{FunctionArgs: "0xzz"}); err == nil {
+		t.Errorf("malformed argument decoded")
+	}
+}
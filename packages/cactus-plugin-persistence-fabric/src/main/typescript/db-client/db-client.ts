@@ -36,9 +36,21 @@ type SchemaTables = DatabaseSchemaType["fabric"]["Tables"];
 type SchemaFunctions = DatabaseSchemaType["fabric"]["Functions"];
 type BlockRowType = SchemaTables["block"]["Row"];
 type CertificateRowType = SchemaTables["certificate"]["Row"];
+type TransactionRowType = SchemaTables["transaction"]["Row"];
+type TransactionActionRowType = SchemaTables["transaction_action"]["Row"];
 type GetMissingRowsInRangeReturnType =
   SchemaFunctions["get_missing_blocks_in_range"]["Returns"];
 
+export type GetTransactionsFilter = {
+  channelId?: string;
+  chaincodeId?: string;
+  functionName?: string;
+  fromBlock?: number;
+  toBlock?: number;
+  limit: number;
+  offset: number;
+};
+
 //////////////////////////////////
 // PostgresDatabaseClient
 //////////////////////////////////
@@ -428,6 +440,89 @@ export default class PostgresDatabaseClient {
     return queryResponse.rows[0];
   }
 
+  /**
+   * Read blocks stored in the range, ordered by block number.
+   * @param fromBlock block to read from (including)
+   * @param toBlock block to read to (including)
+   * @param limit maximum number of blocks returned
+   * @returns Blocks data.
+   */
+  public async getBlocksInRange(
+    fromBlock: number,
+    toBlock: number,
+    limit: number,
+  ): Promise<BlockRowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query<BlockRowType>(
+      `SELECT * FROM fabric.block
+     WHERE number BETWEEN $1 AND $2 ORDER BY number LIMIT $3`,
+      [fromBlock, toBlock, limit],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} blocks between ${fromBlock} and ${toBlock}`,
+    );
+    return queryResponse.rows;
+  }
+
+  /**
+   * Read transactions matching the filter, ordered by block number and timestamp.
+   * A transaction matches the chaincode and function filter if one of its actions does.
+   * @param filter transaction filter, every field set must match
+   * @returns Transactions data.
+   */
+  public async getTransactions(
+    filter: GetTransactionsFilter,
+  ): Promise<TransactionRowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query<TransactionRowType>(
+      `SELECT * FROM fabric.transaction AS tx
+     WHERE ($1::text IS NULL OR tx.channel_id = $1)
+     AND ($2::numeric IS NULL OR tx.block_number >= $2)
+     AND ($3::numeric IS NULL OR tx.block_number <= $3)
+     AND (($4::text IS NULL AND $5::text IS NULL) OR EXISTS (
+       SELECT 1 FROM fabric.transaction_action AS action
+       WHERE action.transaction_id = tx.id
+       AND ($4::text IS NULL OR action.chaincode_id = $4)
+       AND ($5::text IS NULL OR action.function_name = $5)))
+     ORDER BY tx.block_number, tx.timestamp, tx.hash LIMIT $6 OFFSET $7`,
+      [
+        filter.channelId ?? null,
+        filter.fromBlock ?? null,
+        filter.toBlock ?? null,
+        filter.chaincodeId ?? null,
+        filter.functionName ?? null,
+        filter.limit,
+        filter.offset,
+      ],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} rows from table transaction`,
+    );
+    return queryResponse.rows;
+  }
+
+  /**
+   * Read actions of the transactions.
+   * @param transactionIds database IDs of the transactions
+   * @returns Transaction actions data.
+   */
+  public async getTransactionActions(
+    transactionIds: string[],
+  ): Promise<TransactionActionRowType[]> {
+    this.assertConnected();
+
+    const queryResponse = await this.client.query<TransactionActionRowType>(
+      "SELECT * FROM fabric.transaction_action WHERE transaction_id = ANY($1)",
+      [transactionIds],
+    );
+    this.log.debug(
+      `Received ${queryResponse.rowCount} rows from table transaction_action`,
+    );
+    return queryResponse.rows;
+  }
+
   /**
    * Insert entire block data into the database (the block itself and transactions).
    * Everything is committed in single atomic transaction (rollback on error).
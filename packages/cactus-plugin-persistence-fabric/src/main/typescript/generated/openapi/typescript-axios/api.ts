@@ -23,6 +23,31 @@ import type { RequestArgs } from './base';
 // @ts-ignore
 import { BASE_PATH, COLLECTION_FORMATS, BaseAPI, RequiredError } from './base';
 
+/**
+ * Block stored in the database.
+ * @export
+ * @interface BlockV1
+ */
+export interface BlockV1 {
+    /**
+     * Block number.
+     * @type {number}
+     * @memberof BlockV1
+     */
+    'number': number;
+    /**
+     * Block hash.
+     * @type {string}
+     * @memberof BlockV1
+     */
+    'hash': string;
+    /**
+     * Number of transactions in the block.
+     * @type {number}
+     * @memberof BlockV1
+     */
+    'transactionCount': number;
+}
 /**
  * 
  * @export
@@ -61,6 +86,106 @@ export interface ErrorExceptionResponseV1 {
      */
     'error': string;
 }
+/**
+ * Request for the stored blocks in a range, ordered by number.
+ * @export
+ * @interface GetBlocksRequestV1
+ */
+export interface GetBlocksRequestV1 {
+    /**
+     * First block number of the range (including).
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'fromBlock': number;
+    /**
+     * Last block number of the range (including).
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'toBlock': number;
+    /**
+     * Maximum number of results, 100 by default.
+     * @type {number}
+     * @memberof GetBlocksRequestV1
+     */
+    'limit'?: number;
+}
+/**
+ * Stored blocks in the requested range.
+ * @export
+ * @interface GetBlocksResponseV1
+ */
+export interface GetBlocksResponseV1 {
+    /**
+     * 
+     * @type {Array<BlockV1>}
+     * @memberof GetBlocksResponseV1
+     */
+    'blocks': Array<BlockV1>;
+}
+/**
+ * Request for the stored transactions matching the filters, ordered by block number and timestamp. A transaction matches the chaincode and function filters if one of its actions does.
+ * @export
+ * @interface GetTransactionsRequestV1
+ */
+export interface GetTransactionsRequestV1 {
+    /**
+     * Channel of the transaction.
+     * @type {string}
+     * @memberof GetTransactionsRequestV1
+     */
+    'channelId'?: string;
+    /**
+     * Called chaincode.
+     * @type {string}
+     * @memberof GetTransactionsRequestV1
+     */
+    'chaincodeId'?: string;
+    /**
+     * Called function.
+     * @type {string}
+     * @memberof GetTransactionsRequestV1
+     */
+    'functionName'?: string;
+    /**
+     * First block number (including).
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'fromBlock'?: number;
+    /**
+     * Last block number (including).
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'toBlock'?: number;
+    /**
+     * Maximum number of results, 100 by default.
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'limit'?: number;
+    /**
+     * Number of results to skip.
+     * @type {number}
+     * @memberof GetTransactionsRequestV1
+     */
+    'offset'?: number;
+}
+/**
+ * Stored transactions matching the filters.
+ * @export
+ * @interface GetTransactionsResponseV1
+ */
+export interface GetTransactionsResponseV1 {
+    /**
+     * 
+     * @type {Array<TransactionV1>}
+     * @memberof GetTransactionsResponseV1
+     */
+    'transactions': Array<TransactionV1>;
+}
 /**
  * Response with plugin status report.
  * @export
@@ -123,6 +248,80 @@ export interface TrackedOperationV1 {
      */
     'operation': string;
 }
+/**
+ * Chaincode call of a transaction.
+ * @export
+ * @interface TransactionActionV1
+ */
+export interface TransactionActionV1 {
+    /**
+     * Called chaincode.
+     * @type {string}
+     * @memberof TransactionActionV1
+     */
+    'chaincodeId': string;
+    /**
+     * Called function.
+     * @type {string}
+     * @memberof TransactionActionV1
+     */
+    'functionName': string;
+    /**
+     * Arguments of the call, as stored by the plugin.
+     * @type {string}
+     * @memberof TransactionActionV1
+     */
+    'functionArgs': string;
+    /**
+     * MSP of the creator of the transaction.
+     * @type {string}
+     * @memberof TransactionActionV1
+     */
+    'creatorMspId': string;
+}
+/**
+ * Transaction stored in the database.
+ * @export
+ * @interface TransactionV1
+ */
+export interface TransactionV1 {
+    /**
+     * Transaction hash.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'hash': string;
+    /**
+     * Channel of the transaction.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'channelId': string;
+    /**
+     * Transaction timestamp.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'timestamp': string;
+    /**
+     * Transaction type.
+     * @type {string}
+     * @memberof TransactionV1
+     */
+    'type': string;
+    /**
+     * Number of the block of the transaction.
+     * @type {number}
+     * @memberof TransactionV1
+     */
+    'blockNumber': number;
+    /**
+     * 
+     * @type {Array<TransactionActionV1>}
+     * @memberof TransactionV1
+     */
+    'actions': Array<TransactionActionV1>;
+}
 
 /**
  * DefaultApi - axios parameter creator
@@ -160,6 +359,42 @@ export const DefaultApiAxiosParamCreator = function (configuration?: Configurati
                 options: localVarRequestOptions,
             };
         },
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getBlocksV1: async (getBlocksRequestV1: GetBlocksRequestV1, options: AxiosRequestConfig = {}): Promise<RequestArgs> => {
+            // verify required parameter 'getBlocksRequestV1' is not null or undefined
+            assertParamExists('getBlocksV1', 'getBlocksRequestV1', getBlocksRequestV1)
+            const localVarPath = `/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/blocks`;
+            // use dummy base URL string because the URL constructor only accepts absolute URLs.
+            const localVarUrlObj = new URL(localVarPath, DUMMY_BASE_URL);
+            let baseOptions;
+            if (configuration) {
+                baseOptions = configuration.baseOptions;
+            }
+
+            const localVarRequestOptions = { method: 'POST', ...baseOptions, ...options};
+            const localVarHeaderParameter = {} as any;
+            const localVarQueryParameter = {} as any;
+
+
+    
+            localVarHeaderParameter['Content-Type'] = 'application/json';
+
+            setSearchParams(localVarUrlObj, localVarQueryParameter);
+            let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
+            localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
+            localVarRequestOptions.data = serializeDataIfNeeded(getBlocksRequestV1, localVarRequestOptions, configuration)
+
+            return {
+                url: toPathString(localVarUrlObj),
+                options: localVarRequestOptions,
+            };
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for fabric
@@ -185,6 +420,42 @@ export const DefaultApiAxiosParamCreator = function (configuration?: Configurati
             let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
             localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
 
+            return {
+                url: toPathString(localVarUrlObj),
+                options: localVarRequestOptions,
+            };
+        },
+        /**
+         * 
+         * @summary Get the stored transactions by chaincode and function
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTransactionsV1: async (getTransactionsRequestV1: GetTransactionsRequestV1, options: AxiosRequestConfig = {}): Promise<RequestArgs> => {
+            // verify required parameter 'getTransactionsRequestV1' is not null or undefined
+            assertParamExists('getTransactionsV1', 'getTransactionsRequestV1', getTransactionsRequestV1)
+            const localVarPath = `/api/v1/plugins/@hyperledger-cacti/cactus-plugin-persistence-fabric/transactions`;
+            // use dummy base URL string because the URL constructor only accepts absolute URLs.
+            const localVarUrlObj = new URL(localVarPath, DUMMY_BASE_URL);
+            let baseOptions;
+            if (configuration) {
+                baseOptions = configuration.baseOptions;
+            }
+
+            const localVarRequestOptions = { method: 'POST', ...baseOptions, ...options};
+            const localVarHeaderParameter = {} as any;
+            const localVarQueryParameter = {} as any;
+
+
+    
+            localVarHeaderParameter['Content-Type'] = 'application/json';
+
+            setSearchParams(localVarUrlObj, localVarQueryParameter);
+            let headersFromBaseOptions = baseOptions && baseOptions.headers ? baseOptions.headers : {};
+            localVarRequestOptions.headers = {...localVarHeaderParameter, ...headersFromBaseOptions, ...options.headers};
+            localVarRequestOptions.data = serializeDataIfNeeded(getTransactionsRequestV1, localVarRequestOptions, configuration)
+
             return {
                 url: toPathString(localVarUrlObj),
                 options: localVarRequestOptions,
@@ -210,6 +481,17 @@ export const DefaultApiFp = function(configuration?: Configuration) {
             const localVarAxiosArgs = await localVarAxiosParamCreator.discoverNetworkV1(options);
             return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
         },
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        async getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: AxiosRequestConfig): Promise<(axios?: AxiosInstance, basePath?: string) => AxiosPromise<GetBlocksResponseV1>> {
+            const localVarAxiosArgs = await localVarAxiosParamCreator.getBlocksV1(getBlocksRequestV1, options);
+            return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for fabric
@@ -220,6 +502,17 @@ export const DefaultApiFp = function(configuration?: Configuration) {
             const localVarAxiosArgs = await localVarAxiosParamCreator.getStatusV1(options);
             return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
         },
+        /**
+         * 
+         * @summary Get the stored transactions by chaincode and function
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        async getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: AxiosRequestConfig): Promise<(axios?: AxiosInstance, basePath?: string) => AxiosPromise<GetTransactionsResponseV1>> {
+            const localVarAxiosArgs = await localVarAxiosParamCreator.getTransactionsV1(getTransactionsRequestV1, options);
+            return createRequestFunction(localVarAxiosArgs, globalAxios, BASE_PATH, configuration);
+        },
     }
 };
 
@@ -239,6 +532,16 @@ export const DefaultApiFactory = function (configuration?: Configuration, basePa
         discoverNetworkV1(options?: any): AxiosPromise<DiscoverNetworkResponseV1> {
             return localVarFp.discoverNetworkV1(options).then((request) => request(axios, basePath));
         },
+        /**
+         * 
+         * @summary Get the stored blocks in a range
+         * @param {GetBlocksRequestV1} getBlocksRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: any): AxiosPromise<GetBlocksResponseV1> {
+            return localVarFp.getBlocksV1(getBlocksRequestV1, options).then((request) => request(axios, basePath));
+        },
         /**
          * 
          * @summary Get the status of persistence plugin for fabric
@@ -248,6 +551,16 @@ export const DefaultApiFactory = function (configuration?: Configuration, basePa
         getStatusV1(options?: any): AxiosPromise<StatusResponseV1> {
             return localVarFp.getStatusV1(options).then((request) => request(axios, basePath));
         },
+        /**
+         * 
+         * @summary Get the stored transactions by chaincode and function
+         * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+         * @param {*} [options] Override http request option.
+         * @throws {RequiredError}
+         */
+        getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: any): AxiosPromise<GetTransactionsResponseV1> {
+            return localVarFp.getTransactionsV1(getTransactionsRequestV1, options).then((request) => request(axios, basePath));
+        },
     };
 };
 
@@ -269,6 +582,18 @@ export class DefaultApi extends BaseAPI {
         return DefaultApiFp(this.configuration).discoverNetworkV1(options).then((request) => request(this.axios, this.basePath));
     }
 
+    /**
+     * 
+     * @summary Get the stored blocks in a range
+     * @param {GetBlocksRequestV1} getBlocksRequestV1 
+     * @param {*} [options] Override http request option.
+     * @throws {RequiredError}
+     * @memberof DefaultApi
+     */
+    public getBlocksV1(getBlocksRequestV1: GetBlocksRequestV1, options?: AxiosRequestConfig) {
+        return DefaultApiFp(this.configuration).getBlocksV1(getBlocksRequestV1, options).then((request) => request(this.axios, this.basePath));
+    }
+
     /**
      * 
      * @summary Get the status of persistence plugin for fabric
@@ -279,6 +604,18 @@ export class DefaultApi extends BaseAPI {
     public getStatusV1(options?: AxiosRequestConfig) {
         return DefaultApiFp(this.configuration).getStatusV1(options).then((request) => request(this.axios, this.basePath));
     }
+
+    /**
+     * 
+     * @summary Get the stored transactions by chaincode and function
+     * @param {GetTransactionsRequestV1} getTransactionsRequestV1 
+     * @param {*} [options] Override http request option.
+     * @throws {RequiredError}
+     * @memberof DefaultApi
+     */
+    public getTransactionsV1(getTransactionsRequestV1: GetTransactionsRequestV1, options?: AxiosRequestConfig) {
+        return DefaultApiFp(this.configuration).getTransactionsV1(getTransactionsRequestV1, options).then((request) => request(this.axios, this.basePath));
+    }
 }
 
 
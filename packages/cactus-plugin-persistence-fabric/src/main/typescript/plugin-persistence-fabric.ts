@@ -31,11 +31,19 @@ import {
 import OAS from "../json/openapi.json";
 import { StatusEndpointV1 } from "./web-services/status-endpoint-v1";
 import { DiscoverNetworkEndpointV1 } from "./web-services/discover-network-endpoint-v1";
+import { GetBlocksEndpointV1 } from "./web-services/get-blocks-endpoint-v1";
+import { GetTransactionsEndpointV1 } from "./web-services/get-transactions-endpoint-v1";
 import PostgresDatabaseClient from "./db-client/db-client";
 import {
+  GetBlocksRequestV1,
+  GetBlocksResponseV1,
+  GetTransactionsRequestV1,
+  GetTransactionsResponseV1,
   StatusResponseV1,
   TrackedOperationV1,
+  TransactionActionV1,
 } from "./generated/openapi/typescript-axios";
+import { getQueryLimit } from "./utils";
 
 /**
  * Constructor parameter for Fabric persistence plugin.
@@ -377,6 +385,20 @@ export class PluginPersistenceFabric
       });
       endpoints.push(endpoint);
     }
+    {
+      const endpoint = new GetBlocksEndpointV1({
+        connector: this,
+        logLevel: this.options.logLevel,
+      });
+      endpoints.push(endpoint);
+    }
+    {
+      const endpoint = new GetTransactionsEndpointV1({
+        connector: this,
+        logLevel: this.options.logLevel,
+      });
+      endpoints.push(endpoint);
+    }
     this.endpoints = endpoints;
 
     log.info(`Instantiated web services for plugin ${pkgName} OK`, {
@@ -401,6 +423,83 @@ export class PluginPersistenceFabric
     };
   }
 
+  /**
+   * Read blocks stored in the database in the requested range, ordered by block number.
+   *
+   * @param request block range and limit (100 by default, 1000 at most).
+   * @returns Stored blocks.
+   */
+  public async getBlocks(
+    request: GetBlocksRequestV1,
+  ): Promise<GetBlocksResponseV1> {
+    Checks.truthy(
+      request.toBlock >= request.fromBlock,
+      `getBlocks fromBlock larger than toBlock`,
+    );
+    const rows = await this.dbClient.getBlocksInRange(
+      request.fromBlock,
+      request.toBlock,
+      getQueryLimit(request.limit),
+    );
+    return {
+      blocks: rows.map((row) => ({
+        number: Number(row.number),
+        hash: row.hash,
+        transactionCount: Number(row.transaction_count),
+      })),
+    };
+  }
+
+  /**
+   * Read transactions stored in the database with their actions, filtered by channel,
+   * called chaincode and function, and block range.
+   *
+   * @param request transaction filter and page (limit is 100 by default, 1000 at most).
+   * @returns Stored transactions ordered by block number and timestamp.
+   */
+  public async getTransactions(
+    request: GetTransactionsRequestV1,
+  ): Promise<GetTransactionsResponseV1> {
+    const rows = await this.dbClient.getTransactions({
+      channelId: request.channelId,
+      chaincodeId: request.chaincodeId,
+      functionName: request.functionName,
+      fromBlock: request.fromBlock,
+      toBlock: request.toBlock,
+      limit: getQueryLimit(request.limit),
+      offset: request.offset ?? 0,
+    });
+
+    const actions = new Map<string, TransactionActionV1[]>();
+    const actionRows = await this.dbClient.getTransactionActions(
+      rows.map((row) => row.id),
+    );
+    for (const actionRow of actionRows) {
+      if (!actionRow.transaction_id) {
+        continue;
+      }
+      const txActions = actions.get(actionRow.transaction_id) ?? [];
+      txActions.push({
+        chaincodeId: actionRow.chaincode_id,
+        functionName: actionRow.function_name ?? "",
+        functionArgs: actionRow.function_args ?? "",
+        creatorMspId: actionRow.creator_msp_id,
+      });
+      actions.set(actionRow.transaction_id, txActions);
+    }
+
+    return {
+      transactions: rows.map((row) => ({
+        hash: row.hash,
+        channelId: row.channel_id,
+        timestamp: new Date(row.timestamp).toISOString(),
+        type: row.type,
+        blockNumber: Number(row.block_number),
+        actions: actions.get(row.id) ?? [],
+      })),
+    };
+  }
+
   /**
    * Start the block monitoring process. New blocks from the ledger will be parsed and pushed to the database.
    * Use `stopMonitor()` to cancel this operation.
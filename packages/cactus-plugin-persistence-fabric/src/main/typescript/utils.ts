@@ -0,0 +1,22 @@
+/**
+ * Helper methods
+ */
+
+/**
+ * Default and maximum number of rows returned by the query endpoints.
+ */
+export const DEFAULT_QUERY_LIMIT = 100;
+export const MAX_QUERY_LIMIT = 1000;
+
+/**
+ * Get the number of rows a query should return, capped at `MAX_QUERY_LIMIT`.
+ * @param limit requested limit, `DEFAULT_QUERY_LIMIT` if not set.
+ * @returns valid query limit
+ */
+export function getQueryLimit(limit?: number): number {
+  if (!limit || limit < 1) {
+    return DEFAULT_QUERY_LIMIT;
+  }
+
+  return Math.min(limit, MAX_QUERY_LIMIT);
+}
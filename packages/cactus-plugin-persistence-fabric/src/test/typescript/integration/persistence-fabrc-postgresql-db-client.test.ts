@@ -344,6 +344,44 @@ describe("Fabric persistence PostgreSQL PostgresDatabaseClient tests", () => {
     );
   });
 
+  test("Blocks and transactions are read by range, chaincode and function", async () => {
+    await dbClient.insertBlockData(sampleBlock);
+    const sampleTx = sampleBlock.cactiTransactionsEvents[0];
+    const sampleTxAction = sampleTx.actions[0];
+
+    const blocks = await dbClient.getBlocksInRange(0, 10, 100);
+    expect(blocks.length).toBe(1);
+    expect(blocks[0].hash).toEqual(sampleBlock.blockHash);
+    const otherBlocks = await dbClient.getBlocksInRange(4, 10, 100);
+    expect(otherBlocks.length).toBe(0);
+
+    const transactions = await dbClient.getTransactions({
+      channelId: sampleTx.channelId,
+      chaincodeId: sampleTxAction.chaincodeId,
+      functionName: sampleTxAction.functionName,
+      fromBlock: sampleBlock.blockNumber,
+      toBlock: sampleBlock.blockNumber,
+      limit: 100,
+      offset: 0,
+    });
+    expect(transactions.length).toBe(1);
+    expect(transactions[0].hash).toEqual(sampleTx.hash);
+
+    const actions = await dbClient.getTransactionActions([transactions[0].id]);
+    expect(actions.length).toBe(1);
+    expect(actions[0].chaincode_id).toEqual(sampleTxAction.chaincodeId);
+
+    const otherFunction = await dbClient.getTransactions({
+      chaincodeId: sampleTxAction.chaincodeId,
+      functionName: "OtherFunction",
+      limit: 100,
+      offset: 0,
+    });
+    expect(otherFunction.length).toBe(0);
+    const nextPage = await dbClient.getTransactions({ limit: 100, offset: 1 });
+    expect(nextPage.length).toBe(0);
+  });
+
   test("insertBlockData does not duplicate certificates in the database", async () => {
     await dbClient.insertBlockData(sampleBlock);
 
@@ -40,6 +40,15 @@ export class Rule extends jspb.Message {
     setResource(value: string): Rule;
     getRead(): boolean;
     setRead(value: boolean): Rule;
+    clearArgumentconstraintsList(): void;
+    getArgumentconstraintsList(): Array<ArgumentConstraint>;
+    setArgumentconstraintsList(value: Array<ArgumentConstraint>): Rule;
+    addArgumentconstraints(value?: ArgumentConstraint, index?: number): ArgumentConstraint;
+
+    hasRatelimit(): boolean;
+    clearRatelimit(): void;
+    getRatelimit(): RateLimit | undefined;
+    setRatelimit(value?: RateLimit): Rule;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Rule.AsObject;
@@ -57,5 +66,56 @@ export namespace Rule {
         principaltype: string,
         resource: string,
         read: boolean,
+        argumentconstraintsList: Array<ArgumentConstraint.AsObject>,
+        ratelimit?: RateLimit.AsObject,
+    }
+}
+
+export class ArgumentConstraint extends jspb.Message { 
+    getIndex(): number;
+    setIndex(value: number): ArgumentConstraint;
+    getField(): string;
+    setField(value: string): ArgumentConstraint;
+    getPattern(): string;
+    setPattern(value: string): ArgumentConstraint;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): ArgumentConstraint.AsObject;
+    static toObject(includeInstance: boolean, msg: ArgumentConstraint): ArgumentConstraint.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: ArgumentConstraint, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): ArgumentConstraint;
+    static deserializeBinaryFromReader(message: ArgumentConstraint, reader: jspb.BinaryReader): ArgumentConstraint;
+}
+
+export namespace ArgumentConstraint {
+    export type AsObject = {
+        index: number,
+        field: string,
+        pattern: string,
+    }
+}
+
+export class RateLimit extends jspb.Message { 
+    getMaxrequests(): number;
+    setMaxrequests(value: number): RateLimit;
+    getWindowseconds(): number;
+    setWindowseconds(value: number): RateLimit;
+
+    serializeBinary(): Uint8Array;
+    toObject(includeInstance?: boolean): RateLimit.AsObject;
+    static toObject(includeInstance: boolean, msg: RateLimit): RateLimit.AsObject;
+    static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+    static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+    static serializeBinaryToWriter(message: RateLimit, writer: jspb.BinaryWriter): void;
+    static deserializeBinary(bytes: Uint8Array): RateLimit;
+    static deserializeBinaryFromReader(message: RateLimit, reader: jspb.BinaryReader): RateLimit;
+}
+
+export namespace RateLimit {
+    export type AsObject = {
+        maxrequests: number,
+        windowseconds: number,
     }
 }
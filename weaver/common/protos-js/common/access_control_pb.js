@@ -22,6 +22,8 @@ var global = (function() {
 }.call(null));
 
 goog.exportSymbol('proto.common.access_control.AccessControlPolicy', null, global);
+goog.exportSymbol('proto.common.access_control.ArgumentConstraint', null, global);
+goog.exportSymbol('proto.common.access_control.RateLimit', null, global);
 goog.exportSymbol('proto.common.access_control.Rule', null, global);
 /**
  * Generated by JsPbCodeGenerator.
@@ -55,7 +57,7 @@ if (goog.DEBUG && !COMPILED) {
  * @constructor
  */
 proto.common.access_control.Rule = function(opt_data) {
-  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.common.access_control.Rule.repeatedFields_, null);
 };
 goog.inherits(proto.common.access_control.Rule, jspb.Message);
 if (goog.DEBUG && !COMPILED) {
@@ -65,6 +67,48 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.common.access_control.Rule.displayName = 'proto.common.access_control.Rule';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.common.access_control.ArgumentConstraint = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.common.access_control.ArgumentConstraint, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.common.access_control.ArgumentConstraint.displayName = 'proto.common.access_control.ArgumentConstraint';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.common.access_control.RateLimit = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.common.access_control.RateLimit, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.common.access_control.RateLimit.displayName = 'proto.common.access_control.RateLimit';
+}
 
 /**
  * List of repeated fields within this message type.
@@ -256,6 +300,15 @@ proto.common.access_control.AccessControlPolicy.prototype.clearRulesList = funct
 
 
 
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.common.access_control.Rule.repeatedFields_ = [5];
+
+
+
 
 
 if (jspb.Message.GENERATE_TO_OBJECT) {
@@ -290,7 +343,10 @@ proto.common.access_control.Rule.toObject = function(includeInstance, msg) {
     principal: jspb.Message.getFieldWithDefault(msg, 1, ""),
     principaltype: jspb.Message.getFieldWithDefault(msg, 2, ""),
     resource: jspb.Message.getFieldWithDefault(msg, 3, ""),
-    read: jspb.Message.getBooleanFieldWithDefault(msg, 4, false)
+    read: jspb.Message.getBooleanFieldWithDefault(msg, 4, false),
+    argumentconstraintsList: jspb.Message.toObjectList(msg.getArgumentconstraintsList(),
+    proto.common.access_control.ArgumentConstraint.toObject, includeInstance),
+    ratelimit: (f = msg.getRatelimit()) && proto.common.access_control.RateLimit.toObject(includeInstance, f)
   };
 
   if (includeInstance) {
@@ -343,6 +399,16 @@ proto.common.access_control.Rule.deserializeBinaryFromReader = function(msg, rea
       var value = /** @type {boolean} */ (reader.readBool());
       msg.setRead(value);
       break;
+    case 5:
+      var value = new proto.common.access_control.ArgumentConstraint;
+      reader.readMessage(value,proto.common.access_control.ArgumentConstraint.deserializeBinaryFromReader);
+      msg.addArgumentconstraints(value);
+      break;
+    case 6:
+      var value = new proto.common.access_control.RateLimit;
+      reader.readMessage(value,proto.common.access_control.RateLimit.deserializeBinaryFromReader);
+      msg.setRatelimit(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -400,6 +466,22 @@ proto.common.access_control.Rule.serializeBinaryToWriter = function(message, wri
       f
     );
   }
+  f = message.getArgumentconstraintsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      5,
+      f,
+      proto.common.access_control.ArgumentConstraint.serializeBinaryToWriter
+    );
+  }
+  f = message.getRatelimit();
+  if (f != null) {
+    writer.writeMessage(
+      6,
+      f,
+      proto.common.access_control.RateLimit.serializeBinaryToWriter
+    );
+  }
 };
 
 
@@ -475,4 +557,427 @@ proto.common.access_control.Rule.prototype.setRead = function(value) {
 };
 
 
+/**
+ * repeated ArgumentConstraint argumentConstraints = 5;
+ * @return {!Array<!proto.common.access_control.ArgumentConstraint>}
+ */
+proto.common.access_control.Rule.prototype.getArgumentconstraintsList = function() {
+  return /** @type{!Array<!proto.common.access_control.ArgumentConstraint>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.common.access_control.ArgumentConstraint, 5));
+};
+
+
+/**
+ * @param {!Array<!proto.common.access_control.ArgumentConstraint>} value
+ * @return {!proto.common.access_control.Rule} returns this
+*/
+proto.common.access_control.Rule.prototype.setArgumentconstraintsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 5, value);
+};
+
+
+/**
+ * @param {!proto.common.access_control.ArgumentConstraint=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.common.access_control.ArgumentConstraint}
+ */
+proto.common.access_control.Rule.prototype.addArgumentconstraints = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 5, opt_value, proto.common.access_control.ArgumentConstraint, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.common.access_control.Rule} returns this
+ */
+proto.common.access_control.Rule.prototype.clearArgumentconstraintsList = function() {
+  return this.setArgumentconstraintsList([]);
+};
+
+
+/**
+ * optional RateLimit rateLimit = 6;
+ * @return {?proto.common.access_control.RateLimit}
+ */
+proto.common.access_control.Rule.prototype.getRatelimit = function() {
+  return /** @type{?proto.common.access_control.RateLimit} */ (
+    jspb.Message.getWrapperField(this, proto.common.access_control.RateLimit, 6));
+};
+
+
+/**
+ * @param {?proto.common.access_control.RateLimit|undefined} value
+ * @return {!proto.common.access_control.Rule} returns this
+*/
+proto.common.access_control.Rule.prototype.setRatelimit = function(value) {
+  return jspb.Message.setWrapperField(this, 6, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.common.access_control.Rule} returns this
+ */
+proto.common.access_control.Rule.prototype.clearRatelimit = function() {
+  return this.setRatelimit(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.common.access_control.Rule.prototype.hasRatelimit = function() {
+  return jspb.Message.getField(this, 6) != null;
+};
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.common.access_control.ArgumentConstraint.prototype.toObject = function(opt_includeInstance) {
+  return proto.common.access_control.ArgumentConstraint.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.common.access_control.ArgumentConstraint} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.common.access_control.ArgumentConstraint.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    index: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    field: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    pattern: jspb.Message.getFieldWithDefault(msg, 3, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.common.access_control.ArgumentConstraint}
+ */
+proto.common.access_control.ArgumentConstraint.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.common.access_control.ArgumentConstraint;
+  return proto.common.access_control.ArgumentConstraint.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.common.access_control.ArgumentConstraint} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.common.access_control.ArgumentConstraint}
+ */
+proto.common.access_control.ArgumentConstraint.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readUint32());
+      msg.setIndex(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setField(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPattern(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.common.access_control.ArgumentConstraint.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.common.access_control.ArgumentConstraint.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.common.access_control.ArgumentConstraint} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.common.access_control.ArgumentConstraint.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getIndex();
+  if (f !== 0) {
+    writer.writeUint32(
+      1,
+      f
+    );
+  }
+  f = message.getField();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getPattern();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional uint32 index = 1;
+ * @return {number}
+ */
+proto.common.access_control.ArgumentConstraint.prototype.getIndex = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.access_control.ArgumentConstraint} returns this
+ */
+proto.common.access_control.ArgumentConstraint.prototype.setIndex = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional string field = 2;
+ * @return {string}
+ */
+proto.common.access_control.ArgumentConstraint.prototype.getField = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.common.access_control.ArgumentConstraint} returns this
+ */
+proto.common.access_control.ArgumentConstraint.prototype.setField = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string pattern = 3;
+ * @return {string}
+ */
+proto.common.access_control.ArgumentConstraint.prototype.getPattern = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.common.access_control.ArgumentConstraint} returns this
+ */
+proto.common.access_control.ArgumentConstraint.prototype.setPattern = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.common.access_control.RateLimit.prototype.toObject = function(opt_includeInstance) {
+  return proto.common.access_control.RateLimit.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.common.access_control.RateLimit} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.common.access_control.RateLimit.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    maxrequests: jspb.Message.getFieldWithDefault(msg, 1, 0),
+    windowseconds: jspb.Message.getFieldWithDefault(msg, 2, 0)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.common.access_control.RateLimit}
+ */
+proto.common.access_control.RateLimit.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.common.access_control.RateLimit;
+  return proto.common.access_control.RateLimit.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.common.access_control.RateLimit} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.common.access_control.RateLimit}
+ */
+proto.common.access_control.RateLimit.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setMaxrequests(value);
+      break;
+    case 2:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setWindowseconds(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.common.access_control.RateLimit.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.common.access_control.RateLimit.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.common.access_control.RateLimit} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.common.access_control.RateLimit.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getMaxrequests();
+  if (f !== 0) {
+    writer.writeUint64(
+      1,
+      f
+    );
+  }
+  f = message.getWindowseconds();
+  if (f !== 0) {
+    writer.writeUint64(
+      2,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional uint64 maxRequests = 1;
+ * @return {number}
+ */
+proto.common.access_control.RateLimit.prototype.getMaxrequests = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 1, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.access_control.RateLimit} returns this
+ */
+proto.common.access_control.RateLimit.prototype.setMaxrequests = function(value) {
+  return jspb.Message.setProto3IntField(this, 1, value);
+};
+
+
+/**
+ * optional uint64 windowSeconds = 2;
+ * @return {number}
+ */
+proto.common.access_control.RateLimit.prototype.getWindowseconds = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 2, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.access_control.RateLimit} returns this
+ */
+proto.common.access_control.RateLimit.prototype.setWindowseconds = function(value) {
+  return jspb.Message.setProto3IntField(this, 2, value);
+};
+
+
 goog.object.extend(exports, proto.common.access_control);
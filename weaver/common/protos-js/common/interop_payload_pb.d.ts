@@ -19,6 +19,8 @@ export class InteropPayload extends jspb.Message {
     setRequestorCertificate(value: string): InteropPayload;
     getNonce(): string;
     setNonce(value: string): InteropPayload;
+    getTimestamp(): number;
+    setTimestamp(value: number): InteropPayload;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): InteropPayload.AsObject;
@@ -37,6 +39,7 @@ export namespace InteropPayload {
         confidential: boolean,
         requestorCertificate: string,
         nonce: string,
+        timestamp: number,
     }
 }
 
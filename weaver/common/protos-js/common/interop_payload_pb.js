@@ -124,7 +124,8 @@ proto.common.interop_payload.InteropPayload.toObject = function(includeInstance,
     address: jspb.Message.getFieldWithDefault(msg, 2, ""),
     confidential: jspb.Message.getBooleanFieldWithDefault(msg, 3, false),
     requestorCertificate: jspb.Message.getFieldWithDefault(msg, 4, ""),
-    nonce: jspb.Message.getFieldWithDefault(msg, 5, "")
+    nonce: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    timestamp: jspb.Message.getFieldWithDefault(msg, 6, 0)
   };
 
   if (includeInstance) {
@@ -181,6 +182,10 @@ proto.common.interop_payload.InteropPayload.deserializeBinaryFromReader = functi
       var value = /** @type {string} */ (reader.readString());
       msg.setNonce(value);
       break;
+    case 6:
+      var value = /** @type {number} */ (reader.readInt64());
+      msg.setTimestamp(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -245,6 +250,13 @@ proto.common.interop_payload.InteropPayload.serializeBinaryToWriter = function(m
       f
     );
   }
+  f = message.getTimestamp();
+  if (f !== 0) {
+    writer.writeInt64(
+      6,
+      f
+    );
+  }
 };
 
 
@@ -362,6 +374,24 @@ proto.common.interop_payload.InteropPayload.prototype.setNonce = function(value)
 };
 
 
+/**
+ * optional int64 timestamp = 6;
+ * @return {number}
+ */
+proto.common.interop_payload.InteropPayload.prototype.getTimestamp = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 6, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.interop_payload.InteropPayload} returns this
+ */
+proto.common.interop_payload.InteropPayload.prototype.setTimestamp = function(value) {
+  return jspb.Message.setProto3IntField(this, 6, value);
+};
+
+
 
 
 
@@ -38,6 +38,10 @@ export class Policy extends jspb.Message {
     getCriteriaList(): Array<string>;
     setCriteriaList(value: Array<string>): Policy;
     addCriteria(value: string, index?: number): string;
+    getMaxage(): number;
+    setMaxage(value: number): Policy;
+    getClockskew(): number;
+    setClockskew(value: number): Policy;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): Policy.AsObject;
@@ -53,6 +57,8 @@ export namespace Policy {
     export type AsObject = {
         type: string,
         criteriaList: Array<string>,
+        maxage: number,
+        clockskew: number,
     }
 }
 
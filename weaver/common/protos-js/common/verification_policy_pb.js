@@ -317,7 +317,9 @@ proto.common.verification_policy.Policy.prototype.toObject = function(opt_includ
 proto.common.verification_policy.Policy.toObject = function(includeInstance, msg) {
   var f, obj = {
     type: jspb.Message.getFieldWithDefault(msg, 1, ""),
-    criteriaList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f
+    criteriaList: (f = jspb.Message.getRepeatedField(msg, 2)) == null ? undefined : f,
+    maxage: jspb.Message.getFieldWithDefault(msg, 3, 0),
+    clockskew: jspb.Message.getFieldWithDefault(msg, 4, 0)
   };
 
   if (includeInstance) {
@@ -362,6 +364,14 @@ proto.common.verification_policy.Policy.deserializeBinaryFromReader = function(m
       var value = /** @type {string} */ (reader.readString());
       msg.addCriteria(value);
       break;
+    case 3:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setMaxage(value);
+      break;
+    case 4:
+      var value = /** @type {number} */ (reader.readUint64());
+      msg.setClockskew(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -405,6 +415,20 @@ proto.common.verification_policy.Policy.serializeBinaryToWriter = function(messa
       f
     );
   }
+  f = message.getMaxage();
+  if (f !== 0) {
+    writer.writeUint64(
+      3,
+      f
+    );
+  }
+  f = message.getClockskew();
+  if (f !== 0) {
+    writer.writeUint64(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -463,6 +487,42 @@ proto.common.verification_policy.Policy.prototype.clearCriteriaList = function()
 };
 
 
+/**
+ * optional uint64 maxAge = 3;
+ * @return {number}
+ */
+proto.common.verification_policy.Policy.prototype.getMaxage = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 3, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.verification_policy.Policy} returns this
+ */
+proto.common.verification_policy.Policy.prototype.setMaxage = function(value) {
+  return jspb.Message.setProto3IntField(this, 3, value);
+};
+
+
+/**
+ * optional uint64 clockSkew = 4;
+ * @return {number}
+ */
+proto.common.verification_policy.Policy.prototype.getClockskew = function() {
+  return /** @type {number} */ (jspb.Message.getFieldWithDefault(this, 4, 0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.common.verification_policy.Policy} returns this
+ */
+proto.common.verification_policy.Policy.prototype.setClockskew = function(value) {
+  return jspb.Message.setProto3IntField(this, 4, value);
+};
+
+
 
 
 
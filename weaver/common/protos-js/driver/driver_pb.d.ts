@@ -42,6 +42,10 @@ export namespace WriteExternalStateMessage {
 export class PerformLockRequest extends jspb.Message { 
     getSessionId(): string;
     setSessionId(value: string): PerformLockRequest;
+    getConsent(): string;
+    setConsent(value: string): PerformLockRequest;
+    getConsentSignature(): string;
+    setConsentSignature(value: string): PerformLockRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): PerformLockRequest.AsObject;
@@ -56,12 +60,20 @@ export class PerformLockRequest extends jspb.Message {
 export namespace PerformLockRequest {
     export type AsObject = {
         sessionId: string,
+        consent: string,
+        consentSignature: string,
     }
 }
 
 export class CreateAssetRequest extends jspb.Message { 
     getSessionId(): string;
     setSessionId(value: string): CreateAssetRequest;
+    getAssetType(): string;
+    setAssetType(value: string): CreateAssetRequest;
+    getAssetId(): string;
+    setAssetId(value: string): CreateAssetRequest;
+    getOriginalOwner(): string;
+    setOriginalOwner(value: string): CreateAssetRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): CreateAssetRequest.AsObject;
@@ -76,12 +88,19 @@ export class CreateAssetRequest extends jspb.Message {
 export namespace CreateAssetRequest {
     export type AsObject = {
         sessionId: string,
+        assetType: string,
+        assetId: string,
+        originalOwner: string,
     }
 }
 
 export class ExtinguishRequest extends jspb.Message { 
     getSessionId(): string;
     setSessionId(value: string): ExtinguishRequest;
+    getAssetType(): string;
+    setAssetType(value: string): ExtinguishRequest;
+    getAssetId(): string;
+    setAssetId(value: string): ExtinguishRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): ExtinguishRequest.AsObject;
@@ -96,12 +115,18 @@ export class ExtinguishRequest extends jspb.Message {
 export namespace ExtinguishRequest {
     export type AsObject = {
         sessionId: string,
+        assetType: string,
+        assetId: string,
     }
 }
 
 export class AssignAssetRequest extends jspb.Message { 
     getSessionId(): string;
     setSessionId(value: string): AssignAssetRequest;
+    getConsent(): string;
+    setConsent(value: string): AssignAssetRequest;
+    getConsentSignature(): string;
+    setConsentSignature(value: string): AssignAssetRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): AssignAssetRequest.AsObject;
@@ -116,5 +141,7 @@ export class AssignAssetRequest extends jspb.Message {
 export namespace AssignAssetRequest {
     export type AsObject = {
         sessionId: string,
+        consent: string,
+        consentSignature: string,
     }
 }
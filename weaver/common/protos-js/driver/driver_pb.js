@@ -373,7 +373,9 @@ proto.driver.driver.PerformLockRequest.prototype.toObject = function(opt_include
  */
 proto.driver.driver.PerformLockRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    sessionId: jspb.Message.getFieldWithDefault(msg, 1, "")
+    sessionId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    consent: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    consentSignature: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -414,6 +416,14 @@ proto.driver.driver.PerformLockRequest.deserializeBinaryFromReader = function(ms
       var value = /** @type {string} */ (reader.readString());
       msg.setSessionId(value);
       break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setConsent(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setConsentSignature(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -450,6 +460,20 @@ proto.driver.driver.PerformLockRequest.serializeBinaryToWriter = function(messag
       f
     );
   }
+  f = message.getConsent();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getConsentSignature();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -471,6 +495,42 @@ proto.driver.driver.PerformLockRequest.prototype.setSessionId = function(value)
 };
 
 
+/**
+ * optional string consent = 2;
+ * @return {string}
+ */
+proto.driver.driver.PerformLockRequest.prototype.getConsent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.PerformLockRequest} returns this
+ */
+proto.driver.driver.PerformLockRequest.prototype.setConsent = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string consent_signature = 3;
+ * @return {string}
+ */
+proto.driver.driver.PerformLockRequest.prototype.getConsentSignature = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.PerformLockRequest} returns this
+ */
+proto.driver.driver.PerformLockRequest.prototype.setConsentSignature = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 
 
 
@@ -503,7 +563,10 @@ proto.driver.driver.CreateAssetRequest.prototype.toObject = function(opt_include
  */
 proto.driver.driver.CreateAssetRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    sessionId: jspb.Message.getFieldWithDefault(msg, 1, "")
+    sessionId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    assetType: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    assetId: jspb.Message.getFieldWithDefault(msg, 3, ""),
+    originalOwner: jspb.Message.getFieldWithDefault(msg, 4, "")
   };
 
   if (includeInstance) {
@@ -544,6 +607,18 @@ proto.driver.driver.CreateAssetRequest.deserializeBinaryFromReader = function(ms
       var value = /** @type {string} */ (reader.readString());
       msg.setSessionId(value);
       break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAssetType(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAssetId(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOriginalOwner(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -580,6 +655,27 @@ proto.driver.driver.CreateAssetRequest.serializeBinaryToWriter = function(messag
       f
     );
   }
+  f = message.getAssetType();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getAssetId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
+  f = message.getOriginalOwner();
+  if (f.length > 0) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
 };
 
 
@@ -601,6 +697,60 @@ proto.driver.driver.CreateAssetRequest.prototype.setSessionId = function(value)
 };
 
 
+/**
+ * optional string asset_type = 2;
+ * @return {string}
+ */
+proto.driver.driver.CreateAssetRequest.prototype.getAssetType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.CreateAssetRequest} returns this
+ */
+proto.driver.driver.CreateAssetRequest.prototype.setAssetType = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string asset_id = 3;
+ * @return {string}
+ */
+proto.driver.driver.CreateAssetRequest.prototype.getAssetId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.CreateAssetRequest} returns this
+ */
+proto.driver.driver.CreateAssetRequest.prototype.setAssetId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
+/**
+ * optional string original_owner = 4;
+ * @return {string}
+ */
+proto.driver.driver.CreateAssetRequest.prototype.getOriginalOwner = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.CreateAssetRequest} returns this
+ */
+proto.driver.driver.CreateAssetRequest.prototype.setOriginalOwner = function(value) {
+  return jspb.Message.setProto3StringField(this, 4, value);
+};
+
+
 
 
 
@@ -633,7 +783,9 @@ proto.driver.driver.ExtinguishRequest.prototype.toObject = function(opt_includeI
  */
 proto.driver.driver.ExtinguishRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    sessionId: jspb.Message.getFieldWithDefault(msg, 1, "")
+    sessionId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    assetType: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    assetId: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -674,6 +826,14 @@ proto.driver.driver.ExtinguishRequest.deserializeBinaryFromReader = function(msg
       var value = /** @type {string} */ (reader.readString());
       msg.setSessionId(value);
       break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAssetType(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setAssetId(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -710,6 +870,20 @@ proto.driver.driver.ExtinguishRequest.serializeBinaryToWriter = function(message
       f
     );
   }
+  f = message.getAssetType();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getAssetId();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -731,6 +905,42 @@ proto.driver.driver.ExtinguishRequest.prototype.setSessionId = function(value) {
 };
 
 
+/**
+ * optional string asset_type = 2;
+ * @return {string}
+ */
+proto.driver.driver.ExtinguishRequest.prototype.getAssetType = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.ExtinguishRequest} returns this
+ */
+proto.driver.driver.ExtinguishRequest.prototype.setAssetType = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string asset_id = 3;
+ * @return {string}
+ */
+proto.driver.driver.ExtinguishRequest.prototype.getAssetId = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.ExtinguishRequest} returns this
+ */
+proto.driver.driver.ExtinguishRequest.prototype.setAssetId = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 
 
 
@@ -763,7 +973,9 @@ proto.driver.driver.AssignAssetRequest.prototype.toObject = function(opt_include
  */
 proto.driver.driver.AssignAssetRequest.toObject = function(includeInstance, msg) {
   var f, obj = {
-    sessionId: jspb.Message.getFieldWithDefault(msg, 1, "")
+    sessionId: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    consent: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    consentSignature: jspb.Message.getFieldWithDefault(msg, 3, "")
   };
 
   if (includeInstance) {
@@ -804,6 +1016,14 @@ proto.driver.driver.AssignAssetRequest.deserializeBinaryFromReader = function(ms
       var value = /** @type {string} */ (reader.readString());
       msg.setSessionId(value);
       break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setConsent(value);
+      break;
+    case 3:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setConsentSignature(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -840,6 +1060,20 @@ proto.driver.driver.AssignAssetRequest.serializeBinaryToWriter = function(messag
       f
     );
   }
+  f = message.getConsent();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = message.getConsentSignature();
+  if (f.length > 0) {
+    writer.writeString(
+      3,
+      f
+    );
+  }
 };
 
 
@@ -861,4 +1095,40 @@ proto.driver.driver.AssignAssetRequest.prototype.setSessionId = function(value)
 };
 
 
+/**
+ * optional string consent = 2;
+ * @return {string}
+ */
+proto.driver.driver.AssignAssetRequest.prototype.getConsent = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.AssignAssetRequest} returns this
+ */
+proto.driver.driver.AssignAssetRequest.prototype.setConsent = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional string consent_signature = 3;
+ * @return {string}
+ */
+proto.driver.driver.AssignAssetRequest.prototype.getConsentSignature = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 3, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.driver.driver.AssignAssetRequest} returns this
+ */
+proto.driver.driver.AssignAssetRequest.prototype.setConsentSignature = function(value) {
+  return jspb.Message.setProto3StringField(this, 3, value);
+};
+
+
 goog.object.extend(exports, proto.driver.driver);
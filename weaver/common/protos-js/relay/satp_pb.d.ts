@@ -34,6 +34,8 @@ export class TransferProposalClaimsRequest extends jspb.Message {
     setSenderGatewayOwnerId(value: string): TransferProposalClaimsRequest;
     getReceiverGatewayOwnerId(): string;
     setReceiverGatewayOwnerId(value: string): TransferProposalClaimsRequest;
+    getTransferNonce(): string;
+    setTransferNonce(value: string): TransferProposalClaimsRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): TransferProposalClaimsRequest.AsObject;
@@ -60,6 +62,7 @@ export namespace TransferProposalClaimsRequest {
         serverIdentityPubkey: string,
         senderGatewayOwnerId: string,
         receiverGatewayOwnerId: string,
+        transferNonce: string,
     }
 }
 
@@ -90,6 +93,8 @@ export class TransferProposalReceiptRequest extends jspb.Message {
     setSenderGatewayOwnerId(value: string): TransferProposalReceiptRequest;
     getReceiverGatewayOwnerId(): string;
     setReceiverGatewayOwnerId(value: string): TransferProposalReceiptRequest;
+    getTransferNonce(): string;
+    setTransferNonce(value: string): TransferProposalReceiptRequest;
 
     serializeBinary(): Uint8Array;
     toObject(includeInstance?: boolean): TransferProposalReceiptRequest.AsObject;
@@ -116,6 +121,7 @@ export namespace TransferProposalReceiptRequest {
         serverIdentityPubkey: string,
         senderGatewayOwnerId: string,
         receiverGatewayOwnerId: string,
+        transferNonce: string,
     }
 }
 
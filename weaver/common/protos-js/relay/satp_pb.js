@@ -331,7 +331,8 @@ proto.relay.satp.TransferProposalClaimsRequest.toObject = function(includeInstan
     clientIdentityPubkey: jspb.Message.getFieldWithDefault(msg, 10, ""),
     serverIdentityPubkey: jspb.Message.getFieldWithDefault(msg, 11, ""),
     senderGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 12, ""),
-    receiverGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 13, "")
+    receiverGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 13, ""),
+    transferNonce: jspb.Message.getFieldWithDefault(msg, 14, "")
   };
 
   if (includeInstance) {
@@ -420,6 +421,10 @@ proto.relay.satp.TransferProposalClaimsRequest.deserializeBinaryFromReader = fun
       var value = /** @type {string} */ (reader.readString());
       msg.setReceiverGatewayOwnerId(value);
       break;
+    case 14:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTransferNonce(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -540,6 +545,13 @@ proto.relay.satp.TransferProposalClaimsRequest.serializeBinaryToWriter = functio
       f
     );
   }
+  f = message.getTransferNonce();
+  if (f.length > 0) {
+    writer.writeString(
+      14,
+      f
+    );
+  }
 };
 
 
@@ -777,6 +789,24 @@ proto.relay.satp.TransferProposalClaimsRequest.prototype.setReceiverGatewayOwner
 };
 
 
+/**
+ * optional string transfer_nonce = 14;
+ * @return {string}
+ */
+proto.relay.satp.TransferProposalClaimsRequest.prototype.getTransferNonce = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 14, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.relay.satp.TransferProposalClaimsRequest} returns this
+ */
+proto.relay.satp.TransferProposalClaimsRequest.prototype.setTransferNonce = function(value) {
+  return jspb.Message.setProto3StringField(this, 14, value);
+};
+
+
 
 
 
@@ -821,7 +851,8 @@ proto.relay.satp.TransferProposalReceiptRequest.toObject = function(includeInsta
     clientIdentityPubkey: jspb.Message.getFieldWithDefault(msg, 10, ""),
     serverIdentityPubkey: jspb.Message.getFieldWithDefault(msg, 11, ""),
     senderGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 12, ""),
-    receiverGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 13, "")
+    receiverGatewayOwnerId: jspb.Message.getFieldWithDefault(msg, 13, ""),
+    transferNonce: jspb.Message.getFieldWithDefault(msg, 14, "")
   };
 
   if (includeInstance) {
@@ -910,6 +941,10 @@ proto.relay.satp.TransferProposalReceiptRequest.deserializeBinaryFromReader = fu
       var value = /** @type {string} */ (reader.readString());
       msg.setReceiverGatewayOwnerId(value);
       break;
+    case 14:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setTransferNonce(value);
+      break;
     default:
       reader.skipField();
       break;
@@ -1030,6 +1065,13 @@ proto.relay.satp.TransferProposalReceiptRequest.serializeBinaryToWriter = functi
       f
     );
   }
+  f = message.getTransferNonce();
+  if (f.length > 0) {
+    writer.writeString(
+      14,
+      f
+    );
+  }
 };
 
 
@@ -1267,6 +1309,24 @@ proto.relay.satp.TransferProposalReceiptRequest.prototype.setReceiverGatewayOwne
 };
 
 
+/**
+ * optional string transfer_nonce = 14;
+ * @return {string}
+ */
+proto.relay.satp.TransferProposalReceiptRequest.prototype.getTransferNonce = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 14, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.relay.satp.TransferProposalReceiptRequest} returns this
+ */
+proto.relay.satp.TransferProposalReceiptRequest.prototype.setTransferNonce = function(value) {
+  return jspb.Message.setProto3StringField(this, 14, value);
+};
+
+
 
 
 
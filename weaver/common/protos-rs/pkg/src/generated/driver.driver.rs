@@ -13,24 +13,50 @@ pub struct WriteExternalStateMessage {
 pub struct PerformLockRequest {
     #[prost(string, tag = "1")]
     pub session_id: ::prost::alloc::string::String,
+    /// base64 encoded consent of the asset owner to the transfer, created and signed by the owner's application
+    #[prost(string, tag = "2")]
+    pub consent: ::prost::alloc::string::String,
+    /// base64 encoded signature of the consent, made with the key of the asset owner's ECert
+    #[prost(string, tag = "3")]
+    pub consent_signature: ::prost::alloc::string::String,
 }
 #[derive(serde::Serialize, serde::Deserialize)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct CreateAssetRequest {
     #[prost(string, tag = "1")]
     pub session_id: ::prost::alloc::string::String,
+    /// type and ID of the asset transferred in the session
+    #[prost(string, tag = "2")]
+    pub asset_type: ::prost::alloc::string::String,
+    #[prost(string, tag = "3")]
+    pub asset_id: ::prost::alloc::string::String,
+    /// base64 encoded ECert of the owner of the asset in the origin network, whose consent the assignment of the
+    /// asset requires
+    #[prost(string, tag = "4")]
+    pub original_owner: ::prost::alloc::string::String,
 }
 #[derive(serde::Serialize, serde::Deserialize)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct ExtinguishRequest {
     #[prost(string, tag = "1")]
     pub session_id: ::prost::alloc::string::String,
+    /// type and ID of the asset transferred in the session
+    #[prost(string, tag = "2")]
+    pub asset_type: ::prost::alloc::string::String,
+    #[prost(string, tag = "3")]
+    pub asset_id: ::prost::alloc::string::String,
 }
 #[derive(serde::Serialize, serde::Deserialize)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct AssignAssetRequest {
     #[prost(string, tag = "1")]
     pub session_id: ::prost::alloc::string::String,
+    /// base64 encoded consent of the asset owner to the transfer, created and signed by the owner's application
+    #[prost(string, tag = "2")]
+    pub consent: ::prost::alloc::string::String,
+    /// base64 encoded signature of the consent, made with the key of the asset owner's ECert
+    #[prost(string, tag = "3")]
+    pub consent_signature: ::prost::alloc::string::String,
 }
 /// Generated client implementations.
 pub mod driver_communication_client {
@@ -28,6 +28,9 @@ pub struct TransferProposalClaimsRequest {
     pub sender_gateway_owner_id: ::prost::alloc::string::String,
     #[prost(string, tag = "13")]
     pub receiver_gateway_owner_id: ::prost::alloc::string::String,
+    /// random value making the transfer context ID of each transfer unique, even for repeated transfers of an asset
+    #[prost(string, tag = "14")]
+    pub transfer_nonce: ::prost::alloc::string::String,
 }
 #[derive(serde::Serialize, serde::Deserialize)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -58,6 +61,9 @@ pub struct TransferProposalReceiptRequest {
     pub sender_gateway_owner_id: ::prost::alloc::string::String,
     #[prost(string, tag = "13")]
     pub receiver_gateway_owner_id: ::prost::alloc::string::String,
+    /// the nonce of the transfer proposal claims
+    #[prost(string, tag = "14")]
+    pub transfer_nonce: ::prost::alloc::string::String,
 }
 #[derive(serde::Serialize, serde::Deserialize)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// fabric connects the driver to a Fabric network through a peer's Gateway service
+package fabric
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperledger-cacti/cacti/weaver/core/drivers/fabric-driver-go/server"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Config struct {
+	// Endpoint of the peer whose Gateway service is used, e.g. "localhost:7051"
+	PeerEndpoint string
+	// PEM file of the CA certificate of the peer's TLS certificate; TLS is disabled if empty
+	PeerTLSCACertPath string
+	// Host name to verify the peer's TLS certificate against, if it differs from the endpoint's
+	PeerHostOverride string
+	// MSP ID, certificate and private key of the driver's identity
+	MspId          string
+	CertPath       string
+	PrivateKeyPath string
+	// Timeout of each call to the peer
+	Timeout time.Duration
+}
+
+// Gateway implements server.Network over a fabric-gateway client
+type Gateway struct {
+	connection  *grpc.ClientConn
+	gateway     *client.Gateway
+	certificate string
+	signer      crypto.Signer
+}
+
+func newGrpcConnection(config Config) (*grpc.ClientConn, error) {
+	if config.PeerTLSCACertPath == "" {
+		return grpc.Dial(config.PeerEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	tlsCACertPEM, err := os.ReadFile(config.PeerTLSCACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read peer TLS CA certificate: %v", err)
+	}
+	tlsCACert, err := identity.CertificateFromPEM(tlsCACertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse peer TLS CA certificate: %v", err)
+	}
+	certPool := x509.NewCertPool()
+	certPool.AddCert(tlsCACert)
+	transportCredentials := credentials.NewClientTLSFromCert(certPool, config.PeerHostOverride)
+	return grpc.Dial(config.PeerEndpoint, grpc.WithTransportCredentials(transportCredentials))
+}
+
+// NewGateway connects to the Gateway service of a peer with the driver's identity
+func NewGateway(config Config) (*Gateway, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	certificatePEM, err := os.ReadFile(config.CertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read driver certificate: %v", err)
+	}
+	certificate, err := identity.CertificateFromPEM(certificatePEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse driver certificate: %v", err)
+	}
+	id, err := identity.NewX509Identity(config.MspId, certificate)
+	if err != nil {
+		return nil, err
+	}
+	privateKeyPEM, err := os.ReadFile(config.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read driver private key: %v", err)
+	}
+	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse driver private key: %v", err)
+	}
+	sign, err := identity.NewPrivateKeySign(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("driver private key cannot be used for signing")
+	}
+
+	connection, err := newGrpcConnection(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to peer at %s: %v", config.PeerEndpoint, err)
+	}
+	gateway, err := client.Connect(
+		id,
+		client.WithSign(sign),
+		client.WithClientConnection(connection),
+		client.WithEvaluateTimeout(config.Timeout),
+		client.WithEndorseTimeout(config.Timeout),
+		client.WithSubmitTimeout(config.Timeout),
+		client.WithCommitStatusTimeout(2*config.Timeout),
+	)
+	if err != nil {
+		connection.Close()
+		return nil, err
+	}
+	return &Gateway{
+		connection:  connection,
+		gateway:     gateway,
+		certificate: string(certificatePEM),
+		signer:      signer,
+	}, nil
+}
+
+// Identity returns the PEM certificate and a signer of the driver's identity
+func (g *Gateway) Identity() (string, crypto.Signer) {
+	return g.certificate, g.signer
+}
+
+func (g *Gateway) Close() {
+	g.gateway.Close()
+	g.connection.Close()
+}
+
+func (g *Gateway) Endorse(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error) {
+	contract := g.gateway.GetNetwork(channel).GetContract(chaincodeId)
+	options := []client.ProposalOption{client.WithArguments(args...)}
+	if len(endorsingOrgs) > 0 {
+		options = append(options, client.WithEndorsingOrganizations(endorsingOrgs...))
+	}
+	proposal, err := contract.NewProposal(function, options...)
+	if err != nil {
+		return nil, err
+	}
+	// the endorsed transaction is not submitted; its endorsements are the proof sent to the remote network
+	transaction, err := proposal.Endorse()
+	if err != nil {
+		return nil, err
+	}
+	return transaction.Bytes()
+}
+
+func (g *Gateway) Submit(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error) {
+	contract := g.gateway.GetNetwork(channel).GetContract(chaincodeId)
+	options := []client.ProposalOption{client.WithArguments(args...)}
+	if len(endorsingOrgs) > 0 {
+		options = append(options, client.WithEndorsingOrganizations(endorsingOrgs...))
+	}
+	return contract.Submit(function, options...)
+}
+
+func (g *Gateway) ChaincodeEvents(ctx context.Context, channel, chaincodeId string) (<-chan *server.ChaincodeEvent, error) {
+	events, err := g.gateway.GetNetwork(channel).ChaincodeEvents(ctx, chaincodeId)
+	if err != nil {
+		return nil, err
+	}
+	chaincodeEvents := make(chan *server.ChaincodeEvent)
+	go func() {
+		defer close(chaincodeEvents)
+		for event := range events {
+			chaincodeEvents <- &server.ChaincodeEvent{
+				BlockNumber:   event.BlockNumber,
+				TransactionId: event.TransactionID,
+				ChaincodeId:   event.ChaincodeName,
+				EventName:     event.EventName,
+				Payload:       event.Payload,
+			}
+		}
+		log.Infof("event stream of chaincode %s on channel %s closed", chaincodeId, channel)
+	}()
+	return chaincodeEvents, nil
+}
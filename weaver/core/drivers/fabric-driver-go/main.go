@@ -0,0 +1,112 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Fabric driver: serves the DriverCommunication service to the local relay of a Fabric network
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/core/drivers/fabric-driver-go/fabric"
+	"github.com/hyperledger-cacti/cacti/weaver/core/drivers/fabric-driver-go/server"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func getEnv(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func relayDialOptions() []grpc.DialOption {
+	if os.Getenv("RELAY_TLS") != "true" {
+		return nil
+	}
+	certPool := x509.NewCertPool()
+	tlsCACertPEM, err := os.ReadFile(os.Getenv("RELAY_TLSCA_CERT_PATH"))
+	if err != nil {
+		log.Fatalf("failed to read relay TLS CA certificate: %v", err)
+	}
+	if !certPool.AppendCertsFromPEM(tlsCACertPEM) {
+		log.Fatalf("failed to parse relay TLS CA certificate")
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(certPool, ""))}
+}
+
+func driverServerOptions() []grpc.ServerOption {
+	if os.Getenv("DRIVER_TLS") != "true" {
+		return nil
+	}
+	certificate, err := tls.LoadX509KeyPair(os.Getenv("DRIVER_TLS_CERT_PATH"), os.Getenv("DRIVER_TLS_KEY_PATH"))
+	if err != nil {
+		log.Fatalf("failed to load driver TLS key pair: %v", err)
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewServerTLSFromCert(&certificate))}
+}
+
+func main() {
+	if os.Getenv("DEBUG") == "true" {
+		log.SetLevel(log.DebugLevel)
+	}
+	networkName := getEnv("NETWORK_NAME", "network1")
+	driverEndpoint := getEnv("DRIVER_ENDPOINT", "localhost:9090")
+
+	gateway, err := fabric.NewGateway(fabric.Config{
+		PeerEndpoint:      getEnv("PEER_ENDPOINT", "localhost:7051"),
+		PeerTLSCACertPath: os.Getenv("PEER_TLSCA_CERT_PATH"),
+		PeerHostOverride:  os.Getenv("PEER_HOST_OVERRIDE"),
+		MspId:             getEnv("MSP_ID", "Org1MSP"),
+		CertPath:          os.Getenv("DRIVER_CERT_PATH"),
+		PrivateKeyPath:    os.Getenv("DRIVER_KEY_PATH"),
+	})
+	if err != nil {
+		log.Fatalf("failed to connect to network %s: %v", networkName, err)
+	}
+	defer gateway.Close()
+
+	certificate, signer := gateway.Identity()
+	dbPath := getEnv("DB_PATH", "driverdbs")
+	err = os.MkdirAll(dbPath, 0700)
+	if err != nil {
+		log.Fatalf("failed to create directory %s: %v", dbPath, err)
+	}
+	fabricDriver, err := server.NewDriver(server.Config{
+		NetworkName:           networkName,
+		RelayEndpoint:         getEnv("RELAY_ENDPOINT", "localhost:9080"),
+		RelayDialOptions:      relayDialOptions(),
+		InteropChaincode:      getEnv("INTEROP_CHAINCODE", "interop"),
+		RecordAccessDecisions: os.Getenv("RECORD_ACCESS_DECISIONS") == "true",
+		Network:               gateway,
+		Certificate:           certificate,
+		Signer:                signer,
+		SubscriptionsPath:     filepath.Join(dbPath, networkName+"-subscriptions.json"),
+		SATPChannel:           getEnv("SATP_CHANNEL", "mychannel"),
+		SATPChaincode:         getEnv("SATP_CHAINCODE", "satpsimpleasset"),
+	})
+	if err != nil {
+		log.Fatalf("failed to create driver: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", driverEndpoint)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", driverEndpoint, err)
+	}
+	grpcServer := grpc.NewServer(driverServerOptions()...)
+	driver.RegisterDriverCommunicationServer(grpcServer, fabricDriver)
+	log.Infof("fabric driver for network %s listening on %s", networkName, driverEndpoint)
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		log.Fatalf("driver server failed: %v", err)
+	}
+}
@@ -0,0 +1,528 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// server implements the DriverCommunication service that a relay uses to reach a Fabric network
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultInteropChaincode = "interop"
+	relayCallTimeout        = 30 * time.Second
+	subscriptionExistsError = "Event subscription already exists with requestId: %s"
+)
+
+// helper functions to log and return errors
+func logThenErrorf(format string, args ...interface{}) error {
+	errorMsg := fmt.Sprintf(format, args...)
+	log.Error(errorMsg)
+	return errors.New(errorMsg)
+}
+
+type Config struct {
+	// Name of the network, used in logs
+	NetworkName string
+	// Endpoint of the local relay, to which query responses, events and subscription statuses are sent
+	RelayEndpoint string
+	// Options to connect to the relay with; an insecure connection is used if none are given
+	RelayDialOptions []grpc.DialOption
+	// ID of the interop chaincode; defaults to "interop"
+	InteropChaincode string
+	// Whether the access decision on each query is recorded on the ledger with RecordAccessDecision before the query is
+	// served; the interop chaincode only serves queries under rate-limited access control rules if it is
+	RecordAccessDecisions bool
+	// Connection to the Fabric network
+	Network Network
+	// PEM certificate and signer of the driver's identity, used to sign event subscription queries
+	Certificate string
+	Signer      crypto.Signer
+	// File in which event subscriptions are persisted; subscriptions are kept in memory only if empty
+	SubscriptionsPath string
+	// Channel and chaincode of the assets transferred with SATP; default to "mychannel" and "satpsimpleasset"
+	SATPChannel   string
+	SATPChaincode string
+}
+
+// an event listener on a chaincode, shared by all event matchers with the same channel and chaincode
+type eventListener struct {
+	cancel   context.CancelFunc
+	matchers int
+}
+
+type Driver struct {
+	driver.UnimplementedDriverCommunicationServer
+	config         Config
+	subscriptions  *subscriptionStore
+	listenersMutex sync.Mutex
+	listeners      map[string]*eventListener
+}
+
+// NewDriver creates a driver, and restarts the event listeners of the subscriptions persisted by a previous run
+func NewDriver(config Config) (*Driver, error) {
+	if config.Network == nil {
+		return nil, fmt.Errorf("no Fabric network connection provided to the driver")
+	}
+	if config.RelayEndpoint == "" {
+		return nil, fmt.Errorf("no relay endpoint provided to the driver")
+	}
+	if config.InteropChaincode == "" {
+		config.InteropChaincode = defaultInteropChaincode
+	}
+	if config.SATPChannel == "" {
+		config.SATPChannel = defaultSATPChannel
+	}
+	if config.SATPChaincode == "" {
+		config.SATPChaincode = defaultSATPChaincode
+	}
+	subscriptions, err := newSubscriptionStore(config.SubscriptionsPath)
+	if err != nil {
+		return nil, err
+	}
+	d := &Driver{
+		config:        config,
+		subscriptions: subscriptions,
+		listeners:     map[string]*eventListener{},
+	}
+	eventMatchers, err := subscriptions.eventMatchers()
+	if err != nil {
+		return nil, err
+	}
+	for _, eventMatcher := range eventMatchers {
+		err = d.registerListener(eventMatcher)
+		if err != nil {
+			log.Errorf("could not start event listener for channel %s and chaincode %s: %v",
+				eventMatcher.TransactionLedgerId, eventMatcher.TransactionContractId, err)
+		}
+	}
+	return d, nil
+}
+
+func (d *Driver) relayConnection() (*grpc.ClientConn, error) {
+	dialOptions := d.config.RelayDialOptions
+	if len(dialOptions) == 0 {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	conn, err := grpc.Dial(d.config.RelayEndpoint, dialOptions...)
+	if err != nil {
+		return nil, logThenErrorf("failed to connect to relay at %s: %v", d.config.RelayEndpoint, err)
+	}
+	return conn, nil
+}
+
+// sendDriverState sends the response to a query to the relay
+func (d *Driver) sendDriverState(viewPayload *common.ViewPayload) {
+	conn, err := d.relayConnection()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), relayCallTimeout)
+	defer cancel()
+	_, err = relay.NewDataTransferClient(conn).SendDriverState(ctx, viewPayload)
+	if err != nil {
+		log.Errorf("failed to send driver state of request %s to relay: %v", viewPayload.RequestId, err)
+	}
+}
+
+// publishDriverState sends the view of an event to the relay
+func (d *Driver) publishDriverState(viewPayload *common.ViewPayload) {
+	conn, err := d.relayConnection()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), relayCallTimeout)
+	defer cancel()
+	_, err = relay.NewEventPublishClient(conn).SendDriverState(ctx, viewPayload)
+	if err != nil {
+		log.Errorf("failed to publish event of subscription %s to relay: %v", viewPayload.RequestId, err)
+	}
+}
+
+// sendSubscriptionStatus sends the outcome of an event subscription request to the relay
+func (d *Driver) sendSubscriptionStatus(ack *common.Ack) {
+	log.Infof("sending to the relay the event subscription ack: %+v", ack)
+	conn, err := d.relayConnection()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), relayCallTimeout)
+	defer cancel()
+	_, err = relay.NewEventSubscribeClient(conn).SendDriverSubscriptionStatus(ctx, ack)
+	if err != nil {
+		log.Errorf("failed to send subscription status of request %s to relay: %v", ack.RequestId, err)
+	}
+}
+
+/**
+ * Run a query against the interop chaincode and collect the endorsements of the response into a view.
+ * HandleExternalRequest takes the serialized query only, while HandleEventRequest also takes the event payload.
+ **/
+func (d *Driver) getView(query *common.Query, function string, eventPayload []byte) (*common.ViewPayload, error) {
+	address, err := parseAddress(query.Address)
+	if err != nil {
+		return nil, err
+	}
+	queryBytes, err := proto.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query: %v", err)
+	}
+	args := []string{base64.StdEncoding.EncodeToString(queryBytes)}
+	if function != "HandleExternalRequest" {
+		args = append(args, string(eventPayload))
+	}
+	log.Infof("running %s on channel %s for query with request ID %s and policy %v", function, address.Channel, query.RequestId, query.Policy)
+	envelopeBytes, err := d.config.Network.Endorse(address.Channel, d.config.InteropChaincode, function, args, query.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s endorsed: %v", function, err)
+	}
+	fabricView, err := fabricViewFromEnvelope(envelopeBytes)
+	if err != nil {
+		return nil, err
+	}
+	return packageFabricView(query, fabricView)
+}
+
+// recordAccessDecision submits the access decision on a query to the ledger, counting the query against the rate
+// limits of the access control rules it falls under
+func (d *Driver) recordAccessDecision(query *common.Query) error {
+	address, err := parseAddress(query.Address)
+	if err != nil {
+		return err
+	}
+	queryBytes, err := proto.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("failed to marshal query: %v", err)
+	}
+	log.Infof("recording access decision on channel %s for query with request ID %s", address.Channel, query.RequestId)
+	decision, err := d.config.Network.Submit(address.Channel, d.config.InteropChaincode, "RecordAccessDecision", []string{base64.StdEncoding.EncodeToString(queryBytes)}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to record access decision: %v", err)
+	}
+	log.Debugf("recorded access decision for request %s: %s", query.RequestId, decision)
+	return nil
+}
+
+func (d *Driver) respondToQuery(query *common.Query) {
+	var viewPayload *common.ViewPayload
+	var err error
+	if d.config.RecordAccessDecisions {
+		err = d.recordAccessDecision(query)
+	}
+	if err == nil {
+		viewPayload, err = d.getView(query, "HandleExternalRequest", nil)
+	}
+	if err != nil {
+		log.Errorf("failed to get view for request %s: %v", query.RequestId, err)
+		viewPayload = errorViewPayload(query.RequestId, err)
+	}
+	d.sendDriverState(viewPayload)
+}
+
+// RequestDriverState acknowledges a query right away, and sends the view (or error) to the relay once the network responds
+func (d *Driver) RequestDriverState(ctx context.Context, query *common.Query) (*common.Ack, error) {
+	log.Infof("received query with request ID %s on network %s", query.RequestId, d.config.NetworkName)
+	go d.respondToQuery(query)
+	return &common.Ack{
+		Status:    common.Ack_OK,
+		RequestId: query.RequestId,
+	}, nil
+}
+
+func listenerKey(channel, chaincodeId string) string {
+	return channel + ":" + chaincodeId
+}
+
+// registerListener starts listening to the events of an event matcher's chaincode, unless a listener is already running
+func (d *Driver) registerListener(eventMatcher *common.EventMatcher) error {
+	d.listenersMutex.Lock()
+	defer d.listenersMutex.Unlock()
+	key := listenerKey(eventMatcher.TransactionLedgerId, eventMatcher.TransactionContractId)
+	if listener, exists := d.listeners[key]; exists {
+		listener.matchers++
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := d.config.Network.ChaincodeEvents(ctx, eventMatcher.TransactionLedgerId, eventMatcher.TransactionContractId)
+	if err != nil {
+		cancel()
+		return err
+	}
+	d.listeners[key] = &eventListener{cancel: cancel, matchers: 1}
+	go func() {
+		for event := range events {
+			d.handleEvent(eventMatcher.TransactionLedgerId, event)
+		}
+	}()
+	log.Infof("started event listener for chaincode %s on channel %s", eventMatcher.TransactionContractId, eventMatcher.TransactionLedgerId)
+	return nil
+}
+
+// unregisterListener stops listening to the events of an event matcher's chaincode once no event matcher needs them
+func (d *Driver) unregisterListener(eventMatcher *common.EventMatcher) bool {
+	d.listenersMutex.Lock()
+	defer d.listenersMutex.Unlock()
+	key := listenerKey(eventMatcher.TransactionLedgerId, eventMatcher.TransactionContractId)
+	listener, exists := d.listeners[key]
+	if !exists {
+		return false
+	}
+	listener.matchers--
+	if listener.matchers == 0 {
+		listener.cancel()
+		delete(d.listeners, key)
+		log.Infof("stopped event listener for chaincode %s on channel %s", eventMatcher.TransactionContractId, eventMatcher.TransactionLedgerId)
+	}
+	return true
+}
+
+// handleEvent generates a view of a chaincode event for each of the subscriptions to it, and publishes the views to the relay
+func (d *Driver) handleEvent(channel string, event *ChaincodeEvent) {
+	eventMatcher := &common.EventMatcher{
+		EventType:             common.EventType_LEDGER_STATE,
+		EventClassId:          event.EventName,
+		TransactionLedgerId:   channel,
+		TransactionContractId: event.ChaincodeId,
+		TransactionFunc:       "*",
+	}
+	queries, err := d.subscriptions.lookup(eventMatcher)
+	if err != nil {
+		log.Errorf("failed to look up subscriptions for event %s of chaincode %s: %v", event.EventName, event.ChaincodeId, err)
+		return
+	}
+	for _, query := range queries {
+		log.Infof("generating view of event %s in transaction %s for subscription %s", event.EventName, event.TransactionId, query.RequestId)
+		viewPayload, err := d.getView(query, "HandleEventRequest", event.Payload)
+		if err != nil {
+			log.Errorf("failed to get view of event %s for subscription %s: %v", event.EventName, query.RequestId, err)
+			continue
+		}
+		d.publishDriverState(viewPayload)
+	}
+}
+
+func (d *Driver) subscribe(eventSubscription *common.EventSubscription) {
+	newRequestId := eventSubscription.GetQuery().GetRequestId()
+	ack := &common.Ack{RequestId: newRequestId}
+	requestId, isNewMatcher, err := d.subscriptions.add(eventSubscription)
+	if err != nil {
+		ack.Status = common.Ack_ERROR
+		ack.Message = fmt.Sprintf("error (thrown as part of async processing while storing to DB during subscribeEvent): %v", err)
+		log.Error(ack.Message)
+		d.sendSubscriptionStatus(ack)
+		return
+	}
+	if requestId != newRequestId {
+		ack.Status = common.Ack_ERROR
+		ack.Message = fmt.Sprintf(subscriptionExistsError, requestId)
+		d.sendSubscriptionStatus(ack)
+		return
+	}
+	if isNewMatcher {
+		err = d.registerListener(eventSubscription.EventMatcher)
+		if err != nil {
+			// remove the subscription too, so that the store stays consistent with the listeners
+			_, _, deleteErr := d.subscriptions.delete(eventSubscription.EventMatcher, newRequestId)
+			if deleteErr != nil {
+				log.Error(deleteErr)
+			}
+			ack.Status = common.Ack_ERROR
+			ack.Message = fmt.Sprintf("Event subscription error: listener registration failed with error: %v", err)
+			log.Error(ack.Message)
+			d.sendSubscriptionStatus(ack)
+			return
+		}
+	}
+	ack.Status = common.Ack_OK
+	ack.Message = "Event subscription is successful!"
+	d.sendSubscriptionStatus(ack)
+}
+
+func (d *Driver) unsubscribe(eventSubscription *common.EventSubscription) {
+	newRequestId := eventSubscription.GetQuery().GetRequestId()
+	ack := &common.Ack{RequestId: newRequestId}
+	deletedSubscription, isLastSubscription, err := d.subscriptions.delete(eventSubscription.EventMatcher, newRequestId)
+	if err != nil {
+		ack.Status = common.Ack_ERROR
+		ack.Message = fmt.Sprintf("error (thrown as part of async processing while deleting from DB during unsubscribeEvent): %v", err)
+		log.Error(ack.Message)
+		d.sendSubscriptionStatus(ack)
+		return
+	}
+	if isLastSubscription && !d.unregisterListener(eventSubscription.EventMatcher) {
+		// Just log a warning. This is not critical.
+		log.Warn("No listener running for the given subscription or unable to stop listener")
+	}
+	ack.Status = common.Ack_OK
+	ack.Message = fmt.Sprintf("Event %v unsubscription is successful!", deletedSubscription.EventMatcher)
+	d.sendSubscriptionStatus(ack)
+}
+
+// SubscribeEvent acknowledges an event subscription request right away, and sends the outcome to the relay once processed
+func (d *Driver) SubscribeEvent(ctx context.Context, eventSubscription *common.EventSubscription) (*common.Ack, error) {
+	newRequestId := eventSubscription.GetQuery().GetRequestId()
+	log.Infof("received event subscription request with request ID %s", newRequestId)
+	if eventSubscription.GetEventMatcher() == nil || eventSubscription.GetQuery() == nil {
+		return &common.Ack{
+			Status:    common.Ack_ERROR,
+			RequestId: newRequestId,
+			Message:   "Error: event subscription must have an event matcher and a query",
+		}, nil
+	}
+	if eventSubscription.EventMatcher.EventClassId == "" {
+		// the driver listens to chaincode events; it does not inspect blocks for function invocations
+		return &common.Ack{
+			Status:    common.Ack_ERROR,
+			RequestId: newRequestId,
+			Message:   "Error: only subscriptions to named chaincode events are supported",
+		}, nil
+	}
+	switch eventSubscription.Operation {
+	case common.EventSubOperation_SUBSCRIBE:
+		go d.subscribe(eventSubscription)
+	case common.EventSubOperation_UNSUBSCRIBE:
+		go d.unsubscribe(eventSubscription)
+	default:
+		errorString := fmt.Sprintf("Error: subscribe operation %s not supported", eventSubscription.Operation)
+		log.Error(errorString)
+		go d.sendSubscriptionStatus(&common.Ack{
+			Status:    common.Ack_ERROR,
+			RequestId: newRequestId,
+			Message:   errorString,
+		})
+	}
+	return &common.Ack{
+		Status:    common.Ack_OK,
+		RequestId: newRequestId,
+		Message:   "Procesing addEventSubscription",
+	}, nil
+}
+
+/**
+ * RequestSignedEventSubscriptionQuery signs an event subscription query with the driver's identity.
+ * The signature is over the address and nonce of the query, as in any other query from a requestor.
+ * On failure, an empty query carrying the error in its signature field is returned.
+ **/
+func (d *Driver) RequestSignedEventSubscriptionQuery(ctx context.Context, eventSubscription *common.EventSubscription) (*common.Query, error) {
+	inputQuery := eventSubscription.GetQuery()
+	if d.config.Signer == nil || d.config.Certificate == "" {
+		errorString := "driver has no identity to sign event subscription queries with"
+		log.Error(errorString)
+		return &common.Query{RequestorSignature: errorString}, nil
+	}
+	hash := sha256.Sum256([]byte(inputQuery.GetAddress() + inputQuery.GetNonce()))
+	signature, err := d.config.Signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		errorString := fmt.Sprintf("signing query failed with error: %v", err)
+		log.Error(errorString)
+		return &common.Query{RequestorSignature: errorString}, nil
+	}
+	return &common.Query{
+		Policy:             inputQuery.GetPolicy(),
+		Address:            inputQuery.GetAddress(),
+		RequestingRelay:    inputQuery.GetRequestingRelay(),
+		RequestingNetwork:  inputQuery.GetRequestingNetwork(),
+		Certificate:        d.config.Certificate,
+		RequestorSignature: base64.StdEncoding.EncodeToString(signature),
+		Nonce:              inputQuery.GetNonce(),
+		RequestId:          inputQuery.GetRequestId(),
+		RequestingOrg:      inputQuery.GetRequestingOrg(),
+		Confidential:       inputQuery.GetConfidential(),
+	}, nil
+}
+
+/**
+ * Write the view of a remote event to the ledger, by submitting the transaction named in the publication spec
+ * through the interop chaincode's WriteExternalState, which verifies the view first.
+ **/
+func (d *Driver) writeExternalState(message *driver.WriteExternalStateMessage) error {
+	viewPayload := message.GetViewPayload()
+	ctx := message.GetCtx()
+	if viewPayload.GetError() != "" {
+		log.Errorf("error viewPayload.getError(): %s", viewPayload.GetError())
+		return fmt.Errorf("erroneous viewPayload identified in WriteExternalState processing")
+	}
+	if ctx == nil {
+		return fmt.Errorf("no contract transaction provided in WriteExternalState")
+	}
+	view := viewPayload.GetView()
+	viewAddress, err := getViewAddress(view)
+	if err != nil {
+		return err
+	}
+	viewBytes, err := proto.Marshal(view)
+	if err != nil {
+		return fmt.Errorf("failed to marshal view: %v", err)
+	}
+
+	ccArgs := []string{}
+	for _, arg := range ctx.Args {
+		ccArgs = append(ccArgs, string(arg))
+	}
+	ccArgsJSON, err := json.Marshal(ccArgs)
+	if err != nil {
+		return err
+	}
+	interopArgIndicesJSON, _ := json.Marshal([]uint64{ctx.ReplaceArgIndex})
+	addressesJSON, _ := json.Marshal([]string{viewAddress})
+	viewsJSON, _ := json.Marshal([]string{base64.StdEncoding.EncodeToString(viewBytes)})
+	viewContentsJSON, _ := json.Marshal([]string{""})
+
+	log.Infof("writing external state to contract: %s with function: %s, and args: %v on channel: %s", ctx.ContractId, ctx.Func, ccArgs, ctx.LedgerId)
+	_, err = d.config.Network.Submit(ctx.LedgerId, d.config.InteropChaincode, "WriteExternalState", []string{
+		ctx.ContractId,
+		ctx.LedgerId,
+		ctx.Func,
+		string(ccArgsJSON),
+		string(interopArgIndicesJSON),
+		string(addressesJSON),
+		string(viewsJSON),
+		string(viewContentsJSON),
+	}, ctx.Members)
+	if err != nil {
+		return fmt.Errorf("failed writing to the ledger with error: %v", err)
+	}
+	return nil
+}
+
+// WriteExternalState writes the view of a remote event to the ledger as specified in the event publication spec
+func (d *Driver) WriteExternalState(ctx context.Context, message *driver.WriteExternalStateMessage) (*common.Ack, error) {
+	requestId := message.GetViewPayload().GetRequestId()
+	err := d.writeExternalState(message)
+	if err != nil {
+		log.Error(err)
+		return &common.Ack{
+			Status:    common.Ack_ERROR,
+			RequestId: requestId,
+			Message:   err.Error(),
+		}, nil
+	}
+	return &common.Ack{
+		Status:    common.Ack_OK,
+		RequestId: requestId,
+		Message:   "Successfully written to the ledger",
+	}, nil
+}
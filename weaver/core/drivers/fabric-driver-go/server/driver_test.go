@@ -0,0 +1,598 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	protoV1 "github.com/golang/protobuf/proto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	fabcommon "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const testAddress = "localhost:9080/network1/mychannel:simplestate:Read:a"
+
+type networkCall struct {
+	channel       string
+	chaincodeId   string
+	function      string
+	args          []string
+	endorsingOrgs []string
+}
+
+// network mock which endorses every request with the same transaction envelope
+type networkMock struct {
+	mutex       sync.Mutex
+	envelope    []byte
+	err         error
+	endorsed    []networkCall
+	submitted   []networkCall
+	events      chan *ChaincodeEvent
+	listenerCtx context.Context
+}
+
+func (n *networkMock) Endorse(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.endorsed = append(n.endorsed, networkCall{channel, chaincodeId, function, args, endorsingOrgs})
+	return n.envelope, n.err
+}
+
+func (n *networkMock) Submit(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.submitted = append(n.submitted, networkCall{channel, chaincodeId, function, args, endorsingOrgs})
+	return nil, n.err
+}
+
+func (n *networkMock) ChaincodeEvents(ctx context.Context, channel, chaincodeId string) (<-chan *ChaincodeEvent, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.listenerCtx = ctx
+	events := make(chan *ChaincodeEvent)
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case event := <-n.events:
+				events <- event
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+func (n *networkMock) getEndorsed() []networkCall {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]networkCall{}, n.endorsed...)
+}
+
+func (n *networkMock) getSubmitted() []networkCall {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]networkCall{}, n.submitted...)
+}
+
+// relay mocks which forward what the driver sends them on channels
+type dataTransferMock struct {
+	relay.UnimplementedDataTransferServer
+	states chan *common.ViewPayload
+}
+
+func (r *dataTransferMock) SendDriverState(ctx context.Context, viewPayload *common.ViewPayload) (*common.Ack, error) {
+	r.states <- viewPayload
+	return &common.Ack{Status: common.Ack_OK, RequestId: viewPayload.RequestId}, nil
+}
+
+type eventSubscribeMock struct {
+	relay.UnimplementedEventSubscribeServer
+	statuses chan *common.Ack
+}
+
+func (r *eventSubscribeMock) SendDriverSubscriptionStatus(ctx context.Context, ack *common.Ack) (*common.Ack, error) {
+	r.statuses <- ack
+	return &common.Ack{Status: common.Ack_OK, RequestId: ack.RequestId}, nil
+}
+
+type eventPublishMock struct {
+	relay.UnimplementedEventPublishServer
+	states chan *common.ViewPayload
+}
+
+func (r *eventPublishMock) SendDriverState(ctx context.Context, viewPayload *common.ViewPayload) (*common.Ack, error) {
+	r.states <- viewPayload
+	return &common.Ack{Status: common.Ack_OK, RequestId: viewPayload.RequestId}, nil
+}
+
+type assetStatus struct {
+	request   *relay.SendAssetStatusRequest
+	signature string
+}
+
+type satpMock struct {
+	relay.UnimplementedSATPServer
+	statuses chan assetStatus
+}
+
+func (r *satpMock) SendAssetStatus(ctx context.Context, request *relay.SendAssetStatusRequest) (*common.Ack, error) {
+	status := assetStatus{request: request}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(satpSignatureMetadataKey)) > 0 {
+		status.signature = md.Get(satpSignatureMetadataKey)[0]
+	}
+	r.statuses <- status
+	return &common.Ack{Status: common.Ack_OK, RequestId: request.SessionId}, nil
+}
+
+type relayMock struct {
+	address      string
+	dataTransfer *dataTransferMock
+	subscribe    *eventSubscribeMock
+	publish      *eventPublishMock
+	satp         *satpMock
+}
+
+func startRelayMock(t *testing.T) *relayMock {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	r := &relayMock{
+		address:      listener.Addr().String(),
+		dataTransfer: &dataTransferMock{states: make(chan *common.ViewPayload, 10)},
+		subscribe:    &eventSubscribeMock{statuses: make(chan *common.Ack, 10)},
+		publish:      &eventPublishMock{states: make(chan *common.ViewPayload, 10)},
+		satp:         &satpMock{statuses: make(chan assetStatus, 10)},
+	}
+	grpcServer := grpc.NewServer()
+	relay.RegisterDataTransferServer(grpcServer, r.dataTransfer)
+	relay.RegisterEventSubscribeServer(grpcServer, r.subscribe)
+	relay.RegisterEventPublishServer(grpcServer, r.publish)
+	relay.RegisterSATPServer(grpcServer, r.satp)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return r
+}
+
+func receive[T any](t *testing.T, messages chan T) T {
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the driver to send a message to the relay")
+	}
+	var empty T
+	return empty
+}
+
+// build a transaction envelope like the one returned by the gateway for an endorsed proposal
+func createEndorsedEnvelope(t *testing.T, address string, responsePayload []byte, endorsers ...string) []byte {
+	interopPayloadBytes, err := proto.Marshal(&common.InteropPayload{Address: address, Payload: responsePayload})
+	require.NoError(t, err)
+	ccActionBytes, err := protoV1.Marshal(&peer.ChaincodeAction{
+		Response: &peer.Response{Status: 200, Payload: interopPayloadBytes},
+	})
+	require.NoError(t, err)
+	proposalResponsePayloadBytes, err := protoV1.Marshal(&peer.ProposalResponsePayload{
+		ProposalHash: []byte("proposal-hash"),
+		Extension:    ccActionBytes,
+	})
+	require.NoError(t, err)
+	endorsements := []*peer.Endorsement{}
+	for _, endorser := range endorsers {
+		endorsements = append(endorsements, &peer.Endorsement{Endorser: []byte(endorser), Signature: []byte(endorser + "-signature")})
+	}
+	ccActionPayloadBytes, err := protoV1.Marshal(&peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: proposalResponsePayloadBytes,
+			Endorsements:            endorsements,
+		},
+	})
+	require.NoError(t, err)
+	transactionBytes, err := protoV1.Marshal(&peer.Transaction{
+		Actions: []*peer.TransactionAction{{Payload: ccActionPayloadBytes}},
+	})
+	require.NoError(t, err)
+	payloadBytes, err := protoV1.Marshal(&fabcommon.Payload{Data: transactionBytes})
+	require.NoError(t, err)
+	envelopeBytes, err := protoV1.Marshal(&fabcommon.Envelope{Payload: payloadBytes})
+	require.NoError(t, err)
+	return envelopeBytes
+}
+
+func newTestDriver(t *testing.T, network *networkMock, relayAddress string, subscriptionsPath string) *Driver {
+	d, err := NewDriver(Config{
+		NetworkName:       "network1",
+		RelayEndpoint:     relayAddress,
+		Network:           network,
+		SubscriptionsPath: subscriptionsPath,
+	})
+	require.NoError(t, err)
+	return d
+}
+
+func getFabricView(t *testing.T, viewPayload *common.ViewPayload) *fabric.FabricView {
+	view := viewPayload.GetView()
+	require.NotNil(t, view)
+	require.Equal(t, common.Meta_FABRIC, view.Meta.Protocol)
+	require.Equal(t, "Notarization", view.Meta.ProofType)
+	fabricView := &fabric.FabricView{}
+	require.NoError(t, proto.Unmarshal(view.Data, fabricView))
+	return fabricView
+}
+
+func TestRequestDriverState(t *testing.T) {
+	relayMock := startRelayMock(t)
+	network := &networkMock{envelope: createEndorsedEnvelope(t, testAddress, []byte("1"), "Org1MSP", "Org2MSP")}
+	d := newTestDriver(t, network, relayMock.address, "")
+
+	query := &common.Query{
+		Address:   testAddress,
+		Policy:    []string{"Org1MSP", "Org2MSP"},
+		RequestId: "request-1",
+		Nonce:     "nonce",
+	}
+	ack, err := d.RequestDriverState(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_OK, ack.Status)
+	require.Equal(t, "request-1", ack.RequestId)
+
+	viewPayload := receive(t, relayMock.dataTransfer.states)
+	require.Equal(t, "request-1", viewPayload.RequestId)
+	fabricView := getFabricView(t, viewPayload)
+	require.Len(t, fabricView.EndorsedProposalResponses, 2)
+	for i, endorser := range []string{"Org1MSP", "Org2MSP"} {
+		response := fabricView.EndorsedProposalResponses[i]
+		require.Equal(t, []byte(endorser), response.Endorsement.Endorser)
+		require.Equal(t, []byte("proposal-hash"), response.Payload.ProposalHash)
+	}
+	address, err := getViewAddress(viewPayload.GetView())
+	require.NoError(t, err)
+	require.Equal(t, testAddress, address)
+
+	// the query is run through the interop chaincode on the channel of the address, endorsed by the policy's orgs
+	endorsed := network.getEndorsed()
+	require.Len(t, endorsed, 1)
+	require.Equal(t, "mychannel", endorsed[0].channel)
+	require.Equal(t, "interop", endorsed[0].chaincodeId)
+	require.Equal(t, "HandleExternalRequest", endorsed[0].function)
+	require.Equal(t, []string{"Org1MSP", "Org2MSP"}, endorsed[0].endorsingOrgs)
+	require.Len(t, endorsed[0].args, 1)
+	queryBytes, err := base64.StdEncoding.DecodeString(endorsed[0].args[0])
+	require.NoError(t, err)
+	sentQuery := &common.Query{}
+	require.NoError(t, proto.Unmarshal(queryBytes, sentQuery))
+	require.True(t, proto.Equal(query, sentQuery))
+}
+
+func TestRequestDriverStateRecordAccessDecision(t *testing.T) {
+	relayMock := startRelayMock(t)
+	network := &networkMock{envelope: createEndorsedEnvelope(t, testAddress, []byte("1"), "Org1MSP")}
+	d := newTestDriver(t, network, relayMock.address, "")
+	d.config.RecordAccessDecisions = true
+
+	query := &common.Query{Address: testAddress, Policy: []string{"Org1MSP"}, RequestId: "request-1", Nonce: "nonce"}
+	_, err := d.RequestDriverState(context.Background(), query)
+	require.NoError(t, err)
+	viewPayload := receive(t, relayMock.dataTransfer.states)
+	require.Len(t, getFabricView(t, viewPayload).EndorsedProposalResponses, 1)
+
+	// the access decision is recorded with the same query before the query is run
+	submitted := network.getSubmitted()
+	require.Len(t, submitted, 1)
+	require.Equal(t, "mychannel", submitted[0].channel)
+	require.Equal(t, "interop", submitted[0].chaincodeId)
+	require.Equal(t, "RecordAccessDecision", submitted[0].function)
+	endorsed := network.getEndorsed()
+	require.Len(t, endorsed, 1)
+	require.Equal(t, submitted[0].args, endorsed[0].args)
+
+	// queries are not run if their access decision cannot be recorded
+	network.err = errors.New("endorsement failure")
+	_, err = d.RequestDriverState(context.Background(), &common.Query{Address: testAddress, RequestId: "request-2"})
+	require.NoError(t, err)
+	viewPayload = receive(t, relayMock.dataTransfer.states)
+	require.Equal(t, "request-2", viewPayload.RequestId)
+	require.Contains(t, viewPayload.GetError(), "failed to record access decision: endorsement failure")
+	require.Len(t, network.getEndorsed(), 1)
+}
+
+func TestRequestDriverStateError(t *testing.T) {
+	relayMock := startRelayMock(t)
+	network := &networkMock{err: errors.New("access denied")}
+	d := newTestDriver(t, network, relayMock.address, "")
+
+	ack, err := d.RequestDriverState(context.Background(), &common.Query{Address: testAddress, RequestId: "request-1"})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_OK, ack.Status)
+	viewPayload := receive(t, relayMock.dataTransfer.states)
+	require.Equal(t, "request-1", viewPayload.RequestId)
+	require.Contains(t, viewPayload.GetError(), "access denied")
+
+	// malformed addresses and envelopes are reported as errors too
+	network.err = nil
+	network.envelope = []byte("not an envelope")
+	d.RequestDriverState(context.Background(), &common.Query{Address: "localhost:9080/network1", RequestId: "request-2"})
+	require.Contains(t, receive(t, relayMock.dataTransfer.states).GetError(), "invalid address string")
+	d.RequestDriverState(context.Background(), &common.Query{Address: testAddress, RequestId: "request-3"})
+	require.Contains(t, receive(t, relayMock.dataTransfer.states).GetError(), "failed to unmarshal transaction envelope")
+}
+
+func TestSubscribeEvent(t *testing.T) {
+	relayMock := startRelayMock(t)
+	network := &networkMock{
+		envelope: createEndorsedEnvelope(t, testAddress, []byte("event"), "Org1MSP"),
+		events:   make(chan *ChaincodeEvent),
+	}
+	subscriptionsPath := filepath.Join(t.TempDir(), "subscriptions.json")
+	d := newTestDriver(t, network, relayMock.address, subscriptionsPath)
+
+	eventMatcher := &common.EventMatcher{
+		EventType:             common.EventType_LEDGER_STATE,
+		EventClassId:          "Transfer",
+		TransactionLedgerId:   "mychannel",
+		TransactionContractId: "simplestate",
+		TransactionFunc:       "Transfer",
+	}
+	subscription := &common.EventSubscription{
+		EventMatcher: eventMatcher,
+		Query:        &common.Query{Address: testAddress, RequestId: "sub-1", Policy: []string{"Org1MSP"}},
+		Operation:    common.EventSubOperation_SUBSCRIBE,
+	}
+	ack, err := d.SubscribeEvent(context.Background(), subscription)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_OK, ack.Status)
+	status := receive(t, relayMock.subscribe.statuses)
+	require.Equal(t, common.Ack_OK, status.Status, status.Message)
+	require.Equal(t, "sub-1", status.RequestId)
+
+	// the same subscription under another request ID is rejected
+	duplicate := proto.Clone(subscription).(*common.EventSubscription)
+	duplicate.Query.RequestId = "sub-2"
+	d.SubscribeEvent(context.Background(), duplicate)
+	status = receive(t, relayMock.subscribe.statuses)
+	require.Equal(t, common.Ack_ERROR, status.Status)
+	require.Equal(t, "Event subscription already exists with requestId: sub-1", status.Message)
+
+	// subscriptions survive a driver restart
+	restarted := newTestDriver(t, &networkMock{}, relayMock.address, subscriptionsPath)
+	queries, err := restarted.subscriptions.lookup(&common.EventMatcher{
+		EventClassId: "Transfer", TransactionLedgerId: "mychannel", TransactionContractId: "simplestate", TransactionFunc: "*",
+	})
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+
+	// an event of the chaincode generates a view for the subscription
+	network.events <- &ChaincodeEvent{ChaincodeId: "simplestate", EventName: "Transfer", TransactionId: "tx1", Payload: []byte("payload")}
+	viewPayload := receive(t, relayMock.publish.states)
+	require.Equal(t, "sub-1", viewPayload.RequestId)
+	require.Len(t, getFabricView(t, viewPayload).EndorsedProposalResponses, 1)
+	endorsed := network.getEndorsed()
+	require.Equal(t, "HandleEventRequest", endorsed[len(endorsed)-1].function)
+	require.Equal(t, "payload", endorsed[len(endorsed)-1].args[1])
+
+	// unsubscribing the last subscription stops the listener
+	unsubscription := proto.Clone(subscription).(*common.EventSubscription)
+	unsubscription.Operation = common.EventSubOperation_UNSUBSCRIBE
+	d.SubscribeEvent(context.Background(), unsubscription)
+	status = receive(t, relayMock.subscribe.statuses)
+	require.Equal(t, common.Ack_OK, status.Status, status.Message)
+	require.Eventually(t, func() bool {
+		network.mutex.Lock()
+		defer network.mutex.Unlock()
+		return network.listenerCtx.Err() != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	d.SubscribeEvent(context.Background(), unsubscription)
+	status = receive(t, relayMock.subscribe.statuses)
+	require.Equal(t, common.Ack_ERROR, status.Status)
+	require.Contains(t, status.Message, "event subscription with requestId: sub-1 is not found!")
+
+	// subscriptions to unnamed events need block inspection, which the driver does not do
+	unnamed := proto.Clone(subscription).(*common.EventSubscription)
+	unnamed.EventMatcher.EventClassId = ""
+	ack, err = d.SubscribeEvent(context.Background(), unnamed)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.Status)
+}
+
+func TestRequestSignedEventSubscriptionQuery(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	d, err := NewDriver(Config{
+		RelayEndpoint: "localhost:9080",
+		Network:       &networkMock{},
+		Certificate:   "driver-certificate",
+		Signer:        key,
+	})
+	require.NoError(t, err)
+
+	query := &common.Query{Address: testAddress, Nonce: "nonce", RequestId: "sub-1", RequestingNetwork: "network2"}
+	signedQuery, err := d.RequestSignedEventSubscriptionQuery(context.Background(), &common.EventSubscription{Query: query})
+	require.NoError(t, err)
+	require.Equal(t, "driver-certificate", signedQuery.Certificate)
+	require.Equal(t, "sub-1", signedQuery.RequestId)
+	require.Equal(t, "network2", signedQuery.RequestingNetwork)
+	signature, err := base64.StdEncoding.DecodeString(signedQuery.RequestorSignature)
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte(testAddress + "nonce"))
+	require.True(t, ecdsa.VerifyASN1(&key.PublicKey, hash[:], signature))
+
+	// without an identity, the error is carried in the signature field
+	d.config.Signer = nil
+	signedQuery, err = d.RequestSignedEventSubscriptionQuery(context.Background(), &common.EventSubscription{Query: query})
+	require.NoError(t, err)
+	require.Empty(t, signedQuery.Address)
+	require.Contains(t, signedQuery.RequestorSignature, "no identity")
+}
+
+func TestWriteExternalState(t *testing.T) {
+	network := &networkMock{}
+	d := newTestDriver(t, network, "localhost:9080", "")
+
+	fabricView, err := fabricViewFromEnvelope(createEndorsedEnvelope(t, testAddress, []byte("1"), "Org1MSP"))
+	require.NoError(t, err)
+	viewPayload, err := packageFabricView(&common.Query{RequestId: "sub-1"}, fabricView)
+	require.NoError(t, err)
+	message := &driver.WriteExternalStateMessage{
+		ViewPayload: viewPayload,
+		Ctx: &common.ContractTransaction{
+			LedgerId:        "mychannel",
+			ContractId:      "simplestate",
+			Func:            "Create",
+			Args:            [][]byte{[]byte("key"), []byte("")},
+			ReplaceArgIndex: 1,
+			Members:         []string{"Org1MSP"},
+		},
+	}
+	ack, err := d.WriteExternalState(context.Background(), message)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_OK, ack.Status, ack.Message)
+	require.Equal(t, "sub-1", ack.RequestId)
+
+	require.Len(t, network.submitted, 1)
+	submitted := network.submitted[0]
+	require.Equal(t, "interop", submitted.chaincodeId)
+	require.Equal(t, "WriteExternalState", submitted.function)
+	require.Equal(t, []string{"Org1MSP"}, submitted.endorsingOrgs)
+	require.Equal(t, []string{"simplestate", "mychannel", "Create", `["key",""]`, "[1]"}, submitted.args[:5])
+	var addresses []string
+	require.NoError(t, json.Unmarshal([]byte(submitted.args[5]), &addresses))
+	require.Equal(t, []string{testAddress}, addresses)
+
+	// views carrying an error are not written
+	message.ViewPayload = errorViewPayload("sub-1", errors.New("remote failure"))
+	ack, err = d.WriteExternalState(context.Background(), message)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.Status)
+	require.Len(t, network.submitted, 1)
+}
+
+func TestSATPAssetOperations(t *testing.T) {
+	relayMock := startRelayMock(t)
+	network := &networkMock{}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	d, err := NewDriver(Config{
+		RelayEndpoint: relayMock.address,
+		Network:       network,
+		Certificate:   "driver-certificate",
+		Signer:        key,
+	})
+	require.NoError(t, err)
+
+	consentBytes, err := json.Marshal(map[string]string{"assetType": "bond01", "assetId": "a05", "recipient": "bob-ecert"})
+	require.NoError(t, err)
+	consent := base64.StdEncoding.EncodeToString(consentBytes)
+
+	// each operation is submitted to the asset chaincode, and its status is reported to the relay once committed
+	operations := []struct {
+		run      func() (*common.Ack, error)
+		function string
+		args     []string
+		status   string
+	}{
+		{
+			run: func() (*common.Ack, error) {
+				return d.PerformLock(context.Background(), &driver.PerformLockRequest{SessionId: "session-1", Consent: consent, ConsentSignature: "alice-signature"})
+			},
+			function: "LockAssetForSATP",
+			args:     []string{"bond01", "a05", "bob-ecert", consent, "alice-signature"},
+			status:   "Locked",
+		},
+		{
+			run: func() (*common.Ack, error) {
+				return d.CreateAsset(context.Background(), &driver.CreateAssetRequest{SessionId: "session-1", AssetType: "bond01", AssetId: "a05", OriginalOwner: "alice-ecert"})
+			},
+			function: "CreateAssetForSATP",
+			args:     []string{"bond01", "a05", "alice-ecert"},
+			status:   "Created",
+		},
+		{
+			run: func() (*common.Ack, error) {
+				return d.Extinguish(context.Background(), &driver.ExtinguishRequest{SessionId: "session-1", AssetType: "bond01", AssetId: "a05"})
+			},
+			function: "ExtinguishAssetForSATP",
+			args:     []string{"bond01", "a05"},
+			status:   "Extinguished",
+		},
+		{
+			run: func() (*common.Ack, error) {
+				return d.AssignAsset(context.Background(), &driver.AssignAssetRequest{SessionId: "session-1", Consent: consent, ConsentSignature: "alice-assignment-signature"})
+			},
+			function: "AssignAssetForSATP",
+			args:     []string{"bond01", "a05", "bob-ecert", consent, "alice-assignment-signature"},
+			status:   "Finalized",
+		},
+	}
+	for i, operation := range operations {
+		ack, err := operation.run()
+		require.NoError(t, err)
+		require.Equal(t, common.Ack_OK, ack.Status, ack.Message)
+		require.Equal(t, "session-1", ack.RequestId)
+
+		status := receive(t, relayMock.satp.statuses)
+		require.Equal(t, "session-1", status.request.SessionId)
+		require.Equal(t, operation.status, status.request.Status)
+		// the status report is signed with the driver's key
+		signature, err := base64.StdEncoding.DecodeString(status.signature)
+		require.NoError(t, err)
+		requestBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(status.request)
+		require.NoError(t, err)
+		hash := sha256.Sum256(requestBytes)
+		require.True(t, ecdsa.VerifyASN1(&key.PublicKey, hash[:], signature))
+
+		submitted := network.getSubmitted()
+		require.Len(t, submitted, i+1)
+		require.Equal(t, networkCall{"mychannel", "satpsimpleasset", operation.function, operation.args, nil}, submitted[i])
+	}
+
+	// requests without the asset details are rejected
+	ack, err := d.PerformLock(context.Background(), &driver.PerformLockRequest{SessionId: "session-2"})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.Status)
+	require.Contains(t, ack.Message, "consent not supplied")
+	ack, err = d.Extinguish(context.Background(), &driver.ExtinguishRequest{SessionId: "session-2"})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.Status)
+	ack, err = d.CreateAsset(context.Background(), &driver.CreateAssetRequest{SessionId: "session-2", AssetType: "bond01", AssetId: "a06"})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.Status)
+	require.Contains(t, ack.Message, "original owner of the asset needs to be supplied")
+
+	// no status is reported if the transaction fails
+	network.err = errors.New("endorsement failure")
+	ack, err = d.CreateAsset(context.Background(), &driver.CreateAssetRequest{SessionId: "session-2", AssetType: "bond01", AssetId: "a06", OriginalOwner: "alice-ecert"})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_OK, ack.Status)
+	require.Eventually(t, func() bool { return len(network.getSubmitted()) == len(operations)+1 }, 10*time.Second, 10*time.Millisecond)
+	select {
+	case status := <-relayMock.satp.statuses:
+		t.Fatalf("unexpected asset status %s reported for a failed transaction", status.request.Status)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+)
+
+// ChaincodeEvent is an event emitted by a chaincode in a committed transaction
+type ChaincodeEvent struct {
+	BlockNumber   uint64
+	TransactionId string
+	ChaincodeId   string
+	EventName     string
+	Payload       []byte
+}
+
+// Network is the driver's connection to the Fabric network it serves
+type Network interface {
+	// Endorse gets a chaincode transaction proposal endorsed without submitting it for ordering, and returns the
+	// serialized transaction envelope carrying the proposal response payload and the endorsements.
+	// If endorsingOrgs is not empty, only peers of these organizations (MSP IDs) are asked to endorse.
+	Endorse(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error)
+	// Submit runs a chaincode transaction through ordering and commit, and returns the result of the transaction
+	Submit(channel, chaincodeId, function string, args []string, endorsingOrgs []string) ([]byte, error)
+	// ChaincodeEvents streams the events emitted by a chaincode on a channel until the context is cancelled
+	ChaincodeEvents(ctx context.Context, channel, chaincodeId string) (<-chan *ChaincodeEvent, error)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultSATPChannel   = "mychannel"
+	defaultSATPChaincode = "satpsimpleasset"
+	// gRPC metadata key carrying the driver's signature of an asset status report, as checked by SATP gateways
+	satpSignatureMetadataKey = "satp-signature"
+)
+
+// asset statuses reported to the gateway once the requested operation is committed
+const (
+	assetStatusLocked       = "Locked"
+	assetStatusCreated      = "Created"
+	assetStatusExtinguished = "Extinguished"
+	assetStatusFinalized    = "Finalized"
+)
+
+// the parts of a SATP transfer consent (created and signed by the asset owner's application) used by the driver
+type satpTransferConsent struct {
+	AssetType string `json:"assetType"`
+	AssetId   string `json:"assetId"`
+	Recipient string `json:"recipient"`
+}
+
+func parseSATPTransferConsent(consentBase64 string) (*satpTransferConsent, error) {
+	if consentBase64 == "" {
+		return nil, fmt.Errorf("SATP transfer consent not supplied in the request")
+	}
+	consentBytes, err := base64.StdEncoding.DecodeString(consentBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SATP transfer consent: %v", err)
+	}
+	consent := &satpTransferConsent{}
+	err = json.Unmarshal(consentBytes, consent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SATP transfer consent: %v", err)
+	}
+	if consent.AssetType == "" || consent.AssetId == "" || consent.Recipient == "" {
+		return nil, fmt.Errorf("SATP transfer consent does not name the asset and its recipient")
+	}
+	return consent, nil
+}
+
+/**
+ * Sign an asset status report with the driver's identity. The signature is over the SHA-256 hash of the
+ * deterministically serialized report, and is carried in the request metadata.
+ **/
+func (d *Driver) signAssetStatus(ctx context.Context, request *relay.SendAssetStatusRequest) (context.Context, error) {
+	if d.config.Signer == nil {
+		return ctx, nil
+	}
+	requestBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(requestBytes)
+	signature, err := d.config.Signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, satpSignatureMetadataKey, base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+// sendAssetStatus reports the outcome of a SATP asset operation to the gateway (relay)
+func (d *Driver) sendAssetStatus(sessionId, status string) {
+	conn, err := d.relayConnection()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), relayCallTimeout)
+	defer cancel()
+	request := &relay.SendAssetStatusRequest{
+		SessionId: sessionId,
+		Status:    status,
+	}
+	ctx, err = d.signAssetStatus(ctx, request)
+	if err != nil {
+		log.Errorf("failed to sign asset status %s of session %s: %v", status, sessionId, err)
+		return
+	}
+	ack, err := relay.NewSATPClient(conn).SendAssetStatus(ctx, request)
+	if err != nil {
+		log.Errorf("failed to send asset status %s of session %s to relay: %v", status, sessionId, err)
+		return
+	}
+	if ack.GetStatus() == common.Ack_ERROR {
+		log.Errorf("asset status %s of session %s rejected by relay: %s", status, sessionId, ack.GetMessage())
+	}
+}
+
+/**
+ * Submit a SATP asset operation to the asset chaincode in the background, and report the status to the gateway
+ * once the transaction is committed. Nothing is reported if the transaction fails, so the session does not proceed.
+ **/
+func (d *Driver) submitAssetOperation(sessionId, function string, args []string, status string) *common.Ack {
+	go func() {
+		log.Infof("submitting %s on chaincode %s for SATP session %s", function, d.config.SATPChaincode, sessionId)
+		_, err := d.config.Network.Submit(d.config.SATPChannel, d.config.SATPChaincode, function, args, nil)
+		if err != nil {
+			log.Errorf("%s failed for SATP session %s: %v", function, sessionId, err)
+			return
+		}
+		d.sendAssetStatus(sessionId, status)
+	}()
+	return &common.Ack{
+		Status:    common.Ack_OK,
+		RequestId: sessionId,
+	}
+}
+
+func satpRequestError(sessionId string, err error) *common.Ack {
+	log.Error(err)
+	return &common.Ack{
+		Status:    common.Ack_ERROR,
+		RequestId: sessionId,
+		Message:   err.Error(),
+	}
+}
+
+// PerformLock hands over the asset named in the owner's consent to the gateway with LockAssetForSATP
+func (d *Driver) PerformLock(ctx context.Context, request *driver.PerformLockRequest) (*common.Ack, error) {
+	consent, err := parseSATPTransferConsent(request.Consent)
+	if err != nil {
+		return satpRequestError(request.SessionId, err), nil
+	}
+	return d.submitAssetOperation(request.SessionId, "LockAssetForSATP", []string{
+		consent.AssetType,
+		consent.AssetId,
+		consent.Recipient,
+		request.Consent,
+		request.ConsentSignature,
+	}, assetStatusLocked), nil
+}
+
+// CreateAsset creates the transferred asset in the recipient network with CreateAssetForSATP, held by the gateway
+// until its original owner consents to the assignment
+func (d *Driver) CreateAsset(ctx context.Context, request *driver.CreateAssetRequest) (*common.Ack, error) {
+	if request.AssetType == "" || request.AssetId == "" {
+		return satpRequestError(request.SessionId, fmt.Errorf("asset type and ID need to be supplied")), nil
+	}
+	if request.OriginalOwner == "" {
+		return satpRequestError(request.SessionId, fmt.Errorf("original owner of the asset needs to be supplied")), nil
+	}
+	return d.submitAssetOperation(request.SessionId, "CreateAssetForSATP", []string{
+		request.AssetType,
+		request.AssetId,
+		request.OriginalOwner,
+	}, assetStatusCreated), nil
+}
+
+// Extinguish deletes the asset locked by the gateway in the origin network with ExtinguishAssetForSATP
+func (d *Driver) Extinguish(ctx context.Context, request *driver.ExtinguishRequest) (*common.Ack, error) {
+	if request.AssetType == "" || request.AssetId == "" {
+		return satpRequestError(request.SessionId, fmt.Errorf("asset type and ID need to be supplied")), nil
+	}
+	return d.submitAssetOperation(request.SessionId, "ExtinguishAssetForSATP", []string{
+		request.AssetType,
+		request.AssetId,
+	}, assetStatusExtinguished), nil
+}
+
+// AssignAsset assigns the asset held by the gateway to the recipient named in the consent with AssignAssetForSATP
+func (d *Driver) AssignAsset(ctx context.Context, request *driver.AssignAssetRequest) (*common.Ack, error) {
+	consent, err := parseSATPTransferConsent(request.Consent)
+	if err != nil {
+		return satpRequestError(request.SessionId, err), nil
+	}
+	return d.submitAssetOperation(request.SessionId, "AssignAssetForSATP", []string{
+		consent.AssetType,
+		consent.AssetId,
+		consent.Recipient,
+		request.Consent,
+		request.ConsentSignature,
+	}, assetStatusFinalized), nil
+}
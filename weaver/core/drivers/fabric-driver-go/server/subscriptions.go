@@ -0,0 +1,235 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"google.golang.org/protobuf/proto"
+)
+
+// subscriptionStore keeps the event subscriptions served by the driver. Subscriptions are grouped by event matcher,
+// and both matchers and queries are kept as base64 encoded protobufs, the same layout the Node driver uses in its
+// database. If a file path is given, the store is persisted there so that subscriptions survive driver restarts.
+type subscriptionStore struct {
+	mutex         sync.Mutex
+	path          string
+	subscriptions map[string][]string
+}
+
+func newSubscriptionStore(path string) (*subscriptionStore, error) {
+	store := &subscriptionStore{
+		path:          path,
+		subscriptions: map[string][]string{},
+	}
+	if path == "" {
+		return store, nil
+	}
+	storeJSON, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read event subscriptions from %s: %v", path, err)
+	}
+	err = json.Unmarshal(storeJSON, &store.subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event subscriptions in %s: %v", path, err)
+	}
+	return store, nil
+}
+
+func (s *subscriptionStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	storeJSON, err := json.Marshal(s.subscriptions)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first so that a crash never leaves a partially written store behind
+	tmpPath := s.path + ".tmp"
+	err = os.WriteFile(tmpPath, storeJSON, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+func encodeProto(msg proto.Message) (string, error) {
+	msgBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(msgBytes), nil
+}
+
+func decodeQuery(queryBase64 string) (*common.Query, error) {
+	queryBytes, err := base64.StdEncoding.DecodeString(queryBase64)
+	if err != nil {
+		return nil, err
+	}
+	query := &common.Query{}
+	err = proto.Unmarshal(queryBytes, query)
+	if err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func decodeEventMatcher(eventMatcherBase64 string) (*common.EventMatcher, error) {
+	eventMatcherBytes, err := base64.StdEncoding.DecodeString(eventMatcherBase64)
+	if err != nil {
+		return nil, err
+	}
+	eventMatcher := &common.EventMatcher{}
+	err = proto.Unmarshal(eventMatcherBytes, eventMatcher)
+	if err != nil {
+		return nil, err
+	}
+	return eventMatcher, nil
+}
+
+// two subscription queries are the same if they only differ in their request ID, nonce and signature
+func isSameSubscriptionQuery(query1, query2 *common.Query) bool {
+	if len(query1.Policy) != len(query2.Policy) {
+		return false
+	}
+	for i := range query1.Policy {
+		if query1.Policy[i] != query2.Policy[i] {
+			return false
+		}
+	}
+	return query1.Address == query2.Address &&
+		query1.RequestingRelay == query2.RequestingRelay &&
+		query1.RequestingNetwork == query2.RequestingNetwork &&
+		query1.Certificate == query2.Certificate &&
+		query1.RequestingOrg == query2.RequestingOrg &&
+		query1.Confidential == query2.Confidential
+}
+
+// an event matcher emitted by the ledger matches a subscribed one if each field is either a wildcard or equal
+func isEventMatch(eventMatcher, subscribedMatcher *common.EventMatcher) bool {
+	return (eventMatcher.EventClassId == "*" || eventMatcher.EventClassId == subscribedMatcher.EventClassId) &&
+		(eventMatcher.TransactionContractId == "*" || eventMatcher.TransactionContractId == subscribedMatcher.TransactionContractId) &&
+		(eventMatcher.TransactionLedgerId == "*" || eventMatcher.TransactionLedgerId == subscribedMatcher.TransactionLedgerId) &&
+		(eventMatcher.TransactionFunc == "*" || strings.EqualFold(eventMatcher.TransactionFunc, subscribedMatcher.TransactionFunc))
+}
+
+/**
+ * Add an event subscription to the store.
+ * Returns the request ID of the subscription, which is that of an existing subscription if the same query
+ * was already subscribed to the same event, and whether the event matcher is new to the store.
+ **/
+func (s *subscriptionStore) add(eventSubscription *common.EventSubscription) (string, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key, err := encodeProto(eventSubscription.EventMatcher)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal event matcher: %v", err)
+	}
+	querySerialized, err := encodeProto(eventSubscription.Query)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to marshal query: %v", err)
+	}
+	subscriptions, exists := s.subscriptions[key]
+	for _, subscriptionSerialized := range subscriptions {
+		subscription, err := decodeQuery(subscriptionSerialized)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to decode stored subscription: %v", err)
+		}
+		if isSameSubscriptionQuery(subscription, eventSubscription.Query) {
+			return subscription.RequestId, false, nil
+		}
+	}
+	s.subscriptions[key] = append(subscriptions, querySerialized)
+	err = s.save()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to save event subscriptions: %v", err)
+	}
+	return eventSubscription.Query.RequestId, !exists, nil
+}
+
+/**
+ * Delete the subscription with a request ID from the subscriptions to an event.
+ * Returns the deleted subscription, and whether no subscriptions remain for the event matcher.
+ **/
+func (s *subscriptionStore) delete(eventMatcher *common.EventMatcher, requestId string) (*common.EventSubscription, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key, err := encodeProto(eventMatcher)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal event matcher: %v", err)
+	}
+	subscriptions := s.subscriptions[key]
+	for i, subscriptionSerialized := range subscriptions {
+		subscription, err := decodeQuery(subscriptionSerialized)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode stored subscription: %v", err)
+		}
+		if subscription.RequestId != requestId {
+			continue
+		}
+		subscriptions = append(subscriptions[:i:i], subscriptions[i+1:]...)
+		if len(subscriptions) == 0 {
+			delete(s.subscriptions, key)
+		} else {
+			s.subscriptions[key] = subscriptions
+		}
+		err = s.save()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to save event subscriptions: %v", err)
+		}
+		return &common.EventSubscription{EventMatcher: eventMatcher, Query: subscription}, len(subscriptions) == 0, nil
+	}
+	return nil, false, fmt.Errorf("event subscription with requestId: %s is not found!", requestId)
+}
+
+// lookup returns the queries of all subscriptions to events matching an event matcher
+func (s *subscriptionStore) lookup(eventMatcher *common.EventMatcher) ([]*common.Query, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	queries := []*common.Query{}
+	for key, subscriptions := range s.subscriptions {
+		subscribedMatcher, err := decodeEventMatcher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode stored event matcher: %v", err)
+		}
+		if !isEventMatch(eventMatcher, subscribedMatcher) {
+			continue
+		}
+		for _, subscriptionSerialized := range subscriptions {
+			query, err := decodeQuery(subscriptionSerialized)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode stored subscription: %v", err)
+			}
+			queries = append(queries, query)
+		}
+	}
+	return queries, nil
+}
+
+// eventMatchers returns all event matchers with at least one subscription
+func (s *subscriptionStore) eventMatchers() ([]*common.EventMatcher, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	eventMatchers := []*common.EventMatcher{}
+	for key := range s.subscriptions {
+		eventMatcher, err := decodeEventMatcher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode stored event matcher: %v", err)
+		}
+		eventMatchers = append(eventMatchers, eventMatcher)
+	}
+	return eventMatchers, nil
+}
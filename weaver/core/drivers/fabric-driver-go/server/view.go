@@ -0,0 +1,176 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/corda"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	fabcommon "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// Address of a view in the local network, e.g. "localhost:9080/network1/mychannel:simplestate:Read:a"
+type parsedAddress struct {
+	Channel  string
+	Contract string
+	CcFunc   string
+	Args     []string
+}
+
+func parseAddress(address string) (*parsedAddress, error) {
+	addressList := strings.Split(address, "/")
+	if len(addressList) != 3 {
+		return nil, fmt.Errorf("invalid address string %s", address)
+	}
+	fabricArgs := strings.Split(addressList[2], ":")
+	if len(fabricArgs) < 3 {
+		return nil, fmt.Errorf("invalid view segment in address %s", address)
+	}
+	return &parsedAddress{
+		Channel:  fabricArgs[0],
+		Contract: fabricArgs[1],
+		CcFunc:   fabricArgs[2],
+		Args:     fabricArgs[3:],
+	}, nil
+}
+
+/**
+ * Collect the proposal response payload and endorsements of an endorsed transaction into a FabricView.
+ * All peers endorse the same proposal response payload, so every endorsed response in the view carries
+ * that payload along with one of the endorsements.
+ **/
+func fabricViewFromEnvelope(envelopeBytes []byte) (*fabric.FabricView, error) {
+	envelope := &fabcommon.Envelope{}
+	err := proto.Unmarshal(envelopeBytes, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction envelope: %v", err)
+	}
+	payload := &fabcommon.Payload{}
+	err = proto.Unmarshal(envelope.Payload, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction payload: %v", err)
+	}
+	transaction := &peer.Transaction{}
+	err = proto.Unmarshal(payload.Data, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction: %v", err)
+	}
+	if len(transaction.Actions) == 0 {
+		return nil, fmt.Errorf("endorsed transaction has no actions")
+	}
+	ccActionPayload := &peer.ChaincodeActionPayload{}
+	err = proto.Unmarshal(transaction.Actions[0].Payload, ccActionPayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chaincode action payload: %v", err)
+	}
+	if ccActionPayload.Action == nil || len(ccActionPayload.Action.Endorsements) == 0 {
+		return nil, fmt.Errorf("endorsed transaction has no endorsements")
+	}
+	proposalResponsePayload := &peer.ProposalResponsePayload{}
+	err = proto.Unmarshal(ccActionPayload.Action.ProposalResponsePayload, proposalResponsePayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal proposal response payload: %v", err)
+	}
+
+	fabricView := &fabric.FabricView{}
+	for _, endorsement := range ccActionPayload.Action.Endorsements {
+		fabricView.EndorsedProposalResponses = append(fabricView.EndorsedProposalResponses, &fabric.FabricView_EndorsedProposalResponse{
+			Payload:     proposalResponsePayload,
+			Endorsement: endorsement,
+		})
+	}
+	return fabricView, nil
+}
+
+// packageFabricView wraps a FabricView in the view payload that is sent to the relay in response to a query
+func packageFabricView(query *common.Query, fabricView *fabric.FabricView) (*common.ViewPayload, error) {
+	viewData, err := protoV2.Marshal(fabricView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fabric view: %v", err)
+	}
+	return &common.ViewPayload{
+		RequestId: query.GetRequestId(),
+		State: &common.ViewPayload_View{
+			View: &common.View{
+				Meta: &common.Meta{
+					Protocol:            common.Meta_FABRIC,
+					Timestamp:           time.Now().UTC().Format(time.RFC3339Nano),
+					ProofType:           "Notarization",
+					SerializationFormat: "STRING",
+				},
+				Data: viewData,
+			},
+		},
+	}, nil
+}
+
+func errorViewPayload(requestId string, err error) *common.ViewPayload {
+	return &common.ViewPayload{
+		RequestId: requestId,
+		State:     &common.ViewPayload_Error{Error: fmt.Sprintf("Error: %s", err.Error())},
+	}
+}
+
+// getViewAddress returns the address of the query a remote view was generated for
+func getViewAddress(view *common.View) (string, error) {
+	var interopPayloadBytes [][]byte
+	switch view.GetMeta().GetProtocol() {
+	case common.Meta_FABRIC:
+		fabricView := &fabric.FabricView{}
+		err := protoV2.Unmarshal(view.Data, fabricView)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal fabric view: %v", err)
+		}
+		for _, response := range fabricView.EndorsedProposalResponses {
+			ccAction := &peer.ChaincodeAction{}
+			err = proto.Unmarshal(response.GetPayload().GetExtension(), ccAction)
+			if err != nil {
+				return "", fmt.Errorf("failed to unmarshal chaincode action: %v", err)
+			}
+			interopPayloadBytes = append(interopPayloadBytes, ccAction.GetResponse().GetPayload())
+		}
+	case common.Meta_CORDA:
+		cordaView := &corda.ViewData{}
+		err := protoV2.Unmarshal(view.Data, cordaView)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal corda view: %v", err)
+		}
+		for _, notarizedPayload := range cordaView.NotarizedPayloads {
+			interopPayloadBytes = append(interopPayloadBytes, notarizedPayload.Payload)
+		}
+	default:
+		return "", fmt.Errorf("cannot extract data from view; unsupported DLT type: %s", view.GetMeta().GetProtocol())
+	}
+	if len(interopPayloadBytes) == 0 {
+		return "", fmt.Errorf("view has no payloads")
+	}
+
+	var viewAddress string
+	for i, payloadBytes := range interopPayloadBytes {
+		interopPayload := &common.InteropPayload{}
+		err := protoV2.Unmarshal(payloadBytes, interopPayload)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal interop payload: %v", err)
+		}
+		if interopPayload.Confidential {
+			return "", fmt.Errorf("encrypted view payloads are not supported by this driver")
+		}
+		if i == 0 {
+			viewAddress = interopPayload.Address
+		} else if viewAddress != interopPayload.Address {
+			return "", fmt.Errorf("view addresses mismatch: 0 - %s, %d - %s", viewAddress, i, interopPayload.Address)
+		}
+	}
+	return viewAddress, nil
+}
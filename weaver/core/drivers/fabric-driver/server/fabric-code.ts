@@ -219,6 +219,37 @@ async function invoke(
   }
 }
 
+// Record the access decision on a query on the ledger, counting the query against the rate limits of the access
+// control rules it falls under. The interop chaincode only serves queries under rate-limited rules once recorded.
+async function recordAccessDecision(
+  query: query_pb.Query,
+  networkName: string,
+): Promise<string> {
+  const gateway = await getNetworkGateway(networkName);
+  try {
+    const parsedAddress = parseAddress(query.getAddress());
+    const network = await gateway.getNetwork(parsedAddress.channel);
+    const chaincodeId = process.env.INTEROP_CHAINCODE
+      ? process.env.INTEROP_CHAINCODE
+      : "interop";
+    const b64QueryBytes = Buffer.from(query.serializeBinary()).toString(
+      "base64",
+    );
+    const decision = await network
+      .getContract(chaincodeId)
+      .submitTransaction("RecordAccessDecision", b64QueryBytes);
+    logger.debug(
+      `Recorded access decision for request ${query.getRequestId()}: ${decision.toString()}`,
+    );
+    return decision.toString();
+  } catch (error) {
+    logger.error(`Failed to record access decision: ${error}`);
+    throw error;
+  } finally {
+    gateway.disconnect();
+  }
+}
+
 // Package view and send to relay
 function packageFabricView(
   query: query_pb.Query,
@@ -238,4 +269,9 @@ function packageFabricView(
   return viewPayload;
 }
 
-export { getNetworkGateway, invoke, packageFabricView };
+export {
+  getNetworkGateway,
+  invoke,
+  packageFabricView,
+  recordAccessDecision,
+};
@@ -4,7 +4,6 @@
  * SPDX-License-Identifier: Apache-2.0
  */
 
-import * as path from "path";
 import satp_pb from "@hyperledger-cacti/cacti-weaver-protos-js/relay/satp_pb";
 import satp_grpc_pb from "@hyperledger-cacti/cacti-weaver-protos-js/relay/satp_grpc_pb";
 import driverPb from "@hyperledger-cacti/cacti-weaver-protos-js/driver/driver_pb";
@@ -12,7 +11,7 @@ import logger from "./logger";
 import { credentials } from "@grpc/grpc-js";
 import { SatpAssetManager } from "@hyperledger-cacti/cacti-weaver-sdk-fabric";
 import fs from "fs";
-import { Gateway, Network, Wallets, X509Identity } from "fabric-network";
+import { Gateway, Network } from "fabric-network";
 import { getNetworkGateway } from "./fabric-code";
 import { getDriverKeyCert } from "./walletSetup";
 
@@ -25,8 +24,6 @@ async function performLockHelper(
   performLockRequest2["target-network"] = "network1";
   performLockRequest2["timeout-duration"] = parseInt("3600");
   performLockRequest2["locker"] = "alice";
-  performLockRequest2["recipient"] = "bob";
-  performLockRequest2["lockerWalletPath"] = "../wallet-network1/alice.id";
   // HACK below; reading from temp file created by the relay SATP client; ideally, we shoudld get this info from the function parameter
   performLockRequest2["param"] = fs.existsSync("satp_info.txt")
     ? fs.readFileSync("satp_info.txt").toString()
@@ -37,7 +34,6 @@ async function performLockHelper(
   performLockRequest2["channel"] = "mychannel";
   performLockRequest2["chaincode-id"] = "satpsimpleasset";
 
-  const recipient = performLockRequest2["recipient"];
   const channel = performLockRequest2["channel"];
   const chaincodeId = performLockRequest2["chaincode-id"];
 
@@ -48,13 +44,20 @@ async function performLockHelper(
   const params = performLockRequest2["param"].split(":");
 
   try {
+    // The locker's consent to this gateway taking custody of the asset, created and signed by the
+    // locker's application, comes with the request and names the recipient of the asset
+    const consent = performLockRequest.getConsent();
+    const recipientCert = getConsentRecipient(consent);
     logger.info(
-      `Trying to lock asset <${params[0]}, ${params[1]}> for SATP to ${recipient}`,
+      `Trying to lock asset <${params[0]}, ${params[1]}> for SATP to the recipient in the locker's consent`,
     );
     const res = await SatpAssetManager.lockAsset(
       contract,
       params[0],
       params[1],
+      recipientCert,
+      consent,
+      performLockRequest.getConsentSignature(),
     );
     if (!res) {
       throw new Error();
@@ -250,7 +253,6 @@ async function assignAssetHelper(
   assignAssetRequest2["target-network"] = "network2";
   assignAssetRequest2["timeout-duration"] = parseInt("3600");
   assignAssetRequest2["locker"] = "admin";
-  assignAssetRequest2["recipient"] = "bob";
   // HACK below; reading from temp file created by the relay SATP client; ideally, we shoudld get this info from the function parameter
   const asset_info = fs.existsSync("satp_info.txt")
     ? fs.readFileSync("satp_info.txt").toString()
@@ -265,7 +267,6 @@ async function assignAssetHelper(
   assignAssetRequest2["channel"] = "mychannel";
   assignAssetRequest2["chaincode-id"] = "satpsimpleasset";
 
-  const recipient = assignAssetRequest2["recipient"];
   const channel = assignAssetRequest2["channel"];
   const chaincodeId = assignAssetRequest2["chaincode-id"];
 
@@ -274,40 +275,30 @@ async function assignAssetHelper(
   const contract = network.getContract(chaincodeId);
 
   const params = assignAssetRequest2["param"].split(":");
-  // TODO: read the recipient's ID and certificate from service parameters
-  //       instead of reading a manually copied wallet ID from the local filesystem
-  const walletPath = process.env.WALLET_PATH
-    ? process.env.WALLET_PATH
-    : path.join(
-        process.cwd(),
-        `wallet-${process.env.NETWORK_NAME ? process.env.NETWORK_NAME : "network1"}`,
-      );
-  const wallet = await Wallets.newFileSystemWallet(walletPath);
-  const recipientId = await wallet.get(recipient);
-  const recipientCert = Buffer.from(
-    (recipientId as X509Identity).credentials.certificate,
-  ).toString("base64");
 
   try {
+    // The consent of the asset's original owner to its assignment comes with the request and names the recipient
+    const consent = assignAssetRequest.getConsent();
+    const recipientCert = getConsentRecipient(consent);
     logger.info(
-      `Trying to assign asset <${params[0]}, ${params[1]}> from SATP to ${recipient}`,
+      `Trying to assign asset <${params[0]}, ${params[1]}> from SATP to the recipient in the consent`,
     );
     const res = await SatpAssetManager.assignAsset(
       contract,
       params[0],
       params[1],
       recipientCert,
+      consent,
+      assignAssetRequest.getConsentSignature(),
     );
     if (!res) {
       throw new Error();
     }
     logger.info(
-      `Asset <${params[0]}, ${params[1]}> assigned successfully successfully by gateway to ${recipient}`,
+      `Asset <${params[0]}, ${params[1]}> assigned successfully by gateway`,
     );
   } catch (error) {
-    logger.error(
-      `Could not assign asset <${params[0]}, ${params[1]}> to ${recipient}`,
-    );
+    logger.error(`Could not assign asset <${params[0]}, ${params[1]}>`);
   } finally {
     if (gateway) {
       await gateway.disconnect();
@@ -322,6 +313,20 @@ async function assignAssetHelper(
   client.sendAssetStatus(request, relayCallback);
 }
 
+// get the recipient named in a base64 encoded SATP transfer consent
+function getConsentRecipient(consent: string): string {
+  if (!consent) {
+    throw new Error("SATP transfer consent not supplied in the request");
+  }
+  const recipient = JSON.parse(Buffer.from(consent, "base64").toString())[
+    "recipient"
+  ];
+  if (!recipient) {
+    throw new Error("SATP transfer consent does not name a recipient");
+  }
+  return recipient;
+}
+
 function getRelayClientForAssetStatusResponse() {
   let client: satp_grpc_pb.SATPClient;
   if (process.env.RELAY_TLS === "true") {
@@ -13,7 +13,11 @@ import eventsPb from "@hyperledger-cacti/cacti-weaver-protos-js/common/events_pb
 import driver_pb_grpc from "@hyperledger-cacti/cacti-weaver-protos-js/driver/driver_grpc_pb";
 import datatransfer_grpc_pb from "@hyperledger-cacti/cacti-weaver-protos-js/relay/datatransfer_grpc_pb";
 import state_pb from "@hyperledger-cacti/cacti-weaver-protos-js/common/state_pb";
-import { invoke, packageFabricView } from "./fabric-code";
+import {
+  invoke,
+  packageFabricView,
+  recordAccessDecision,
+} from "./fabric-code";
 import "dotenv/config";
 import {
   loadEventSubscriptionsFromStorage,
@@ -102,10 +106,20 @@ const fabricCommunication = async (
   if (!process.env.RELAY_ENDPOINT) {
     throw new Error("RELAY_ENDPOINT is not set.");
   }
+  // Records the access decision first if queries under rate-limited rules are to be served
+  let invokeError: Error | undefined;
+  if (process.env.RECORD_ACCESS_DECISIONS === "true") {
+    [, invokeError] = await handlePromise(
+      recordAccessDecision(query, networkName),
+    );
+  }
   // Invokes the fabric network
-  const [result, invokeError] = await handlePromise(
-    invoke(query, networkName, "HandleExternalRequest"),
-  );
+  let result;
+  if (!invokeError) {
+    [result, invokeError] = await handlePromise(
+      invoke(query, networkName, "HandleExternalRequest"),
+    );
+  }
   const client = getRelayClientForQueryResponse();
   if (invokeError) {
     logger.error("Invoke Error");
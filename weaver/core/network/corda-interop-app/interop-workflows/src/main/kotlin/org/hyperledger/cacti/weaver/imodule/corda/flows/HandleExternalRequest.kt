@@ -84,6 +84,7 @@ class HandleExternalRequest(val query: QueryOuterClass.Query) : FlowLogic<Either
             val interopPayload = InteropPayloadOuterClass.InteropPayload.newBuilder()
                     .setAddress(query.address)
                     .setPayload(ByteString.copyFrom(flowResult))
+                    .setTimestamp(Instant.now().epochSecond)
                     .build()
             // 7. Assemble the view from the result returned from the flow
             subFlow(CreateNodeSignatureFlow(interopPayload.toByteArray())).flatMap { signature ->
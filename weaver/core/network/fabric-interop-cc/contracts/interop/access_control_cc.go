@@ -9,17 +9,47 @@
 package main
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	log "github.com/sirupsen/logrus"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+	protoV2 "google.golang.org/protobuf/proto"
 )
 
-const accessControlObjectType = "accessControl"
+const (
+	accessControlObjectType  = "accessControl"
+	accessDecisionObjectType = "accessDecision"
+	rateLimitUsageObjectType = "rateLimitUsage"
+)
+
+// noPermittingRule is the reason for denying a request that no rule applies to
+const noPermittingRule = "no rule permits the request"
+
+// accessDecisionValidity is how long after it is recorded a decision permits serving a request under a rate-limited rule
+const accessDecisionValidity = 60 * time.Second
+
+// rateLimitMode is how an access check treats the rate limits of the rules it applies
+type rateLimitMode int
+
+const (
+	// requests under a rate-limited rule need a recent decision recorded with RecordAccessDecision
+	requireRecordedDecision rateLimitMode = iota
+	// requests under a rate-limited rule are counted against its limit
+	countRequest
+	// requests under a rate-limited rule are not limited, as for the events pushed to subscribers
+	skipRateLimit
+)
 
 // CreateAccessControlPolicy cc is used to store a AccessControlPolicy in the ledger
 func (s *SmartContract) CreateAccessControlPolicy(ctx contractapi.TransactionContextInterface, accessControlPolicyJSON string) error {
@@ -138,40 +168,362 @@ func (s *SmartContract) DeleteAccessControlPolicy(ctx contractapi.TransactionCon
 	return nil
 }
 
-// verifyAccessToCC looks up the Access Control State for the external network
-// and verifies that the requester has the required permission to call the specified CC function.
-func verifyAccessToCC(s *SmartContract, ctx contractapi.TransactionContextInterface, viewAddress *FabricViewAddress, viewAddressString string, query *common.Query) error {
+// accessDecision is the outcome of an access control check on a request from a remote network
+type accessDecision struct {
+	TxId              string `json:"txId"`
+	Timestamp         int64  `json:"timestamp"`
+	RequestingNetwork string `json:"requestingNetwork"`
+	RequestingOrg     string `json:"requestingOrg"`
+	Requester         string `json:"requester"`
+	Address           string `json:"address"`
+	Nonce             string `json:"nonce"`
+	Permitted         bool   `json:"permitted"`
+	Rule              string `json:"rule,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+// rateLimitUsage counts the requests made by a requester under a rate-limited rule in the current window
+type rateLimitUsage struct {
+	WindowStart int64  `json:"windowStart"`
+	Requests    uint64 `json:"requests"`
+}
+
+// RecordAccessDecision cc is submitted by the relay driver to record, before serving it, the access control decision on
+// a request (a serialized and base64-encoded Query) from a remote network. Requests from remote networks are served by
+// HandleExternalRequest in transactions that are evaluated but never submitted for ordering, so decisions and the
+// request counts of rate-limited rules can only be kept on the ledger through this separate transaction. Requests
+// under rate-limited rules are only served if their decision was recorded, and denied decisions are recorded too.
+// It returns the decision as JSON.
+func (s *SmartContract) RecordAccessDecision(ctx contractapi.TransactionContextInterface, b64QueryBytes string) (string, error) {
+	queryBytes, err := base64.StdEncoding.DecodeString(b64QueryBytes)
+	if err != nil {
+		return "", logThenErrorf("Unable to base64 decode data: %s", err.Error())
+	}
+	var query common.Query
+	err = protoV2.Unmarshal(queryBytes, &query)
+	if err != nil {
+		return "", logThenErrorf("Unable to unmarshal query: %s", err.Error())
+	}
+	request, err := authenticateRequest(s, ctx, &query, query.Address)
+	if err != nil {
+		return "", err
+	}
+	decision, err := decideAccess(s, ctx, request.viewAddress, request.address.ViewSegment, &query, countRequest)
+	if err != nil {
+		return "", err
+	}
+	decisionKey, err := ctx.GetStub().CreateCompositeKey(accessDecisionObjectType, []string{decision.RequestingNetwork, decision.Nonce})
+	if err != nil {
+		return "", err
+	}
+	recordedDecisionBytes, err := ctx.GetStub().GetState(decisionKey)
+	if err != nil {
+		return "", err
+	}
+	if recordedDecisionBytes != nil {
+		return "", logThenErrorf("Access decision on the request with nonce %s from %s is already recorded", decision.Nonce, decision.RequestingNetwork)
+	}
+	decisionBytes, err := json.Marshal(decision)
+	if err != nil {
+		return "", logThenErrorf("Marshal error: %s", err)
+	}
+	err = ctx.GetStub().PutState(decisionKey, decisionBytes)
+	if err != nil {
+		return "", err
+	}
+	return string(decisionBytes), nil
+}
+
+// GetAccessDecisions cc returns the recorded decisions on requests from the provided securityDomain
+func (s *SmartContract) GetAccessDecisions(ctx contractapi.TransactionContextInterface, securityDomain string) (string, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(accessDecisionObjectType, []string{securityDomain})
+	if err != nil {
+		log.Error(err.Error())
+		return "", err
+	}
+	defer iterator.Close()
+
+	decisions := []accessDecision{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			log.Error(err.Error())
+			return "", err
+		}
+		var decision accessDecision
+		err = json.Unmarshal(item.Value, &decision)
+		if err != nil {
+			errorMessage := fmt.Sprintf("Unmarshal error: %s", err)
+			log.Error(errorMessage)
+			return "", errors.New(errorMessage)
+		}
+		decisions = append(decisions, decision)
+	}
+	decisionsBytes, err := json.Marshal(decisions)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Marshal error: %s", err)
+		log.Error(errorMessage)
+		return "", errors.New(errorMessage)
+	}
+	return string(decisionsBytes), nil
+}
+
+// logAccessDecision logs an access decision
+func logAccessDecision(decision *accessDecision) {
+	decisionBytes, err := json.Marshal(decision)
+	if err != nil {
+		log.Errorf("Marshal error: %s", err)
+		return
+	}
+	if decision.Permitted {
+		log.Infof("Access control decision: %s", decisionBytes)
+	} else {
+		log.Warnf("Access control decision: %s", decisionBytes)
+	}
+}
+
+// argumentConstraintsViolation returns why the arguments of a view address do not satisfy the constraints of a rule, or
+// the empty string if they do
+func argumentConstraintsViolation(constraints []*common.ArgumentConstraint, args []string) string {
+	for _, constraint := range constraints {
+		if int(constraint.Index) >= len(args) {
+			return fmt.Sprintf("argument %d is missing", constraint.Index)
+		}
+		value := args[constraint.Index]
+		if constraint.Field != "" {
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(value), &fields); err != nil {
+				return fmt.Sprintf("argument %d is not a JSON object", constraint.Index)
+			}
+			fieldValue, exists := fields[constraint.Field]
+			if !exists {
+				return fmt.Sprintf("argument %d has no field %s", constraint.Index, constraint.Field)
+			}
+			if stringValue, isString := fieldValue.(string); isString {
+				value = stringValue
+			} else {
+				fieldBytes, _ := json.Marshal(fieldValue)
+				value = string(fieldBytes)
+			}
+			if !isPatternSegmentMatch(constraint.Pattern, value) {
+				return fmt.Sprintf("field %s of argument %d does not match %s", constraint.Field, constraint.Index, constraint.Pattern)
+			}
+		} else if !isPatternSegmentMatch(constraint.Pattern, value) {
+			return fmt.Sprintf("argument %d does not match %s", constraint.Index, constraint.Pattern)
+		}
+	}
+	return ""
+}
+
+// isPrincipalMatch checks whether the requester of a query is the principal of a rule. The principal of a rule of type
+// "certificate" is the requester's PEM certificate, and that of a rule of type "ca" is the requester's organization.
+// The principal of a rule on requester attributes is prefixed by the organization ("*" for any organization of the
+// requesting network) followed by a '.', and certificate attributes are read the same way for any network:
+//   - "role": the MSP role (client, peer, admin or orderer) in the organizational units, e.g. "BankBMSP.admin";
+//   - "ou": an organizational unit, e.g. "BankBMSP.auditor";
+//   - "attribute": a Fabric CA attribute and a literal, glob or /regular expression/ its value must match,
+//     e.g. "BankBMSP.hf.Affiliation=bankb.audit*" or "*.auditor=true".
+func isPrincipalMatch(rule *common.Rule, query *common.Query, requesterCert *x509.Certificate) bool {
+	switch rule.PrincipalType {
+	case "certificate":
+		return query.Certificate == rule.Principal
+	case "ca":
+		return query.RequestingOrg == rule.Principal
+	case "role", "ou", "attribute":
+	default:
+		return false
+	}
+	if requesterCert == nil {
+		return false
+	}
+	org, principal, found := strings.Cut(rule.Principal, ".")
+	if !found || (org != "*" && org != query.RequestingOrg) {
+		return false
+	}
+	switch rule.PrincipalType {
+	case "role":
+		return getCertificateRole(requesterCert) == principal
+	case "ou":
+		return Contains(requesterCert.Subject.OrganizationalUnit, principal)
+	default:
+		name, valuePattern, found := strings.Cut(principal, "=")
+		if !found {
+			return false
+		}
+		attributes, err := getCertificateAttributes(requesterCert)
+		if err != nil {
+			log.Warnf("Unable to read attributes of requester certificate: %s", err)
+			return false
+		}
+		value, exists := attributes[name]
+		return exists && isPatternSegmentMatch(valuePattern, value)
+	}
+}
+
+// countRateLimitedRequest counts a request against the rate limit of a rule, and returns why the request exceeds the
+// limit, or the empty string if it does not. Windows are aligned on multiples of their length since the Unix epoch,
+// so that all endorsers agree on the window of a transaction.
+func countRateLimitedRequest(ctx contractapi.TransactionContextInterface, decision *accessDecision, ruleIndex int, rateLimit *common.RateLimit) (string, error) {
+	usageKey, err := ctx.GetStub().CreateCompositeKey(rateLimitUsageObjectType, []string{decision.RequestingNetwork, decision.Requester, strconv.Itoa(ruleIndex)})
+	if err != nil {
+		return "", err
+	}
+	usageBytes, err := ctx.GetStub().GetState(usageKey)
+	if err != nil {
+		return "", err
+	}
+	windowStart := decision.Timestamp - decision.Timestamp%int64(rateLimit.WindowSeconds)
+	usage := rateLimitUsage{WindowStart: windowStart}
+	if usageBytes != nil {
+		if err := json.Unmarshal(usageBytes, &usage); err != nil {
+			return "", fmt.Errorf("Unmarshal error: %s", err)
+		}
+		if usage.WindowStart != windowStart {
+			usage = rateLimitUsage{WindowStart: windowStart}
+		}
+	}
+	if usage.Requests >= rateLimit.MaxRequests {
+		return fmt.Sprintf("rate limit of %d requests per %d seconds exceeded", rateLimit.MaxRequests, rateLimit.WindowSeconds), nil
+	}
+	usage.Requests++
+	usageBytes, err = json.Marshal(&usage)
+	if err != nil {
+		return "", fmt.Errorf("Marshal error: %s", err)
+	}
+	return "", ctx.GetStub().PutState(usageKey, usageBytes)
+}
+
+// recordedDecisionViolation returns why the decision recorded for a request does not let it be served under a rule,
+// or the empty string if it does
+func recordedDecisionViolation(ctx contractapi.TransactionContextInterface, decision *accessDecision) (string, error) {
+	decisionKey, err := ctx.GetStub().CreateCompositeKey(accessDecisionObjectType, []string{decision.RequestingNetwork, decision.Nonce})
+	if err != nil {
+		return "", err
+	}
+	recordedDecisionBytes, err := ctx.GetStub().GetState(decisionKey)
+	if err != nil {
+		return "", err
+	}
+	if recordedDecisionBytes == nil {
+		return "the rule is rate-limited and no access decision is recorded for the request (see RecordAccessDecision)", nil
+	}
+	var recordedDecision accessDecision
+	if err := json.Unmarshal(recordedDecisionBytes, &recordedDecision); err != nil {
+		return "", fmt.Errorf("Unmarshal error: %s", err)
+	}
+	if !recordedDecision.Permitted || recordedDecision.Rule != decision.Rule || recordedDecision.Address != decision.Address || recordedDecision.Requester != decision.Requester {
+		return "the access decision recorded for the request does not permit it under the rule", nil
+	}
+	if decision.Timestamp-recordedDecision.Timestamp > int64(accessDecisionValidity/time.Second) {
+		return fmt.Sprintf("the access decision recorded for the request is older than %s", accessDecisionValidity), nil
+	}
+	return "", nil
+}
+
+// decideAccess looks up the Access Control State for the external network and decides whether the requester has the
+// required permission to call the specified CC function. An error is only returned if no decision can be made.
+//
+// A rule permits a request if it is active (read), its resource matches the view address, its principal matches the
+// requester, the arguments of the view address satisfy its argument constraints, and the request is within its rate
+// limit, as handled by the rateLimitMode. Every decision is logged.
+func decideAccess(s *SmartContract, ctx contractapi.TransactionContextInterface, viewAddress *FabricViewAddress, viewAddressString string, query *common.Query, mode rateLimitMode) (*accessDecision, error) {
 	acpString, err := s.GetAccessControlPolicyBySecurityDomain(ctx, query.RequestingNetwork)
 	if err != nil {
 		errorMessage := fmt.Sprintf("Access control policy does not exist for network: %s", query.RequestingNetwork)
 		log.Error(errorMessage)
-		return errors.New(errorMessage)
+		return nil, errors.New(errorMessage)
 	}
 	acp, err := decodeAccessControlPolicy([]byte(acpString))
 	if err != nil {
 		errorMessage := fmt.Sprintf("Failed to unmarshal access control policy: %s", err.Error())
 		log.Error(errorMessage)
-		return errors.New(errorMessage)
+		return nil, errors.New(errorMessage)
 	}
 
-	for _, rule := range acp.Rules {
-		if rule.Resource == viewAddressString || (validPatternString(rule.Resource) && isPatternAndAddressMatch(rule.Resource, viewAddressString)) {
-			// TODO: Check if these will be the same format (Or convert to matching formats at some point)
-			// TODO: Need to use principalType and perform different validation for type "certificate" and "ca".
-			// Code below assumes that requestor's membership has already been authenticated earlier if the type is "ca"
-			if (rule.PrincipalType == "certificate" && query.Certificate == rule.Principal) {
-				// Break loop as cert is valid.
-				log.Infof("Access Control Policy PERMITS the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate)
-				return nil
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, logThenErrorf("Unable to get transaction timestamp: %s", err)
+	}
+	certificateHash := sha256.Sum256([]byte(query.Certificate))
+	decision := accessDecision{
+		TxId:              ctx.GetStub().GetTxID(),
+		Timestamp:         txTimestamp.GetSeconds(),
+		RequestingNetwork: query.RequestingNetwork,
+		RequestingOrg:     query.RequestingOrg,
+		Requester:         hex.EncodeToString(certificateHash[:]),
+		Address:           viewAddressString,
+		Nonce:             query.Nonce,
+	}
+
+	// Rules on attributes of the requester cannot match if its certificate cannot be parsed
+	requesterCert, err := parseCert(query.Certificate)
+	if err != nil {
+		requesterCert = nil
+	}
+
+	for i, rule := range acp.Rules {
+		if !rule.Read {
+			continue
+		}
+		if rule.Resource == viewAddressString || isPatternAndAddressMatch(rule.Resource, viewAddressString) {
+			// Code below assumes that requestor's membership has already been authenticated earlier
+			if !isPrincipalMatch(rule, query, requesterCert) {
+				continue
 			}
-			if (rule.PrincipalType == "ca" && query.RequestingOrg == rule.Principal) {
-				// Break loop as cert is valid.
+			decision.Rule = rule.Resource
+			if reason := argumentConstraintsViolation(rule.ArgumentConstraints, viewAddress.Args); reason != "" {
+				decision.Reason = reason
+				continue
+			}
+			if rule.RateLimit != nil && mode != skipRateLimit {
+				if rule.RateLimit.WindowSeconds == 0 {
+					return nil, logThenErrorf("Rate limit of rule %d has no window", i)
+				}
+				var reason string
+				if mode == countRequest {
+					reason, err = countRateLimitedRequest(ctx, &decision, i, rule.RateLimit)
+				} else {
+					reason, err = recordedDecisionViolation(ctx, &decision)
+				}
+				if err != nil {
+					return nil, logThenErrorf("Rate limit check failed: %s", err)
+				}
+				if reason != "" {
+					decision.Reason = reason
+					continue
+				}
+			}
+			decision.Permitted = true
+			decision.Reason = ""
+			if rule.PrincipalType == "certificate" {
+				log.Infof("Access Control Policy PERMITS the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate)
+			} else if rule.PrincipalType == "ca" {
 				log.Infof("Access Control Policy PERMITS the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.RequestingOrg)
-				return nil
+			} else {
+				log.Infof("Access Control Policy PERMITS the request '%s' from '%s:%s' as %s '%s'", viewAddressString, query.RequestingNetwork, query.RequestingOrg, rule.PrincipalType, rule.Principal)
 			}
+			logAccessDecision(&decision)
+			return &decision, nil
 		}
 
 	}
+	if decision.Reason == "" {
+		decision.Reason = noPermittingRule
+	}
+	logAccessDecision(&decision)
+	return &decision, nil
+}
+
+// verifyAccessToCC verifies that the requester of a request from a remote network has the required permission to call
+// the specified CC function, as decided by decideAccess
+func verifyAccessToCC(s *SmartContract, ctx contractapi.TransactionContextInterface, viewAddress *FabricViewAddress, viewAddressString string, query *common.Query, mode rateLimitMode) error {
+	decision, err := decideAccess(s, ctx, viewAddress, viewAddressString, query, mode)
+	if err != nil {
+		return err
+	}
+	if decision.Permitted {
+		return nil
+	}
 	var errorMessage string
 	if (query.Certificate != "") {
         errorMessage = fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate)
@@ -180,6 +532,9 @@ func verifyAccessToCC(s *SmartContract, ctx contractapi.TransactionContextInterf
 	} else {
 		errorMessage = fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from a foreign entity", viewAddressString)
 	}
+	if decision.Reason != noPermittingRule {
+		errorMessage = fmt.Sprintf("%s: %s", errorMessage, decision.Reason)
+	}
 	log.Error(errorMessage)
 	return errors.New(errorMessage)
 
@@ -9,12 +9,15 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils/mocks"
 	"github.com/stretchr/testify/require"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var accessControlAsset = common.AccessControlPolicy{
@@ -163,7 +166,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 
 	// Test: Happy case
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.NoError(t, err)
 	newRule := common.Rule{
 		Principal:     "cert",
@@ -175,7 +178,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.NoError(t, err)
 
 	newRule = common.Rule{
@@ -188,7 +191,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.NoError(t, err)
 
 	// Test: Invalid Cert
@@ -202,7 +205,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.EqualError(t, err, fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate))
 
 	// Test: Invalid CA
@@ -216,7 +219,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.EqualError(t, err, fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate))
 
 	// Test: No rule for requested resource
@@ -230,7 +233,7 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.EqualError(t, err, fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate))
 
 	differentResourceRule = common.Rule{
@@ -243,11 +246,230 @@ func TestVerifyAccessToCC(t *testing.T) {
 	accessControlBytes, err = json.Marshal(&accessControlAsset)
 	require.NoError(t, err)
 	chaincodeStub.GetStateReturns(accessControlBytes, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.EqualError(t, err, fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from '%s:%s'", viewAddressString, query.RequestingNetwork, query.Certificate))
 
 	// Test: No Rule for ID
 	chaincodeStub.GetStateReturns(nil, nil)
-	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query)
+	err = verifyAccessToCC(&interopcc, ctx, &validAddressStruct, viewAddressString, &query, requireRecordedDecision)
 	require.EqualError(t, err, fmt.Sprintf("Access control policy does not exist for network: %s", query.RequestingNetwork))
 }
+
+func TestVerifyAccessToCCConstraints(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetTxIDReturns("tx1")
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: 1760000000}, nil)
+
+	viewAddress := FabricViewAddress{
+		Channel:  "mychannel",
+		Contract: "simpleasset",
+		CCFunc:   "ReadAsset",
+		Args:     []string{`{"assetType":"bond","id":"a01","owner":{"name":"alice"}}`, "true"},
+	}
+	viewAddressString := "mychannel:simpleasset:ReadAsset:" + strings.Join(viewAddress.Args, ":")
+	query := common.Query{
+		RequestingNetwork: "network1",
+		Certificate:       "cert",
+		RequestingOrg:     "Org1MSP",
+	}
+	setPolicy := func(rules ...*common.Rule) {
+		accessControlBytes, err := json.Marshal(&common.AccessControlPolicy{SecurityDomain: "network1", Rules: rules})
+		require.NoError(t, err)
+		chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), accessControlBytes, nil)
+	}
+	deniedMessage := fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from 'network1:cert'", viewAddressString)
+
+	// Inactive rules are ignored
+	setPolicy(&common.Rule{Principal: "Org1MSP", PrincipalType: "ca", Resource: "mychannel:simpleasset:ReadAsset:*", Read: false})
+	err := verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage)
+	require.Equal(t, 0, chaincodeStub.PutStateCallCount())
+
+	// Argument constraints on a JSON field and a plain argument
+	rule := &common.Rule{
+		Principal:     "Org1MSP",
+		PrincipalType: "ca",
+		Resource:      "mychannel:simpleasset:ReadAsset:*",
+		Read:          true,
+		ArgumentConstraints: []*common.ArgumentConstraint{
+			{Index: 0, Field: "assetType", Pattern: "bond"},
+			{Index: 1, Pattern: "/true|false/"},
+		},
+	}
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.NoError(t, err)
+
+	rule.ArgumentConstraints[0].Pattern = "token*"
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": field assetType of argument 0 does not match token*")
+
+	rule.ArgumentConstraints = []*common.ArgumentConstraint{{Index: 0, Field: "owner", Pattern: `{"name":"alice"}`}}
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.NoError(t, err)
+
+	rule.ArgumentConstraints = []*common.ArgumentConstraint{{Index: 1, Field: "assetType", Pattern: "*"}}
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": argument 1 is not a JSON object")
+
+	rule.ArgumentConstraints = []*common.ArgumentConstraint{{Index: 2, Pattern: "*"}}
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": argument 2 is missing")
+
+	// A later rule may permit a request that an earlier rule's constraints reject
+	setPolicy(rule, &common.Rule{Principal: "cert", PrincipalType: "certificate", Resource: "mychannel:simpleasset:*", Read: true})
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.NoError(t, err)
+
+	// Access checks serve evaluated requests, so they do not write to the ledger
+	require.Equal(t, 0, chaincodeStub.PutStateCallCount())
+
+	// Rate limits count the requests recorded with RecordAccessDecision per requester within windows of transaction time
+	query.Nonce = "nonce1"
+	rule.ArgumentConstraints = nil
+	rule.RateLimit = &common.RateLimit{MaxRequests: 2, WindowSeconds: 3600}
+	setPolicy(rule)
+	decision, err := decideAccess(&interopcc, ctx, &viewAddress, viewAddressString, &query, countRequest)
+	require.NoError(t, err)
+	require.True(t, decision.Permitted)
+	require.Equal(t, "mychannel:simpleasset:ReadAsset:*", decision.Rule)
+	require.Equal(t, "nonce1", decision.Nonce)
+	_, usageBytes := chaincodeStub.PutStateArgsForCall(chaincodeStub.PutStateCallCount() - 1)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(chaincodeStub.CreateCompositeKeyCallCount() - 1)
+	require.Equal(t, rateLimitUsageObjectType, objectType)
+	require.Equal(t, []string{"network1", decision.Requester, "0"}, keys)
+	require.JSONEq(t, `{"windowStart":1759996800,"requests":1}`, string(usageBytes))
+
+	setPolicy(rule)
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, []byte(`{"windowStart":1759996800,"requests":1}`), nil)
+	_, err = decideAccess(&interopcc, ctx, &viewAddress, viewAddressString, &query, countRequest)
+	require.NoError(t, err)
+	_, usageBytes = chaincodeStub.PutStateArgsForCall(chaincodeStub.PutStateCallCount() - 1)
+	require.JSONEq(t, `{"windowStart":1759996800,"requests":2}`, string(usageBytes))
+
+	putStateCount := chaincodeStub.PutStateCallCount()
+	setPolicy(rule)
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, []byte(`{"windowStart":1759996800,"requests":2}`), nil)
+	exceeded, err := decideAccess(&interopcc, ctx, &viewAddress, viewAddressString, &query, countRequest)
+	require.NoError(t, err)
+	require.False(t, exceeded.Permitted)
+	require.Equal(t, "rate limit of 2 requests per 3600 seconds exceeded", exceeded.Reason)
+	require.Equal(t, putStateCount, chaincodeStub.PutStateCallCount())
+
+	// Usage from an earlier window does not count
+	setPolicy(rule)
+	chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, []byte(`{"windowStart":1759993200,"requests":2}`), nil)
+	_, err = decideAccess(&interopcc, ctx, &viewAddress, viewAddressString, &query, countRequest)
+	require.NoError(t, err)
+	_, usageBytes = chaincodeStub.PutStateArgsForCall(chaincodeStub.PutStateCallCount() - 1)
+	require.JSONEq(t, `{"windowStart":1759996800,"requests":1}`, string(usageBytes))
+
+	// Requests are only served under a rate-limited rule if a recent decision permitting them is recorded
+	putStateCount = chaincodeStub.PutStateCallCount()
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": the rule is rate-limited and no access decision is recorded for the request (see RecordAccessDecision)")
+	objectType, keys = chaincodeStub.CreateCompositeKeyArgsForCall(chaincodeStub.CreateCompositeKeyCallCount() - 1)
+	require.Equal(t, accessDecisionObjectType, objectType)
+	require.Equal(t, []string{"network1", "nonce1"}, keys)
+
+	recordDecision := func(decision accessDecision) {
+		setPolicy(rule)
+		decisionBytes, err := json.Marshal(&decision)
+		require.NoError(t, err)
+		chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, decisionBytes, nil)
+	}
+	recordDecision(*decision)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.NoError(t, err)
+
+	recordDecision(*exceeded)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": the access decision recorded for the request does not permit it under the rule")
+
+	staleDecision := *decision
+	staleDecision.Timestamp -= 61
+	recordDecision(staleDecision)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	require.EqualError(t, err, deniedMessage+": the access decision recorded for the request is older than 1m0s")
+
+	// Events are not rate-limited
+	setPolicy(rule)
+	err = verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, skipRateLimit)
+	require.NoError(t, err)
+	require.Equal(t, putStateCount, chaincodeStub.PutStateCallCount())
+}
+
+func TestGetAccessDecisions(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+
+	decision := accessDecision{TxId: "tx1", RequestingNetwork: "network1", Address: "mychannel:interop:Read:a", Nonce: "nonce1", Permitted: true}
+	decisionBytes, err := json.Marshal(&decision)
+	require.NoError(t, err)
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturns(&queryresult.KV{Key: "nonce1", Value: decisionBytes}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+
+	decisionsJSON, err := interopcc.GetAccessDecisions(ctx, "network1")
+	require.NoError(t, err)
+	require.Equal(t, "["+string(decisionBytes)+"]", decisionsJSON)
+	objectType, keys := chaincodeStub.GetStateByPartialCompositeKeyArgsForCall(0)
+	require.Equal(t, accessDecisionObjectType, objectType)
+	require.Equal(t, []string{"network1"}, keys)
+}
+
+func TestVerifyAccessToCCAttributePrincipals(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+
+	viewAddress := FabricViewAddress{Channel: "mychannel", Contract: "simpleasset", CCFunc: "ReadAsset", Args: []string{"bond01"}}
+	viewAddressString := "mychannel:simpleasset:ReadAsset:bond01"
+	auditorCert := createAttributeCertPEM(t, "BankB", []string{"client", "auditor"}, `{"attrs":{"hf.Affiliation":"bankb.audit.emea","auditor":"true"}}`)
+	query := common.Query{RequestingNetwork: "network2", Certificate: auditorCert, RequestingOrg: "BankBMSP"}
+	verify := func(principalType string, principal string) error {
+		accessControlBytes, err := json.Marshal(&common.AccessControlPolicy{
+			SecurityDomain: "network2",
+			Rules:          []*common.Rule{{Principal: principal, PrincipalType: principalType, Resource: "mychannel:simpleasset:*", Read: true}},
+		})
+		require.NoError(t, err)
+		chaincodeStub.GetStateReturns(accessControlBytes, nil)
+		return verifyAccessToCC(&interopcc, ctx, &viewAddress, viewAddressString, &query, requireRecordedDecision)
+	}
+
+	for _, principal := range [][]string{
+		{"role", "BankBMSP.client"},
+		{"role", "*.client"},
+		{"ou", "BankBMSP.auditor"},
+		{"attribute", "BankBMSP.hf.Affiliation=bankb.audit.*"},
+		{"attribute", "*.auditor=true"},
+		{"attribute", "BankBMSP.hf.Affiliation=/bankb\\.audit\\.(emea|apac)/"},
+	} {
+		require.NoError(t, verify(principal[0], principal[1]), principal[1])
+	}
+
+	deniedMessage := fmt.Sprintf("Access Control Policy DOES NOT PERMIT the request '%s' from 'network2:%s'", viewAddressString, auditorCert)
+	for _, principal := range [][]string{
+		{"role", "BankBMSP.admin"},
+		{"role", "BankCMSP.client"},
+		{"role", "client"},
+		{"ou", "BankBMSP.trader"},
+		{"attribute", "BankBMSP.hf.Affiliation=bankb.trading.*"},
+		{"attribute", "BankBMSP.hf.EnrollmentID=user1"},
+		{"attribute", "BankBMSP.auditor"},
+		{"group", "BankBMSP.auditor"},
+	} {
+		require.EqualError(t, verify(principal[0], principal[1]), deniedMessage, principal[1])
+	}
+
+	// Attribute rules cannot match a requester whose certificate cannot be parsed
+	query.Certificate = "cert"
+	require.EqualError(t, verify("ou", "BankBMSP.auditor"), "Access Control Policy DOES NOT PERMIT the request 'mychannel:simpleasset:ReadAsset:bond01' from 'network2:cert'")
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// asset_transfers contains the code that mirrors the asset pledges and claims of application chaincodes,
+// so that remote networks can query their status from the interop chaincode directly
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+const (
+	assetPledgeObjectType = "assetPledge"
+	assetClaimObjectType  = "assetClaim"
+)
+
+// getRecordingChaincodeID returns the ID of the application chaincode calling a record function,
+// rejecting calls made directly by clients
+func getRecordingChaincodeID(ctx contractapi.TransactionContextInterface, function string) (string, error) {
+	callerChaincodeID, err := wutils.GetLocalChaincodeID(ctx.GetStub())
+	if err != nil {
+		return "", logThenErrorf("%s", err.Error())
+	}
+	interopChaincodeID, err := ctx.GetStub().GetState(wutils.GetInteropChaincodeIDKey())
+	if err != nil {
+		return "", logThenErrorf("%s", err.Error())
+	}
+	if callerChaincodeID == string(interopChaincodeID) {
+		return "", logThenErrorf("Illegal access: %s being called directly by client", function)
+	}
+	return callerChaincodeID, nil
+}
+
+// getAssetTransferRecordKey returns the key of a pledge or claim, which is recorded under the ID of the application
+// chaincode that recorded it, so that chaincodes can't overwrite each other's pledges and claims and queries get
+// the pledges and claims of the chaincode they name
+func getAssetTransferRecordKey(ctx contractapi.TransactionContextInterface, objectType string, contract string, pledgeId string) (string, error) {
+	if pledgeId == "" {
+		return "", fmt.Errorf("pledgeId can not be empty")
+	}
+	return ctx.GetStub().CreateCompositeKey(objectType, []string{contract, pledgeId})
+}
+
+// getAssetTransferRecord returns the pledge or claim recorded by a chaincode for a pledge ID, or nil if there is none
+func getAssetTransferRecord(ctx contractapi.TransactionContextInterface, objectType string, contract string, pledgeId string) ([]byte, error) {
+	recordKey, err := getAssetTransferRecordKey(ctx, objectType, contract, pledgeId)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.GetStub().GetState(recordKey)
+}
+
+// putAssetTransferRecord records a pledge or claim on behalf of the calling application chaincode
+func putAssetTransferRecord(ctx contractapi.TransactionContextInterface, objectType string, callerChaincodeID string, pledgeId string, record []byte) error {
+	recordKey, err := getAssetTransferRecordKey(ctx, objectType, callerChaincodeID, pledgeId)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().PutState(recordKey, record)
+}
+
+// RecordAssetPledge cc is called by an application chaincode to record an asset pledge (a serialized and
+// base64-encoded AssetPledge) for remote networks to query
+func (s *SmartContract) RecordAssetPledge(ctx contractapi.TransactionContextInterface, pledgeId string, pledgeBytesBase64 string) error {
+	callerChaincodeID, err := getRecordingChaincodeID(ctx, "RecordAssetPledge")
+	if err != nil {
+		return err
+	}
+	pledgeBytes, err := base64.StdEncoding.DecodeString(pledgeBytesBase64)
+	if err != nil {
+		return logThenErrorf("Unable to base64 decode asset pledge: %s", err)
+	}
+	err = protoV2.Unmarshal(pledgeBytes, &common.AssetPledge{})
+	if err != nil {
+		return logThenErrorf("Unable to unmarshal asset pledge: %s", err)
+	}
+	return putAssetTransferRecord(ctx, assetPledgeObjectType, callerChaincodeID, pledgeId, pledgeBytes)
+}
+
+// DeleteAssetPledge cc is called by an application chaincode to delete an asset pledge it recorded,
+// once the pledged asset has been reclaimed
+func (s *SmartContract) DeleteAssetPledge(ctx contractapi.TransactionContextInterface, pledgeId string) error {
+	callerChaincodeID, err := getRecordingChaincodeID(ctx, "DeleteAssetPledge")
+	if err != nil {
+		return err
+	}
+	recordKey, err := getAssetTransferRecordKey(ctx, assetPledgeObjectType, callerChaincodeID, pledgeId)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().DelState(recordKey)
+}
+
+// RecordAssetClaim cc is called by an application chaincode to record the claim (a serialized and base64-encoded
+// AssetClaimStatus) of an asset pledged in a remote network, for the pledging network to query
+func (s *SmartContract) RecordAssetClaim(ctx contractapi.TransactionContextInterface, pledgeId string, claimStatusBytesBase64 string) error {
+	callerChaincodeID, err := getRecordingChaincodeID(ctx, "RecordAssetClaim")
+	if err != nil {
+		return err
+	}
+	claimStatusBytes, err := base64.StdEncoding.DecodeString(claimStatusBytesBase64)
+	if err != nil {
+		return logThenErrorf("Unable to base64 decode asset claim status: %s", err)
+	}
+	err = protoV2.Unmarshal(claimStatusBytes, &common.AssetClaimStatus{})
+	if err != nil {
+		return logThenErrorf("Unable to unmarshal asset claim status: %s", err)
+	}
+	return putAssetTransferRecord(ctx, assetClaimObjectType, callerChaincodeID, pledgeId, claimStatusBytes)
+}
+
+// GetAssetPledgeStatus cc returns the asset pledge (serialized and base64-encoded) recorded by the given application
+// chaincode for the given recipient, or a blank pledge if the asset has not been pledged through that chaincode
+func (s *SmartContract) GetAssetPledgeStatus(ctx contractapi.TransactionContextInterface, contract string, pledgeId string, recipientNetworkId string, recipientCert string) (string, error) {
+	record, err := getAssetTransferRecord(ctx, assetPledgeObjectType, contract, pledgeId)
+	if err != nil {
+		return "", logThenErrorf("failed to read asset pledge status: %s", err)
+	}
+	pledge := &common.AssetPledge{}
+	if record != nil {
+		err = protoV2.Unmarshal(record, pledge)
+		if err != nil {
+			return "", logThenErrorf("Unable to unmarshal asset pledge: %s", err)
+		}
+		// Match pledge with request parameters
+		if pledge.RemoteNetworkID != recipientNetworkId {
+			return "", logThenErrorf("No Pledge exists for recipient network id: %s", recipientNetworkId)
+		}
+		if pledge.Recipient != recipientCert {
+			return "", logThenErrorf("No Pledge exists for recipient: %s", recipientCert)
+		}
+	}
+	pledgeBytes, err := protoV2.Marshal(pledge)
+	if err != nil {
+		return "", logThenErrorf("Unable to marshal asset pledge: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(pledgeBytes), nil
+}
+
+// GetAssetClaimStatus cc returns the asset claim status (serialized and base64-encoded) recorded by the given application
+// chaincode for the given recipient, or a blank claim status if the asset has not been claimed through that chaincode,
+// along with whether the pledge has expired
+func (s *SmartContract) GetAssetClaimStatus(ctx contractapi.TransactionContextInterface, contract string, pledgeId string, recipientCert string, pledgerNetworkId string, pledgeExpiryTimeSecs uint64) (string, error) {
+	record, err := getAssetTransferRecord(ctx, assetClaimObjectType, contract, pledgeId)
+	if err != nil {
+		return "", logThenErrorf("failed to read asset claim status: %s", err)
+	}
+	claimStatus := &common.AssetClaimStatus{}
+	if record != nil {
+		err = protoV2.Unmarshal(record, claimStatus)
+		if err != nil {
+			return "", logThenErrorf("Unable to unmarshal asset claim status: %s", err)
+		}
+		// Match claim with request parameters
+		if claimStatus.RemoteNetworkID != pledgerNetworkId {
+			return "", logThenErrorf("No claim exists for pledger network id: %s", pledgerNetworkId)
+		}
+		if claimStatus.Recipient != recipientCert {
+			return "", logThenErrorf("No claim exists for recipient: %s", recipientCert)
+		}
+	}
+	// Judge expiry by the transaction timestamp, so that all endorsers agree on it
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", logThenErrorf("Unable to get transaction timestamp: %s", err)
+	}
+	claimStatus.ExpiryTimeSecs = pledgeExpiryTimeSecs
+	claimStatus.ExpirationStatus = uint64(txTimestamp.GetSeconds()) >= pledgeExpiryTimeSecs
+	claimStatusBytes, err := protoV2.Marshal(claimStatus)
+	if err != nil {
+		return "", logThenErrorf("Unable to marshal asset claim status: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(claimStatusBytes), nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRecordAssetPledge(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	wtest.SetMockStubCCId(chaincodeStub, "simpleassettransfer")
+
+	pledge := &common.AssetPledge{
+		AssetDetails:    []byte(`{"id":"a01"}`),
+		LocalNetworkID:  "network1",
+		RemoteNetworkID: "network2",
+		Recipient:       "bob",
+		ExpiryTimeSecs:  1760000600,
+	}
+	pledgeBytes, err := protoV2.Marshal(pledge)
+	require.NoError(t, err)
+	pledgeBytesBase64 := base64.StdEncoding.EncodeToString(pledgeBytes)
+
+	// Test success: the pledge is recorded on behalf of the calling application chaincode
+	chaincodeStub.GetStateReturnsOnCall(0, []byte("interopcc"), nil)
+	err = interopcc.RecordAssetPledge(ctx, "p01", pledgeBytesBase64)
+	require.NoError(t, err)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, assetPledgeObjectType, objectType)
+	require.Equal(t, []string{"simpleassettransfer", "p01"}, keys)
+	_, recordBytes := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, pledgeBytes, recordBytes)
+
+	// Test success: another chaincode records and deletes a pledge with the same ID under its own ID
+	wtest.SetMockStubCCId(chaincodeStub, "othercc")
+	chaincodeStub.GetStateReturnsOnCall(1, []byte("interopcc"), nil)
+	err = interopcc.RecordAssetPledge(ctx, "p01", pledgeBytesBase64)
+	require.NoError(t, err)
+	_, keys = chaincodeStub.CreateCompositeKeyArgsForCall(1)
+	require.Equal(t, []string{"othercc", "p01"}, keys)
+	chaincodeStub.GetStateReturnsOnCall(2, []byte("interopcc"), nil)
+	err = interopcc.DeleteAssetPledge(ctx, "p01")
+	require.NoError(t, err)
+	require.Equal(t, 1, chaincodeStub.DelStateCallCount())
+	_, keys = chaincodeStub.CreateCompositeKeyArgsForCall(2)
+	require.Equal(t, []string{"othercc", "p01"}, keys)
+
+	// Test failure: pledge IDs can't be empty
+	chaincodeStub.GetStateReturnsOnCall(3, []byte("interopcc"), nil)
+	err = interopcc.RecordAssetPledge(ctx, "", pledgeBytesBase64)
+	require.EqualError(t, err, "pledgeId can not be empty")
+
+	// Test failure: clients can't record pledges by calling the interop chaincode directly
+	wtest.SetMockStubCCId(chaincodeStub, "interopcc")
+	chaincodeStub.GetStateReturnsOnCall(4, []byte("interopcc"), nil)
+	err = interopcc.RecordAssetPledge(ctx, "p01", pledgeBytesBase64)
+	require.EqualError(t, err, "Illegal access: RecordAssetPledge being called directly by client")
+	require.Equal(t, 2, chaincodeStub.PutStateCallCount())
+}
+
+func TestGetAssetPledgeStatus(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+
+	pledge := &common.AssetPledge{
+		AssetDetails:    []byte(`{"id":"a01"}`),
+		LocalNetworkID:  "network1",
+		RemoteNetworkID: "network2",
+		Recipient:       "bob",
+		ExpiryTimeSecs:  1760000600,
+	}
+	pledgeBytes, err := protoV2.Marshal(pledge)
+	require.NoError(t, err)
+
+	// Test success: the pledge recorded by the named chaincode is returned to its recipient
+	chaincodeStub.GetStateReturnsOnCall(0, pledgeBytes, nil)
+	pledgeStatus, err := interopcc.GetAssetPledgeStatus(ctx, "simpleassettransfer", "p01", "network2", "bob")
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(pledgeBytes), pledgeStatus)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, assetPledgeObjectType, objectType)
+	require.Equal(t, []string{"simpleassettransfer", "p01"}, keys)
+
+	// Test failure: the pledge was made to another recipient
+	chaincodeStub.GetStateReturnsOnCall(1, pledgeBytes, nil)
+	_, err = interopcc.GetAssetPledgeStatus(ctx, "simpleassettransfer", "p01", "network2", "alice")
+	require.EqualError(t, err, "No Pledge exists for recipient: alice")
+	chaincodeStub.GetStateReturnsOnCall(2, pledgeBytes, nil)
+	_, err = interopcc.GetAssetPledgeStatus(ctx, "simpleassettransfer", "p01", "network3", "bob")
+	require.EqualError(t, err, "No Pledge exists for recipient network id: network3")
+
+	// Test success: a blank pledge is returned if the asset has not been pledged through the named chaincode
+	chaincodeStub.GetStateReturnsOnCall(3, nil, nil)
+	pledgeStatus, err = interopcc.GetAssetPledgeStatus(ctx, "othercc", "p01", "network2", "bob")
+	require.NoError(t, err)
+	require.Equal(t, "", pledgeStatus)
+	_, keys = chaincodeStub.CreateCompositeKeyArgsForCall(3)
+	require.Equal(t, []string{"othercc", "p01"}, keys)
+}
+
+func TestGetAssetClaimStatus(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: 1760000000}, nil)
+
+	claimStatus := &common.AssetClaimStatus{
+		AssetDetails:    []byte(`{"id":"a01"}`),
+		LocalNetworkID:  "network2",
+		RemoteNetworkID: "network1",
+		Recipient:       "bob",
+		ClaimStatus:     true,
+		ExpiryTimeSecs:  1760000600,
+	}
+	claimStatusBytes, err := protoV2.Marshal(claimStatus)
+	require.NoError(t, err)
+
+	// Test success: the recorded claim is returned with the expiration status of the pledge
+	chaincodeStub.GetStateReturnsOnCall(0, claimStatusBytes, nil)
+	claimStatusBase64, err := interopcc.GetAssetClaimStatus(ctx, "simpleassettransfer", "p01", "bob", "network1", 1759999000)
+	require.NoError(t, err)
+	lookupClaimStatus := unmarshalTestClaimStatus(t, claimStatusBase64)
+	require.True(t, lookupClaimStatus.ClaimStatus)
+	require.Equal(t, "bob", lookupClaimStatus.Recipient)
+	require.Equal(t, uint64(1759999000), lookupClaimStatus.ExpiryTimeSecs)
+	require.True(t, lookupClaimStatus.ExpirationStatus)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, assetClaimObjectType, objectType)
+	require.Equal(t, []string{"simpleassettransfer", "p01"}, keys)
+
+	// Test failure: the claim was made in another network
+	chaincodeStub.GetStateReturnsOnCall(1, claimStatusBytes, nil)
+	_, err = interopcc.GetAssetClaimStatus(ctx, "simpleassettransfer", "p01", "bob", "network3", 1760000600)
+	require.EqualError(t, err, "No claim exists for pledger network id: network3")
+
+	// Test success: a blank claim status is returned if the asset has not been claimed through the named chaincode
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
+	claimStatusBase64, err = interopcc.GetAssetClaimStatus(ctx, "othercc", "p01", "bob", "network1", 1760000600)
+	require.NoError(t, err)
+	lookupClaimStatus = unmarshalTestClaimStatus(t, claimStatusBase64)
+	require.False(t, lookupClaimStatus.ClaimStatus)
+	require.Equal(t, uint64(1760000600), lookupClaimStatus.ExpiryTimeSecs)
+	require.False(t, lookupClaimStatus.ExpirationStatus)
+}
+
+func unmarshalTestClaimStatus(t *testing.T, claimStatusBase64 string) *common.AssetClaimStatus {
+	claimStatusBytes, err := base64.StdEncoding.DecodeString(claimStatusBase64)
+	require.NoError(t, err)
+	claimStatus := &common.AssetClaimStatus{}
+	err = protoV2.Unmarshal(claimStatusBytes, claimStatus)
+	require.NoError(t, err)
+	return claimStatus
+}
@@ -0,0 +1,474 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// besu_view contains the verification logic for views returned by Besu networks, which are
+// proven by the commit seals of the network's BFT validators over a block header and a
+// Merkle-Patricia proof of the queried contract's storage against that header's state root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/besu"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3/besuheader"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	log "github.com/sirupsen/logrus"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// Verification policy types for Besu views, naming the consensus protocol whose commit seals
+// are carried in the block header's extra data.
+const (
+	besuPolicyTypeQBFT  = "QBFT"
+	besuPolicyTypeIBFT2 = "IBFT2"
+)
+
+// Membership member type for a Besu validator; the member value is the validator's address.
+const besuValidatorMemberType = "validator"
+
+// Positions of the items in the RLP-encoded BFT extra data: [vanity, validators, vote, round, seals].
+const (
+	bftExtraDataSealsIndex = 4
+	bftExtraDataMinItems   = 5
+)
+
+// Function name of a view address that reads contract storage, as required for views proven by
+// state proofs. Its arguments name the storage slots whose values make up the view's payload.
+const besuStorageFunction = "storage"
+
+// BesuViewAddress contains the data relevant to the view sent in the address string by the remote client.
+type BesuViewAddress struct {
+	NetworkID string
+	Contract  string
+	Function  string
+	Args      []string
+}
+
+// parseBesuViewAddress receives the view segment of an address and constructs a BesuViewAddress from it.
+// It splits on ':' to get the network id, contract address, function signature and the function arguments.
+func parseBesuViewAddress(viewAddress string) (*BesuViewAddress, error) {
+	if strings.Contains(viewAddress, "/") {
+		return nil, fmt.Errorf("View segment contains a '/' %s", viewAddress)
+	}
+	besuArgs := strings.Split(viewAddress, ":")
+	if len(besuArgs) < 3 {
+		return nil, fmt.Errorf("View segment not formatted correctly %s", viewAddress)
+	}
+	if !ethcommon.IsHexAddress(besuArgs[1]) {
+		return nil, fmt.Errorf("View segment contains an invalid contract address %s", besuArgs[1])
+	}
+
+	return &BesuViewAddress{NetworkID: besuArgs[0], Contract: besuArgs[1], Function: besuArgs[2], Args: besuArgs[3:]}, nil
+}
+
+// storageKeys returns the storage keys named by a view address that reads contract storage, in the
+// order of its arguments. Each argument is a slot number, optionally followed by mapping keys in
+// brackets, e.g. "3[0x627306090abaB3A6e1400e9345bC60c78a8BEf57]". The key of a mapping entry is
+// derived as in the Solidity storage layout: keccak256(key . slot), both left-padded to 32 bytes.
+func (viewAddress *BesuViewAddress) storageKeys() ([][]byte, error) {
+	if viewAddress.Function != besuStorageFunction {
+		return nil, fmt.Errorf("State proofs can only prove views of function '%s', not %s", besuStorageFunction, viewAddress.Function)
+	}
+	if len(viewAddress.Args) == 0 {
+		return nil, fmt.Errorf("View address does not name any storage slots")
+	}
+	keys := make([][]byte, len(viewAddress.Args))
+	for i, arg := range viewAddress.Args {
+		slot, mappingKeys, _ := strings.Cut(arg, "[")
+		key, err := parseStorageWord(slot)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid storage slot %s: %s", arg, err.Error())
+		}
+		for mappingKeys != "" {
+			mappingKey, rest, found := strings.Cut(mappingKeys, "]")
+			if !found || (rest != "" && rest[0] != '[') {
+				return nil, fmt.Errorf("Invalid storage slot %s: mapping keys must be enclosed in brackets", arg)
+			}
+			word, err := parseStorageWord(mappingKey)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid mapping key in storage slot %s: %s", arg, err.Error())
+			}
+			key = crypto.Keccak256(word, key)
+			mappingKeys = strings.TrimPrefix(rest, "[")
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// parseStorageWord parses a decimal or 0x-prefixed hexadecimal number of at most 256 bits into a 32-byte word.
+func parseStorageWord(value string) ([]byte, error) {
+	number, ok := new(big.Int).SetString(value, 0)
+	if !ok || number.Sign() < 0 || number.BitLen() > 8*ethcommon.HashLength {
+		return nil, fmt.Errorf("not an unsigned 256-bit number: '%s'", value)
+	}
+	return ethcommon.LeftPadBytes(number.Bytes(), ethcommon.HashLength), nil
+}
+
+// The verifyBesuStateProof function is used to verify views that come from a Besu network
+// that were generated with StateProof proofs.
+//
+// Verification requires the following checks to be performed:
+// 1. Ensure the response is in a valid format - view data should be parsed to [BesuView] and its
+// interop payload should carry the original address.
+// 2. Recover the signer of each commit seal in the block header's extra data.
+// 3. Check that the signers are validators in the network's Membership and that they fulfill the
+// verification policy of the request (a BFT quorum of validators plus any validators named in the criteria).
+// 4. Verify the account proof of the contract named in the address against the header's state root.
+// 5. Verify the storage proofs, which must be for the storage slots named in the address, against the
+// account's storage root and check that the interop payload is the concatenation of the proven storage values.
+func verifyBesuStateProof(s *SmartContract, ctx contractapi.TransactionContextInterface, data []byte, verificationPolicy *common.Policy, securityDomain string, address string) error {
+	membershipJSON, err := s.GetMembershipBySecurityDomain(ctx, securityDomain)
+	if err != nil {
+		return fmt.Errorf("Unable to get membership: %s", err.Error())
+	}
+	membership, err := decodeMembership([]byte(membershipJSON))
+	if err != nil {
+		return fmt.Errorf("Unable to decode membership: %s", err.Error())
+	}
+	err = verifyBesuView(data, verificationPolicy, membership, address)
+	if err != nil {
+		return err
+	}
+	log.Infof("Proof associated with response from Besu network for query '%s' is VALID", address)
+	return nil
+}
+
+// verifyBesuView performs the checks of verifyBesuStateProof against an already resolved membership.
+func verifyBesuView(data []byte, verificationPolicy *common.Policy, membership *common.Membership, address string) error {
+	// 1. Ensure the response is in a valid format
+	var besuView besu.BesuView
+	err := protoV2.Unmarshal(data, &besuView)
+	if err != nil {
+		return fmt.Errorf("Unable to decode besu view data: %s", err.Error())
+	}
+	var interopPayload common.InteropPayload
+	err = protoV2.Unmarshal(besuView.InteropPayload, &interopPayload)
+	if err != nil {
+		return fmt.Errorf("Unable to Unmarshal interopPayload: %s", err.Error())
+	}
+	if address != interopPayload.Address {
+		return fmt.Errorf("Address in response does not match original address: Original: %s Response: %s", address, interopPayload.Address)
+	}
+	if interopPayload.Confidential {
+		return fmt.Errorf("Confidential payloads are not supported for Besu state proofs")
+	}
+	addressStruct, err := parseAddress(address)
+	if err != nil {
+		return fmt.Errorf("Unable to parse address: %s", err.Error())
+	}
+	viewAddress, err := parseBesuViewAddress(addressStruct.ViewSegment)
+	if err != nil {
+		return fmt.Errorf("Unable to parse view address: %s", err.Error())
+	}
+	storageKeys, err := viewAddress.storageKeys()
+	if err != nil {
+		return fmt.Errorf("Unable to parse view address: %s", err.Error())
+	}
+
+	// 2. Recover the signer of each commit seal in the block header's extra data.
+	headerFields, err := besuheader.Fields(besuView.BlockHeaderRlp)
+	if err != nil {
+		return fmt.Errorf("Unable to decode block header: %s", err.Error())
+	}
+	signers, err := recoverCommitSealSigners(headerFields, verificationPolicy.Type)
+	if err != nil {
+		return err
+	}
+
+	// 3. Check that the signers are validators and fulfill the verification policy.
+	err = checkBesuValidatorQuorum(signers, membership, verificationPolicy)
+	if err != nil {
+		return err
+	}
+
+	// 4. Verify the account proof of the contract against the header's state root.
+	var stateRoot []byte
+	err = rlp.DecodeBytes(headerFields[besuheader.StateRootIndex], &stateRoot)
+	if err != nil {
+		return fmt.Errorf("Unable to decode state root: %s", err.Error())
+	}
+	accountProof := besuView.AccountProof
+	if accountProof == nil {
+		return fmt.Errorf("Besu view does not contain an account proof")
+	}
+	if ethcommon.BytesToAddress(accountProof.Address) != ethcommon.HexToAddress(viewAddress.Contract) || len(accountProof.Address) != ethcommon.AddressLength {
+		return fmt.Errorf("Account proof is for %x, expected contract %s", accountProof.Address, viewAddress.Contract)
+	}
+	accountRLP, err := verifyMerkleProof(stateRoot, crypto.Keccak256(accountProof.Address), accountProof.Proof)
+	if err != nil {
+		return fmt.Errorf("Account proof verification failed: %s", err.Error())
+	}
+	if accountRLP == nil {
+		return fmt.Errorf("Account proof shows that contract %s does not exist", viewAddress.Contract)
+	}
+	var account struct {
+		Nonce       uint64
+		Balance     []byte
+		StorageRoot []byte
+		CodeHash    []byte
+	}
+	err = rlp.DecodeBytes(accountRLP, &account)
+	if err != nil {
+		return fmt.Errorf("Unable to decode account: %s", err.Error())
+	}
+
+	// 5. Verify the storage proofs of the addressed slots and match the payload against the proven values.
+	if len(accountProof.StorageProofs) == 0 {
+		return fmt.Errorf("Besu view does not contain storage proofs")
+	}
+	if len(accountProof.StorageProofs) != len(storageKeys) {
+		return fmt.Errorf("Besu view contains %d storage proofs, address names %d storage slots", len(accountProof.StorageProofs), len(storageKeys))
+	}
+	var provenPayload []byte
+	for i, storageProof := range accountProof.StorageProofs {
+		if !bytes.Equal(storageProof.Key, storageKeys[i]) {
+			return fmt.Errorf("Storage proof %d is for key %x, address names key %x", i, storageProof.Key, storageKeys[i])
+		}
+		valueRLP, err := verifyMerkleProof(account.StorageRoot, crypto.Keccak256(storageProof.Key), storageProof.Proof)
+		if err != nil {
+			return fmt.Errorf("Storage proof %d verification failed: %s", i, err.Error())
+		}
+		var value []byte
+		if valueRLP != nil {
+			err = rlp.DecodeBytes(valueRLP, &value)
+			if err != nil {
+				return fmt.Errorf("Unable to decode storage value %d: %s", i, err.Error())
+			}
+		}
+		if !bytes.Equal(value, bytes.TrimLeft(storageProof.Value, "\x00")) {
+			return fmt.Errorf("Storage proof %d proves value %x, view claims %x", i, value, storageProof.Value)
+		}
+		provenPayload = append(provenPayload, ethcommon.LeftPadBytes(value, ethcommon.HashLength)...)
+	}
+	if !bytes.Equal(provenPayload, interopPayload.Payload) {
+		return fmt.Errorf("View payload does not match the proven storage values")
+	}
+	return nil
+}
+
+// besuViewTimestamp returns the timestamp of the block whose header is carried in a Besu view.
+func besuViewTimestamp(data []byte) (int64, error) {
+	var besuView besu.BesuView
+	err := protoV2.Unmarshal(data, &besuView)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to decode besu view data: %s", err.Error())
+	}
+	timestamp, err := besuheader.Timestamp(besuView.BlockHeaderRlp)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to decode block header: %s", err.Error())
+	}
+	return timestamp, nil
+}
+
+// recoverCommitSealSigners returns the addresses that signed the commit seals of a BFT block header.
+// The seals sign the hash of the header whose extra data excludes the seals, which QBFT replaces
+// with an empty list and IBFT 2.0 omits altogether.
+func recoverCommitSealSigners(headerFields []rlp.RawValue, policyType string) ([]ethcommon.Address, error) {
+	var extraData []byte
+	err := rlp.DecodeBytes(headerFields[besuheader.ExtraDataIndex], &extraData)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode block header extra data: %s", err.Error())
+	}
+	extraItems, err := besuheader.SplitList(extraData)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode BFT extra data: %s", err.Error())
+	}
+	if len(extraItems) < bftExtraDataMinItems {
+		return nil, fmt.Errorf("BFT extra data has %d items, expected %d", len(extraItems), bftExtraDataMinItems)
+	}
+	var seals [][]byte
+	err = rlp.DecodeBytes(extraItems[bftExtraDataSealsIndex], &seals)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode commit seals: %s", err.Error())
+	}
+
+	sealedExtraItems := append([]rlp.RawValue{}, extraItems[:bftExtraDataSealsIndex]...)
+	switch policyType {
+	case besuPolicyTypeQBFT:
+		sealedExtraItems = append(sealedExtraItems, rlp.EmptyList)
+	case besuPolicyTypeIBFT2:
+	default:
+		return nil, fmt.Errorf("Verification policy type not supported for Besu views: %s", policyType)
+	}
+	sealedExtraData, err := rlp.EncodeToBytes(sealedExtraItems)
+	if err != nil {
+		return nil, err
+	}
+	sealedHeaderFields := append([]rlp.RawValue{}, headerFields...)
+	sealedHeaderFields[besuheader.ExtraDataIndex], err = rlp.EncodeToBytes(sealedExtraData)
+	if err != nil {
+		return nil, err
+	}
+	sealedHeader, err := rlp.EncodeToBytes(sealedHeaderFields)
+	if err != nil {
+		return nil, err
+	}
+	sealHash := crypto.Keccak256(sealedHeader)
+
+	signers := make([]ethcommon.Address, len(seals))
+	for i, seal := range seals {
+		publicKey, err := crypto.SigToPub(sealHash, seal)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to recover signer of commit seal %d: %s", i, err.Error())
+		}
+		signers[i] = crypto.PubkeyToAddress(*publicKey)
+	}
+	return signers, nil
+}
+
+// checkBesuValidatorQuorum checks that distinct validators in the membership signed at least
+// ceil(2n/3) of the n validators' commit seals, and that every member listed in the policy criteria signed.
+func checkBesuValidatorQuorum(signers []ethcommon.Address, membership *common.Membership, verificationPolicy *common.Policy) error {
+	validators := map[ethcommon.Address]string{}
+	for id, member := range membership.Members {
+		if member.Type != besuValidatorMemberType {
+			continue
+		}
+		if !ethcommon.IsHexAddress(member.Value) {
+			return fmt.Errorf("Validator %s has an invalid address: %s", id, member.Value)
+		}
+		validators[ethcommon.HexToAddress(member.Value)] = id
+	}
+	if len(validators) == 0 {
+		return fmt.Errorf("Membership for %s has no validators", membership.SecurityDomain)
+	}
+
+	signerList := []string{}
+	for _, signer := range signers {
+		id, ok := validators[signer]
+		if !ok {
+			return fmt.Errorf("Commit seal signer %s is not a validator in the membership", signer.Hex())
+		}
+		if Contains(signerList, id) {
+			return fmt.Errorf("Duplicate commit seal from validator: %s", id)
+		}
+		signerList = append(signerList, id)
+	}
+	quorum := (2*len(validators) + 2) / 3
+	if len(signerList) < quorum {
+		return fmt.Errorf("Commit seals from %d validators do not meet the quorum of %d", len(signerList), quorum)
+	}
+	for _, signer := range verificationPolicy.Criteria {
+		if !Contains(signerList, signer) {
+			return fmt.Errorf("Commit seals missing signer: %s", signer)
+		}
+	}
+	return nil
+}
+
+// verifyMerkleProof walks a Merkle-Patricia trie proof from the root to the given (hashed) key and
+// returns the value stored there, or nil if the proof shows that the key is absent from the trie.
+func verifyMerkleProof(root []byte, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := map[string][]byte{}
+	for _, node := range proof {
+		nodes[string(crypto.Keccak256(node))] = node
+	}
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	reference := root
+	var node []byte
+	for {
+		// A reference is either the hash of a node in the proof or, for nodes shorter than a hash, the node itself.
+		if len(reference) == 0 {
+			return nil, nil
+		} else if len(reference) == ethcommon.HashLength {
+			found, ok := nodes[string(reference)]
+			if !ok {
+				return nil, fmt.Errorf("Proof is missing trie node %x", reference)
+			}
+			node = found
+		} else {
+			node = reference
+		}
+		items, err := besuheader.SplitList(node)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to decode trie node: %s", err.Error())
+		}
+
+		switch len(items) {
+		case 17:
+			// Branch node
+			if len(nibbles) == 0 {
+				return decodeTrieValue(items[16])
+			}
+			reference, err = decodeTrieReference(items[nibbles[0]])
+			if err != nil {
+				return nil, err
+			}
+			nibbles = nibbles[1:]
+		case 2:
+			// Leaf or extension node with a hex-prefix encoded path
+			var path []byte
+			err = rlp.DecodeBytes(items[0], &path)
+			if err != nil || len(path) == 0 {
+				return nil, fmt.Errorf("Invalid trie node path")
+			}
+			isLeaf := path[0]>>4 >= 2
+			pathNibbles := []byte{}
+			if path[0]>>4&1 == 1 {
+				pathNibbles = append(pathNibbles, path[0]&0x0f)
+			}
+			for _, b := range path[1:] {
+				pathNibbles = append(pathNibbles, b>>4, b&0x0f)
+			}
+			if isLeaf {
+				if !bytes.Equal(pathNibbles, nibbles) {
+					return nil, nil
+				}
+				return decodeTrieValue(items[1])
+			}
+			if !bytes.HasPrefix(nibbles, pathNibbles) {
+				return nil, nil
+			}
+			nibbles = nibbles[len(pathNibbles):]
+			reference, err = decodeTrieReference(items[1])
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("Invalid trie node with %d items", len(items))
+		}
+	}
+}
+
+// decodeTrieReference decodes a child reference of a trie node, which is either a hash or an embedded node.
+func decodeTrieReference(item rlp.RawValue) ([]byte, error) {
+	kind, content, _, err := rlp.Split(item)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode trie node reference: %s", err.Error())
+	}
+	if kind == rlp.List {
+		return item, nil
+	}
+	if len(content) != 0 && len(content) != ethcommon.HashLength {
+		return nil, fmt.Errorf("Invalid trie node reference of %d bytes", len(content))
+	}
+	return content, nil
+}
+
+// decodeTrieValue decodes the value held by a leaf or branch node, returning nil for an empty value.
+func decodeTrieValue(item rlp.RawValue) ([]byte, error) {
+	var value []byte
+	err := rlp.DecodeBytes(item, &value)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode trie value: %s", err.Error())
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/besu"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// BesuTestData holds a view from a QBFT network of four validators, three of which sealed the block.
+//
+// The view in test_data/besu_viewdata.json is synthetic: its block header, commit seals and storage proof were built
+// offline with the development keys of the validators of weaver/tests/network-setups/besu, following the QBFT header
+// and seal encoding, as no Besu QBFT network could be run to record one. It checks the verification logic against
+// that encoding, not against Besu itself; it should be replaced with a view recorded from a Besu QBFT network.
+type BesuTestData struct {
+	Address    string   `json:"address"`
+	Validators []string `json:"validators"`
+	B64View    string   `json:"view64"`
+}
+
+func readBesuTestData(t *testing.T) (*BesuTestData, *common.View, *besu.BesuView) {
+	testDataBytes, err := ioutil.ReadFile("./test_data/besu_viewdata.json")
+	require.NoError(t, err)
+	var testData BesuTestData
+	require.NoError(t, json.Unmarshal(testDataBytes, &testData))
+	viewBytes, err := base64.StdEncoding.DecodeString(testData.B64View)
+	require.NoError(t, err)
+	var view common.View
+	require.NoError(t, protoV2.Unmarshal(viewBytes, &view))
+	var besuView besu.BesuView
+	require.NoError(t, protoV2.Unmarshal(view.Data, &besuView))
+	return &testData, &view, &besuView
+}
+
+func besuValidatorMembership(validators ...string) *common.Membership {
+	membership := &common.Membership{SecurityDomain: "besu-network", Members: map[string]*common.Member{}}
+	for i, validator := range validators {
+		membership.Members["validator"+string(rune('1'+i))] = &common.Member{Value: validator, Type: "validator"}
+	}
+	return membership
+}
+
+func marshalBesuView(t *testing.T, besuView *besu.BesuView) []byte {
+	data, err := protoV2.Marshal(besuView)
+	require.NoError(t, err)
+	return data
+}
+
+func TestVerifyBesuView(t *testing.T) {
+	testData, view, besuView := readBesuTestData(t)
+	membership := besuValidatorMembership(testData.Validators...)
+	policy := &common.Policy{Type: "QBFT", Criteria: []string{"validator1", "validator4"}}
+
+	// Happy case: three of four validators sealed the block and the storage proofs match the payload
+	err := verifyBesuView(view.Data, policy, membership, testData.Address)
+	require.NoError(t, err)
+
+	// The commit seals of a QBFT block do not verify as IBFT 2.0 seals
+	err = verifyBesuView(view.Data, &common.Policy{Type: "IBFT2"}, membership, testData.Address)
+	require.ErrorContains(t, err, "is not a validator in the membership")
+
+	err = verifyBesuView(view.Data, &common.Policy{Type: "Signature"}, membership, testData.Address)
+	require.EqualError(t, err, "Verification policy type not supported for Besu views: Signature")
+
+	// A validator named in the criteria did not seal the block
+	err = verifyBesuView(view.Data, &common.Policy{Type: "QBFT", Criteria: []string{"validator3"}}, membership, testData.Address)
+	require.EqualError(t, err, "Commit seals missing signer: validator3")
+
+	// Three seals do not meet the quorum of a five-validator network
+	largerMembership := besuValidatorMembership(append(testData.Validators, "0x0000000000000000000000000000000000000001")...)
+	err = verifyBesuView(view.Data, policy, largerMembership, testData.Address)
+	require.EqualError(t, err, "Commit seals from 3 validators do not meet the quorum of 4")
+
+	// A seal from a validator outside the membership is rejected
+	err = verifyBesuView(view.Data, policy, besuValidatorMembership(testData.Validators[1:]...), testData.Address)
+	require.ErrorContains(t, err, "is not a validator in the membership")
+
+	// The view must answer the requested address
+	err = verifyBesuView(view.Data, policy, membership, testData.Address+"1")
+	require.ErrorContains(t, err, "Address in response does not match original address")
+
+	// Tampered storage value
+	tampered := protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.StorageProofs[1].Value = []byte{0x2b}
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.EqualError(t, err, "Storage proof 1 proves value 2a, view claims 2b")
+
+	// Tampered payload
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	var interopPayload common.InteropPayload
+	require.NoError(t, protoV2.Unmarshal(tampered.InteropPayload, &interopPayload))
+	interopPayload.Payload[len(interopPayload.Payload)-1] = 1
+	tampered.InteropPayload, err = protoV2.Marshal(&interopPayload)
+	require.NoError(t, err)
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.EqualError(t, err, "View payload does not match the proven storage values")
+
+	// Truncated account proof
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.Proof = tampered.AccountProof.Proof[:1]
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.ErrorContains(t, err, "Account proof verification failed: Proof is missing trie node")
+
+	// Account proof for another contract
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.Address[0] ^= 0xff
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.ErrorContains(t, err, "expected contract 0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+	// A valid proof of another slot does not prove the addressed slot
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.StorageProofs[0], tampered.AccountProof.StorageProofs[1] = tampered.AccountProof.StorageProofs[1], tampered.AccountProof.StorageProofs[0]
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.EqualError(t, err, "Storage proof 0 is for key 0000000000000000000000000000000000000000000000000000000000000001, address names key 0000000000000000000000000000000000000000000000000000000000000000")
+
+	// The proofs must cover exactly the addressed slots
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.StorageProofs = tampered.AccountProof.StorageProofs[:3]
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.EqualError(t, err, "Besu view contains 3 storage proofs, address names 4 storage slots")
+
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	tampered.AccountProof.StorageProofs = nil
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, testData.Address)
+	require.EqualError(t, err, "Besu view does not contain storage proofs")
+
+	// The view must answer an address reading contract storage
+	otherAddress := strings.Replace(testData.Address, ":storage:", ":get(uint256):", 1)
+	tampered = protoV2.Clone(besuView).(*besu.BesuView)
+	require.NoError(t, protoV2.Unmarshal(tampered.InteropPayload, &interopPayload))
+	interopPayload.Address = otherAddress
+	tampered.InteropPayload, err = protoV2.Marshal(&interopPayload)
+	require.NoError(t, err)
+	err = verifyBesuView(marshalBesuView(t, tampered), policy, membership, otherAddress)
+	require.EqualError(t, err, "Unable to parse view address: State proofs can only prove views of function 'storage', not get(uint256)")
+}
+
+func TestBesuStorageKeys(t *testing.T) {
+	holder := "0x627306090abaB3A6e1400e9345bC60c78a8BEf57"
+	viewAddress, err := parseBesuViewAddress("1337:0x5FbDB2315678afecb367f032d93F642f64180aa3:storage:2:0x10:3[" + holder + "]:4[1][0x2a]")
+	require.NoError(t, err)
+	keys, err := viewAddress.storageKeys()
+	require.NoError(t, err)
+
+	word := func(hex string) []byte {
+		return hexutil.MustDecode("0x" + strings.Repeat("0", 64-len(hex)) + hex)
+	}
+	require.Equal(t, [][]byte{
+		word("2"),
+		word("10"),
+		// Entry of a mapping at slot 3, keyed by an address
+		crypto.Keccak256(word(holder[2:]), word("3")),
+		// Entry of a nested mapping at slot 4
+		crypto.Keccak256(word("2a"), crypto.Keccak256(word("1"), word("4"))),
+	}, keys)
+
+	for _, slots := range []string{"", ":x", ":-1", ":0x1" + strings.Repeat("0", 64), ":3[1", ":3[1]2", ":3[]"} {
+		viewAddress, err = parseBesuViewAddress("1337:0x5FbDB2315678afecb367f032d93F642f64180aa3:storage" + slots)
+		require.NoError(t, err)
+		_, err = viewAddress.storageKeys()
+		require.Error(t, err, slots)
+	}
+}
+
+func TestWriteExternalStateBesu(t *testing.T) {
+	testData, view, besuView := readBesuTestData(t)
+	membership := besuValidatorMembership(testData.Validators...)
+	verificationPolicy := common.VerificationPolicy{
+		SecurityDomain: "besu-network",
+		Identifiers: []*common.Identifier{{
+			Pattern: "1337:0x5FbDB2315678afecb367f032d93F642f64180aa3:*",
+			Policy:  &common.Policy{Type: "QBFT", Criteria: []string{}},
+		}},
+	}
+
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	verificationPolicyBytes, err := json.Marshal(&verificationPolicy)
+	require.NoError(t, err)
+	membershipBytes, err := json.Marshal(membership)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturnsOnCall(0, verificationPolicyBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, membershipBytes, nil)
+	chaincodeStub.InvokeChaincodeReturns(peer.Response{
+		Status:  200,
+		Message: "",
+		Payload: []byte("I am a result"),
+	})
+
+	err = interopcc.WriteExternalState(ctx, "besuApp", "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{testData.Address}, []string{testData.B64View}, [][]string{{""}})
+	require.NoError(t, err)
+	_, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	var interopPayload common.InteropPayload
+	require.NoError(t, protoV2.Unmarshal(besuView.InteropPayload, &interopPayload))
+	require.Equal(t, interopPayload.Payload, args[2])
+
+	viewData, err := ExtractAndValidateDataFromView(view, []string{""}, &common.Policy{Type: "QBFT"})
+	require.NoError(t, err)
+	require.Equal(t, interopPayload.Payload, viewData)
+
+	// Unsupported proof type
+	view.Meta.ProofType = "Notarization"
+	viewBytes, err := protoV2.Marshal(view)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturnsOnCall(2, verificationPolicyBytes, nil)
+	err = interopcc.VerifyView(ctx, base64.StdEncoding.EncodeToString(viewBytes), testData.Address)
+	require.EqualError(t, err, "Proof type not supported: Notarization")
+}
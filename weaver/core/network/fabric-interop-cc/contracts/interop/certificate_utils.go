@@ -17,11 +17,13 @@ import (
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"hash"
 	"math/big"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/ed25519"
@@ -35,6 +37,12 @@ const (
 	intCertsKey  = "intermediate_certs"
 )
 
+// fabricCAAttributesOID identifies the certificate extension in which Fabric CA stores the attributes of an identity
+var fabricCAAttributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// mspRoles are the organizational units with which Fabric MSPs (with NodeOUs enabled) classify identities
+var mspRoles = []string{"client", "peer", "admin", "orderer"}
+
 // ECDSASignature represents an ECDSA signature
 type ECDSASignature struct {
 	R, S *big.Int
@@ -274,6 +282,38 @@ func parseCert(certString string) (*x509.Certificate, error) {
 	return cert, err
 }
 
+// getCertificateRole returns the MSP role (client, peer, admin or orderer) carried by the organizational units of a
+// certificate, or the empty string if it carries none
+func getCertificateRole(cert *x509.Certificate) string {
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		for _, role := range mspRoles {
+			if strings.EqualFold(ou, role) {
+				return role
+			}
+		}
+	}
+	return ""
+}
+
+// getCertificateAttributes returns the Fabric CA attributes of a certificate (e.g., hf.Affiliation, hf.EnrollmentID
+// and custom attributes), or an empty map if it carries none
+func getCertificateAttributes(cert *x509.Certificate) (map[string]string, error) {
+	var attributes struct {
+		Attrs map[string]string `json:"attrs"`
+	}
+	for _, extension := range cert.Extensions {
+		if extension.Id.Equal(fabricCAAttributesOID) {
+			if err := json.Unmarshal(extension.Value, &attributes); err != nil {
+				return nil, fmt.Errorf("Invalid attributes extension: %s", err)
+			}
+		}
+	}
+	if attributes.Attrs == nil {
+		return map[string]string{}, nil
+	}
+	return attributes.Attrs, nil
+}
+
 func isCertificateWithinExpiry(cert *x509.Certificate) error {
 	if cert == nil {
 		return errors.New("Cert is nil")
@@ -245,6 +245,46 @@ func TestConfidentialInteropPayload(t *testing.T) {
 	require.Equal(t, confPayload.Hash, fmac)
 }
 
+// createAttributeCertPEM creates a PEM certificate with organizational units and Fabric CA attributes
+func createAttributeCertPEM(t *testing.T, organization string, ous []string, attributesJSON string) string {
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:         "user1",
+			Organization:       []string{organization},
+			OrganizationalUnit: ous,
+		},
+		SerialNumber: big.NewInt(1337),
+	}
+	if attributesJSON != "" {
+		template.ExtraExtensions = []pkix.Extension{{Id: fabricCAAttributesOID, Value: []byte(attributesJSON)}}
+	}
+	certBytes, _, err := createECDSACertAndKeyFromTemplate(template)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}))
+}
+
+func TestCertificateRoleAndAttributes(t *testing.T) {
+	cert, err := parseCert(createAttributeCertPEM(t, "BankB", []string{"Admin", "audit"}, `{"attrs":{"hf.Affiliation":"bankb.audit","auditor":"true"}}`))
+	require.NoError(t, err)
+	require.Equal(t, "admin", getCertificateRole(cert))
+	attributes, err := getCertificateAttributes(cert)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"hf.Affiliation": "bankb.audit", "auditor": "true"}, attributes)
+
+	// Certificates without NodeOUs or attributes, e.g. those of Corda nodes
+	cert, err = parseCert(createAttributeCertPEM(t, "PartyA", nil, ""))
+	require.NoError(t, err)
+	require.Equal(t, "", getCertificateRole(cert))
+	attributes, err = getCertificateAttributes(cert)
+	require.NoError(t, err)
+	require.Empty(t, attributes)
+
+	cert, err = parseCert(createAttributeCertPEM(t, "BankB", nil, "attrs"))
+	require.NoError(t, err)
+	_, err = getCertificateAttributes(cert)
+	require.ErrorContains(t, err, "Invalid attributes extension")
+}
+
 func generateCertFromTemplate(template x509.Certificate, keyType string) ([]byte, error) {
 	random := rand.Reader
 	switch keyType {
@@ -0,0 +1,122 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// channels contains the configuration of the channels, other than its own, on which the interop chaincode
+// may serve views from application chaincodes, and the routing of external requests to those channels
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+)
+
+const crossChannelObjectType = "crossChannel"
+
+// EnableCrossChannelAccess cc allows external requests to be served by application chaincodes on the given channel,
+// which must differ from the channel of the interop chaincode
+func (s *SmartContract) EnableCrossChannelAccess(ctx contractapi.TransactionContextInterface, channelId string) error {
+	// Check if the caller has network admin privileges
+	if isAdmin, err := wutils.IsClientNetworkAdmin(ctx); err != nil {
+		return fmt.Errorf("Admin client check error: %s", err)
+	} else if !isAdmin {
+		return fmt.Errorf("Caller not a network admin; access denied")
+	}
+
+	if channelId == "" {
+		return logThenErrorf("Channel ID can not be empty")
+	}
+	if channelId == ctx.GetStub().GetChannelID() {
+		return logThenErrorf("Channel %s is the channel of the interop chaincode and needs no enablement", channelId)
+	}
+	crossChannelKey, err := ctx.GetStub().CreateCompositeKey(crossChannelObjectType, []string{channelId})
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().PutState(crossChannelKey, []byte(channelId))
+}
+
+// DisableCrossChannelAccess cc stops external requests from being served by application chaincodes on the given channel
+func (s *SmartContract) DisableCrossChannelAccess(ctx contractapi.TransactionContextInterface, channelId string) error {
+	// Check if the caller has network admin privileges
+	if isAdmin, err := wutils.IsClientNetworkAdmin(ctx); err != nil {
+		return fmt.Errorf("Admin client check error: %s", err)
+	} else if !isAdmin {
+		return fmt.Errorf("Caller not a network admin; access denied")
+	}
+
+	enabled, err := isCrossChannelAccessEnabled(ctx, channelId)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	if !enabled {
+		return logThenErrorf("Channel %s is not enabled for cross-channel requests", channelId)
+	}
+	crossChannelKey, err := ctx.GetStub().CreateCompositeKey(crossChannelObjectType, []string{channelId})
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().DelState(crossChannelKey)
+}
+
+// GetCrossChannelAccess cc returns the channels enabled for cross-channel requests, as a JSON array
+func (s *SmartContract) GetCrossChannelAccess(ctx contractapi.TransactionContextInterface) (string, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(crossChannelObjectType, []string{})
+	if err != nil {
+		return "", logThenErrorf("%s", err.Error())
+	}
+	defer iterator.Close()
+
+	channelIds := []string{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return "", logThenErrorf("%s", err.Error())
+		}
+		channelIds = append(channelIds, string(item.Value))
+	}
+	channelIdsJSON, err := json.Marshal(channelIds)
+	if err != nil {
+		return "", logThenErrorf("Marshal error: %s", err)
+	}
+	return string(channelIdsJSON), nil
+}
+
+func isCrossChannelAccessEnabled(ctx contractapi.TransactionContextInterface, channelId string) (bool, error) {
+	crossChannelKey, err := ctx.GetStub().CreateCompositeKey(crossChannelObjectType, []string{channelId})
+	if err != nil {
+		return false, err
+	}
+	enabled, err := ctx.GetStub().GetState(crossChannelKey)
+	if err != nil {
+		return false, err
+	}
+	return enabled != nil, nil
+}
+
+// verifyChannelRouting checks that the request for a view address can be served from the channel it names.
+// Application chaincodes on the channel of the interop chaincode are always reachable; those on other channels only
+// if the channel has been enabled, and only through read-only queries, as Fabric discards the writes of chaincodes
+// invoked on another channel. Interop chaincode functions are only served on the channel of the interop chaincode.
+func verifyChannelRouting(ctx contractapi.TransactionContextInterface, viewAddress *FabricViewAddress, localCCId string) error {
+	localChannelId := ctx.GetStub().GetChannelID()
+	if viewAddress.Channel == localChannelId {
+		return nil
+	}
+	if viewAddress.Contract == localCCId {
+		return fmt.Errorf("Interop Chaincode functions can only be invoked on channel %s, not %s", localChannelId, viewAddress.Channel)
+	}
+	enabled, err := isCrossChannelAccessEnabled(ctx, viewAddress.Channel)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("Channel %s is not enabled for cross-channel requests by the interop chaincode on channel %s", viewAddress.Channel, localChannelId)
+	}
+	return nil
+}
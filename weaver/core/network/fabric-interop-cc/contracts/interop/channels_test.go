@@ -0,0 +1,86 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"testing"
+
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils/mocks"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableCrossChannelAccess(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetChannelIDReturns("interopchannel")
+
+	// Case when caller is not an admin
+	err := interopcc.EnableCrossChannelAccess(ctx, "appchannel")
+	require.EqualError(t, err, "Caller not a network admin; access denied")
+	// Set caller to be admin now
+	clientIdentity := &mocks.ClientIdentity{}
+	clientIdentity.GetAttributeValueCalls(setClientAdmin)
+	ctx.GetClientIdentityReturns(clientIdentity)
+	err = interopcc.EnableCrossChannelAccess(ctx, "appchannel")
+	require.NoError(t, err)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, crossChannelObjectType, objectType)
+	require.Equal(t, []string{"appchannel"}, keys)
+	_, value := chaincodeStub.PutStateArgsForCall(0)
+	require.Equal(t, "appchannel", string(value))
+
+	// The channel of the interop chaincode needs no enablement
+	err = interopcc.EnableCrossChannelAccess(ctx, "interopchannel")
+	require.EqualError(t, err, "Channel interopchannel is the channel of the interop chaincode and needs no enablement")
+
+	// Enabled channels are listed
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturns(&queryresult.KV{Key: "appchannel", Value: []byte("appchannel")}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	channelIds, err := interopcc.GetCrossChannelAccess(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `["appchannel"]`, channelIds)
+
+	// Only enabled channels can be disabled
+	chaincodeStub.GetStateReturnsOnCall(0, nil, nil)
+	err = interopcc.DisableCrossChannelAccess(ctx, "otherchannel")
+	require.EqualError(t, err, "Channel otherchannel is not enabled for cross-channel requests")
+	chaincodeStub.GetStateReturnsOnCall(1, []byte("appchannel"), nil)
+	err = interopcc.DisableCrossChannelAccess(ctx, "appchannel")
+	require.NoError(t, err)
+	require.Equal(t, 1, chaincodeStub.DelStateCallCount())
+}
+
+func TestVerifyChannelRouting(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	chaincodeStub.GetChannelIDReturns("interopchannel")
+
+	// Application chaincodes and interop chaincode functions on the channel of the interop chaincode are reachable
+	err := verifyChannelRouting(ctx, &FabricViewAddress{Channel: "interopchannel", Contract: "simplestate", CCFunc: "Read", Args: []string{"a"}}, "interop")
+	require.NoError(t, err)
+	err = verifyChannelRouting(ctx, &FabricViewAddress{Channel: "interopchannel", Contract: "interop", CCFunc: "GetHTLCHashByContractId", Args: []string{"c01"}}, "interop")
+	require.NoError(t, err)
+	require.Equal(t, 0, chaincodeStub.GetStateCallCount())
+
+	// Interop chaincode functions are not served on other channels
+	err = verifyChannelRouting(ctx, &FabricViewAddress{Channel: "appchannel", Contract: "interop", CCFunc: "GetHTLCHashByContractId", Args: []string{"c01"}}, "interop")
+	require.EqualError(t, err, "Interop Chaincode functions can only be invoked on channel interopchannel, not appchannel")
+
+	// Application chaincodes on other channels are only reachable once the channel is enabled
+	chaincodeStub.GetStateReturnsOnCall(0, nil, nil)
+	err = verifyChannelRouting(ctx, &FabricViewAddress{Channel: "appchannel", Contract: "simplestate", CCFunc: "Read", Args: []string{"a"}}, "interop")
+	require.EqualError(t, err, "Channel appchannel is not enabled for cross-channel requests by the interop chaincode on channel interopchannel")
+	chaincodeStub.GetStateReturnsOnCall(1, []byte("appchannel"), nil)
+	err = verifyChannelRouting(ctx, &FabricViewAddress{Channel: "appchannel", Contract: "simplestate", CCFunc: "Read", Args: []string{"a"}}, "interop")
+	require.NoError(t, err)
+	_, keys := chaincodeStub.CreateCompositeKeyArgsForCall(1)
+	require.Equal(t, []string{"appchannel"}, keys)
+}
@@ -10,9 +10,9 @@ package main
 
 import (
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
@@ -22,12 +22,17 @@ import (
 	protoV2 "google.golang.org/protobuf/proto"
 )
 
+// maxProposalClockSkew is the maximum difference between the timestamp of a request's transaction proposal
+// and the endorsing peer's clock
+const maxProposalClockSkew = defaultViewClockSkew * time.Second
+
 // HandleExternalRequest chaincode processes requests that come from external networks.
 //
 // The flow coordinates the following:
 // 1. Checks the validity of query signature
 // 2. Checks that the certificate of the requester is valid according to the network's Membership
-// 3. Checks the access control policy for the requester and view address is met
+// 3. Checks the access control policy for the requester and view address is met; requests under rate-limited rules
+// are only served if their access decision was recorded with RecordAccessDecision
 // 4. Calls application chaincode
 func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContextInterface, b64QueryBytes string) (string, error) {
 	queryBytes, err := base64.StdEncoding.DecodeString(b64QueryBytes)
@@ -39,7 +44,7 @@ func (s *SmartContract) HandleExternalRequest(ctx contractapi.TransactionContext
 	if err != nil {
 		return "", logThenErrorf("Unable to unmarshal query: %s", err.Error())
 	}
-	resp, err := handleRequest(s, ctx, query, query.Address)
+	resp, err := handleRequest(s, ctx, query, query.Address, requireRecordedDecision)
 	return resp, err
 }
 
@@ -66,40 +71,49 @@ func (s *SmartContract) HandleEventRequest(ctx contractapi.TransactionContextInt
 		fmt.Println("There are no dynamic arguments in the event query address, queryArg: ", dynamicQueryArg)
 	}
 
-	resp, err := handleRequest(s, ctx, query, queryAddress)
+	// Events are pushed to their subscribers rather than requested, so they are not rate-limited
+	resp, err := handleRequest(s, ctx, query, queryAddress, skipRateLimit)
 	return resp, err
 }
 
-// This function handleRequest handle requests that originate in external requests and have come through relays.
+// authenticatedRequest is a request from a remote network whose requester has been authenticated
+type authenticatedRequest struct {
+	address     *Address
+	viewAddress *FabricViewAddress
+	// function is the interop chaincode function called by the request, if it is addressed to the interop chaincode
+	function  remoteFunction
+	localCCId string
+}
+
+// authenticateRequest checks that a request from a remote network came through the relay and that its requester
+// is a member of the requesting network, and parses the address of the requested view.
 //
 // The flow coordinates the following:
 // 1. Checks the validity of query signature
 // 2. Checks that the certificate of the requester is valid according to the network's Membership
-// 3. Checks the access control policy for the requester and view address is met
-// 4. Calls application chaincode
-func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface, query common.Query, queryAddress string) (string, error) {
+func authenticateRequest(s *SmartContract, ctx contractapi.TransactionContextInterface, query *common.Query, queryAddress string) (*authenticatedRequest, error) {
 	// Ensure that this function cannot be called by a client without relay permissions
 	relayAccessCheck, err := wutils.IsClientRelay(ctx.GetStub())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if !relayAccessCheck {
-		return "", fmt.Errorf("Illegal access by client without relay permissions")
+		return nil, fmt.Errorf("Illegal access by client without relay permissions")
 	}
 	fmt.Println("Relay access check passed")
 
 	x509Cert, err := parseCert(query.Certificate)
 	if err != nil {
-		return "", logThenErrorf("Unable to parse certificate: %s", err)
+		return nil, logThenErrorf("Unable to parse certificate: %s", err)
 	}
 	// 1. Checks the validity of query signature
 	signatureBytes, err := base64.StdEncoding.DecodeString(query.RequestorSignature)
 	if err != nil {
-		return "", logThenErrorf("Signature base64 decoding failed: %s", err)
+		return nil, logThenErrorf("Signature base64 decoding failed: %s", err)
 	}
 	err = validateSignature(query.Address+query.Nonce, x509Cert, string(signatureBytes))
 	if err != nil {
-		return "", logThenErrorf("Invalid Signature: %s", err)
+		return nil, logThenErrorf("Invalid Signature: %s", err)
 	}
 	// 2. Checks that the certificate of the requester is valid according to the network's Membership
 	if query.RequestingOrg == "" {
@@ -108,18 +122,50 @@ func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface
 
 	err = verifyMemberInSecurityDomain(s, ctx, query.Certificate, query.RequestingNetwork, query.RequestingOrg)
 	if err != nil {
-		return "", logThenErrorf("Membership Verification failed: %s", err)
+		return nil, logThenErrorf("Membership Verification failed: %s", err)
 	}
-	// 3. Checks the access control policy for the requester and view address is met
 	address, err := parseAddress(queryAddress)
 	if err != nil {
-		return "", logThenErrorf("Invalid address: %s", err)
+		return nil, logThenErrorf("Invalid address: %s", err)
 	}
 	viewAddress, err := parseFabricViewAddress(address.ViewSegment)
 	if err != nil {
-		return "", logThenErrorf("Invalid view address: %s", err)
+		return nil, logThenErrorf("Invalid view address: %s", err)
+	}
+	// Requests for the interop chaincode itself may only call the functions it exposes to remote networks
+	localCCId, err := wutils.GetLocalChaincodeID(ctx.GetStub())
+	if err != nil {
+		return nil, logThenErrorf("%s", err.Error())
 	}
-	err = verifyAccessToCC(s, ctx, viewAddress, address.ViewSegment, &query)
+	err = verifyChannelRouting(ctx, viewAddress, localCCId)
+	if err != nil {
+		return nil, logThenErrorf("Invalid channel: %s", err)
+	}
+	request := authenticatedRequest{address: address, viewAddress: viewAddress, localCCId: localCCId}
+	if localCCId == viewAddress.Contract {
+		request.function, err = getRemoteFunction(viewAddress)
+		if err != nil {
+			return nil, logThenErrorf("%s", err.Error())
+		}
+	}
+	return &request, nil
+}
+
+// This function handleRequest handle requests that originate in external requests and have come through relays.
+//
+// The flow coordinates the following:
+// 1. Checks the validity of query signature
+// 2. Checks that the certificate of the requester is valid according to the network's Membership
+// 3. Checks the access control policy for the requester and view address is met
+// 4. Calls application chaincode
+func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface, query common.Query, queryAddress string, mode rateLimitMode) (string, error) {
+	request, err := authenticateRequest(s, ctx, &query, queryAddress)
+	if err != nil {
+		return "", err
+	}
+	viewAddress, localCCId, function := request.viewAddress, request.localCCId, request.function
+	// 3. Checks the access control policy for the requester and view address is met
+	err = verifyAccessToCC(s, ctx, viewAddress, request.address.ViewSegment, &query, mode)
 	if err != nil {
 		return "", logThenErrorf("CC Access Denied: %s", err)
 	}
@@ -127,36 +173,11 @@ func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface
 	arr := append([]string{viewAddress.CCFunc}, viewAddress.Args...)
 	byteArgs := strArrToBytesArr(arr)
 
-	localCCId, err := wutils.GetLocalChaincodeID(ctx.GetStub())
 	payload := []byte("")
 	confidential := false
 	if localCCId == viewAddress.Contract {
 		// Interop call to InteropCC itself.
-		resp := ""
-		if viewAddress.CCFunc == "GetHTLCHash" {
-			if len(viewAddress.Args) > 1 {
-				return "", logThenErrorf("Recieved more arguments than required 1 argument.")
-			}
-			resp, err = s.GetHTLCHash(ctx, viewAddress.Args[0])
-		} else if viewAddress.CCFunc == "GetHTLCHashByContractId" {
-			if len(viewAddress.Args) > 1 {
-				return "", logThenErrorf("Recieved more arguments than required 1 argument.")
-			}
-			resp, err = s.GetHTLCHashByContractId(ctx, viewAddress.Args[0])
-		} else if viewAddress.CCFunc == "GetHTLCHashPreImage" {
-			if len(viewAddress.Args) > 1 {
-				return "", logThenErrorf("Recieved more arguments than required 1 argument.")
-			}
-			resp, err = s.GetHTLCHashPreImage(ctx, viewAddress.Args[0])
-		} else if viewAddress.CCFunc == "GetHTLCHashPreImageByContractId" {
-			if len(viewAddress.Args) > 1 {
-				return "", logThenErrorf("Recieved more arguments than required 1 argument.")
-			}
-			resp, err = s.GetHTLCHashPreImageByContractId(ctx, viewAddress.Args[0])
-		} else {
-			errorMessage := fmt.Sprintf("Given function %s can not be invoked in Interop Chaincode.", viewAddress.CCFunc)
-			err = errors.New(errorMessage)
-		}
+		resp, err := function.call(s, ctx, viewAddress.Args)
 		if err != nil {
 			log.Error(err)
 			return "", err
@@ -166,6 +187,9 @@ func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface
 		// General Interop Call to AppCC
 		pbResp := ctx.GetStub().InvokeChaincode(viewAddress.Contract, byteArgs, viewAddress.Channel)
 		if pbResp.Status != shim.OK {
+			if viewAddress.Channel != ctx.GetStub().GetChannelID() {
+				return "", logThenErrorf("Application chaincode invoke error on channel %s (the chaincode must be installed on the endorsing peers, which must be joined to the channel): %s", viewAddress.Channel, string(pbResp.GetMessage()))
+			}
 			return "", logThenErrorf("Application chaincode invoke error: %s", string(pbResp.GetMessage()))
 		}
 		// 5. Encrypt payload if necessary
@@ -187,12 +211,24 @@ func handleRequest(s *SmartContract, ctx contractapi.TransactionContextInterface
 		}
 	}
 
+	// 6. Date the payload so that the requesting network can judge its freshness. The transaction timestamp is
+	// chosen by the relay driver submitting the proposal, so it is only endorsed if it is close to the peer's clock.
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", logThenErrorf("Unable to get transaction timestamp: %s", err)
+	}
+	clockSkew := time.Since(txTimestamp.AsTime())
+	if clockSkew > maxProposalClockSkew || clockSkew < -maxProposalClockSkew {
+		return "", logThenErrorf("Transaction timestamp %s is more than %s away from the peer's clock", txTimestamp.AsTime().UTC().Format(time.RFC3339), maxProposalClockSkew)
+	}
+
 	interopPayloadStruct := common.InteropPayload{
 		Address:              queryAddress,
 		Payload:              payload,
 		Confidential:         confidential,
 		RequestorCertificate: query.Certificate,
 		Nonce:                query.Nonce,
+		Timestamp:            txTimestamp.GetSeconds(),
 	}
 	interopPayloadBytes, err := protoV2.Marshal(&interopPayloadStruct)
 	if err != nil {
@@ -31,6 +31,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/require"
 	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // function that supplies value that is to be returned by ctx.GetStub().GetCreator()
@@ -130,6 +131,10 @@ func TestHandleExternalRequest(t *testing.T) {
 	testHandleExternalRequestNoMembership(t, &query, validCertificate, signature, pbResp)
 	// Happy case. ECDSA Cert and Valid Signature
 	testHandleExternalRequestECDSAHappyCase(t, &query, validCertificate, key, signature, pbResp, &accessControlAsset, &membershipAsset)
+	// Requests under rate-limited rules, served after recording their access decisions
+	testRecordAccessDecision(t, &query, validCertificate, signature, pbResp, &membershipAsset)
+	// Requests for application chaincodes on other channels
+	testHandleExternalRequestCrossChannel(t, &query, validCertificate, key, pbResp, &membershipAsset)
 	// ed25519 Cert and Signature
 	testHandleExternalRequestED25519Signature(t, &query, pbResp, &accessControlAsset, &membershipAsset, template)
 	// Test event requests
@@ -229,8 +234,11 @@ func testHandleExternalRequestECDSAHappyCase(t *testing.T, query *common.Query,
 	ctx, chaincodeStub := wtest.PrepMockStub()
 	interopcc := SmartContract{}
 	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
+	txTimestamp := timestamppb.Now()
+	chaincodeStub.GetTxTimestampReturns(txTimestamp, nil)
 	interopCCId := "interopcc"
 	wtest.SetMockStubCCId(chaincodeStub, interopCCId)
+	chaincodeStub.GetChannelIDReturns("mychannel")
 
 	// set correct values for the success case
 	query.Certificate = validCertificate
@@ -245,6 +253,7 @@ func testHandleExternalRequestECDSAHappyCase(t *testing.T, query *common.Query,
 		Confidential:         false,
 		RequestorCertificate: query.Certificate,
 		Nonce:                query.Nonce,
+		Timestamp:            txTimestamp.GetSeconds(),
 	}
 	interopPayloadBytes, err := protoV2.Marshal(&interopPayload)
 	require.NoError(t, err)
@@ -295,14 +304,173 @@ func testHandleExternalRequestECDSAHappyCase(t *testing.T, query *common.Query,
 	mac.Write(confPayloadContents.Payload)
 	fmac := mac.Sum(nil)
 	require.Equal(t, confPayload.Hash, fmac)
+
+	// proposals dated away from the peer's clock are not endorsed
+	for _, skewedTime := range []time.Time{time.Now().Add(-2 * maxProposalClockSkew), time.Now().Add(2 * maxProposalClockSkew)} {
+		chaincodeStub.GetTxTimestampReturns(timestamppb.New(skewedTime), nil)
+		chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount(), membershipBytes, nil)
+		chaincodeStub.GetStateReturnsOnCall(chaincodeStub.GetStateCallCount()+1, accessControlBytes, nil)
+		_, err = interopcc.HandleExternalRequest(ctx, string(b64QueryBytes))
+		require.ErrorContains(t, err, "away from the peer's clock")
+	}
+}
+
+func testRecordAccessDecision(t *testing.T, query *common.Query, validCertificate string, signature []byte, pbResp pb.Response, membership *common.Membership) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
+	chaincodeStub.GetTxIDReturns("tx1")
+	chaincodeStub.GetTxTimestampReturns(timestamppb.Now(), nil)
+	wtest.SetMockStubCCId(chaincodeStub, "interopcc")
+	chaincodeStub.GetChannelIDReturns("mychannel")
+
+	query.Certificate = validCertificate
+	query.RequestorSignature = base64.StdEncoding.EncodeToString(signature)
+	query.Confidential = false
+	queryBytes, err := protoV2.Marshal(query)
+	require.NoError(t, err)
+	b64QueryBytes := base64.StdEncoding.EncodeToString(queryBytes)
+
+	membershipBytes, err := json.Marshal(membership)
+	require.NoError(t, err)
+	accessControlBytes, err := json.Marshal(&common.AccessControlPolicy{
+		SecurityDomain: "network1",
+		Rules: []*common.Rule{{
+			Principal:     validCertificate,
+			PrincipalType: "certificate",
+			Read:          true,
+			Resource:      "mychannel:interop:Read:a",
+			RateLimit:     &common.RateLimit{MaxRequests: 10, WindowSeconds: 60},
+		}},
+	})
+	require.NoError(t, err)
+	chaincodeStub.InvokeChaincodeReturns(pbResp)
+
+	// Without a recorded decision, the request is not served
+	chaincodeStub.GetStateReturnsOnCall(0, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, accessControlBytes, nil)
+	_, err = interopcc.HandleExternalRequest(ctx, b64QueryBytes)
+	require.ErrorContains(t, err, "no access decision is recorded for the request (see RecordAccessDecision)")
+
+	// The decision is recorded under the nonce of the request, counting the request against the rate limit
+	chaincodeStub.GetStateReturnsOnCall(3, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(4, accessControlBytes, nil)
+	decisionJSON, err := interopcc.RecordAccessDecision(ctx, b64QueryBytes)
+	require.NoError(t, err)
+	var decision accessDecision
+	require.NoError(t, json.Unmarshal([]byte(decisionJSON), &decision))
+	require.True(t, decision.Permitted)
+	require.Equal(t, "tx1", decision.TxId)
+	require.Equal(t, query.Nonce, decision.Nonce)
+	require.Equal(t, 2, chaincodeStub.PutStateCallCount())
+	_, decisionBytes := chaincodeStub.PutStateArgsForCall(1)
+	require.Equal(t, decisionJSON, string(decisionBytes))
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(chaincodeStub.CreateCompositeKeyCallCount() - 1)
+	require.Equal(t, accessDecisionObjectType, objectType)
+	require.Equal(t, []string{"network1", query.Nonce}, keys)
+
+	// A decision is recorded only once for a nonce
+	chaincodeStub.GetStateReturnsOnCall(7, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(8, accessControlBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(10, decisionBytes, nil)
+	_, err = interopcc.RecordAccessDecision(ctx, b64QueryBytes)
+	require.EqualError(t, err, fmt.Sprintf("Access decision on the request with nonce %s from network1 is already recorded", query.Nonce))
+
+	// With the recorded decision, the request is served
+	chaincodeStub.GetStateReturnsOnCall(11, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(12, accessControlBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(13, decisionBytes, nil)
+	interopResponse, err := interopcc.HandleExternalRequest(ctx, b64QueryBytes)
+	require.NoError(t, err)
+	var interopPayload common.InteropPayload
+	require.NoError(t, protoV2.Unmarshal([]byte(interopResponse), &interopPayload))
+	require.Equal(t, []byte("17.12"), interopPayload.Payload)
+
+	// Decisions are only recorded by the relay
+	chaincodeStub.GetCreatorReturns([]byte(getCreator()), nil)
+	_, err = interopcc.RecordAccessDecision(ctx, b64QueryBytes)
+	require.EqualError(t, err, "Illegal access by client without relay permissions")
+}
+
+func testHandleExternalRequestCrossChannel(t *testing.T, query *common.Query, validCertificate string, validPrivateKey *ecdsa.PrivateKey, pbResp pb.Response, membership *common.Membership) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
+	txTimestamp := timestamppb.Now()
+	chaincodeStub.GetTxTimestampReturns(txTimestamp, nil)
+	interopCCId := "interopcc"
+	wtest.SetMockStubCCId(chaincodeStub, interopCCId)
+	chaincodeStub.GetChannelIDReturns("interopchannel")
+
+	// set correct values for a request for a chaincode on another channel
+	crossChannelQuery := protoV2.Clone(query).(*common.Query)
+	crossChannelQuery.Address = "localhost:9080/network1/appchannel:simplestate:Read:a"
+	crossChannelQuery.Certificate = validCertificate
+	crossChannelQuery.Confidential = false
+	hashed, err := computeSHA2Hash([]byte(crossChannelQuery.Address+crossChannelQuery.Nonce), validPrivateKey.PublicKey.Params().BitSize)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, validPrivateKey, hashed)
+	require.NoError(t, err)
+	crossChannelQuery.RequestorSignature = base64.StdEncoding.EncodeToString(signature)
+	queryBytes, err := protoV2.Marshal(crossChannelQuery)
+	require.NoError(t, err)
+	b64QueryBytes := base64.StdEncoding.EncodeToString(queryBytes)
+
+	// mock all the calls to the chaincode stub
+	membershipBytes, err := json.Marshal(membership)
+	require.NoError(t, err)
+	accessControlBytes, err := json.Marshal(&common.AccessControlPolicy{
+		SecurityDomain: "2345",
+		Rules: []*common.Rule{{
+			Principal:     validCertificate,
+			PrincipalType: "certificate",
+			Read:          true,
+			Resource:      "appchannel:simplestate:Read:a",
+		}},
+	})
+	require.NoError(t, err)
+
+	// Test failure: the channel has not been enabled
+	chaincodeStub.GetStateReturnsOnCall(0, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(1, nil, nil)
+	_, err = interopcc.HandleExternalRequest(ctx, string(b64QueryBytes))
+	require.EqualError(t, err, "Invalid channel: Channel appchannel is not enabled for cross-channel requests by the interop chaincode on channel interopchannel")
+	require.Equal(t, 0, chaincodeStub.InvokeChaincodeCallCount())
+
+	// Test success: the application chaincode is queried on the enabled channel
+	chaincodeStub.GetStateReturnsOnCall(2, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(3, []byte("appchannel"), nil)
+	chaincodeStub.GetStateReturnsOnCall(4, accessControlBytes, nil)
+	chaincodeStub.InvokeChaincodeReturns(pbResp)
+	interopResponse, err := interopcc.HandleExternalRequest(ctx, string(b64QueryBytes))
+	require.NoError(t, err)
+	contract, args, channel := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, "simplestate", contract)
+	require.Equal(t, [][]byte{[]byte("Read"), []byte("a")}, args)
+	require.Equal(t, "appchannel", channel)
+	var interopPayloadResp common.InteropPayload
+	err = protoV2.Unmarshal([]byte(interopResponse), &interopPayloadResp)
+	require.NoError(t, err)
+	require.Equal(t, pbResp.Payload, interopPayloadResp.Payload)
+
+	// Test failure: the application chaincode can't be reached on the enabled channel
+	chaincodeStub.GetStateReturnsOnCall(6, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(7, []byte("appchannel"), nil)
+	chaincodeStub.GetStateReturnsOnCall(8, accessControlBytes, nil)
+	chaincodeStub.InvokeChaincodeReturns(pb.Response{Status: shim.ERROR, Message: "chaincode simplestate not found"})
+	_, err = interopcc.HandleExternalRequest(ctx, string(b64QueryBytes))
+	require.EqualError(t, err, "Application chaincode invoke error on channel appchannel (the chaincode must be installed on the endorsing peers, which must be joined to the channel): chaincode simplestate not found")
 }
 
 func testHandleEventRequestECDSAHappyCase(t *testing.T, query *common.Query, validCertificate string, validPrivateKey *ecdsa.PrivateKey, signature []byte, pbResp pb.Response, accessControl *common.AccessControlPolicy, membership *common.Membership) {
 	ctx, chaincodeStub := wtest.PrepMockStub()
+	txTimestamp := timestamppb.Now()
+	chaincodeStub.GetTxTimestampReturns(txTimestamp, nil)
 	interopcc := SmartContract{}
 	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
 	interopCCId := "interopcc"
 	wtest.SetMockStubCCId(chaincodeStub, interopCCId)
+	chaincodeStub.GetChannelIDReturns("mychannel")
 	query.Confidential = false
 
 	// set correct values for the success case
@@ -319,6 +487,7 @@ func testHandleEventRequestECDSAHappyCase(t *testing.T, query *common.Query, val
 		Confidential:         false,
 		RequestorCertificate: query.Certificate,
 		Nonce:                query.Nonce,
+		Timestamp:            txTimestamp.GetSeconds(),
 	}
 	interopPayloadBytes, err := protoV2.Marshal(&interopPayload)
 	require.NoError(t, err)
@@ -402,10 +571,13 @@ func testHandleEventRequestECDSAHappyCase(t *testing.T, query *common.Query, val
 
 func testHandleExternalRequestED25519Signature(t *testing.T, query *common.Query, pbResp pb.Response, accessControl *common.AccessControlPolicy, fabricMembership *common.Membership, template x509.Certificate) {
 	ctx, chaincodeStub := wtest.PrepMockStub()
+	txTimestamp := timestamppb.Now()
+	chaincodeStub.GetTxTimestampReturns(txTimestamp, nil)
 	interopcc := SmartContract{}
 	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
 	interopCCId := "interopcc"
 	wtest.SetMockStubCCId(chaincodeStub, interopCCId)
+	chaincodeStub.GetChannelIDReturns("mychannel")
 
 	// create ed25519 cert and signature
 	certBytes, privKey, err := createED25519CertAndKeyFromTemplate(template)
@@ -466,6 +638,7 @@ func testHandleExternalRequestNoAccessControlPolicy(t *testing.T, query *common.
 	chaincodeStub.GetCreatorReturns([]byte(getRelayCreator()), nil)
 	interopCCId := "interopcc"
 	wtest.SetMockStubCCId(chaincodeStub, interopCCId)
+	chaincodeStub.GetChannelIDReturns("mychannel")
 
 	// set correct values for this test case
 	query.Certificate = validCertificate
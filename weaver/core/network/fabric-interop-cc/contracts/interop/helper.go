@@ -9,6 +9,8 @@ package main
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 )
 
@@ -76,37 +78,127 @@ func Contains(a []string, x string) bool {
 	return false
 }
 
-func validPatternString(pattern string) bool {
-	// count number of stars in pattern
-	numStars := strings.Count(pattern, "*")
+// View address patterns
+//
+// Access control rules and verification policy identifiers select view addresses with patterns that are
+// matched one ':'-separated segment at a time (e.g., channel, contract, function and each argument of a
+// Fabric view address), so that a pattern segment never matches text in a different address segment.
+// Each pattern segment takes one of the following forms:
+//   - a literal, which matches the address segment exactly;
+//   - a glob containing '*', '?' or '[...]' (see path.Match), which matches within the address segment only;
+//   - a regular expression enclosed in slashes (e.g., "/asset[0-9]+/"), anchored to the whole address segment;
+//   - a lone '*', which matches any single address segment, or, as the last pattern segment,
+//     all remaining address segments (at least one).
+//
+// When several patterns match an address, the most specific one applies: patterns are compared segment by
+// segment from the left, ranking literals above globs, globs above regular expressions, and those above
+// wildcards; a pattern with more segments wins a tie, and remaining ties are broken by the pattern strings.
+
+// Kinds of pattern segments, in increasing order of specificity
+const (
+	trailingWildcardSegment = iota
+	wildcardSegment
+	regexSegment
+	globSegment
+	literalSegment
+)
 
-	// check if 0 or 1 stars
-	if numStars <= 1 {
-		// if 0 stars, return true, if 1 star, make sure its at the end
-		return strings.HasSuffix(pattern, "*") || numStars == 0
+const patternSegmentSeparator = ":"
+
+// patternSegmentKind classifies a segment of a pattern
+func patternSegmentKind(segment string, last bool) int {
+	if segment == "*" {
+		if last {
+			return trailingWildcardSegment
+		}
+		return wildcardSegment
 	}
+	if len(segment) >= 2 && strings.HasPrefix(segment, "/") && strings.HasSuffix(segment, "/") {
+		return regexSegment
+	}
+	if strings.ContainsAny(segment, "*?[") {
+		return globSegment
+	}
+	return literalSegment
+}
 
-	return false
+// patternSegmentKinds returns the kind of each segment of a pattern
+func patternSegmentKinds(pattern string) []int {
+	segments := strings.Split(pattern, patternSegmentSeparator)
+	kinds := make([]int, len(segments))
+	for i, segment := range segments {
+		kinds[i] = patternSegmentKind(segment, i == len(segments)-1)
+	}
+	return kinds
+}
+
+// validPatternString checks that every glob and regular expression segment of a pattern is well formed
+func validPatternString(pattern string) bool {
+	segments := strings.Split(pattern, patternSegmentSeparator)
+	for i, segment := range segments {
+		switch patternSegmentKind(segment, i == len(segments)-1) {
+		case regexSegment:
+			if _, err := regexp.Compile("^(?:" + segment[1:len(segment)-1] + ")$"); err != nil {
+				return false
+			}
+		case globSegment:
+			if _, err := path.Match(segment, ""); err != nil {
+				return false
+			}
+		}
+	}
+	return true
 }
 
+// isPatternAndAddressMatch checks whether a (view segment of an) address matches a pattern
 func isPatternAndAddressMatch(pattern string, address string) bool {
 	// make sure the pattern is valid
 	if !validPatternString(pattern) {
 		return false
 	}
 
-	// count number of stars in pattern
-	numStars := strings.Count(pattern, "*")
-
-	// if 0 stars, and exact match, return true
-	if numStars == 0 && pattern == address {
-		return true
+	patternSegments := strings.Split(pattern, patternSegmentSeparator)
+	addressSegments := strings.Split(address, patternSegmentSeparator)
+	for i, segment := range patternSegments {
+		if i >= len(addressSegments) {
+			return false
+		}
+		if patternSegmentKind(segment, i == len(patternSegments)-1) == trailingWildcardSegment {
+			return true
+		}
+		if !isPatternSegmentMatch(segment, addressSegments[i]) {
+			return false
+		}
 	}
+	return len(patternSegments) == len(addressSegments)
+}
 
-	// if 1 star and pattern is a substring of address, return true
-	if numStars == 1 && strings.Contains(address, strings.TrimRight(pattern, "*")) {
+// isPatternSegmentMatch checks whether a single address segment matches a pattern segment, a lone '*' matching any value
+func isPatternSegmentMatch(segment string, value string) bool {
+	switch patternSegmentKind(segment, false) {
+	case wildcardSegment:
 		return true
+	case regexSegment:
+		re, err := regexp.Compile("^(?:" + segment[1:len(segment)-1] + ")$")
+		return err == nil && re.MatchString(value)
+	case globSegment:
+		matched, err := path.Match(segment, value)
+		return err == nil && matched
+	default:
+		return segment == value
 	}
+}
 
-	return false
+// isMoreSpecificPattern tells whether pattern a takes precedence over pattern b when both match an address
+func isMoreSpecificPattern(a string, b string) bool {
+	kindsA, kindsB := patternSegmentKinds(a), patternSegmentKinds(b)
+	for i := 0; i < len(kindsA) && i < len(kindsB); i++ {
+		if kindsA[i] != kindsB[i] {
+			return kindsA[i] > kindsB[i]
+		}
+	}
+	if len(kindsA) != len(kindsB) {
+		return len(kindsA) > len(kindsB)
+	}
+	return a < b
 }
@@ -64,13 +64,19 @@ func TestValidPatternString(t *testing.T) {
 	validStarPattern := "valid:star:*"
 	result = validPatternString(validStarPattern)
 	require.True(t, result)
-	//Unhappy too many stars
-	tooManyStars := "One*:*too:many"
-	result = validPatternString(tooManyStars)
+	//Happy star in any segment
+	result = validPatternString("test:*:star")
+	require.True(t, result)
+	//Happy globs and regular expressions
+	result = validPatternString("One*:?too:[a-m]any")
+	require.True(t, result)
+	result = validPatternString("mychannel:asset:/Read(Asset)?/:*")
+	require.True(t, result)
+	//Unhappy malformed glob
+	result = validPatternString("mychannel:asset:[Read")
 	require.False(t, result)
-	//Unhappy invalid star location
-	invalidStarLocation := "test:*:star"
-	result = validPatternString(invalidStarLocation)
+	//Unhappy malformed regular expression
+	result = validPatternString("mychannel:asset:/Read(/")
 	require.False(t, result)
 	//Just star
 	result = validPatternString("*")
@@ -99,4 +105,46 @@ func TestIsPatternAndAddressMatch(t *testing.T) {
 	result = isPatternAndAddressMatch("*", exactMatchString)
 	require.True(t, result)
 
+	// A trailing star matches all remaining segments, but at least one
+	require.True(t, isPatternAndAddressMatch("mychannel:asset:Read:*", "mychannel:asset:Read:a:b"))
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:Read:*", "mychannel:asset:Read"))
+
+	// Patterns are anchored at the start of the address
+	require.False(t, isPatternAndAddressMatch("asset:Read:*", "mychannel:asset:Read:a"))
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:Read*", "otherchannel:mychannel:asset:ReadAsset"))
+
+	// Globs match within their own segment only
+	require.True(t, isPatternAndAddressMatch("mychannel:asset:Read*", "mychannel:asset:ReadAsset"))
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:Read*", "mychannel:asset:ReadAsset:a"))
+	require.True(t, isPatternAndAddressMatch("mychannel:asset:Read*:*", "mychannel:asset:ReadAsset:a"))
+	require.True(t, isPatternAndAddressMatch("mychannel:*:Read:a?", "mychannel:asset:Read:a1"))
+	require.False(t, isPatternAndAddressMatch("mychannel:*:Read:a?", "mychannel:asset:Read:a12"))
+	require.False(t, isPatternAndAddressMatch("mychannel:*:Read:a", "mychannel:asset:Read:a:b"))
+
+	// Regular expressions are anchored to their segment
+	require.True(t, isPatternAndAddressMatch("mychannel:asset:Read:/bond[0-9]+/", "mychannel:asset:Read:bond42"))
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:Read:/bond[0-9]+/", "mychannel:asset:Read:xbond42"))
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:Read:/bond[0-9]+/", "mychannel:asset:Read:bond42x"))
+
+	// Malformed patterns never match
+	require.False(t, isPatternAndAddressMatch("mychannel:asset:[Read", "mychannel:asset:[Read"))
+}
+
+func TestIsMoreSpecificPattern(t *testing.T) {
+	// Literals take precedence over globs, globs over regular expressions and those over wildcards
+	require.True(t, isMoreSpecificPattern("mychannel:asset:Read:*", "mychannel:asset:Read*:*"))
+	require.True(t, isMoreSpecificPattern("mychannel:asset:Read*:*", "mychannel:asset:/Read.*/:*"))
+	require.True(t, isMoreSpecificPattern("mychannel:asset:/Read.*/:*", "mychannel:*:Read:*"))
+	require.False(t, isMoreSpecificPattern("mychannel:*:Read:a", "mychannel:asset:*"))
+
+	// Segments on the left decide before segments on the right
+	require.True(t, isMoreSpecificPattern("mychannel:asset:*:*", "mychannel:*:Read:a"))
+
+	// A single-segment wildcard is more specific than a trailing one, and more segments win a tie
+	require.True(t, isMoreSpecificPattern("mychannel:asset:Read:*:b", "mychannel:asset:Read:*"))
+	require.True(t, isMoreSpecificPattern("mychannel:asset:Read:a", "mychannel:asset:Read"))
+
+	// Remaining ties are broken by the pattern strings
+	require.True(t, isMoreSpecificPattern("mychannel:asset:Read:a*", "mychannel:asset:Read:b*"))
+	require.False(t, isMoreSpecificPattern("mychannel:asset:Read:b*", "mychannel:asset:Read:a*"))
 }
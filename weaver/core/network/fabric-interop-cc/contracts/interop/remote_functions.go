@@ -0,0 +1,65 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// remote_functions contains the registry of interop chaincode functions that remote networks
+// can query through a view address naming the interop chaincode itself
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// remoteFunction is an interop chaincode function exposed to remote networks
+type remoteFunction struct {
+	numArgs int
+	call    func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error)
+}
+
+// remoteFunctions lists the interop chaincode functions that remote networks can query, by name.
+// Requests are subject to the access control policy of the requesting network like any other view address.
+var remoteFunctions = map[string]remoteFunction{
+	"GetHTLCHash": {1, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetHTLCHash(ctx, args[0])
+	}},
+	"GetHTLCHashByContractId": {1, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetHTLCHashByContractId(ctx, args[0])
+	}},
+	"GetHTLCHashPreImage": {1, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetHTLCHashPreImage(ctx, args[0])
+	}},
+	"GetHTLCHashPreImageByContractId": {1, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetHTLCHashPreImageByContractId(ctx, args[0])
+	}},
+	"GetAssetPledgeStatus": {4, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetAssetPledgeStatus(ctx, args[0], args[1], args[2], args[3])
+	}},
+	"GetAssetClaimStatus": {5, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		pledgeExpiryTimeSecs, err := strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("Invalid pledge expiry time %s: %s", args[4], err)
+		}
+		return s.GetAssetClaimStatus(ctx, args[0], args[1], args[2], args[3], pledgeExpiryTimeSecs)
+	}},
+	"GetMembershipBySecurityDomain": {1, func(s *SmartContract, ctx contractapi.TransactionContextInterface, args []string) (string, error) {
+		return s.GetMembershipBySecurityDomain(ctx, args[0])
+	}},
+}
+
+// getRemoteFunction returns the registered interop chaincode function called by a view address,
+// after checking the number of arguments it is called with
+func getRemoteFunction(viewAddress *FabricViewAddress) (remoteFunction, error) {
+	function, ok := remoteFunctions[viewAddress.CCFunc]
+	if !ok {
+		return remoteFunction{}, fmt.Errorf("Given function %s can not be invoked in Interop Chaincode.", viewAddress.CCFunc)
+	}
+	if len(viewAddress.Args) != function.numArgs {
+		return remoteFunction{}, fmt.Errorf("Function %s of Interop Chaincode requires %d argument(s) but received %d.", viewAddress.CCFunc, function.numArgs, len(viewAddress.Args))
+	}
+	return function, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"testing"
+
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRemoteFunction(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+
+	// Test success: registered functions are called with the arguments of the view address
+	function, err := getRemoteFunction(&FabricViewAddress{Channel: "mychannel", Contract: "interop", CCFunc: "GetMembershipBySecurityDomain", Args: []string{"network1"}})
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturns([]byte(`{"securityDomain":"network1"}`), nil)
+	resp, err := function.call(&interopcc, ctx, []string{"network1"})
+	require.NoError(t, err)
+	require.Equal(t, `{"securityDomain":"network1"}`, resp)
+	_, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, []string{"network1"}, keys)
+
+	// Test failure: arity is checked before the function is called
+	_, err = getRemoteFunction(&FabricViewAddress{Channel: "mychannel", Contract: "interop", CCFunc: "GetHTLCHash", Args: []string{"a", "b"}})
+	require.EqualError(t, err, "Function GetHTLCHash of Interop Chaincode requires 1 argument(s) but received 2.")
+	_, err = getRemoteFunction(&FabricViewAddress{Channel: "mychannel", Contract: "interop", CCFunc: "GetAssetPledgeStatus", Args: []string{"p01"}})
+	require.EqualError(t, err, "Function GetAssetPledgeStatus of Interop Chaincode requires 4 argument(s) but received 1.")
+
+	// Test failure: unregistered functions can't be called by remote networks
+	_, err = getRemoteFunction(&FabricViewAddress{Channel: "mychannel", Contract: "interop", CCFunc: "CreateMembership", Args: []string{"{}"}})
+	require.EqualError(t, err, "Given function CreateMembership can not be invoked in Interop Chaincode.")
+
+	// Test failure: malformed numeric arguments are rejected
+	function, err = getRemoteFunction(&FabricViewAddress{Channel: "mychannel", Contract: "interop", CCFunc: "GetAssetClaimStatus", Args: []string{"simpleassettransfer", "p01", "bob", "network1", "soon"}})
+	require.NoError(t, err)
+	_, err = function.call(&interopcc, ctx, []string{"simpleassettransfer", "p01", "bob", "network1", "soon"})
+	require.ErrorContains(t, err, "Invalid pledge expiry time soon")
+}
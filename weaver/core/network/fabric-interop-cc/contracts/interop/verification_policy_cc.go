@@ -126,25 +126,30 @@ func resolvePolicy(s *SmartContract, ctx contractapi.TransactionContextInterface
 	if err != nil {
 		return nil, fmt.Errorf("Unable to get verification policy: %s", err.Error())
 	}
+	return matchVerificationPolicy(verificationPolicyString, viewAddress)
+}
+
+// matchVerificationPolicy returns the policy of the identifier in a verification policy that applies to a view address
+func matchVerificationPolicy(verificationPolicyString string, viewAddress string) (*common.Policy, error) {
 	verificationPolicy, err := decodeVerificationPolicy([]byte(verificationPolicyString))
 	if err != nil {
 		return nil, fmt.Errorf("Failed to unmarshal verification policy: %s", err.Error())
 	}
-	currentBestMatch := &common.Identifier{}
+	var currentBestMatch *common.Identifier
 	for _, identifier := range verificationPolicy.Identifiers {
 		// short circuit if there is an exact match
 		if identifier.Pattern == viewAddress {
 			return identifier.Policy, nil
 		}
 
-		// check if the identifier pattern is valid, that it matches the address and it's longer (i.e. more specific) than the currentBestMatch
-		if validPatternString(identifier.Pattern) && isPatternAndAddressMatch(identifier.Pattern, viewAddress) && len(identifier.Pattern) > len(currentBestMatch.Pattern) {
+		// check if the identifier pattern matches the address and is more specific than the currentBestMatch
+		if isPatternAndAddressMatch(identifier.Pattern, viewAddress) && (currentBestMatch == nil || isMoreSpecificPattern(identifier.Pattern, currentBestMatch.Pattern)) {
 			currentBestMatch = identifier
 		}
 	}
 
 	// return the bestMatch if there was one
-	if currentBestMatch.Pattern != "" {
+	if currentBestMatch != nil {
 		return currentBestMatch.Policy, nil
 	}
 
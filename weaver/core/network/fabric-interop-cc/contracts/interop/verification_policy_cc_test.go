@@ -128,3 +128,45 @@ func TestDeleteVerificationPolicy(t *testing.T) {
 	err = interopcc.DeleteVerificationPolicy(ctx, "2343")
 	require.EqualError(t, err, fmt.Sprintf("unable to retrieve asset"))
 }
+
+func TestResolvePolicy(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+
+	newIdentifier := func(pattern string, criteria string) *common.Identifier {
+		return &common.Identifier{Pattern: pattern, Policy: &common.Policy{Type: "Signature", Criteria: []string{criteria}}}
+	}
+	verificationPolicy := common.VerificationPolicy{
+		SecurityDomain: "network1",
+		Identifiers: []*common.Identifier{
+			newIdentifier("*", "AnyMSP"),
+			newIdentifier("mychannel:*:Read:*", "ReadMSP"),
+			newIdentifier("mychannel:simplestate:*", "SimpleStateMSP"),
+			newIdentifier("mychannel:simplestate:Read:/bond[0-9]+/", "BondMSP"),
+			newIdentifier("mychannel:simplestate:Read:a", "ExactMSP"),
+		},
+	}
+	value, err := json.Marshal(&verificationPolicy)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturns(value, nil)
+
+	for address, criteria := range map[string]string{
+		"mychannel:simplestate:Read:a":      "ExactMSP",
+		"mychannel:simplestate:Read:bond42": "BondMSP",
+		"mychannel:simplestate:Read:b":      "SimpleStateMSP",
+		"mychannel:simpleasset:Read:b":      "ReadMSP",
+		"otherchannel:simplestate:Read:a":   "AnyMSP",
+	} {
+		resolvedPolicy, err := resolvePolicy(&interopcc, ctx, "network1", address)
+		require.NoError(t, err)
+		require.Equal(t, []string{criteria}, resolvedPolicy.Criteria, address)
+	}
+
+	// Patterns no longer match text in the middle of an address
+	verificationPolicy.Identifiers = []*common.Identifier{newIdentifier("simplestate:Read:*", "SimpleStateMSP")}
+	value, err = json.Marshal(&verificationPolicy)
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturns(value, nil)
+	_, err = resolvePolicy(&interopcc, ctx, "network1", "mychannel:simplestate:Read:a")
+	require.EqualError(t, err, "Verification Policy Error: Failed to find verification policy matching view address: mychannel:simplestate:Read:a")
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// view_audit contains the audit log of the views accepted from remote networks, from which the remote state that
+// local transactions relied upon can be reconstructed along with the view proofs kept off-chain
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const viewAuditObjectType = "viewAudit"
+
+// viewAuditRecord is the record of a view accepted from a remote network in a transaction
+type viewAuditRecord struct {
+	TxId string `json:"txId"`
+	// Transaction timestamp, in seconds since the epoch
+	Timestamp int64  `json:"timestamp"`
+	Address   string `json:"address"`
+	Network   string `json:"network"`
+	// SHA-256 hash (in hex) of the serialized view proto, by which its proof can be looked up off-chain
+	ViewHash string `json:"viewHash"`
+	// SHA-256 hash (in hex) of the data accepted from the view
+	DataHash string       `json:"dataHash"`
+	Signers  []viewSigner `json:"signers"`
+	// SHA-256 hash (in hex) of the verification policy the view was verified against
+	PolicyVersion string `json:"policyVersion"`
+}
+
+// recordViewAudit records the acceptance of a verified view in the audit log, indexed by network and address
+func recordViewAudit(ctx contractapi.TransactionContextInterface, address string, viewBytes []byte, resolvedPolicy *viewPolicy, agreement *viewAgreement) (*viewAuditRecord, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get transaction timestamp: %s", err.Error())
+	}
+	viewHash := sha256.Sum256(viewBytes)
+	dataHash := sha256.Sum256(agreement.Data)
+	record := viewAuditRecord{
+		TxId:          ctx.GetStub().GetTxID(),
+		Timestamp:     txTimestamp.GetSeconds(),
+		Address:       address,
+		Network:       resolvedPolicy.securityDomain,
+		ViewHash:      hex.EncodeToString(viewHash[:]),
+		DataHash:      hex.EncodeToString(dataHash[:]),
+		Signers:       agreement.Signers,
+		PolicyVersion: resolvedPolicy.version,
+	}
+	recordBytes, err := json.Marshal(&record)
+	if err != nil {
+		return nil, fmt.Errorf("Marshal error: %s", err)
+	}
+	recordKey, err := ctx.GetStub().CreateCompositeKey(viewAuditObjectType, []string{record.Network, address, record.TxId, record.ViewHash})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to record view audit: %s", err.Error())
+	}
+	err = ctx.GetStub().PutState(recordKey, recordBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to record view audit: %s", err.Error())
+	}
+	return &record, nil
+}
+
+// GetViewAuditRecordsByNetwork returns the audit records of the views accepted from the provided network, as a JSON array
+func (s *SmartContract) GetViewAuditRecordsByNetwork(ctx contractapi.TransactionContextInterface, network string) (string, error) {
+	if network == "" {
+		return "", logThenErrorf("Network can not be empty")
+	}
+	return getViewAuditRecords(ctx, []string{network})
+}
+
+// GetViewAuditRecordsByAddress returns the audit records of the views accepted for the provided address, as a JSON array
+func (s *SmartContract) GetViewAuditRecordsByAddress(ctx contractapi.TransactionContextInterface, address string) (string, error) {
+	addressStruct, err := parseAddress(address)
+	if err != nil {
+		return "", logThenErrorf("Unable to parse address: %s", err.Error())
+	}
+	return getViewAuditRecords(ctx, []string{addressStruct.LedgerSegment, address})
+}
+
+func getViewAuditRecords(ctx contractapi.TransactionContextInterface, keys []string) (string, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(viewAuditObjectType, keys)
+	if err != nil {
+		return "", logThenErrorf("%s", err.Error())
+	}
+	defer iterator.Close()
+
+	records := []viewAuditRecord{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return "", logThenErrorf("%s", err.Error())
+		}
+		var record viewAuditRecord
+		err = json.Unmarshal(item.Value, &record)
+		if err != nil {
+			return "", logThenErrorf("Unmarshal error: %s", err)
+		}
+		records = append(records, record)
+	}
+	recordsJSON, err := json.Marshal(records)
+	if err != nil {
+		return "", logThenErrorf("Marshal error: %s", err)
+	}
+	return string(recordsJSON), nil
+}
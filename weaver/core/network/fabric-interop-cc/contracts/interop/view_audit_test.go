@@ -0,0 +1,92 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils/mocks"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRecordViewAudit(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetTxIDReturns("tx1")
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: 1760000000}, nil)
+	address := "relay-network1:9080/network1/mychannel:simplestate:Read:a"
+
+	// The policy version identifies the verification policy the view was verified against
+	policyBytes, err := json.Marshal(&common.VerificationPolicy{
+		SecurityDomain: "network1",
+		Identifiers: []*common.Identifier{{
+			Pattern: "mychannel:simplestate:Read:*",
+			Policy:  &common.Policy{Criteria: []string{"Org1MSP"}, Type: "signature"},
+		}},
+	})
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturnsOnCall(0, policyBytes, nil)
+	resolvedPolicy, err := resolveViewPolicy(&interopcc, ctx, address)
+	require.NoError(t, err)
+	policyHash := sha256.Sum256(policyBytes)
+	require.Equal(t, hex.EncodeToString(policyHash[:]), resolvedPolicy.version)
+
+	// Test success: the accepted view is recorded under its network and address
+	agreement := &viewAgreement{
+		Data:    []byte("Arcturus"),
+		Signers: []viewSigner{{Org: "Org1MSP", Id: "peer0.org1.network1.com"}},
+	}
+	record, err := recordViewAudit(ctx, address, []byte("view"), resolvedPolicy, agreement)
+	require.NoError(t, err)
+	viewHash := sha256.Sum256([]byte("view"))
+	dataHash := sha256.Sum256([]byte("Arcturus"))
+	require.Equal(t, &viewAuditRecord{
+		TxId:          "tx1",
+		Timestamp:     1760000000,
+		Address:       address,
+		Network:       "network1",
+		ViewHash:      hex.EncodeToString(viewHash[:]),
+		DataHash:      hex.EncodeToString(dataHash[:]),
+		Signers:       agreement.Signers,
+		PolicyVersion: resolvedPolicy.version,
+	}, record)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(1)
+	require.Equal(t, viewAuditObjectType, objectType)
+	require.Equal(t, []string{"network1", address, "tx1", record.ViewHash}, keys)
+	_, recordBytes := chaincodeStub.PutStateArgsForCall(0)
+
+	// Records are looked up by network or by address
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturns(&queryresult.KV{Key: "tx1", Value: recordBytes}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	recordsJSON, err := interopcc.GetViewAuditRecordsByAddress(ctx, address)
+	require.NoError(t, err)
+	require.JSONEq(t, "["+string(recordBytes)+"]", recordsJSON)
+	objectType, keys = chaincodeStub.GetStateByPartialCompositeKeyArgsForCall(0)
+	require.Equal(t, viewAuditObjectType, objectType)
+	require.Equal(t, []string{"network1", address}, keys)
+
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(&mocks.StateQueryIterator{}, nil)
+	recordsJSON, err = interopcc.GetViewAuditRecordsByNetwork(ctx, "network2")
+	require.NoError(t, err)
+	require.Equal(t, "[]", recordsJSON)
+	_, keys = chaincodeStub.GetStateByPartialCompositeKeyArgsForCall(1)
+	require.Equal(t, []string{"network2"}, keys)
+
+	// Test failure: malformed addresses are rejected
+	_, err = interopcc.GetViewAuditRecordsByAddress(ctx, "network1")
+	require.ErrorContains(t, err, "Unable to parse address")
+}
@@ -11,6 +11,8 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -19,19 +21,27 @@ import (
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	log "github.com/sirupsen/logrus"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/besu"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/corda"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
 	protoV2 "google.golang.org/protobuf/proto"
 )
 
+// Allowance (in seconds) for views dated in the future when the verification policy does not specify one
+const defaultViewClockSkew = 60
+
+const (
+	viewDissentObjectType   = "viewDissent"
+	externalStateObjectType = "externalState"
+)
+
 type interop interface {
 	WriteExternalState(state string) error
 }
 
-// Extract data (i.e., query response) from view
-// TODO - Also take verification policy as parameter and determine if enough matching responses exist (current logic mandates unanimity among payloads)
-func ExtractAndValidateDataFromView(view *common.View, b64ViewContentList []string) ([]byte, error) {
+// extractInteropPayloads returns the interop payloads carried by the proofs in a view
+func extractInteropPayloads(view *common.View) ([]*common.InteropPayload, error) {
 	var interopPayloadList []*common.InteropPayload
 	if view.Meta.Protocol == common.Meta_FABRIC {
 		var fabricViewData fabric.FabricView
@@ -68,15 +78,120 @@ func ExtractAndValidateDataFromView(view *common.View, b64ViewContentList []stri
 			}
 			interopPayloadList[i] = &interopPayload
 		}
+	} else if view.Meta.Protocol == common.Meta_ETHEREUM {
+		var besuViewData besu.BesuView
+		err := protoV2.Unmarshal(view.Data, &besuViewData)
+		if err != nil {
+			return nil, fmt.Errorf("BesuView Unmarshal error: %s", err)
+		}
+		var interopPayload common.InteropPayload
+		err = protoV2.Unmarshal(besuViewData.InteropPayload, &interopPayload)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to Unmarshal interopPayload: %s", err.Error())
+		}
+		interopPayloadList = []*common.InteropPayload{&interopPayload}
 	} else {
 		return nil, fmt.Errorf("Cannot extract data from view; unsupported DLT type: %+v", view.Meta.Protocol)
 	}
+	return interopPayloadList, nil
+}
 
-	var payloadConfidential bool
-	var viewPayload []byte
+// viewSigner identifies the party (Fabric endorser or Corda notary) that produced a payload in a view
+type viewSigner struct {
+	// Organization matched against the criteria of verification policies (Fabric MSP ID or Corda party)
+	Org string `json:"org"`
+	// Identity of the signer within its organization, for the record
+	Id string `json:"id"`
+}
+
+// viewResponse is the (decrypted) data of a payload in a view, along with the party that produced it
+type viewResponse struct {
+	viewSigner
+	PayloadHash string `json:"payloadHash"`
+	data        []byte
+}
+
+// viewAgreement is the data of a view on which the responses satisfying the verification policy agree,
+// along with the responses that dissent from it
+type viewAgreement struct {
+	Data       []byte
+	Signers    []viewSigner
+	Dissenters []viewResponse
+}
+
+// viewDissent records the responses of a view that dissent from the data accepted from it
+type viewDissent struct {
+	TxId       string         `json:"txId"`
+	Address    string         `json:"address"`
+	Signers    []viewSigner   `json:"signers"`
+	Dissenters []viewResponse `json:"dissenters"`
+}
+
+// extractViewSigners returns the parties that produced the payloads in a view, in the order of the payloads.
+// Besu views carry a single payload whose signers (validators) are checked against the block's commit seals.
+func extractViewSigners(view *common.View) ([]viewSigner, error) {
+	var signers []viewSigner
+	if view.Meta.Protocol == common.Meta_FABRIC {
+		var fabricViewData fabric.FabricView
+		err := protoV2.Unmarshal(view.Data, &fabricViewData)
+		if err != nil {
+			return nil, fmt.Errorf("FabricView Unmarshal error: %s", err)
+		}
+		for _, endorsedProposalResponse := range fabricViewData.EndorsedProposalResponses {
+			var serialisedIdentity msp.SerializedIdentity
+			err = proto.Unmarshal(endorsedProposalResponse.Endorsement.Endorser, &serialisedIdentity)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to Unmarshal endorser identity: %s", err.Error())
+			}
+			signer := viewSigner{Org: serialisedIdentity.Mspid, Id: serialisedIdentity.Mspid}
+			if x509Cert, err := parseCert(string(serialisedIdentity.IdBytes)); err == nil {
+				signer.Id = x509Cert.Subject.CommonName
+			}
+			signers = append(signers, signer)
+		}
+	} else if view.Meta.Protocol == common.Meta_CORDA {
+		var cordaViewData corda.ViewData
+		err := protoV2.Unmarshal(view.Data, &cordaViewData)
+		if err != nil {
+			return nil, fmt.Errorf("CordaView Unmarshal error: %s", err)
+		}
+		for _, notarizedPayload := range cordaViewData.NotarizedPayloads {
+			signers = append(signers, viewSigner{Org: notarizedPayload.Id, Id: notarizedPayload.Id})
+		}
+	} else if view.Meta.Protocol == common.Meta_ETHEREUM {
+		signers = []viewSigner{{}}
+	} else {
+		return nil, fmt.Errorf("Cannot extract data from view; unsupported DLT type: %+v", view.Meta.Protocol)
+	}
+	return signers, nil
+}
+
+// extractViewResponses returns the data of each payload in a view, decrypting confidential payloads with the
+// contents supplied by the caller
+func extractViewResponses(view *common.View, b64ViewContentList []string) ([]viewResponse, error) {
+	interopPayloadList, err := extractInteropPayloads(view)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := extractViewSigners(view)
+	if err != nil {
+		return nil, err
+	}
+	if len(signers) != len(interopPayloadList) {
+		return nil, fmt.Errorf("Number of signers (%d) does not match number of interop payloads (%d)", len(signers), len(interopPayloadList))
+	}
+
+	responses := make([]viewResponse, len(interopPayloadList))
 	for i, interopPayload := range interopPayloadList {
+		responses[i].viewSigner = signers[i]
+		if i > 0 && interopPayload.Confidential != interopPayloadList[0].Confidential {
+			return nil, fmt.Errorf("Mismatching confidentiality flags among interop payloads")
+		}
 		// If view data is encrypted, match it to supplied decrypted data using the hash in the view payload
 		if interopPayload.Confidential {
+			if len(b64ViewContentList) != len(interopPayloadList) {
+				return nil, fmt.Errorf("Number of decrypted payloads (%d) does not match number of view contents (%d)", len(b64ViewContentList), len(interopPayloadList))
+			}
 			// Unmarshal the (decrypted) confidential payload contents supplied by the caller
 			viewB64ContentBytes, err := base64.StdEncoding.DecodeString(b64ViewContentList[i])
 			if err != nil {
@@ -92,17 +207,6 @@ func ExtractAndValidateDataFromView(view *common.View, b64ViewContentList []stri
 			if err != nil {
 				return nil, fmt.Errorf("ConfidentialPayload Unmarshal error: %s", err)
 			}
-			if i == 0 {
-				if len(b64ViewContentList) != len(interopPayloadList) {
-					return nil, fmt.Errorf("Number of decrypted payloads (%d) does not match number of view contents (%d)", len(b64ViewContentList), len(interopPayloadList))
-				}
-				payloadConfidential = true
-				viewPayload = confidentialPayloadContents.Payload
-			} else if !payloadConfidential {
-				return nil, fmt.Errorf("Mismatching confidentiality flags among interop payloads")
-			} else if !bytes.Equal(viewPayload, confidentialPayloadContents.Payload) {
-				return nil, fmt.Errorf("Mismatching payloads in proposal responses: 0 - %+v, %d - %+v", viewPayload, i, confidentialPayloadContents.Payload)
-			}
 			if confidentialPayload.HashType == common.ConfidentialPayload_HMAC {
 				payloadHMAC := hmac.New(sha256.New, confidentialPayloadContents.Random)
 				payloadHMAC.Write(confidentialPayloadContents.Payload)
@@ -113,18 +217,166 @@ func ExtractAndValidateDataFromView(view *common.View, b64ViewContentList []stri
 			} else {
 				return nil, fmt.Errorf("Unsupported hash type in interop view payload: %+v", confidentialPayload.HashType)
 			}
+			responses[i].data = confidentialPayloadContents.Payload
 		} else {
-			if i == 0 {
-				viewPayload = interopPayload.Payload
-				payloadConfidential = false
-			} else if payloadConfidential {
-				return nil, fmt.Errorf("Mismatching confidentiality flags among interop payloads")
-			} else if !bytes.Equal(viewPayload, interopPayload.Payload) {
-				return nil, fmt.Errorf("Mismatching payloads in proposal responses: 0 - %+v, %d - %+v", viewPayload, i, interopPayload.Payload)
+			responses[i].data = interopPayload.Payload
+		}
+		payloadHash := sha256.Sum256(responses[i].data)
+		responses[i].PayloadHash = hex.EncodeToString(payloadHash[:])
+	}
+	return responses, nil
+}
+
+// isPolicySatisfiedBySigners checks that every party required by a verification policy is among the signers
+func isPolicySatisfiedBySigners(verificationPolicy *common.Policy, signers []viewSigner) bool {
+	signerOrgs := make([]string, len(signers))
+	for i, signer := range signers {
+		signerOrgs[i] = signer.Org
+	}
+	for _, requiredSigner := range verificationPolicy.GetCriteria() {
+		if !Contains(signerOrgs, requiredSigner) {
+			return false
+		}
+	}
+	return true
+}
+
+// extractViewAgreement groups the responses in a view by data and returns the data of the group satisfying the
+// verification policy. Views in which all responses match are accepted as before. Otherwise exactly one group must
+// satisfy the policy on its own, and the responses outside it (e.g. stale reads from a lagging peer) are returned as
+// dissenters; a policy without criteria is satisfied by any group and hence requires unanimity.
+func extractViewAgreement(view *common.View, b64ViewContentList []string, verificationPolicy *common.Policy) (*viewAgreement, error) {
+	responses, err := extractViewResponses(view, b64ViewContentList)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("View does not carry any interop payloads")
+	}
+
+	// Group responses by data, in the order in which each data first appears
+	groups := [][]viewResponse{}
+	for _, response := range responses {
+		grouped := false
+		for j, group := range groups {
+			if bytes.Equal(group[0].data, response.data) {
+				groups[j] = append(group, response)
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			groups = append(groups, []viewResponse{response})
+		}
+	}
+
+	agreedGroup := -1
+	for i, group := range groups {
+		signers := make([]viewSigner, len(group))
+		for j, response := range group {
+			signers[j] = response.viewSigner
+		}
+		if len(groups) > 1 && !isPolicySatisfiedBySigners(verificationPolicy, signers) {
+			continue
+		}
+		if agreedGroup >= 0 {
+			return nil, fmt.Errorf("Mismatching payloads in proposal responses: responses with payloads %s and %s both satisfy the verification policy", groups[agreedGroup][0].PayloadHash, group[0].PayloadHash)
+		}
+		agreedGroup = i
+	}
+	if agreedGroup < 0 {
+		return nil, fmt.Errorf("Mismatching payloads in proposal responses: no group of matching responses among %d groups satisfies the verification policy", len(groups))
+	}
+
+	agreement := viewAgreement{Data: groups[agreedGroup][0].data}
+	for i, group := range groups {
+		for _, response := range group {
+			if i == agreedGroup {
+				agreement.Signers = append(agreement.Signers, response.viewSigner)
+			} else {
+				agreement.Dissenters = append(agreement.Dissenters, response)
 			}
 		}
 	}
-	return viewPayload, nil
+	return &agreement, nil
+}
+
+// Extract data (i.e., query response) from view, using the verification policy to settle mismatching responses
+func ExtractAndValidateDataFromView(view *common.View, b64ViewContentList []string, verificationPolicy *common.Policy) ([]byte, error) {
+	agreement, err := extractViewAgreement(view, b64ViewContentList, verificationPolicy)
+	if err != nil {
+		return nil, err
+	}
+	for _, dissenter := range agreement.Dissenters {
+		log.Warnf("Response from %s (%s) with payload %s dissents from the accepted view data", dissenter.Id, dissenter.Org, dissenter.PayloadHash)
+	}
+	return agreement.Data, nil
+}
+
+// recordViewDissent stores the responses of a view that dissent from the data accepted from it, for auditing
+func recordViewDissent(ctx contractapi.TransactionContextInterface, securityDomain string, address string, agreement *viewAgreement) error {
+	dissent := viewDissent{
+		TxId:       ctx.GetStub().GetTxID(),
+		Address:    address,
+		Signers:    agreement.Signers,
+		Dissenters: agreement.Dissenters,
+	}
+	dissentBytes, err := json.Marshal(&dissent)
+	if err != nil {
+		return fmt.Errorf("Marshal error: %s", err)
+	}
+	dissentKey, err := ctx.GetStub().CreateCompositeKey(viewDissentObjectType, []string{securityDomain, dissent.TxId, address})
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(dissentKey, dissentBytes)
+}
+
+// recordDissentingResponses logs and records the responses of a view that dissent from the data accepted from it, if any
+func recordDissentingResponses(ctx contractapi.TransactionContextInterface, address string, agreement *viewAgreement) error {
+	if len(agreement.Dissenters) == 0 {
+		return nil
+	}
+	for _, dissenter := range agreement.Dissenters {
+		log.Warnf("Response from %s (%s) with payload %s to query '%s' dissents from the accepted view data", dissenter.Id, dissenter.Org, dissenter.PayloadHash, address)
+	}
+	addressStruct, err := parseAddress(address)
+	if err != nil {
+		return fmt.Errorf("Unable to parse address: %s", err.Error())
+	}
+	err = recordViewDissent(ctx, addressStruct.LedgerSegment, address, agreement)
+	if err != nil {
+		return fmt.Errorf("Unable to record dissenting responses: %s", err.Error())
+	}
+	return nil
+}
+
+// GetViewDissents returns the recorded responses from the provided securityDomain that dissented from accepted views
+func (s *SmartContract) GetViewDissents(ctx contractapi.TransactionContextInterface, securityDomain string) (string, error) {
+	iterator, err := ctx.GetStub().GetStateByPartialCompositeKey(viewDissentObjectType, []string{securityDomain})
+	if err != nil {
+		return "", err
+	}
+	defer iterator.Close()
+
+	dissents := []viewDissent{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		if err != nil {
+			return "", err
+		}
+		var dissent viewDissent
+		err = json.Unmarshal(item.Value, &dissent)
+		if err != nil {
+			return "", fmt.Errorf("Unmarshal error: %s", err)
+		}
+		dissents = append(dissents, dissent)
+	}
+	dissentsBytes, err := json.Marshal(dissents)
+	if err != nil {
+		return "", fmt.Errorf("Marshal error: %s", err)
+	}
+	return string(dissentsBytes), nil
 }
 
 // Validate view against address, and extract data (i.e., query response) from view
@@ -140,20 +392,30 @@ func (s *SmartContract) ParseAndValidateView(ctx contractapi.TransactionContextI
 	}
 
 	// 1. Verify proof
-	err = s.VerifyView(ctx, b64ViewProto, address)
+	resolvedPolicy, err := verifyView(s, ctx, &view, address)
 	if err != nil {
 		log.Errorf("Proof obtained from foreign network for query '%s' is INVALID", address)
 		return "", fmt.Errorf("VerifyView error: %s", err)
 	}
 
 	// 2. Extract response data for consumption by application chaincode
-	viewData, err := ExtractAndValidateDataFromView(&view, b64ViewContentList)
+	agreement, err := extractViewAgreement(&view, b64ViewContentList, resolvedPolicy.policy)
+	if err != nil {
+		return "", err
+	}
+	// 3. Record responses dissenting from the extracted data
+	err = recordDissentingResponses(ctx, address, agreement)
 	if err != nil {
 		return "", err
 	}
-	fmt.Printf("View data: %s\n", string(viewData))
+	// 4. Record the accepted view for auditing
+	_, err = recordViewAudit(ctx, address, viewB64Bytes, resolvedPolicy, agreement)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("View data: %s\n", string(agreement.Data))
 
-	return string(viewData), nil
+	return string(agreement.Data), nil
 }
 
 // WriteExternalState flow is used to process a response from a foreign network for state.
@@ -197,6 +459,200 @@ func (s *SmartContract) WriteExternalState(ctx contractapi.TransactionContextInt
 	return nil
 }
 
+// viewReport is the outcome of verifying one view of a batch
+type viewReport struct {
+	Address string `json:"address"`
+	// Parties that produced the payloads in the view
+	Signers []viewSigner `json:"signers"`
+	// Whether the signers satisfy the criteria of the verification policy
+	PolicySatisfied bool `json:"policySatisfied"`
+	// Whether the view has been verified and its data accepted
+	Verified bool `json:"verified"`
+	// SHA-256 hash (in hex) of the accepted view data
+	DataHash string `json:"dataHash,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// externalState is the data of a verified view written under a key space, with the time the view was produced
+type externalState struct {
+	ViewTimestamp int64  `json:"viewTimestamp"`
+	Data          []byte `json:"data"`
+}
+
+// getExternalStateKey returns the key of an address in a key space of the transaction submitter. Key spaces are
+// scoped to the submitter's MSP ID and the hash of their certificate, so that one client cannot overwrite the
+// state another has ingested.
+func getExternalStateKey(ctx contractapi.TransactionContextInterface, keySpace string, address string) (string, error) {
+	mspId, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("Unable to get the submitter's MSP ID: %s", err.Error())
+	}
+	cert, err := ctx.GetClientIdentity().GetX509Certificate()
+	if err != nil {
+		return "", fmt.Errorf("Unable to get the submitter's certificate: %s", err.Error())
+	}
+	if cert == nil {
+		return "", fmt.Errorf("Submitter does not have an X.509 certificate")
+	}
+	certHash := sha256.Sum256(cert.Raw)
+	return ctx.GetStub().CreateCompositeKey(externalStateObjectType, []string{mspId, hex.EncodeToString(certHash[:]), keySpace, address})
+}
+
+// writeExternalState writes the data of a verified view under a key space of the transaction submitter, unless
+// the key space already holds a newer view of the address. Views whose timestamp cannot be determined are
+// treated as the oldest. As GetState does not return the writes of the current transaction, the timestamps of
+// the views written earlier in the same transaction are tracked in batchTimestamps, keyed by address. It returns
+// whether the data was written.
+func writeExternalState(ctx contractapi.TransactionContextInterface, keySpace string, address string, state *externalState, batchTimestamps map[string]int64) (bool, error) {
+	if batchTimestamp, exists := batchTimestamps[address]; exists && batchTimestamp > state.ViewTimestamp {
+		return false, nil
+	}
+	externalStateKey, err := getExternalStateKey(ctx, keySpace, address)
+	if err != nil {
+		return false, err
+	}
+	storedStateBytes, err := ctx.GetStub().GetState(externalStateKey)
+	if err != nil {
+		return false, err
+	}
+	if storedStateBytes != nil {
+		var storedState externalState
+		err = json.Unmarshal(storedStateBytes, &storedState)
+		if err != nil {
+			return false, fmt.Errorf("Unmarshal error: %s", err)
+		}
+		if storedState.ViewTimestamp > state.ViewTimestamp {
+			return false, nil
+		}
+	}
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return false, fmt.Errorf("Marshal error: %s", err)
+	}
+	err = ctx.GetStub().PutState(externalStateKey, stateBytes)
+	if err != nil {
+		return false, err
+	}
+	batchTimestamps[address] = state.ViewTimestamp
+	return true, nil
+}
+
+// VerifyViews verifies a batch of views returned from external networks in a single transaction, and returns a
+// report for each view as a JSON array in the order of the views. A view failing verification does not fail the
+// transaction; its report gives the reason instead. If a key space is given, the data of each verified view is
+// also written under the submitter's key space of that name, keyed by the view's address, and can be read with
+// GetExternalState. The data is not written if the key space already holds a newer view of the address.
+func (s *SmartContract) VerifyViews(ctx contractapi.TransactionContextInterface, addresses []string, b64ViewProtos []string, b64ViewContents [][]string, keySpace string) (string, error) {
+	if len(addresses) != len(b64ViewProtos) {
+		return "", fmt.Errorf("Number of addresses (%d) does not match number of views (%d)", len(addresses), len(b64ViewProtos))
+	}
+	if len(addresses) != len(b64ViewContents) {
+		return "", fmt.Errorf("Number of addresses (%d) does not match number of view contents (%d)", len(addresses), len(b64ViewContents))
+	}
+
+	reports := make([]viewReport, len(addresses))
+	batchTimestamps := map[string]int64{}
+	for i, address := range addresses {
+		reports[i].Address = address
+		state, err := verifyViewForReport(s, ctx, address, b64ViewProtos[i], b64ViewContents[i], &reports[i])
+		if err != nil {
+			log.Errorf("Proof obtained from foreign network for query '%s' is INVALID: %s", address, err)
+			reports[i].Reason = err.Error()
+			continue
+		}
+		if keySpace != "" {
+			written, err := writeExternalState(ctx, keySpace, address, state, batchTimestamps)
+			if err != nil {
+				return "", err
+			}
+			if !written {
+				reports[i].Reason = fmt.Sprintf("Key space %s already holds a newer view of the address", keySpace)
+			}
+		}
+	}
+	reportsBytes, err := json.Marshal(reports)
+	if err != nil {
+		return "", fmt.Errorf("Marshal error: %s", err)
+	}
+	return string(reportsBytes), nil
+}
+
+// verifyViewForReport verifies a view of a batch, filling in its report as verification proceeds, and returns
+// the accepted view data with the time the view was produced
+func verifyViewForReport(s *SmartContract, ctx contractapi.TransactionContextInterface, address string, b64ViewProto string, b64ViewContentList []string, report *viewReport) (*externalState, error) {
+	viewB64Bytes, err := base64.StdEncoding.DecodeString(b64ViewProto)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to base64 decode data: %s", err.Error())
+	}
+	var view common.View
+	err = protoV2.Unmarshal(viewB64Bytes, &view)
+	if err != nil {
+		return nil, fmt.Errorf("View Unmarshal error: %s", err)
+	}
+	if view.Meta == nil {
+		return nil, fmt.Errorf("View does not carry any metadata")
+	}
+	resolvedPolicy, err := resolveViewPolicy(s, ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	// Besu views are signed by the validators of a block, which are checked against the policy with the proof
+	if view.Meta.Protocol != common.Meta_ETHEREUM {
+		report.Signers, err = extractViewSigners(&view)
+		if err != nil {
+			return nil, err
+		}
+		report.PolicySatisfied = isPolicySatisfiedBySigners(resolvedPolicy.policy, report.Signers)
+	}
+	err = verifyViewProof(s, ctx, &view, resolvedPolicy.policy, resolvedPolicy.securityDomain, address)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyView error: %s", err)
+	}
+	report.PolicySatisfied = true
+	agreement, err := extractViewAgreement(&view, b64ViewContentList, resolvedPolicy.policy)
+	if err != nil {
+		return nil, err
+	}
+	err = recordDissentingResponses(ctx, address, agreement)
+	if err != nil {
+		return nil, err
+	}
+	auditRecord, err := recordViewAudit(ctx, address, viewB64Bytes, resolvedPolicy, agreement)
+	if err != nil {
+		return nil, err
+	}
+	report.DataHash = auditRecord.DataHash
+	report.Verified = true
+	// Undated views are accepted when the policy sets no maximum age, and are then the oldest of their address
+	timestamp, err := viewTimestamp(&view)
+	if err != nil {
+		timestamp = 0
+	}
+	return &externalState{ViewTimestamp: timestamp, Data: agreement.Data}, nil
+}
+
+// GetExternalState returns the data of a view verified by VerifyViews and written under the caller's key space
+// of the given name
+func (s *SmartContract) GetExternalState(ctx contractapi.TransactionContextInterface, keySpace string, address string) (string, error) {
+	externalStateKey, err := getExternalStateKey(ctx, keySpace, address)
+	if err != nil {
+		return "", err
+	}
+	stateBytes, err := ctx.GetStub().GetState(externalStateKey)
+	if err != nil {
+		return "", err
+	}
+	if stateBytes == nil {
+		return "", fmt.Errorf("No external state for address %s in key space %s", address, keySpace)
+	}
+	var state externalState
+	err = json.Unmarshal(stateBytes, &state)
+	if err != nil {
+		return "", fmt.Errorf("Unmarshal error: %s", err)
+	}
+	return string(state.Data), nil
+}
+
 // VerifyView takes a view that is returned from an external network and verifies
 // that it is valid according to the proof type used for the particular protocol.
 //
@@ -212,39 +668,94 @@ func (s *SmartContract) VerifyView(ctx contractapi.TransactionContextInterface,
 	if err != nil {
 		return fmt.Errorf("View Unmarshal error: %s", err)
 	}
+	_, err = verifyView(s, ctx, &view, address)
+	return err
+}
+
+// viewPolicy is the verification policy applied to a view
+type viewPolicy struct {
+	securityDomain string
+	policy         *common.Policy
+	// SHA-256 hash (in hex) of the verification policy of the security domain, identifying its version
+	version string
+}
+
+// verifyView verifies a view against the verification policy matching its address, and returns that policy
+func verifyView(s *SmartContract, ctx contractapi.TransactionContextInterface, view *common.View, address string) (*viewPolicy, error) {
+	resolvedPolicy, err := resolveViewPolicy(s, ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyViewProof(s, ctx, view, resolvedPolicy.policy, resolvedPolicy.securityDomain, address)
+	if err != nil {
+		return nil, err
+	}
+	return resolvedPolicy, nil
+}
+
+// resolveViewPolicy returns the verification policy matching a view address
+func resolveViewPolicy(s *SmartContract, ctx contractapi.TransactionContextInterface, address string) (*viewPolicy, error) {
 	addressStruct, err := parseAddress(address)
 	if err != nil {
-		return fmt.Errorf("Unable to parse address: %s", err.Error())
+		return nil, fmt.Errorf("Unable to parse address: %s", err.Error())
 	}
 	// Find the verification policy for the network and view.
-	verificationPolicy, err := resolvePolicy(s, ctx, addressStruct.LedgerSegment, addressStruct.ViewSegment)
+	verificationPolicyString, err := s.GetVerificationPolicyBySecurityDomain(ctx, addressStruct.LedgerSegment)
 	if err != nil {
-		return fmt.Errorf("Unable to resolve verification policy: %s", err.Error())
+		return nil, fmt.Errorf("Unable to resolve verification policy: Unable to get verification policy: %s", err.Error())
 	}
+	verificationPolicy, err := matchVerificationPolicy(verificationPolicyString, addressStruct.ViewSegment)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to resolve verification policy: %s", err.Error())
+	}
+	version := sha256.Sum256([]byte(verificationPolicyString))
+	return &viewPolicy{
+		securityDomain: addressStruct.LedgerSegment,
+		policy:         verificationPolicy,
+		version:        hex.EncodeToString(version[:]),
+	}, nil
+}
+
+// verifyViewProof verifies the proof and the freshness of a view against a verification policy
+func verifyViewProof(s *SmartContract, ctx contractapi.TransactionContextInterface, view *common.View, verificationPolicy *common.Policy, securityDomain string, address string) error {
+	var err error
 	switch view.Meta.Protocol {
 	case common.Meta_CORDA:
 		switch view.Meta.ProofType {
 		case "Notarization":
-			return verifyCordaNotarization(s, ctx, view.Data, verificationPolicy, addressStruct.LedgerSegment, address)
+			err = verifyCordaNotarization(s, ctx, view.Data, verificationPolicy, securityDomain, address)
 		default:
 			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
 		}
 	case common.Meta_FABRIC:
 		switch view.Meta.ProofType {
 		case "Notarization":
-			return verifyFabricNotarization(
+			err = verifyFabricNotarization(
 				s,
 				ctx,
 				view.Data,
 				verificationPolicy,
-				addressStruct.LedgerSegment,
+				securityDomain,
 				address)
 		default:
 			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
 		}
+	case common.Meta_ETHEREUM:
+		switch view.Meta.ProofType {
+		case "StateProof":
+			err = verifyBesuStateProof(s, ctx, view.Data, verificationPolicy, securityDomain, address)
+		default:
+			return fmt.Errorf("Proof type not supported: %s", view.Meta.ProofType)
+		}
 	default:
 		return fmt.Errorf("Verification Error: Unrecognised protocol %s", view.Meta.Protocol)
 	}
+	if err != nil {
+		return err
+	}
+
+	// Reject views that are older than the verification policy allows
+	return verifyViewFreshness(ctx, view, verificationPolicy)
 
 	// TODO: Somewhere, we need to validate the requestor certificate and the nonce within the InteropPayload
 }
@@ -381,3 +892,61 @@ func verifyFabricNotarization(s *SmartContract, ctx contractapi.TransactionConte
 	log.Infof("Proof associated with response '%s' from Fabric network for query '%s' is VALID", string(viewPayload), address)
 	return nil
 }
+
+// viewTimestamp returns the time at which the source network produced a view, as dated within its proof.
+// Fabric payloads are dated with the timestamp of the transaction proposal, which is chosen by the remote relay
+// driver and only endorsed by peers whose clocks are within maxProposalClockSkew of it. Corda payloads are dated
+// by the node signing them, and Besu views by their sealed block. The timestamp in the view's metadata is set by
+// the remote relay driver outside the proof and is hence not used. For views carrying several payloads, the
+// oldest timestamp is returned.
+func viewTimestamp(view *common.View) (int64, error) {
+	if view.Meta.Protocol == common.Meta_ETHEREUM {
+		return besuViewTimestamp(view.Data)
+	}
+	interopPayloadList, err := extractInteropPayloads(view)
+	if err != nil {
+		return 0, err
+	}
+	var timestamp int64
+	for i, interopPayload := range interopPayloadList {
+		if interopPayload.Timestamp == 0 {
+			return 0, fmt.Errorf("Interop payload %d does not carry a timestamp; it was produced by a version of the interoperation module that does not date payloads", i)
+		}
+		if i == 0 || interopPayload.Timestamp < timestamp {
+			timestamp = interopPayload.Timestamp
+		}
+	}
+	if len(interopPayloadList) == 0 {
+		return 0, fmt.Errorf("View does not carry any interop payloads")
+	}
+	return timestamp, nil
+}
+
+// verifyViewFreshness checks the timestamp of a view against the current transaction's timestamp.
+// Views older than the policy's maxAge, or dated further in the future than its clockSkew allowance,
+// are rejected. Policies without a maxAge accept views of any age.
+func verifyViewFreshness(ctx contractapi.TransactionContextInterface, view *common.View, verificationPolicy *common.Policy) error {
+	if verificationPolicy.MaxAge == 0 {
+		return nil
+	}
+	timestamp, err := viewTimestamp(view)
+	if err != nil {
+		return fmt.Errorf("Unable to determine view timestamp: %s", err.Error())
+	}
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return fmt.Errorf("Unable to get transaction timestamp: %s", err.Error())
+	}
+	clockSkew := verificationPolicy.ClockSkew
+	if clockSkew == 0 {
+		clockSkew = defaultViewClockSkew
+	}
+	age := txTimestamp.GetSeconds() - timestamp
+	if age < -int64(clockSkew) {
+		return fmt.Errorf("View is dated %d seconds in the future, beyond the allowed clock skew of %d seconds", -age, clockSkew)
+	}
+	if age > int64(verificationPolicy.MaxAge) {
+		return fmt.Errorf("View is %d seconds old, exceeding the maximum age of %d seconds", age, verificationPolicy.MaxAge)
+	}
+	return nil
+}
@@ -9,13 +9,27 @@
 package main
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
-	"testing"
+	"encoding/pem"
 	"io/ioutil"
+	"math/big"
+	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/corda"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils/mocks"
 	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
 )
 
@@ -249,3 +263,312 @@ func TestWriteExternalState(t *testing.T) {
 	err = interopcc.WriteExternalState(ctx, fabricNetwork, "mychannel", "Write", []string{"test-key", ""}, []int{1}, []string{fabricViewAddress}, []string{fabricTestData_2_Orgs.B64View}, decContentsList)
 	require.EqualError(t, err, "VerifyView error: Unable to resolve verification policy: Verification Policy Error: Failed to find verification policy matching view address: " + fabricPattern)
 }
+
+func TestVerifyViewFreshness(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: 1760000000}, nil)
+
+	cordaView := func(timestamps ...int64) *common.View {
+		var viewData corda.ViewData
+		for _, timestamp := range timestamps {
+			payload, err := protoV2.Marshal(&common.InteropPayload{Payload: []byte("data"), Timestamp: timestamp})
+			require.NoError(t, err)
+			viewData.NotarizedPayloads = append(viewData.NotarizedPayloads, &corda.ViewData_NotarizedPayload{Payload: payload})
+		}
+		data, err := protoV2.Marshal(&viewData)
+		require.NoError(t, err)
+		return &common.View{Meta: &common.Meta{Protocol: common.Meta_CORDA, ProofType: "Notarization"}, Data: data}
+	}
+
+	// Policies without a maximum age accept views of any age
+	err := verifyViewFreshness(ctx, cordaView(1), &common.Policy{})
+	require.NoError(t, err)
+
+	policy := &common.Policy{MaxAge: 300}
+	err = verifyViewFreshness(ctx, cordaView(1760000000-300, 1759999990), policy)
+	require.NoError(t, err)
+
+	// The oldest payload determines the age of the view
+	err = verifyViewFreshness(ctx, cordaView(1759999990, 1760000000-301), policy)
+	require.EqualError(t, err, "View is 301 seconds old, exceeding the maximum age of 300 seconds")
+
+	// Views dated in the future are accepted within the default clock skew allowance
+	err = verifyViewFreshness(ctx, cordaView(1760000000+defaultViewClockSkew), policy)
+	require.NoError(t, err)
+	err = verifyViewFreshness(ctx, cordaView(1760000000+defaultViewClockSkew+1), policy)
+	require.EqualError(t, err, "View is dated 61 seconds in the future, beyond the allowed clock skew of 60 seconds")
+	err = verifyViewFreshness(ctx, cordaView(1760000000+120), &common.Policy{MaxAge: 300, ClockSkew: 120})
+	require.NoError(t, err)
+
+	// Views without a timestamp cannot be checked for freshness
+	err = verifyViewFreshness(ctx, cordaView(1760000000, 0), policy)
+	require.EqualError(t, err, "Unable to determine view timestamp: Interop payload 1 does not carry a timestamp; it was produced by a version of the interoperation module that does not date payloads")
+
+	// Besu views are dated by the timestamp of the sealed block
+	_, besuView, _ := readBesuTestData(t)
+	err = verifyViewFreshness(ctx, besuView, policy)
+	require.NoError(t, err)
+	chaincodeStub.GetTxTimestampReturns(&timestamppb.Timestamp{Seconds: 1760000000 + 3600}, nil)
+	err = verifyViewFreshness(ctx, besuView, policy)
+	require.EqualError(t, err, "View is 3600 seconds old, exceeding the maximum age of 300 seconds")
+}
+
+// newFabricTestView creates an (unsigned) Fabric view with a response from each endorser, given as MSP ID, common name
+// and payload
+func newFabricTestView(t *testing.T, endorsers ...[3]string) *common.View {
+	fabricView := fabric.FabricView{}
+	for _, endorser := range endorsers {
+		certBytes, _, err := createECDSACertAndKeyFromTemplate(x509.Certificate{Subject: pkix.Name{CommonName: endorser[1]}, SerialNumber: big.NewInt(1)})
+		require.NoError(t, err)
+		endorserBytes, err := proto.Marshal(&msp.SerializedIdentity{Mspid: endorser[0], IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})})
+		require.NoError(t, err)
+		interopPayloadBytes, err := protoV2.Marshal(&common.InteropPayload{Address: "relay-network1:9080/network1/mychannel:simplestate:Read:a", Payload: []byte(endorser[2])})
+		require.NoError(t, err)
+		chaincodeActionBytes, err := proto.Marshal(&peer.ChaincodeAction{Response: &peer.Response{Status: 200, Payload: interopPayloadBytes}})
+		require.NoError(t, err)
+		fabricView.EndorsedProposalResponses = append(fabricView.EndorsedProposalResponses, &fabric.FabricView_EndorsedProposalResponse{
+			Payload:     &peer.ProposalResponsePayload{Extension: chaincodeActionBytes},
+			Endorsement: &peer.Endorsement{Endorser: endorserBytes},
+		})
+	}
+	viewData, err := protoV2.Marshal(&fabricView)
+	require.NoError(t, err)
+	return &common.View{Meta: &common.Meta{Protocol: common.Meta_FABRIC, ProofType: "Notarization"}, Data: viewData}
+}
+
+func TestExtractViewAgreement(t *testing.T) {
+	// A lagging peer of Org1 returns a stale value
+	view := newFabricTestView(t,
+		[3]string{"Org1MSP", "peer0.org1.network1.com", "new"},
+		[3]string{"Org1MSP", "peer1.org1.network1.com", "old"},
+		[3]string{"Org2MSP", "peer0.org2.network1.com", "new"})
+	contents := []string{"", "", ""}
+
+	agreement, err := extractViewAgreement(view, contents, &common.Policy{Type: "Signature", Criteria: []string{"Org1MSP", "Org2MSP"}})
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), agreement.Data)
+	require.Equal(t, []viewSigner{{Org: "Org1MSP", Id: "peer0.org1.network1.com"}, {Org: "Org2MSP", Id: "peer0.org2.network1.com"}}, agreement.Signers)
+	require.Len(t, agreement.Dissenters, 1)
+	require.Equal(t, viewSigner{Org: "Org1MSP", Id: "peer1.org1.network1.com"}, agreement.Dissenters[0].viewSigner)
+	oldHash := sha256.Sum256([]byte("old"))
+	require.Equal(t, hex.EncodeToString(oldHash[:]), agreement.Dissenters[0].PayloadHash)
+
+	viewData, err := ExtractAndValidateDataFromView(view, contents, &common.Policy{Type: "Signature", Criteria: []string{"Org1MSP", "Org2MSP"}})
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), viewData)
+
+	// Both values are endorsed by Org1 alone, so neither prevails
+	_, err = extractViewAgreement(view, contents, &common.Policy{Type: "Signature", Criteria: []string{"Org1MSP"}})
+	require.ErrorContains(t, err, "both satisfy the verification policy")
+	_, err = extractViewAgreement(view, contents, &common.Policy{Type: "Signature"})
+	require.ErrorContains(t, err, "both satisfy the verification policy")
+
+	// Neither value is endorsed by both organizations
+	view = newFabricTestView(t,
+		[3]string{"Org1MSP", "peer0.org1.network1.com", "new"},
+		[3]string{"Org2MSP", "peer0.org2.network1.com", "old"})
+	_, err = extractViewAgreement(view, contents[:2], &common.Policy{Type: "Signature", Criteria: []string{"Org1MSP", "Org2MSP"}})
+	require.EqualError(t, err, "Mismatching payloads in proposal responses: no group of matching responses among 2 groups satisfies the verification policy")
+
+	// Unanimous responses are accepted whatever the policy
+	view = newFabricTestView(t,
+		[3]string{"Org1MSP", "peer0.org1.network1.com", "new"},
+		[3]string{"Org2MSP", "peer0.org2.network1.com", "new"})
+	agreement, err = extractViewAgreement(view, contents[:2], &common.Policy{Type: "Signature"})
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), agreement.Data)
+	require.Empty(t, agreement.Dissenters)
+}
+
+func TestRecordViewDissent(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	interopcc := SmartContract{}
+	chaincodeStub.GetTxIDReturns("tx1")
+
+	address := "relay-network1:9080/network1/mychannel:simplestate:Read:a"
+	agreement := &viewAgreement{
+		Data:       []byte("new"),
+		Signers:    []viewSigner{{Org: "Org1MSP", Id: "peer0.org1.network1.com"}},
+		Dissenters: []viewResponse{{viewSigner: viewSigner{Org: "Org1MSP", Id: "peer1.org1.network1.com"}, PayloadHash: "abcd"}},
+	}
+	err := recordViewDissent(ctx, "network1", address, agreement)
+	require.NoError(t, err)
+	objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(0)
+	require.Equal(t, viewDissentObjectType, objectType)
+	require.Equal(t, []string{"network1", "tx1", address}, keys)
+	_, dissentBytes := chaincodeStub.PutStateArgsForCall(0)
+	require.JSONEq(t, `{"txId":"tx1","address":"`+address+`","signers":[{"org":"Org1MSP","id":"peer0.org1.network1.com"}],`+
+		`"dissenters":[{"org":"Org1MSP","id":"peer1.org1.network1.com","payloadHash":"abcd"}]}`, string(dissentBytes))
+
+	iterator := &mocks.StateQueryIterator{}
+	iterator.HasNextReturnsOnCall(0, true)
+	iterator.HasNextReturnsOnCall(1, false)
+	iterator.NextReturns(&queryresult.KV{Key: "tx1", Value: dissentBytes}, nil)
+	chaincodeStub.GetStateByPartialCompositeKeyReturns(iterator, nil)
+	dissentsJSON, err := interopcc.GetViewDissents(ctx, "network1")
+	require.NoError(t, err)
+	require.Equal(t, "["+string(dissentBytes)+"]", dissentsJSON)
+}
+
+func TestWriteExternalStateDuplicateAddress(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	ctx.GetClientIdentity().(*mocks.ClientIdentity).GetX509CertificateReturns(&x509.Certificate{Raw: []byte("submitter")}, nil)
+	// GetState does not return the writes of the current transaction
+	chaincodeStub.GetStateReturns(nil, nil)
+	address := "relay-network1:9080/network1/mychannel:simplestate:Read:a"
+	batchTimestamps := map[string]int64{}
+
+	// an older view of an address written earlier in the batch does not overwrite a newer one
+	written, err := writeExternalState(ctx, "reconciliation", address, &externalState{ViewTimestamp: 2, Data: []byte("newer")}, batchTimestamps)
+	require.NoError(t, err)
+	require.True(t, written)
+	written, err = writeExternalState(ctx, "reconciliation", address, &externalState{ViewTimestamp: 1, Data: []byte("older")}, batchTimestamps)
+	require.NoError(t, err)
+	require.False(t, written)
+	require.Equal(t, 1, chaincodeStub.PutStateCallCount())
+
+	// a view as recent as the one written earlier in the batch replaces it
+	written, err = writeExternalState(ctx, "reconciliation", address, &externalState{ViewTimestamp: 2, Data: []byte("latest")}, batchTimestamps)
+	require.NoError(t, err)
+	require.True(t, written)
+	_, stateBytes := chaincodeStub.PutStateArgsForCall(1)
+	var state externalState
+	err = json.Unmarshal(stateBytes, &state)
+	require.NoError(t, err)
+	require.Equal(t, "latest", string(state.Data))
+
+	// other addresses are unaffected
+	written, err = writeExternalState(ctx, "reconciliation", address+"b", &externalState{ViewTimestamp: 1, Data: []byte("other")}, batchTimestamps)
+	require.NoError(t, err)
+	require.True(t, written)
+}
+
+func TestVerifyViews(t *testing.T) {
+	var fabricTestDataBytes, _ = ioutil.ReadFile("./test_data/fabric_viewdata_1_org.json")
+	var fabricTestData TestData
+	json.Unmarshal(fabricTestDataBytes, &fabricTestData)
+	var fabricCaCertNetwork1, _ = ioutil.ReadFile("./test_data/fabric_cacert_org1.pem")
+	fabricViewAddress := "relay-network1:9080/network1/mychannel:simplestate:Read:a"
+
+	membershipBytes, err := json.Marshal(&common.Membership{
+		SecurityDomain: "network1",
+		Members:        map[string]*common.Member{"Org1MSP": {Value: string(fabricCaCertNetwork1), Type: "ca", Chain: []string{}}},
+	})
+	require.NoError(t, err)
+	newPolicyBytes := func(criteria ...string) []byte {
+		policyBytes, err := json.Marshal(&common.VerificationPolicy{
+			SecurityDomain: "network1",
+			Identifiers: []*common.Identifier{{
+				Pattern: "mychannel:simplestate:Read:a",
+				Policy:  &common.Policy{Criteria: criteria, Type: "signature"},
+			}},
+		})
+		require.NoError(t, err)
+		return policyBytes
+	}
+
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	submitterCert := &x509.Certificate{Raw: []byte("submitter")}
+	ctx.GetClientIdentity().(*mocks.ClientIdentity).GetX509CertificateReturns(submitterCert, nil)
+	submitterCertHash := sha256.Sum256(submitterCert.Raw)
+	interopcc := SmartContract{}
+	// a verified view, a malformed view and a view whose signers don't satisfy the verification policy
+	chaincodeStub.GetStateReturnsOnCall(0, newPolicyBytes("Org1MSP"), nil)
+	chaincodeStub.GetStateReturnsOnCall(1, membershipBytes, nil)
+	// the key space does not hold a view of the address yet
+	chaincodeStub.GetStateReturnsOnCall(2, nil, nil)
+	chaincodeStub.GetStateReturnsOnCall(3, newPolicyBytes("Org1MSP", "Org2MSP"), nil)
+	chaincodeStub.GetStateReturnsOnCall(4, membershipBytes, nil)
+	addresses := []string{fabricViewAddress, fabricViewAddress, fabricViewAddress}
+	views := []string{fabricTestData.B64View, "not base64", fabricTestData.B64View}
+	contents := [][]string{{""}, {""}, {""}}
+	reportsJSON, err := interopcc.VerifyViews(ctx, addresses, views, contents, "reconciliation")
+	require.NoError(t, err)
+	var reports []viewReport
+	err = json.Unmarshal([]byte(reportsJSON), &reports)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+
+	require.True(t, reports[0].Verified)
+	require.True(t, reports[0].PolicySatisfied)
+	require.Len(t, reports[0].Signers, 1)
+	require.Equal(t, "Org1MSP", reports[0].Signers[0].Org)
+	require.Empty(t, reports[0].Reason)
+	// only the verified view is audited and has its data written, under the caller's key space
+	require.Equal(t, 2, chaincodeStub.PutStateCallCount())
+	objectType, _ := chaincodeStub.CreateCompositeKeyArgsForCall(2)
+	require.Equal(t, viewAuditObjectType, objectType)
+	var externalStateKeys [][]string
+	for i := 0; i < chaincodeStub.CreateCompositeKeyCallCount(); i++ {
+		if objectType, keys := chaincodeStub.CreateCompositeKeyArgsForCall(i); objectType == externalStateObjectType {
+			externalStateKeys = append(externalStateKeys, keys)
+		}
+	}
+	mspId, _ := ctx.GetClientIdentity().GetMSPID()
+	require.Equal(t, [][]string{{mspId, hex.EncodeToString(submitterCertHash[:]), "reconciliation", fabricViewAddress}}, externalStateKeys)
+	_, stateBytes := chaincodeStub.PutStateArgsForCall(1)
+	var state externalState
+	err = json.Unmarshal(stateBytes, &state)
+	require.NoError(t, err)
+	dataHash := sha256.Sum256(state.Data)
+	require.Equal(t, hex.EncodeToString(dataHash[:]), reports[0].DataHash)
+
+	require.False(t, reports[1].Verified)
+	require.Equal(t, fabricViewAddress, reports[1].Address)
+	require.Contains(t, reports[1].Reason, "Unable to base64 decode data")
+
+	require.False(t, reports[2].Verified)
+	require.False(t, reports[2].PolicySatisfied)
+	require.Len(t, reports[2].Signers, 1)
+	require.Empty(t, reports[2].DataHash)
+	require.Contains(t, reports[2].Reason, "VerifyView error")
+
+	// the written data can be read back by its submitter
+	chaincodeStub.GetStateReturnsOnCall(5, stateBytes, nil)
+	data, err := interopcc.GetExternalState(ctx, "reconciliation", fabricViewAddress)
+	require.NoError(t, err)
+	require.Equal(t, string(state.Data), data)
+	chaincodeStub.GetStateReturnsOnCall(6, nil, nil)
+	_, err = interopcc.GetExternalState(ctx, "reconciliation", fabricViewAddress)
+	require.EqualError(t, err, "No external state for address "+fabricViewAddress+" in key space reconciliation")
+
+	// without a key space only the audit record is written
+	chaincodeStub.GetStateReturnsOnCall(7, newPolicyBytes("Org1MSP"), nil)
+	chaincodeStub.GetStateReturnsOnCall(8, membershipBytes, nil)
+	reportsJSON, err = interopcc.VerifyViews(ctx, addresses[:1], views[:1], contents[:1], "")
+	require.NoError(t, err)
+	require.Contains(t, reportsJSON, `"verified":true`)
+	require.Equal(t, 3, chaincodeStub.PutStateCallCount())
+
+	// a view older than the one held by the key space is verified and audited, but does not overwrite it
+	newerStateBytes, err := json.Marshal(&externalState{ViewTimestamp: state.ViewTimestamp + 1, Data: []byte("newer")})
+	require.NoError(t, err)
+	chaincodeStub.GetStateReturnsOnCall(9, newPolicyBytes("Org1MSP"), nil)
+	chaincodeStub.GetStateReturnsOnCall(10, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(11, newerStateBytes, nil)
+	reportsJSON, err = interopcc.VerifyViews(ctx, addresses[:1], views[:1], contents[:1], "reconciliation")
+	require.NoError(t, err)
+	err = json.Unmarshal([]byte(reportsJSON), &reports)
+	require.NoError(t, err)
+	require.True(t, reports[0].Verified)
+	require.Equal(t, "Key space reconciliation already holds a newer view of the address", reports[0].Reason)
+	require.Equal(t, 4, chaincodeStub.PutStateCallCount())
+
+	// a view as recent as the one held by the key space replaces it
+	chaincodeStub.GetStateReturnsOnCall(12, newPolicyBytes("Org1MSP"), nil)
+	chaincodeStub.GetStateReturnsOnCall(13, membershipBytes, nil)
+	chaincodeStub.GetStateReturnsOnCall(14, stateBytes, nil)
+	reportsJSON, err = interopcc.VerifyViews(ctx, addresses[:1], views[:1], contents[:1], "reconciliation")
+	require.NoError(t, err)
+	require.Contains(t, reportsJSON, `"verified":true`)
+	require.NotContains(t, reportsJSON, `"reason"`)
+	require.Equal(t, 6, chaincodeStub.PutStateCallCount())
+
+	// submitters without an X.509 certificate have no key space
+	ctx.GetClientIdentity().(*mocks.ClientIdentity).GetX509CertificateReturns(nil, nil)
+	_, err = interopcc.GetExternalState(ctx, "reconciliation", fabricViewAddress)
+	require.EqualError(t, err, "Submitter does not have an X.509 certificate")
+
+	_, err = interopcc.VerifyViews(ctx, addresses, views[:1], contents, "")
+	require.EqualError(t, err, "Number of addresses (3) does not match number of views (1)")
+}
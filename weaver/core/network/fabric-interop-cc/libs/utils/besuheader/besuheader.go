@@ -0,0 +1,71 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// Package besuheader decodes the RLP-encoded block headers carried in views of Besu networks. It is shared by the
+// interop chaincode, which verifies these views, and the Fabric SDK, which reads their timestamps, so that both
+// read a header the same way.
+package besuheader
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Positions of the fields of an RLP-encoded block header read by Weaver.
+const (
+	StateRootIndex = 3
+	TimestampIndex = 11
+	ExtraDataIndex = 12
+	MinFields      = 15
+)
+
+// Fields returns the encoded fields of an RLP-encoded block header.
+func Fields(headerRLP []byte) ([]rlp.RawValue, error) {
+	fields, err := SplitList(headerRLP)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < MinFields {
+		return nil, fmt.Errorf("block header has %d fields, expected at least %d", len(fields), MinFields)
+	}
+	return fields, nil
+}
+
+// Timestamp returns the timestamp, in seconds since the Unix epoch, of an RLP-encoded block header.
+func Timestamp(headerRLP []byte) (int64, error) {
+	fields, err := Fields(headerRLP)
+	if err != nil {
+		return 0, err
+	}
+	var timestamp uint64
+	err = rlp.DecodeBytes(fields[TimestampIndex], &timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block timestamp: %s", err.Error())
+	}
+	return int64(timestamp), nil
+}
+
+// SplitList returns the encoded items of an RLP list.
+func SplitList(data []byte) ([]rlp.RawValue, error) {
+	content, rest, err := rlp.SplitList(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after RLP list", len(rest))
+	}
+	items := []rlp.RawValue{}
+	for len(content) > 0 {
+		_, _, tail, err := rlp.Split(content)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, content[:len(content)-len(tail)])
+		content = tail
+	}
+	return items, nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package besuheader_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3/besuheader"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeHeader encodes a block header of the given number of fields, with the given encoded timestamp
+func encodeHeader(t *testing.T, timestamp rlp.RawValue, fields int) []byte {
+	header := []rlp.RawValue{}
+	for i := 0; i < fields; i++ {
+		if i == besuheader.TimestampIndex {
+			header = append(header, timestamp)
+			continue
+		}
+		field, err := rlp.EncodeToBytes(bytes.Repeat([]byte{byte(i + 1)}, 32))
+		require.NoError(t, err)
+		header = append(header, field)
+	}
+	headerRLP, err := rlp.EncodeToBytes(header)
+	require.NoError(t, err)
+	return headerRLP
+}
+
+func TestTimestamp(t *testing.T) {
+	timestampRLP, err := rlp.EncodeToBytes(uint64(0x68e8e600))
+	require.NoError(t, err)
+
+	timestamp, err := besuheader.Timestamp(encodeHeader(t, timestampRLP, 15))
+	require.NoError(t, err)
+	require.Equal(t, int64(0x68e8e600), timestamp)
+
+	fields, err := besuheader.Fields(encodeHeader(t, timestampRLP, 16))
+	require.NoError(t, err)
+	require.Len(t, fields, 16)
+
+	_, err = besuheader.Timestamp(encodeHeader(t, timestampRLP, 12))
+	require.EqualError(t, err, "block header has 12 fields, expected at least 15")
+
+	_, err = besuheader.Timestamp(append(encodeHeader(t, timestampRLP, 15), 0x80))
+	require.EqualError(t, err, "1 trailing bytes after RLP list")
+
+	// timestamps with leading zero bytes are not canonical
+	_, err = besuheader.Timestamp(encodeHeader(t, rlp.RawValue{0x85, 0x00, 0x68, 0xe8, 0xe6, 0x00}, 15))
+	require.ErrorContains(t, err, "invalid block timestamp")
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+// satp contains the functions that asset chaincodes use to verify an asset owner's consent
+// before handing over the asset to a gateway (relay) for a SATP transfer
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SATPTransferConsent is the payload an asset owner signs to let a gateway take custody of an asset in a SATP session
+type SATPTransferConsent struct {
+	AssetType        string `json:"assetType"`
+	AssetId          string `json:"assetId"`
+	RecipientGateway string `json:"recipientGateway"` // base64 encoded ECert of the gateway (relay) allowed to take custody
+	Recipient        string `json:"recipient"`        // base64 encoded ECert of the party the asset is finally assigned to
+	SessionId        string `json:"sessionId"`
+	ExpiryTimeSecs   uint64 `json:"expiryTimeSecs"`
+}
+
+func getSATPConsentKey(ownerECertBase64 string, consentBytes []byte) string {
+	return "SATPConsent_" + generateSHA256HashInHexForm(ownerECertBase64+string(consentBytes))
+}
+
+// UnmarshalSATPTransferConsent decodes a base64 encoded JSON consent payload
+func UnmarshalSATPTransferConsent(consentBase64 string) (*SATPTransferConsent, error) {
+	consentBytes, err := base64.StdEncoding.DecodeString(consentBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SATP transfer consent: %v", err)
+	}
+	if len(consentBytes) == 0 {
+		return nil, fmt.Errorf("empty SATP transfer consent")
+	}
+	consent := &SATPTransferConsent{}
+	err = json.Unmarshal(consentBytes, consent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SATP transfer consent: %v", err)
+	}
+	return consent, nil
+}
+
+// parse the public key from a base64 encoded PEM certificate (the form in which asset owners are recorded)
+func getECDSAPublicKeyFromECertBase64(eCertBase64 string) (*ecdsa.PublicKey, error) {
+	eCertBytes, err := base64.StdEncoding.DecodeString(eCertBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %v", err)
+	}
+	block, _ := pem.Decode(eCertBytes)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("certificate public key is not an ECDSA key")
+	}
+	return publicKey, nil
+}
+
+// VerifySATPTransferConsent checks that the owner of an asset has consented to the calling gateway taking
+// custody of the asset in a SATP session on behalf of the given recipient, and marks the consent as used so that
+// it cannot be replayed.
+// The consent is a base64 encoded JSON SATPTransferConsent, and the signature is a base64 encoded ASN.1 ECDSA
+// signature over the SHA-256 hash of the (decoded) consent bytes, made with the key of the owner's ECert.
+func VerifySATPTransferConsent(ctx contractapi.TransactionContextInterface, assetType, assetId, ownerECertBase64, recipientECertBase64, consentBase64, consentSignatureBase64 string) (*SATPTransferConsent, error) {
+	consent, err := UnmarshalSATPTransferConsent(consentBase64)
+	if err != nil {
+		return nil, err
+	}
+	if consent.AssetType != assetType || consent.AssetId != assetId {
+		return nil, fmt.Errorf("SATP transfer consent is for asset <%s, %s> and not for asset <%s, %s>", consent.AssetType, consent.AssetId, assetType, assetId)
+	}
+	if consent.SessionId == "" {
+		return nil, fmt.Errorf("SATP transfer consent does not specify a session ID")
+	}
+	if consent.RecipientGateway == "" {
+		return nil, fmt.Errorf("SATP transfer consent does not specify a recipient gateway")
+	}
+	if consent.Recipient == "" {
+		return nil, fmt.Errorf("SATP transfer consent does not specify a recipient")
+	}
+	if consent.Recipient != recipientECertBase64 {
+		return nil, fmt.Errorf("SATP transfer consent for session %s was not given for this recipient", consent.SessionId)
+	}
+
+	// Use the transaction timestamp so that all endorsers reach the same verdict
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+	if uint64(txTimestamp.GetSeconds()) >= consent.ExpiryTimeSecs {
+		return nil, fmt.Errorf("SATP transfer consent for session %s has expired", consent.SessionId)
+	}
+
+	// Only the gateway named in the consent can take custody of the asset
+	callerECertBase64, err := GetECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if callerECertBase64 != consent.RecipientGateway {
+		return nil, fmt.Errorf("caller is not the recipient gateway in the SATP transfer consent for session %s", consent.SessionId)
+	}
+
+	consentBytes, _ := base64.StdEncoding.DecodeString(consentBase64)
+	signature, err := base64.StdEncoding.DecodeString(consentSignatureBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SATP transfer consent signature: %v", err)
+	}
+	ownerPublicKey, err := getECDSAPublicKeyFromECertBase64(ownerECertBase64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of asset owner: %v", err)
+	}
+	consentHash := sha256.Sum256(consentBytes)
+	if !ecdsa.VerifyASN1(ownerPublicKey, consentHash[:], signature) {
+		return nil, fmt.Errorf("SATP transfer consent signature is not valid for the owner of asset <%s, %s>", assetType, assetId)
+	}
+
+	consentKey := getSATPConsentKey(ownerECertBase64, consentBytes)
+	usedConsent, err := ctx.GetStub().GetState(consentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SATP transfer consent from world state: %v", err)
+	}
+	if usedConsent != nil {
+		return nil, fmt.Errorf("SATP transfer consent for session %s has already been used", consent.SessionId)
+	}
+	err = ctx.GetStub().PutState(consentKey, []byte(consent.SessionId))
+	if err != nil {
+		return nil, err
+	}
+	return consent, nil
+}
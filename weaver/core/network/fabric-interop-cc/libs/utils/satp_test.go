@@ -0,0 +1,144 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+	mspProtobuf "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// function that generates a signing key and a base64 encoded PEM ECert for it
+func generateKeyAndECertBase64(t *testing.T, commonName string) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	return key, base64.StdEncoding.EncodeToString(certPEM)
+}
+
+// function that supplies the serialized identity returned by ctx.GetStub().GetCreator() for a base64 encoded ECert
+func getCreatorForECert(eCertBase64 string) []byte {
+	eCertBytes, _ := base64.StdEncoding.DecodeString(eCertBase64)
+	serializedIdentityBytes, _ := proto.Marshal(&mspProtobuf.SerializedIdentity{
+		IdBytes: eCertBytes,
+		Mspid:   "ca.org1.example.com",
+	})
+	return serializedIdentityBytes
+}
+
+// function that creates a SATP transfer consent signed with the given key
+func signSATPTransferConsent(t *testing.T, key *ecdsa.PrivateKey, consent wutils.SATPTransferConsent) (string, string) {
+	consentBytes, err := json.Marshal(consent)
+	require.NoError(t, err)
+	consentHash := sha256.Sum256(consentBytes)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, consentHash[:])
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(consentBytes), base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerifySATPTransferConsent(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	worldState := map[string][]byte{}
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return worldState[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		worldState[key] = value
+		return nil
+	}
+	currentTime := time.Now()
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(currentTime), nil)
+
+	ownerKey, ownerECert := generateKeyAndECertBase64(t, "alice")
+	gatewayKey, gatewayECert := generateKeyAndECertBase64(t, "relay")
+	_, otherGatewayECert := generateKeyAndECertBase64(t, "other-relay")
+	_, recipientECert := generateKeyAndECertBase64(t, "bob")
+	_, otherECert := generateKeyAndECertBase64(t, "mallory")
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+
+	consent := wutils.SATPTransferConsent{
+		AssetType:        "bond",
+		AssetId:          "b01",
+		RecipientGateway: gatewayECert,
+		Recipient:        recipientECert,
+		SessionId:        "session-1",
+		ExpiryTimeSecs:   uint64(currentTime.Add(time.Hour).Unix()),
+	}
+	consentBase64, signatureBase64 := signSATPTransferConsent(t, ownerKey, consent)
+
+	// consents which are not for this asset, recipient and gateway, or which are incomplete or expired, are rejected
+	invalidConsents := []struct {
+		name   string
+		modify func(consent *wutils.SATPTransferConsent)
+		err    string
+	}{
+		{"other asset", func(c *wutils.SATPTransferConsent) { c.AssetId = "b02" }, "is for asset <bond, b02> and not for asset <bond, b01>"},
+		{"no session", func(c *wutils.SATPTransferConsent) { c.SessionId = "" }, "does not specify a session ID"},
+		{"no gateway", func(c *wutils.SATPTransferConsent) { c.RecipientGateway = "" }, "does not specify a recipient gateway"},
+		{"no recipient", func(c *wutils.SATPTransferConsent) { c.Recipient = "" }, "does not specify a recipient"},
+		{"other recipient", func(c *wutils.SATPTransferConsent) { c.Recipient = otherECert }, "was not given for this recipient"},
+		{"expired", func(c *wutils.SATPTransferConsent) { c.ExpiryTimeSecs = uint64(currentTime.Unix()) }, "has expired"},
+		{"other gateway", func(c *wutils.SATPTransferConsent) { c.RecipientGateway = otherGatewayECert }, "caller is not the recipient gateway"},
+	}
+	for _, invalid := range invalidConsents {
+		t.Run(invalid.name, func(t *testing.T) {
+			invalidConsent := consent
+			invalid.modify(&invalidConsent)
+			invalidConsentBase64, invalidSignatureBase64 := signSATPTransferConsent(t, ownerKey, invalidConsent)
+			_, err := wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, invalidConsentBase64, invalidSignatureBase64)
+			require.ErrorContains(t, err, invalid.err)
+		})
+	}
+
+	// malformed consents and owner certificates are rejected
+	_, err := wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, "not base64", signatureBase64)
+	require.ErrorContains(t, err, "failed to decode SATP transfer consent")
+	_, err = wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, "", signatureBase64)
+	require.ErrorContains(t, err, "empty SATP transfer consent")
+	_, err = wutils.VerifySATPTransferConsent(ctx, "bond", "b01", base64.StdEncoding.EncodeToString([]byte("owner")), recipientECert, consentBase64, signatureBase64)
+	require.ErrorContains(t, err, "failed to get public key of asset owner")
+
+	// the consent has to be signed by the owner, and not by the gateway it names
+	_, gatewaySignatureBase64 := signSATPTransferConsent(t, gatewayKey, consent)
+	_, err = wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, consentBase64, gatewaySignatureBase64)
+	require.ErrorContains(t, err, "signature is not valid for the owner of asset <bond, b01>")
+	_, err = wutils.VerifySATPTransferConsent(ctx, "bond", "b01", otherECert, recipientECert, consentBase64, signatureBase64)
+	require.ErrorContains(t, err, "signature is not valid")
+	require.Empty(t, worldState)
+
+	// a valid consent is accepted once, and then recorded as used
+	verified, err := wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, consentBase64, signatureBase64)
+	require.NoError(t, err)
+	require.Equal(t, consent, *verified)
+	require.Len(t, worldState, 1)
+	_, err = wutils.VerifySATPTransferConsent(ctx, "bond", "b01", ownerECert, recipientECert, consentBase64, signatureBase64)
+	require.ErrorContains(t, err, "SATP transfer consent for session session-1 has already been used")
+}
@@ -227,6 +227,51 @@ func unmarshalAssetClaimStatus(claimStatusBase64 string) (*common.AssetClaimStat
 	return claimStatus, nil
 }
 
+// GetAssetTransferMirroringKey returns the key that an application chaincode sets (to any non-empty value) to opt in
+// to mirroring its asset pledges and claims in the Interop Chaincode. It must only be set once the Interop Chaincode
+// exposes RecordAssetPledge, RecordAssetClaim and DeleteAssetPledge, as pledges, claims and reclaims fail otherwise.
+func GetAssetTransferMirroringKey() string {
+	return "mirrorAssetTransfers"
+}
+
+// recordWithInteropChaincode mirrors an asset pledge or claim in the Interop Chaincode, if the application chaincode
+// has opted in, so that remote networks can query its status from the Interop Chaincode without an application
+// chaincode wrapper. Pledges and claims made in a transaction submitted to the Interop Chaincode (through
+// WriteExternalState) are not mirrored, as Fabric does not let the application chaincode invoke the Interop
+// Chaincode again within that transaction.
+func recordWithInteropChaincode(ctx contractapi.TransactionContextInterface, function string, args ...string) error {
+	mirroring, err := ctx.GetStub().GetState(GetAssetTransferMirroringKey())
+	if err != nil {
+		return err
+	}
+	if len(mirroring) == 0 {
+		return nil
+	}
+	interopChaincodeID, err := ctx.GetStub().GetState(GetInteropChaincodeIDKey())
+	if err != nil {
+		return err
+	}
+	if len(interopChaincodeID) == 0 {
+		return fmt.Errorf("asset transfer mirroring is enabled but no interop chaincode ID is recorded")
+	}
+	localChaincodeID, err := GetLocalChaincodeID(ctx.GetStub())
+	if err != nil {
+		return err
+	}
+	if localChaincodeID == string(interopChaincodeID) {
+		return nil
+	}
+	byteArgs := [][]byte{[]byte(function)}
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+	resp := ctx.GetStub().InvokeChaincode(string(interopChaincodeID), byteArgs, "")
+	if resp.GetStatus() != shim.OK {
+		return fmt.Errorf("%s in interop chaincode %s failed: %s", function, string(interopChaincodeID), resp.GetMessage())
+	}
+	return nil
+}
+
 // PledgeAsset locks an asset for transfer to a different ledger/network.
 func PledgeAsset(ctx contractapi.TransactionContextInterface, assetJSON []byte, assetType, assetIdOrQuantity, remoteNetworkId, recipientCert string, expiryTimeSecs uint64) (string, error) {
 	if assetIdOrQuantity == "" {
@@ -285,6 +330,10 @@ func PledgeAsset(ctx contractapi.TransactionContextInterface, assetJSON []byte,
 	if err != nil {
 		return "", err
 	}
+	err = recordWithInteropChaincode(ctx, "RecordAssetPledge", pledgeId, base64.StdEncoding.EncodeToString(pledgeBytes))
+	if err != nil {
+		return "", err
+	}
 	return pledgeId, nil
 }
 
@@ -343,7 +392,7 @@ func ClaimRemoteAsset(ctx contractapi.TransactionContextInterface, pledgeId, rem
 	claimKey := getAssetClaimKey(pledgeId)
 	lookupClaimBytes, err := ctx.GetStub().GetState(claimKey)
 	if err != nil {								// No Record of claim
-		return pledge.AssetDetails, ctx.GetStub().PutState(claimKey, claimBytes)
+		return pledge.AssetDetails, recordAssetClaim(ctx, pledgeId, claimKey, claimBytes)
 	}
 
 	lookupClaimStatus := &common.AssetClaimStatus{}
@@ -353,7 +402,16 @@ func ClaimRemoteAsset(ctx contractapi.TransactionContextInterface, pledgeId, rem
 	}
 
 	// Else proceed to claim
-	return pledge.AssetDetails, ctx.GetStub().PutState(claimKey, claimBytes)
+	return pledge.AssetDetails, recordAssetClaim(ctx, pledgeId, claimKey, claimBytes)
+}
+
+// recordAssetClaim records a claim on the ledger and mirrors it in the Interop Chaincode
+func recordAssetClaim(ctx contractapi.TransactionContextInterface, pledgeId, claimKey string, claimBytes []byte) error {
+	err := ctx.GetStub().PutState(claimKey, claimBytes)
+	if err != nil {
+		return err
+	}
+	return recordWithInteropChaincode(ctx, "RecordAssetClaim", pledgeId, base64.StdEncoding.EncodeToString(claimBytes))
 }
 
 // ReclaimAsset gets back the ownership of an asset pledged for transfer to a different ledger/network.
@@ -426,6 +484,10 @@ func ReclaimAsset(ctx contractapi.TransactionContextInterface, pledgeId, recipie
 	if err != nil {
 		return nil, nil, err
 	}
+	err = recordWithInteropChaincode(ctx, "DeleteAssetPledge", pledgeId)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return claimStatus.AssetDetails, pledge.AssetDetails, nil
 }
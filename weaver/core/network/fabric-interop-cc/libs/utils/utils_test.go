@@ -0,0 +1,76 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package utils_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/stretchr/testify/require"
+)
+
+// test case for mirroring asset claims in the Interop Chaincode
+func TestClaimRemoteAssetMirroring(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	worldState := map[string][]byte{
+		wutils.GetLocalNetworkIDKey():         []byte("network2"),
+		wutils.GetInteropChaincodeIDKey():     []byte("interopcc"),
+		wutils.GetAssetTransferMirroringKey(): []byte("true"),
+	}
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return worldState[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		worldState[key] = value
+		return nil
+	}
+	chaincodeStub.InvokeChaincodeReturns(shim.Success(nil))
+
+	_, claimerECert := generateKeyAndECertBase64(t, "bob")
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(claimerECert), nil)
+	pledgeBytes, err := proto.Marshal(&common.AssetPledge{
+		AssetDetails:    []byte("asset"),
+		LocalNetworkID:  "network1",
+		RemoteNetworkID: "network2",
+		Recipient:       claimerECert,
+		ExpiryTimeSecs:  uint64(time.Now().Add(time.Hour).Unix()),
+	})
+	require.NoError(t, err)
+	pledgeBase64 := base64.StdEncoding.EncodeToString(pledgeBytes)
+
+	// a claim submitted to the application chaincode is mirrored in the Interop Chaincode
+	wtest.SetMockStubCCId(chaincodeStub, "simpleasset")
+	assetDetails, err := wutils.ClaimRemoteAsset(ctx, "pledge-1", "network1", pledgeBase64)
+	require.NoError(t, err)
+	require.Equal(t, []byte("asset"), assetDetails)
+	require.Equal(t, 1, chaincodeStub.InvokeChaincodeCallCount())
+	interopChaincodeID, args, _ := chaincodeStub.InvokeChaincodeArgsForCall(0)
+	require.Equal(t, "interopcc", interopChaincodeID)
+	require.Equal(t, "RecordAssetClaim", string(args[0]))
+	require.Equal(t, "pledge-1", string(args[1]))
+
+	// a claim submitted through WriteExternalState succeeds without calling back into the Interop Chaincode
+	wtest.SetMockStubCCId(chaincodeStub, "interopcc")
+	assetDetails, err = wutils.ClaimRemoteAsset(ctx, "pledge-2", "network1", pledgeBase64)
+	require.NoError(t, err)
+	require.Equal(t, []byte("asset"), assetDetails)
+	require.Equal(t, 1, chaincodeStub.InvokeChaincodeCallCount())
+	require.NotEmpty(t, worldState["Claimed_pledge-2"])
+
+	// nothing is mirrored unless the application chaincode has opted in
+	delete(worldState, wutils.GetAssetTransferMirroringKey())
+	wtest.SetMockStubCCId(chaincodeStub, "simpleasset")
+	_, err = wutils.ClaimRemoteAsset(ctx, "pledge-3", "network1", pledgeBase64)
+	require.NoError(t, err)
+	require.Equal(t, 1, chaincodeStub.InvokeChaincodeCallCount())
+}
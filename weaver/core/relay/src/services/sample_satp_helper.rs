@@ -875,6 +875,7 @@ pub fn create_transfer_proposal_claims_request(
         recipient_gateway_network_id: network_asset_transfer.destination_relay,
         sender_gateway_owner_id: "sender_gateway_owner_id".to_string(),
         receiver_gateway_owner_id: "receiver_gateway_owner_id".to_string(),
+        transfer_nonce: Uuid::new_v4().to_string(),
     };
     return transfer_proposal_claims_request;
 }
@@ -916,6 +917,7 @@ pub fn create_transfer_proposal_receipt_request(
         recipient_gateway_network_id: transfer_proposal_claims_request.recipient_gateway_network_id,
         sender_gateway_owner_id: "sender_gateway_owner_id".to_string(),
         receiver_gateway_owner_id: "receiver_gateway_owner_id".to_string(),
+        transfer_nonce: transfer_proposal_claims_request.transfer_nonce,
     };
     return transfer_proposal_receipt_request;
 }
@@ -1036,8 +1038,11 @@ pub fn create_transfer_completed_request(
 
 pub fn create_perform_lock_request(_ack_commence_request: AckCommenceRequest) -> PerformLockRequest {
     // TODO: remove hard coded values
+    // TODO: pass on the consent that the asset owner's application created and signed for the transfer
     let perform_lock_request = PerformLockRequest {
         session_id: "session_id1".to_string(),
+        consent: "".to_string(),
+        consent_signature: "".to_string(),
     };
     return perform_lock_request;
 }
@@ -1048,6 +1053,9 @@ pub fn create_create_asset_request(
     // TODO: remove hard coded values
     let create_asset_request = CreateAssetRequest {
         session_id: "session_id1".to_string(),
+        asset_type: "".to_string(),
+        asset_id: "".to_string(),
+        original_owner: "".to_string(),
     };
     return create_asset_request;
 }
@@ -1056,6 +1064,8 @@ pub fn create_extinguish_request(_commit_ready_request: CommitReadyRequest) -> E
     // TODO: remove hard coded values
     let extinguish_request = ExtinguishRequest {
         session_id: "session_id1".to_string(),
+        asset_type: "".to_string(),
+        asset_id: "".to_string(),
     };
     return extinguish_request;
 }
@@ -1064,8 +1074,11 @@ pub fn create_assign_asset_request(
     _commit_final_assertion_request: CommitFinalAssertionRequest,
 ) -> AssignAssetRequest {
     // TODO: remove hard coded values
+    // TODO: pass on the consent of the custodian of the created asset to its assignment to the beneficiary
     let assign_asset_request = AssignAssetRequest {
         session_id: "session_id1".to_string(),
+        consent: "".to_string(),
+        consent_signature: "".to_string(),
     };
     return assign_asset_request;
 }
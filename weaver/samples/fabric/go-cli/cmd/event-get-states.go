@@ -0,0 +1,64 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// eventGetStatesCmd represents the event get-states command
+var eventGetStatesCmd = &cobra.Command{
+	Use:   "get-states --local-network=<network1|network2> --request-id=<request-id>",
+	Short: "get all event states received for a subscription",
+	Long: `Get all event states received by the local relay for a subscription.
+Note: the relay deletes the events from its database as soon as they are fetched.
+
+Example:
+  fabric-cli event get-states --local-network=network1 --request-id=<request-id>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logDebug, _ := cmd.Flags().GetString("debug")
+		restoreLogLevel := setEventLogLevel(logDebug)
+		defer restoreLogLevel()
+
+		localNetwork, _ := cmd.Flags().GetString("local-network")
+		requestId, _ := cmd.Flags().GetString("request-id")
+		err := eventGetStates(localNetwork, requestId)
+		if err != nil {
+			log.Fatalf("fabric-cli event get-states failed with error: %s", err.Error())
+		}
+	},
+}
+
+func init() {
+	eventCmd.AddCommand(eventGetStatesCmd)
+
+	eventGetStatesCmd.Flags().String("local-network", "", "local-network network for command. <network1|network2>")
+	eventGetStatesCmd.Flags().String("request-id", "", "request ID received during subscription")
+	eventGetStatesCmd.Flags().String("debug", "false", "shows debug logs when running. Disabled by default. To enable --debug=true")
+}
+
+func eventGetStates(localNetwork, requestId string) error {
+	netConfig, err := eventNetworkConfig(localNetwork)
+	if err != nil {
+		return err
+	}
+	if requestId == "" {
+		return fmt.Errorf("--request-id needs to be specified")
+	}
+
+	eventStates, err := eventsmanager.GetAllReceivedEvents(requestId, netConfig.RelayEndPoint)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("received %d event states: %s", len(eventStates.GetStates()), formatEventProto(eventStates))
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// eventStatusCmd represents the event status command
+var eventStatusCmd = &cobra.Command{
+	Use:   "status --local-network=<network1|network2> --request-id=<request-id>",
+	Short: "get the status of an event subscription",
+	Long: `Get the status of an event subscription from the local relay.
+
+Example:
+  fabric-cli event status --local-network=network1 --request-id=<request-id>`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logDebug, _ := cmd.Flags().GetString("debug")
+		restoreLogLevel := setEventLogLevel(logDebug)
+		defer restoreLogLevel()
+
+		localNetwork, _ := cmd.Flags().GetString("local-network")
+		requestId, _ := cmd.Flags().GetString("request-id")
+		err := eventStatus(localNetwork, requestId)
+		if err != nil {
+			log.Fatalf("fabric-cli event status failed with error: %s", err.Error())
+		}
+	},
+}
+
+func init() {
+	eventCmd.AddCommand(eventStatusCmd)
+
+	eventStatusCmd.Flags().String("local-network", "", "local-network network for command. <network1|network2>")
+	eventStatusCmd.Flags().String("request-id", "", "request ID received during subscription")
+	eventStatusCmd.Flags().String("debug", "false", "shows debug logs when running. Disabled by default. To enable --debug=true")
+}
+
+func eventStatus(localNetwork, requestId string) error {
+	netConfig, err := eventNetworkConfig(localNetwork)
+	if err != nil {
+		return err
+	}
+	if requestId == "" {
+		return fmt.Errorf("--request-id needs to be specified")
+	}
+
+	state, err := eventsmanager.GetSubscriptionStatus(requestId, netConfig.RelayEndPoint)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("event subscription status: %s", formatEventProto(state))
+	return nil
+}
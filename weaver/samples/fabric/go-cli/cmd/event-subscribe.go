@@ -0,0 +1,79 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// eventSubscribeCmd represents the event subscribe command
+var eventSubscribeCmd = &cobra.Command{
+	Use:   "subscribe --local-network=<network1|network2> --view-address=<view-address> --ledger=<channel> --contract=<chaincode> [--func=<function>] (--app-url=<url> | --pub-driver=<driver-id> --pub-channel=<channel> --pub-contract=<chaincode> --pub-func=<function> --pub-args=<args>)",
+	Short: "subscribe to a remote event via the relay",
+	Long: `Subscribe to a remote event via the relay.
+The remote view at --view-address is published, either to --app-url or through a local chaincode transaction,
+every time an event matching --event-type, --event-class-id, --ledger, --contract and --func occurs in the remote network.
+
+Example:
+  fabric-cli event subscribe --local-network=network1 --view-address=localhost:9083/network2/mychannel:simplestate:Read:a --ledger=mychannel --contract=simplestate --func=create --app-url=http://localhost:8080/simple-event-callback
+  fabric-cli event subscribe --local-network=network1 --view-address=localhost:9083/network2/mychannel:simplestate:Read:a --ledger=mychannel --contract=simplestate --func=create --pub-driver=network1 --pub-channel=mychannel --pub-contract=simplestate --pub-func=Create --pub-args='["keyType", ""]' --pub-replace-arg-index=1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logDebug, _ := cmd.Flags().GetString("debug")
+		restoreLogLevel := setEventLogLevel(logDebug)
+		defer restoreLogLevel()
+
+		err := eventSubscribe(cmd)
+		if err != nil {
+			log.Fatalf("fabric-cli event subscribe failed with error: %s", err.Error())
+		}
+	},
+}
+
+func init() {
+	eventCmd.AddCommand(eventSubscribeCmd)
+	addEventSubscriptionFlags(eventSubscribeCmd)
+}
+
+func eventSubscribe(cmd *cobra.Command) error {
+	localNetwork, _ := cmd.Flags().GetString("local-network")
+	netConfig, err := eventNetworkConfig(localNetwork)
+	if err != nil {
+		return err
+	}
+
+	eventMatcher, eventPublicationSpec, interopJSON, err := eventSubscriptionFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	username, _ := cmd.Flags().GetString("user")
+	interopChaincode, _ := cmd.Flags().GetString("interop-chaincode")
+	contract, signer, certUser, err := eventRequestContext(netConfig, localNetwork, interopChaincode, username)
+	if err != nil {
+		return err
+	}
+
+	confidential, _ := cmd.Flags().GetBool("confidential")
+	state, err := eventsmanager.SubscribeRemoteEvent(contract, eventMatcher, eventPublicationSpec, localNetwork, netConfig.MspId,
+		netConfig.RelayEndPoint, interopJSON, signer, certUser, confidential)
+	if err != nil {
+		return err
+	}
+	if state.GetStatus() != common.EventSubscriptionState_SUBSCRIBED &&
+		state.GetStatus() != common.EventSubscriptionState_DUPLICATE_QUERY_SUBSCRIBED {
+		return fmt.Errorf("unexpected event subscription status %s: %s", state.GetStatus(), state.GetMessage())
+	}
+
+	log.Infof("event subscription status success with requestId: %s and event matcher: %s", state.GetRequestId(),
+		formatEventProto(eventMatcher))
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// eventUnsubscribeCmd represents the event unsubscribe command
+var eventUnsubscribeCmd = &cobra.Command{
+	Use:   "unsubscribe --local-network=<network1|network2> --request-id=<request-id> --view-address=<view-address> --ledger=<channel> --contract=<chaincode> [--func=<function>] (--app-url=<url> | --pub-driver=<driver-id> --pub-channel=<channel> --pub-contract=<chaincode> --pub-func=<function> --pub-args=<args>)",
+	Short: "unsubscribe from a remote event via the relay",
+	Long: `Unsubscribe from a remote event via the relay.
+The event matcher, publication spec and view address must be the same as the ones supplied during subscription.
+
+Example:
+  fabric-cli event unsubscribe --local-network=network1 --request-id=<request-id> --view-address=localhost:9083/network2/mychannel:simplestate:Read:a --ledger=mychannel --contract=simplestate --func=create --app-url=http://localhost:8080/simple-event-callback`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logDebug, _ := cmd.Flags().GetString("debug")
+		restoreLogLevel := setEventLogLevel(logDebug)
+		defer restoreLogLevel()
+
+		err := eventUnsubscribe(cmd)
+		if err != nil {
+			log.Fatalf("fabric-cli event unsubscribe failed with error: %s", err.Error())
+		}
+	},
+}
+
+func init() {
+	eventCmd.AddCommand(eventUnsubscribeCmd)
+	addEventSubscriptionFlags(eventUnsubscribeCmd)
+	eventUnsubscribeCmd.Flags().String("request-id", "", "request ID received during subscription")
+}
+
+func eventUnsubscribe(cmd *cobra.Command) error {
+	localNetwork, _ := cmd.Flags().GetString("local-network")
+	netConfig, err := eventNetworkConfig(localNetwork)
+	if err != nil {
+		return err
+	}
+
+	requestId, _ := cmd.Flags().GetString("request-id")
+	if requestId == "" {
+		return fmt.Errorf("--request-id needs to be specified")
+	}
+
+	eventMatcher, eventPublicationSpec, interopJSON, err := eventSubscriptionFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	username, _ := cmd.Flags().GetString("user")
+	interopChaincode, _ := cmd.Flags().GetString("interop-chaincode")
+	contract, signer, certUser, err := eventRequestContext(netConfig, localNetwork, interopChaincode, username)
+	if err != nil {
+		return err
+	}
+
+	confidential, _ := cmd.Flags().GetBool("confidential")
+	state, err := eventsmanager.UnsubscribeRemoteEvent(contract, eventMatcher, eventPublicationSpec, requestId, localNetwork,
+		netConfig.MspId, netConfig.RelayEndPoint, interopJSON, signer, certUser, confidential)
+	if err != nil {
+		return err
+	}
+	if state.GetStatus() != common.EventSubscriptionState_UNSUBSCRIBED {
+		return fmt.Errorf("unexpected event unsubscription status %s: %s", state.GetStatus(), state.GetMessage())
+	}
+
+	log.Infof("event unsubscription success for requestId: %s and event matcher: %s", state.GetRequestId(),
+		formatEventProto(eventMatcher))
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/samples/fabric/go-cli/helpers"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/interoperablehelper"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventCmd represents the event command
+var eventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "operate on remote events via the relay: subscribe|unsubscribe|status|get-states",
+	Long: `Command does nothing by itself
+Operate on remote events via the relay: subscribe|unsubscribe|status|get-states
+
+Example:
+  fabric-cli event status --local-network=network1 --request-id=<request-id>`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+func init() {
+	rootCmd.AddCommand(eventCmd)
+}
+
+// add the flags describing an event subscription (event matcher, publication spec and remote view) to a command
+func addEventSubscriptionFlags(c *cobra.Command) {
+	c.Flags().String("local-network", "", "local-network network for command. <network1|network2>")
+	c.Flags().String("user", "", "user for the event subscription. (Optional: Default user is used)")
+	c.Flags().String("interop-chaincode", "interop", "interop chaincode holding the verification policies of the local network")
+	c.Flags().String("view-address", "", "address of the remote view published for every matching event, e.g., localhost:9083/network2/mychannel:simplestate:Read:a")
+	c.Flags().Bool("confidential", false, "request the remote view to be encrypted. Disabled by default")
+
+	c.Flags().String("event-type", "LEDGER_STATE", "type of event to match. <LEDGER_STATE|ASSET_LOCK|ASSET_CLAIM>")
+	c.Flags().String("event-class-id", "", "event class (e.g. chaincode event name) to match (Optional)")
+	c.Flags().String("ledger", "", "remote ledger (channel) of the transactions to match")
+	c.Flags().String("contract", "", "remote contract (chaincode) of the transactions to match")
+	c.Flags().String("func", "", "remote contract function of the transactions to match")
+
+	c.Flags().String("app-url", "", "URL to which the relay publishes the events. If not set, a local chaincode transaction is used for publication")
+	c.Flags().String("pub-driver", "", "driver ID of the local network submitting the publication transaction")
+	c.Flags().String("pub-channel", "", "local channel of the publication transaction")
+	c.Flags().String("pub-contract", "", "local chaincode of the publication transaction")
+	c.Flags().String("pub-func", "", "local chaincode function of the publication transaction")
+	c.Flags().String("pub-args", "[]", "JSON array of arguments of the publication transaction, e.g., '[\"keyType\", \"\"]'")
+	c.Flags().Uint64("pub-replace-arg-index", 0, "index of the publication transaction argument replaced by the event data")
+	c.Flags().StringSlice("pub-members", []string{}, "members of the local network endorsing the publication transaction (Optional)")
+
+	c.Flags().String("debug", "false", "shows debug logs when running. Disabled by default. To enable --debug=true")
+}
+
+// build the event matcher, publication spec and remote view request from the command flags
+func eventSubscriptionFromFlags(c *cobra.Command) (*common.EventMatcher, *common.EventPublication, types.InteropJSON, error) {
+	viewAddress, _ := c.Flags().GetString("view-address")
+	if viewAddress == "" {
+		return nil, nil, types.InteropJSON{}, fmt.Errorf("--view-address needs to be specified")
+	}
+	interopJSON := types.InteropJSON{
+		Address: viewAddress,
+		Sign:    true,
+	}
+
+	eventTypeName, _ := c.Flags().GetString("event-type")
+	eventType, err := eventsmanager.ParseEventType(eventTypeName)
+	if err != nil {
+		return nil, nil, interopJSON, err
+	}
+	eventClassId, _ := c.Flags().GetString("event-class-id")
+	ledger, _ := c.Flags().GetString("ledger")
+	contract, _ := c.Flags().GetString("contract")
+	ccFunc, _ := c.Flags().GetString("func")
+	if ledger == "" || contract == "" {
+		return nil, nil, interopJSON, fmt.Errorf("--ledger and --contract need to be specified")
+	}
+	eventMatcher := eventsmanager.CreateEventMatcher(eventType, eventClassId, ledger, contract, ccFunc)
+
+	var eventPublicationSpec *common.EventPublication
+	appUrl, _ := c.Flags().GetString("app-url")
+	if appUrl != "" {
+		eventPublicationSpec = eventsmanager.CreateEventPublicationSpecForApp(appUrl)
+	} else {
+		pubDriver, _ := c.Flags().GetString("pub-driver")
+		pubChannel, _ := c.Flags().GetString("pub-channel")
+		pubContract, _ := c.Flags().GetString("pub-contract")
+		pubFunc, _ := c.Flags().GetString("pub-func")
+		if pubDriver == "" || pubChannel == "" || pubContract == "" || pubFunc == "" {
+			return nil, nil, interopJSON, fmt.Errorf("either --app-url or all of --pub-driver, --pub-channel, --pub-contract and --pub-func need to be specified")
+		}
+		pubArgsJSON, _ := c.Flags().GetString("pub-args")
+		pubArgs := []string{}
+		err = json.Unmarshal([]byte(pubArgsJSON), &pubArgs)
+		if err != nil {
+			return nil, nil, interopJSON, fmt.Errorf("failed unmarshalling --pub-args: %s", pubArgsJSON)
+		}
+		pubReplaceArgIndex, _ := c.Flags().GetUint64("pub-replace-arg-index")
+		if pubReplaceArgIndex >= uint64(len(pubArgs)) {
+			return nil, nil, interopJSON, fmt.Errorf("--pub-replace-arg-index %d is out of range for --pub-args %s", pubReplaceArgIndex, pubArgsJSON)
+		}
+		pubMembers, _ := c.Flags().GetStringSlice("pub-members")
+		eventPublicationSpec = eventsmanager.CreateEventPublicationSpecForContract(pubDriver, pubChannel, pubContract, pubFunc,
+			pubArgs, pubReplaceArgIndex, pubMembers)
+	}
+
+	return eventMatcher, eventPublicationSpec, interopJSON, nil
+}
+
+// get the interop chaincode handle along with the signer and certificate of the user making the event request
+func eventRequestContext(netConfig helpers.NetworkConfig, localNetwork, interopChaincode, username string) (interoperablehelper.GatewayContract,
+	interoperablehelper.Signer, string, error) {
+	if username == "" {
+		username = "user1"
+	}
+	_, contract, wallet, err := helpers.FabricHelper(helpers.NewGatewayNetworkInterface(), netConfig.ChannelName, interopChaincode,
+		netConfig.ConnProfilePath, localNetwork, netConfig.MspId, true, username, "", true)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed helpers.FabricHelper with error: %s", err.Error())
+	}
+	keyUser, certUser, err := helpers.GetKeyAndCertForRemoteRequestbyUserName(wallet, username)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed helpers.GetKeyAndCertForRemoteRequestbyUserName with error: %s", err.Error())
+	}
+	return contract, helpers.NewWalletSigner(keyUser), certUser, nil
+}
+
+// get the network configuration of the local network, making sure the relay endpoint is set
+func eventNetworkConfig(localNetwork string) (helpers.NetworkConfig, error) {
+	if localNetwork == "" {
+		return helpers.NetworkConfig{}, fmt.Errorf("--local-network needs to be specified")
+	}
+	netConfig, err := helpers.GetNetworkConfig(localNetwork)
+	if err != nil {
+		return netConfig, fmt.Errorf("failed to get network configuration for %s with error: %s", localNetwork, err.Error())
+	}
+	if netConfig.RelayEndPoint == "" {
+		return netConfig, fmt.Errorf("no relay endpoint found for %s in config.json", localNetwork)
+	}
+	return netConfig, nil
+}
+
+func formatEventProto(message proto.Message) string {
+	formatted, err := protojson.MarshalOptions{Multiline: true, Indent: "    "}.Marshal(message)
+	if err != nil {
+		log.Errorf("failed to format %v as JSON with error: %s", message, err.Error())
+		return fmt.Sprintf("%v", message)
+	}
+	return string(formatted)
+}
+
+// enable debug logging if requested, returning a function that restores the original log level
+func setEventLogLevel(logDebug string) func() {
+	currentLogLevel := log.GetLevel()
+	if logDebug == "true" && currentLogLevel != log.DebugLevel {
+		helpers.SetLogLevel(log.DebugLevel)
+		log.Debug("debugging is enabled")
+		return func() {
+			helpers.SetLogLevel(currentLogLevel)
+		}
+	}
+	return func() {}
+}
@@ -7,7 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package helpers
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -508,3 +513,36 @@ func GetKeyAndCertForRemoteRequestbyUserName(wallet *gateway.Wallet, username st
 	// Assume the identity is of type 'fabric-network.X509Identity'
 	return identity.(*gateway.X509Identity).Key(), identity.(*gateway.X509Identity).Certificate(), nil
 }
+
+// WalletSigner signs interop requests (e.g., remote view requests and event subscriptions) with a wallet identity's key
+type WalletSigner struct {
+	signkeyPEM []byte
+}
+
+func NewWalletSigner(keyPEM string) *WalletSigner {
+	return &WalletSigner{
+		signkeyPEM: []byte(keyPEM),
+	}
+}
+
+func (s *WalletSigner) Sign(msg []byte) ([]byte, error) {
+	signkeyBytes, _ := pem.Decode(s.signkeyPEM)
+	if signkeyBytes == nil {
+		return nil, fmt.Errorf("no PEM data found in signkeyPEM")
+	}
+	signkeyPriv, err := x509.ParsePKCS8PrivateKey(signkeyBytes.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed x509.ParsePKCS8PrivateKey with error: %s", err.Error())
+	}
+	ecdsaKey, ok := signkeyPriv.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key is not an ECDSA key")
+	}
+
+	hash := sha256.Sum256(msg)
+	signature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed ecdsa.SignASN1 with error: %s", err.Error())
+	}
+	return signature, nil
+}
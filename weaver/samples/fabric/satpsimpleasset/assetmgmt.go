@@ -44,11 +44,9 @@ func (s *SmartContract) BondAssetSpecificChecks(ctx contractapi.TransactionConte
 	return nil
 }
 
-// LockAssetForSATP transfers the ownership of an asset to a calling relay.
-// TODO: Currently, this is a hacky implementation.
-//       We will need to add some logic for owner's consent that can be verified here.
-//       Right now, we are treating the relay as a trusted party.
-func (s *SmartContract) LockAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, id string) error {
+// LockAssetForSATP transfers the ownership of an asset to the calling gateway (relay), for assignment to a recipient.
+// The owner's consent, signed with the key of the owner's ECert, is verified before the ownership changes.
+func (s *SmartContract) LockAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, id, recipientECertBase64, consentBase64, consentSignatureBase64 string) error {
 	// Read asset
 	asset, err := getBondAsset(ctx, assetType, id)
 	if err != nil {
@@ -68,11 +66,19 @@ func (s *SmartContract) LockAssetForSATP(ctx contractapi.TransactionContextInter
 		return logThenErrorf("Cannot update attributes of locked asset %s\n", asset.ID)
 	}
 
-	recipientECertBase64, err := getECertOfTxCreatorBase64(ctx)
+	// Ensure that the owner has consented to the caller taking custody of the asset
+	consent, err := wutils.VerifySATPTransferConsent(ctx, assetType, id, asset.Owner, recipientECertBase64, consentBase64, consentSignatureBase64)
 	if err != nil {
 		return logThenErrorf("%s", err.Error())
 	}
-	asset.Owner = recipientECertBase64
+	log.Infof("asset %s locked for SATP session %s", asset.ID, consent.SessionId)
+
+	// Record the owner, whose consent is needed to assign the asset held by the gateway
+	err = ctx.GetStub().PutState(getSATPOriginalOwnerKey(assetType, id), []byte(asset.Owner))
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	asset.Owner = consent.RecipientGateway
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
 		return logThenErrorf("%s", err.Error())
@@ -207,7 +213,10 @@ func (s *SmartContract) ClaimAsset(ctx contractapi.TransactionContextInterface,
 	}
 }
 
-func (s *SmartContract) AssignAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, assetId, recipientECertBase64 string) error {
+// AssignAssetForSATP transfers the ownership of an asset held by the calling gateway (relay) to a recipient.
+// The consent to the assignment to this recipient is verified as in LockAssetForSATP, but against the owner of the
+// asset recorded when it was locked or created for SATP, as the gateway holding the asset cannot consent for itself.
+func (s *SmartContract) AssignAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, assetId, recipientECertBase64, consentBase64, consentSignatureBase64 string) error {
 	// Allow access for assigning only if caller is a relay
 	relayAccessCheck, err := wutils.IsClientRelay(ctx.GetStub())
 	if err != nil {
@@ -217,11 +226,27 @@ func (s *SmartContract) AssignAssetForSATP(ctx contractapi.TransactionContextInt
 		return logThenErrorf("Illegal update: caller is not a relay\n")
 	}
 
-	// Change asset ownership to recipient
 	asset, err := getBondAsset(ctx, assetType, assetId)
 	if err != nil {
 		return logThenErrorf("%s", err.Error())
 	}
+	if !isCallerAssetOwner(ctx, asset) {
+		return logThenErrorf("Illegal update: asset %s is not held by the calling gateway\n", asset.ID)
+	}
+	originalOwner, err := ctx.GetStub().GetState(getSATPOriginalOwnerKey(assetType, assetId))
+	if err != nil {
+		return logThenErrorf("failed to read the original owner of asset %s: %v", asset.ID, err)
+	}
+	if len(originalOwner) == 0 {
+		return logThenErrorf("no original owner recorded for asset %s", asset.ID)
+	}
+	consent, err := wutils.VerifySATPTransferConsent(ctx, assetType, assetId, string(originalOwner), recipientECertBase64, consentBase64, consentSignatureBase64)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	log.Infof("asset %s assigned for SATP session %s", asset.ID, consent.SessionId)
+
+	// Change asset ownership to recipient
 	asset.Owner = string(recipientECertBase64)
 	assetJSON, err := json.Marshal(asset)
 	if err != nil {
@@ -232,7 +257,79 @@ func (s *SmartContract) AssignAssetForSATP(ctx contractapi.TransactionContextInt
 		return logThenErrorf("%s", err.Error())
 	}
 
-	return nil
+	return ctx.GetStub().DelState(getSATPOriginalOwnerKey(assetType, assetId))
+}
+
+// CreateAssetForSATP creates the asset transferred in a SATP session in the recipient network, held by the calling
+// gateway (relay) until it is assigned to the recipient with AssignAssetForSATP. The ECert of the asset's owner in
+// the origin network is recorded, as the assignment needs this owner's consent.
+func (s *SmartContract) CreateAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, id, originalOwnerECertBase64 string) error {
+	// Allow creation only if caller is a relay
+	relayAccessCheck, err := wutils.IsClientRelay(ctx.GetStub())
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	if !relayAccessCheck {
+		return logThenErrorf("Illegal update: caller is not a relay\n")
+	}
+	if assetType == "" || id == "" {
+		return logThenErrorf("Asset type and ID cannot be blank")
+	}
+	if originalOwnerECertBase64 == "" {
+		return logThenErrorf("Original owner of the asset cannot be blank")
+	}
+	exists, err := s.AssetExists(ctx, assetType, id)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	if exists {
+		return logThenErrorf("the asset %s already exists", id)
+	}
+	gatewayECert, err := getECertOfTxCreatorBase64(ctx)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+
+	asset := BondAsset{
+		Type:  assetType,
+		ID:    id,
+		Owner: gatewayECert,
+	}
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	err = ctx.GetStub().PutState(getSATPOriginalOwnerKey(assetType, id), []byte(originalOwnerECertBase64))
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().PutState(getBondAssetKey(assetType, id), assetJSON)
+}
+
+// ExtinguishAssetForSATP deletes an asset locked for a SATP session in the origin network, once it has been created
+// in the recipient network. Only the gateway (relay) holding the asset can extinguish it.
+func (s *SmartContract) ExtinguishAssetForSATP(ctx contractapi.TransactionContextInterface, assetType, id string) error {
+	// Allow access for extinguishing only if caller is a relay
+	relayAccessCheck, err := wutils.IsClientRelay(ctx.GetStub())
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	if !relayAccessCheck {
+		return logThenErrorf("Illegal update: caller is not a relay\n")
+	}
+
+	asset, err := getBondAsset(ctx, assetType, id)
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	if !isCallerAssetOwner(ctx, asset) {
+		return logThenErrorf("Illegal update: asset %s is not held by the calling gateway\n", asset.ID)
+	}
+	err = ctx.GetStub().DelState(getSATPOriginalOwnerKey(assetType, id))
+	if err != nil {
+		return logThenErrorf("%s", err.Error())
+	}
+	return ctx.GetStub().DelState(getBondAssetKey(assetType, id))
 }
 
 func (s *SmartContract) ClaimAssetUsingContractId(ctx contractapi.TransactionContextInterface, contractId, claimInfoSerializedProto64 string) (bool, error) {
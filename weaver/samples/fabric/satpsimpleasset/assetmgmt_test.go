@@ -3,8 +3,16 @@ package main_test
 import (
 	"encoding/json"
 	"encoding/base64"
+	"encoding/asn1"
+	"encoding/pem"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -15,6 +23,8 @@ import (
 	sa "github.com/hyperledger-cacti/cacti/weaver/samples/fabric/satpsimpleasset"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	wtest "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/testutils"
+	wutils "github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // function that supplies value that is to be returned by ctx.GetStub().GetCreator() in locker/recipient context
@@ -230,3 +240,254 @@ func TestExchangeBondAssetWithTokenAsset(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+// function that generates a signing key and a base64 encoded ECert, optionally carrying the "relay" attribute
+func generateKeyAndECertBase64(t *testing.T, commonName string, isRelay bool) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if isRelay {
+		// attributes are carried in the same certificate extension used by Fabric CA
+		template.ExtraExtensions = []pkix.Extension{{
+			Id:    asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1},
+			Value: []byte(`{"attrs":{"relay":"true"}}`),
+		}}
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	return key, base64.StdEncoding.EncodeToString(certPEM)
+}
+
+// function that supplies the serialized identity returned by ctx.GetStub().GetCreator() for a base64 encoded ECert
+func getCreatorForECert(eCertBase64 string) []byte {
+	eCertBytes, _ := base64.StdEncoding.DecodeString(eCertBase64)
+	serializedIdentity := &mspProtobuf.SerializedIdentity{
+		IdBytes: eCertBytes,
+		Mspid:   "ca.org1.example.com",
+	}
+	serializedIdentityBytes, _ := proto.Marshal(serializedIdentity)
+	return serializedIdentityBytes
+}
+
+// function that creates a SATP transfer consent signed with the given key
+func signSATPTransferConsent(t *testing.T, key *ecdsa.PrivateKey, consent wutils.SATPTransferConsent) (string, string) {
+	consentBytes, err := json.Marshal(consent)
+	require.NoError(t, err)
+	consentHash := sha256.Sum256(consentBytes)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, consentHash[:])
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(consentBytes), base64.StdEncoding.EncodeToString(signature)
+}
+
+// test case for locking and assigning a bond asset with the owner's consent in a SATP session
+func TestLockAndAssignAssetForSATP(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	sc := sa.SmartContract{}
+	sc.ConfigureInterop("interopcc")
+
+	// back the world state with a map so that the recorded consents are visible to later calls
+	worldState := map[string][]byte{}
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return worldState[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		worldState[key] = value
+		return nil
+	}
+	chaincodeStub.DelStateStub = func(key string) error {
+		delete(worldState, key)
+		return nil
+	}
+	chaincodeStub.InvokeChaincodeReturns(shim.Success([]byte("false")))
+	currentTime := time.Now()
+	chaincodeStub.GetTxTimestampReturns(timestamppb.New(currentTime), nil)
+
+	ownerKey, ownerECert := generateKeyAndECertBase64(t, "alice", false)
+	gatewayKey, gatewayECert := generateKeyAndECertBase64(t, "relay", true)
+	_, otherGatewayECert := generateKeyAndECertBase64(t, "other-relay", true)
+	otherKey, otherECert := generateKeyAndECertBase64(t, "mallory", false)
+	_, recipientECert := generateKeyAndECertBase64(t, "bob", false)
+	bondType := "bond"
+	bondId := "b01"
+	bondAsset := sa.BondAsset{
+		Type:         bondType,
+		ID:           bondId,
+		Owner:        ownerECert,
+		Issuer:       "network1",
+		FaceValue:    1,
+		MaturityDate: currentTime.Add(time.Hour * 24),
+	}
+	bondAssetBytes, _ := json.Marshal(bondAsset)
+	bondAssetKey := bondType + bondId
+	worldState[bondAssetKey] = bondAssetBytes
+
+	consent := wutils.SATPTransferConsent{
+		AssetType:        bondType,
+		AssetId:          bondId,
+		RecipientGateway: gatewayECert,
+		Recipient:        recipientECert,
+		SessionId:        "session-1",
+		ExpiryTimeSecs:   uint64(currentTime.Add(time.Hour).Unix()),
+	}
+	consentBase64, consentSignatureBase64 := signSATPTransferConsent(t, ownerKey, consent)
+
+	// Lock fails if the consent is not signed by the owner
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+	_, forgedSignatureBase64 := signSATPTransferConsent(t, otherKey, consent)
+	err := sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, consentBase64, forgedSignatureBase64)
+	require.ErrorContains(t, err, "signature is not valid")
+
+	// Lock fails if the caller is not the recipient gateway in the consent
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(otherGatewayECert), nil)
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, consentBase64, consentSignatureBase64)
+	require.ErrorContains(t, err, "caller is not the recipient gateway")
+
+	// Lock fails if the consent is for a different asset
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+	otherAssetConsent := consent
+	otherAssetConsent.AssetId = "b02"
+	otherAssetConsentBase64, otherAssetSignatureBase64 := signSATPTransferConsent(t, ownerKey, otherAssetConsent)
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, otherAssetConsentBase64, otherAssetSignatureBase64)
+	require.ErrorContains(t, err, "is for asset <bond, b02>")
+
+	// Lock fails if the consent was given for another recipient
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, otherECert, consentBase64, consentSignatureBase64)
+	require.ErrorContains(t, err, "was not given for this recipient")
+
+	// Lock fails if the consent has expired
+	expiredConsent := consent
+	expiredConsent.ExpiryTimeSecs = uint64(currentTime.Add(-time.Minute).Unix())
+	expiredConsentBase64, expiredSignatureBase64 := signSATPTransferConsent(t, ownerKey, expiredConsent)
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, expiredConsentBase64, expiredSignatureBase64)
+	require.ErrorContains(t, err, "has expired")
+
+	// Lock succeeds with the owner's consent, transfers the asset to the gateway and records the owner
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, consentBase64, consentSignatureBase64)
+	require.NoError(t, err)
+	lockedAssetBytes := worldState[bondAssetKey]
+	lockedAsset := sa.BondAsset{}
+	json.Unmarshal(lockedAssetBytes, &lockedAsset)
+	require.Equal(t, gatewayECert, lockedAsset.Owner)
+	originalOwnerKey := "SATPOriginalOwner_" + bondAssetKey
+	require.Equal(t, ownerECert, string(worldState[originalOwnerKey]))
+
+	// The same consent cannot be replayed, even if the asset were back with its owner
+	worldState[bondAssetKey] = bondAssetBytes
+	err = sc.LockAssetForSATP(ctx, bondType, bondId, recipientECert, consentBase64, consentSignatureBase64)
+	require.ErrorContains(t, err, "has already been used")
+	worldState[bondAssetKey] = lockedAssetBytes
+
+	// Assign fails with the consent of the gateway holding the asset, which is not its original owner
+	assignConsent := consent
+	assignConsent.SessionId = "session-2"
+	assignConsentBase64, assignSignatureBase64 := signSATPTransferConsent(t, gatewayKey, assignConsent)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "signature is not valid")
+
+	// Assign fails without the consent of the original owner
+	assignConsentBase64, assignSignatureBase64 = signSATPTransferConsent(t, otherKey, assignConsent)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "signature is not valid")
+
+	// Assign fails if the consent was given for another recipient
+	assignConsentBase64, assignSignatureBase64 = signSATPTransferConsent(t, ownerKey, assignConsent)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, otherECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "was not given for this recipient")
+
+	// Assign fails for a gateway not holding the asset
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(otherGatewayECert), nil)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "not held by the calling gateway")
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+
+	// Assign succeeds with the consent of the original owner, whose record is removed
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.NoError(t, err)
+	assignedAsset := sa.BondAsset{}
+	json.Unmarshal(worldState[bondAssetKey], &assignedAsset)
+	require.Equal(t, recipientECert, assignedAsset.Owner)
+	require.NotContains(t, worldState, originalOwnerKey)
+
+	// Assign fails for an asset held by the gateway without a recorded original owner
+	worldState[bondAssetKey] = lockedAssetBytes
+	assignConsent.SessionId = "session-3"
+	assignConsentBase64, assignSignatureBase64 = signSATPTransferConsent(t, ownerKey, assignConsent)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "no original owner recorded")
+
+	// Assign is only allowed for relays
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(ownerECert), nil)
+	err = sc.AssignAssetForSATP(ctx, bondType, bondId, recipientECert, assignConsentBase64, assignSignatureBase64)
+	require.ErrorContains(t, err, "caller is not a relay")
+}
+
+// test case for creating and extinguishing the bond assets held by a gateway in a SATP session
+func TestCreateAndExtinguishAssetForSATP(t *testing.T) {
+	ctx, chaincodeStub := wtest.PrepMockStub()
+	sc := sa.SmartContract{}
+	sc.ConfigureInterop("interopcc")
+
+	worldState := map[string][]byte{}
+	chaincodeStub.GetStateStub = func(key string) ([]byte, error) {
+		return worldState[key], nil
+	}
+	chaincodeStub.PutStateStub = func(key string, value []byte) error {
+		worldState[key] = value
+		return nil
+	}
+	chaincodeStub.DelStateStub = func(key string) error {
+		delete(worldState, key)
+		return nil
+	}
+
+	_, ownerECert := generateKeyAndECertBase64(t, "alice", false)
+	_, gatewayECert := generateKeyAndECertBase64(t, "relay", true)
+	_, otherGatewayECert := generateKeyAndECertBase64(t, "other-relay", true)
+	bondType := "bond"
+	bondId := "b01"
+	bondAssetKey := bondType + bondId
+	originalOwnerKey := "SATPOriginalOwner_" + bondAssetKey
+
+	// Create is only allowed for relays
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(ownerECert), nil)
+	err := sc.CreateAssetForSATP(ctx, bondType, bondId, ownerECert)
+	require.ErrorContains(t, err, "caller is not a relay")
+
+	// Create needs the original owner of the asset
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+	err = sc.CreateAssetForSATP(ctx, bondType, bondId, "")
+	require.ErrorContains(t, err, "Original owner of the asset cannot be blank")
+
+	// Create succeeds for a relay, which holds the created asset, and records the original owner
+	err = sc.CreateAssetForSATP(ctx, bondType, bondId, ownerECert)
+	require.NoError(t, err)
+	createdAsset := sa.BondAsset{}
+	json.Unmarshal(worldState[bondAssetKey], &createdAsset)
+	require.Equal(t, gatewayECert, createdAsset.Owner)
+	require.Equal(t, ownerECert, string(worldState[originalOwnerKey]))
+
+	// An existing asset cannot be created again
+	err = sc.CreateAssetForSATP(ctx, bondType, bondId, ownerECert)
+	require.ErrorContains(t, err, "already exists")
+
+	// Extinguish is only allowed for the relay holding the asset
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(ownerECert), nil)
+	err = sc.ExtinguishAssetForSATP(ctx, bondType, bondId)
+	require.ErrorContains(t, err, "caller is not a relay")
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(otherGatewayECert), nil)
+	err = sc.ExtinguishAssetForSATP(ctx, bondType, bondId)
+	require.ErrorContains(t, err, "not held by the calling gateway")
+
+	// Extinguish succeeds for the relay holding the asset
+	chaincodeStub.GetCreatorReturns(getCreatorForECert(gatewayECert), nil)
+	err = sc.ExtinguishAssetForSATP(ctx, bondType, bondId)
+	require.NoError(t, err)
+	require.NotContains(t, worldState, bondAssetKey)
+	require.NotContains(t, worldState, originalOwnerKey)
+}
@@ -25,6 +25,11 @@ func getBondAssetKey(assetType string, assetId string) string {
 	return assetType + assetId
 }
 
+// key of the owner of an asset before it was locked or created for a SATP session, whose consent its assignment needs
+func getSATPOriginalOwnerKey(assetType string, assetId string) string {
+	return "SATPOriginalOwner_" + assetType + assetId
+}
+
 func getBondAsset(ctx contractapi.TransactionContextInterface, assetType, id string) (*BondAsset, error) {
 	assetJSON, err := ctx.GetStub().GetState(getBondAssetKey(assetType, id))
 	if err != nil {
@@ -9,6 +9,7 @@ package assetmanager
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -267,6 +268,74 @@ func IsAssetLockedInHTLCqueryUsingContractId(contract GatewayContract, contractI
 	return string(result), nil
 }
 
+// hash lock of an HTLC, as returned by the GetHTLCHash functions of the asset chaincodes
+type htlcHashLock struct {
+	HashMechanism common.HashMechanism `json:"hashMechanism"`
+	HashBase64    string               `json:"hashBase64"`
+}
+
+func unmarshalHTLCHash(result []byte) (string, error) {
+	hashLock := htlcHashLock{}
+	err := json.Unmarshal(result, &hashLock)
+	if err != nil {
+		return "", logThenErrorf("failed to unmarshal the hash lock: %+v", err)
+	}
+	if hashLock.HashMechanism != common.HashMechanism_SHA256 {
+		return "", logThenErrorf("unsupported hash mechanism %s", hashLock.HashMechanism)
+	}
+	return hashLock.HashBase64, nil
+}
+
+// GetHTLCHash returns the base64 encoded hash an asset is locked with, for checking it before revealing the preimage
+func GetHTLCHash(contract GatewayContract, assetType string, assetId string, recipientECertBase64 string, lockerECertBase64 string) (string, error) {
+
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if assetType == "" {
+		return "", logThenErrorf("asset type not supplied")
+	}
+	if assetId == "" {
+		return "", logThenErrorf("asset id not supplied")
+	}
+	if recipientECertBase64 == "" {
+		return "", logThenErrorf("recipientECertBase64 id not supplied")
+	}
+	if lockerECertBase64 == "" {
+		return "", logThenErrorf("lockerECertBase64 id not supplied")
+	}
+
+	assetExchangeAgreementStr, err := createAssetExchangeAgreementSerializedBase64(assetType, assetId, recipientECertBase64, lockerECertBase64)
+	if err != nil {
+		return "", logThenErrorf("%s", err.Error())
+	}
+
+	result, err := contract.EvaluateTransaction("GetHTLCHash", assetExchangeAgreementStr)
+	if err != nil {
+		return "", logThenErrorf("error in contract.EvaluateTransaction GetHTLCHash: %+v", err.Error())
+	}
+
+	return unmarshalHTLCHash(result)
+}
+
+// GetHTLCHashByContractId returns the base64 encoded hash of the lock with a contract ID (of fungible or non-fungible assets)
+func GetHTLCHashByContractId(contract GatewayContract, contractId string) (string, error) {
+
+	if contract == nil {
+		return "", logThenErrorf("contract handle not supplied")
+	}
+	if contractId == "" {
+		return "", logThenErrorf("contractId not supplied")
+	}
+
+	result, err := contract.EvaluateTransaction("GetHTLCHashByContractId", contractId)
+	if err != nil {
+		return "", logThenErrorf("error in contract.EvaluateTransaction GetHTLCHashByContractId: %+v", err.Error())
+	}
+
+	return unmarshalHTLCHash(result)
+}
+
 func ClaimAssetInHTLC(contract GatewayContract, assetType string, assetId string, lockerECertBase64 string, hashPreimageBase64 string) (string, error) {
 	if contract == nil {
 		return "", logThenErrorf("contract handle not supplied")
@@ -318,6 +318,92 @@ func TestIsAssetLockedInHTLCqueryUsingContractId(t *testing.T) {
 	require.EqualError(t, err, expectedError)
 }
 
+func TestGetHTLCHash(t *testing.T) {
+
+	contract := gatewayContractMock{}
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(`{"hashMechanism":0,"hashBase64":"hash-base64"}`), nil
+	}
+
+	assetType := "asset-type"
+	assetId := "asset-id"
+	recipientECertBase64 := "recipientECertBase64"
+	lockerECertBase64 := "lockerECertBase64"
+
+	expectedError := "contract handle not supplied"
+	_, err := assetmanager.GetHTLCHash(nil, assetType, assetId, recipientECertBase64, lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "asset type not supplied"
+	_, err = assetmanager.GetHTLCHash(contract, "", assetId, recipientECertBase64, lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "asset id not supplied"
+	_, err = assetmanager.GetHTLCHash(contract, assetType, "", recipientECertBase64, lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "recipientECertBase64 id not supplied"
+	_, err = assetmanager.GetHTLCHash(contract, assetType, assetId, "", lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "lockerECertBase64 id not supplied"
+	_, err = assetmanager.GetHTLCHash(contract, assetType, assetId, recipientECertBase64, "")
+	require.EqualError(t, err, expectedError)
+
+	hashBase64, err := assetmanager.GetHTLCHash(contract, assetType, assetId, recipientECertBase64, lockerECertBase64)
+	require.NoError(t, err)
+	require.Equal(t, "hash-base64", hashBase64)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(`{"hashMechanism":1,"hashBase64":"hash-base64"}`), nil
+	}
+	expectedError = "unsupported hash mechanism SHA512"
+	_, err = assetmanager.GetHTLCHash(contract, assetType, assetId, recipientECertBase64, lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetHTLCHash: failed evaluation"
+	_, err = assetmanager.GetHTLCHash(contract, assetType, assetId, recipientECertBase64, lockerECertBase64)
+	require.EqualError(t, err, expectedError)
+}
+
+func TestGetHTLCHashByContractId(t *testing.T) {
+
+	contract := gatewayContractMock{}
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(`{"hashMechanism":0,"hashBase64":"hash-base64"}`), nil
+	}
+
+	contractId := "contract-id"
+
+	expectedError := "contract handle not supplied"
+	_, err := assetmanager.GetHTLCHashByContractId(nil, contractId)
+	require.EqualError(t, err, expectedError)
+
+	expectedError = "contractId not supplied"
+	_, err = assetmanager.GetHTLCHashByContractId(contract, "")
+	require.EqualError(t, err, expectedError)
+
+	hashBase64, err := assetmanager.GetHTLCHashByContractId(contract, contractId)
+	require.NoError(t, err)
+	require.Equal(t, "hash-base64", hashBase64)
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte("not json"), nil
+	}
+	_, err = assetmanager.GetHTLCHashByContractId(contract, contractId)
+	require.ErrorContains(t, err, "failed to unmarshal the hash lock")
+
+	evaluateTransactionMock = func() ([]byte, error) {
+		return []byte(""), errors.New("failed evaluation")
+	}
+	expectedError = "error in contract.EvaluateTransaction GetHTLCHashByContractId: failed evaluation"
+	_, err = assetmanager.GetHTLCHashByContractId(contract, contractId)
+	require.EqualError(t, err, expectedError)
+}
+
 func TestClaimAssetInHTLC(t *testing.T) {
 
 	contract := gatewayContractMock{}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventsmanager
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/interoperablehelper"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/relay"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// timeout (in seconds) used while waiting for the relay to complete an event (un)subscription
+const relayTimeoutSecs = 600
+
+// helper functions to log and return errors
+func logThenErrorf(format string, args ...interface{}) error {
+	errorMsg := fmt.Sprintf(format, args...)
+	log.Error(errorMsg)
+	return errors.New(errorMsg)
+}
+
+// ParseEventType maps a case-sensitive event type name (e.g. "LEDGER_STATE") to the corresponding protobuf enum value
+func ParseEventType(eventType string) (common.EventType, error) {
+	value, exists := common.EventType_value[eventType]
+	if !exists {
+		return common.EventType_LEDGER_STATE, logThenErrorf("invalid event type: %s", eventType)
+	}
+	return common.EventType(value), nil
+}
+
+// CreateEventMatcher creates the matcher identifying the remote events of interest
+func CreateEventMatcher(eventType common.EventType, eventClassId, transactionLedgerId, transactionContractId,
+	transactionFunc string) *common.EventMatcher {
+	return &common.EventMatcher{
+		EventType:             eventType,
+		EventClassId:          eventClassId,
+		TransactionLedgerId:   transactionLedgerId,
+		TransactionContractId: transactionContractId,
+		TransactionFunc:       transactionFunc,
+	}
+}
+
+// CreateEventPublicationSpecForApp creates a publication spec that makes the relay forward events to an application URL
+func CreateEventPublicationSpecForApp(appUrl string) *common.EventPublication {
+	return &common.EventPublication{
+		PublicationTarget: &common.EventPublication_AppUrl{
+			AppUrl: appUrl,
+		},
+	}
+}
+
+// CreateEventPublicationSpecForContract creates a publication spec that makes the relay (through the driver) submit
+// a local contract transaction for every event, with the event data replacing the argument at replaceArgIndex
+func CreateEventPublicationSpecForContract(driverId, channelId, chaincodeId, ccFunc string, ccArgs []string,
+	replaceArgIndex uint64, members []string) *common.EventPublication {
+	var ccArgsBytes [][]byte
+	for _, ccArg := range ccArgs {
+		ccArgsBytes = append(ccArgsBytes, []byte(ccArg))
+	}
+	return &common.EventPublication{
+		PublicationTarget: &common.EventPublication_Ctx{
+			Ctx: &common.ContractTransaction{
+				DriverId:        driverId,
+				LedgerId:        channelId,
+				ContractId:      chaincodeId,
+				Func:            ccFunc,
+				Args:            ccArgsBytes,
+				ReplaceArgIndex: replaceArgIndex,
+				Members:         members,
+			},
+		},
+	}
+}
+
+/**
+ * Compute the view address, look up the verification policy criteria and sign the address along with a fresh nonce.
+ * Returns the computed address, the policy criteria, the signature (empty if signing is not requested) and the nonce.
+ **/
+func prepareRemoteEventRequest(interopContract interoperablehelper.GatewayContract, interopJSON types.InteropJSON,
+	signer interoperablehelper.Signer) (string, []string, string, string, error) {
+	var computedAddress string
+	if interopJSON.Address == "" {
+		query := types.Query{
+			ContractName: interopJSON.ChaincodeId,
+			Channel:      interopJSON.ChannelId,
+			CcFunc:       interopJSON.ChaincodeFunc,
+			CcArgs:       interopJSON.CcArgs,
+		}
+		computedAddress = interoperablehelper.CreateAddress(query, interopJSON.NetworkId, interopJSON.RemoteEndPoint)
+	} else {
+		computedAddress = interopJSON.Address
+	}
+
+	policyCriteria, err := interoperablehelper.GetPolicyCriteriaForAddress(interopContract, computedAddress)
+	if err != nil {
+		return "", nil, "", "", logThenErrorf("failed to get policy criteria for address %s with error: %s", computedAddress, err.Error())
+	}
+
+	uuidValue := uuid.New()
+	uuidStr := base64.StdEncoding.EncodeToString([]byte(uuidValue.String()))
+
+	signatureBase64 := ""
+	if interopJSON.Sign {
+		signatureBase64, err = interoperablehelper.SignMessage(computedAddress, uuidStr, signer)
+		if err != nil {
+			return "", nil, "", "", logThenErrorf("failed SignMessage with error: %s", err.Error())
+		}
+	}
+	return computedAddress, policyCriteria, signatureBase64, uuidStr, nil
+}
+
+/**
+ * Subscribe to a remote event through the local relay.
+ * The remote view address (or the parameters needed to compute it) is supplied in interopJSON; the remote network
+ * evaluates the view at that address and publishes it as per eventPublicationSpec every time a matching event occurs.
+ **/
+func SubscribeRemoteEvent(interopContract interoperablehelper.GatewayContract, eventMatcher *common.EventMatcher,
+	eventPublicationSpec *common.EventPublication, networkId, org, localRelayEndpoint string, interopJSON types.InteropJSON,
+	signer interoperablehelper.Signer, certUser string, confidential bool) (*common.EventSubscriptionState, error) {
+
+	computedAddress, policyCriteria, signatureBase64, nonce, err := prepareRemoteEventRequest(interopContract, interopJSON, signer)
+	if err != nil {
+		return nil, logThenErrorf("event subscription failed with error: %s", err.Error())
+	}
+	log.Debugf("making event subscription call to relay for event: %+v and publication spec: %+v", eventMatcher, eventPublicationSpec)
+
+	relayObj := relay.NewRelay(localRelayEndpoint, relayTimeoutSecs)
+	relayResponse, err := relayObj.ProcessSubscribeEventRequest(eventMatcher, eventPublicationSpec, computedAddress, policyCriteria,
+		networkId, certUser, signatureBase64, nonce, org, confidential)
+	if err != nil {
+		return nil, logThenErrorf("event subscription relay response error: %s", err.Error())
+	}
+	log.Debugf("event subscription successful: %+v", relayResponse)
+
+	return relayResponse, nil
+}
+
+/**
+ * Cancel a remote event subscription, identified by the request ID returned during subscription, through the local relay.
+ **/
+func UnsubscribeRemoteEvent(interopContract interoperablehelper.GatewayContract, eventMatcher *common.EventMatcher,
+	eventPublicationSpec *common.EventPublication, requestId, networkId, org, localRelayEndpoint string,
+	interopJSON types.InteropJSON, signer interoperablehelper.Signer, certUser string,
+	confidential bool) (*common.EventSubscriptionState, error) {
+
+	computedAddress, policyCriteria, signatureBase64, nonce, err := prepareRemoteEventRequest(interopContract, interopJSON, signer)
+	if err != nil {
+		return nil, logThenErrorf("event unsubscription failed with error: %s", err.Error())
+	}
+	log.Debugf("making event unsubscription call to relay for event: %+v and publication spec: %+v", eventMatcher, eventPublicationSpec)
+
+	relayObj := relay.NewRelay(localRelayEndpoint, relayTimeoutSecs)
+	relayResponse, err := relayObj.ProcessUnsubscribeEventRequest(eventMatcher, eventPublicationSpec, requestId, computedAddress,
+		policyCriteria, networkId, certUser, signatureBase64, nonce, org, confidential)
+	if err != nil {
+		return nil, logThenErrorf("event unsubscription relay response error: %s", err.Error())
+	}
+	log.Debugf("event unsubscription successful: %+v", relayResponse)
+
+	return relayResponse, nil
+}
+
+/**
+ * Get the current state of an event subscription from the local relay.
+ **/
+func GetSubscriptionStatus(requestId, localRelayEndpoint string) (*common.EventSubscriptionState, error) {
+	relayObj := relay.NewRelay(localRelayEndpoint, relayTimeoutSecs)
+	relayResponse, err := relayObj.GetEventSubscriptionState(requestId)
+	if err != nil {
+		return nil, logThenErrorf("get event subscription relay response error: %s", err.Error())
+	}
+	return relayResponse, nil
+}
+
+/**
+ * Fetch all events received by the local relay for a subscription.
+ * Note: the relay deletes the events from its database as soon as they are fetched.
+ **/
+func GetAllReceivedEvents(requestId, localRelayEndpoint string) (*common.EventStates, error) {
+	relayObj := relay.NewRelay(localRelayEndpoint, relayTimeoutSecs)
+	relayResponse, err := relayObj.GetEventStates(requestId)
+	if err != nil {
+		return nil, logThenErrorf("get event states relay response error: %s", err.Error())
+	}
+	return relayResponse, nil
+}
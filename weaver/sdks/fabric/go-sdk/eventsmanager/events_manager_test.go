@@ -0,0 +1,172 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventsmanager_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/networks"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/eventsmanager"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const verificationPolicy = `{"securityDomain":"network2","identifiers":[{"pattern":"mychannel:simplestate:Read:*","policy":{"type":"Signature","criteria":["Org1MSP"]}}]}`
+
+type gatewayContractMock struct{}
+
+func (gwMock gatewayContractMock) SubmitTransaction(ccFunc string, args ...string) ([]byte, error) {
+	return nil, nil
+}
+
+func (gwMock gatewayContractMock) EvaluateTransaction(ccFunc string, args ...string) ([]byte, error) {
+	return []byte(verificationPolicy), nil
+}
+
+type signerMock struct{}
+
+func (s signerMock) Sign(msg []byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+// relay mock which confirms every (un)subscription after one poll in the pending state
+type relayMock struct {
+	networks.UnimplementedNetworkServer
+	subscriptions map[string]*networks.NetworkEventSubscription
+	polled        map[string]bool
+	unsubscribed  map[string]bool
+}
+
+func (r *relayMock) SubscribeEvent(ctx context.Context, req *networks.NetworkEventSubscription) (*common.Ack, error) {
+	if req.GetEventMatcher().GetTransactionContractId() == "" {
+		return &common.Ack{Status: common.Ack_ERROR, Message: "missing contract in event matcher"}, nil
+	}
+	r.subscriptions["sub-1"] = req
+	return &common.Ack{Status: common.Ack_OK, RequestId: "sub-1"}, nil
+}
+
+func (r *relayMock) UnsubscribeEvent(ctx context.Context, req *networks.NetworkEventUnsubscription) (*common.Ack, error) {
+	r.unsubscribed[req.GetRequestId()] = true
+	return &common.Ack{Status: common.Ack_OK, RequestId: req.GetRequestId()}, nil
+}
+
+func (r *relayMock) GetEventSubscriptionState(ctx context.Context, req *networks.GetStateMessage) (*common.EventSubscriptionState, error) {
+	sub := r.subscriptions[req.GetRequestId()]
+	state := &common.EventSubscriptionState{
+		RequestId:             req.GetRequestId(),
+		EventMatcher:          sub.GetEventMatcher(),
+		EventPublicationSpecs: []*common.EventPublication{sub.GetEventPublicationSpec()},
+	}
+	switch {
+	case !r.polled[req.GetRequestId()]:
+		r.polled[req.GetRequestId()] = true
+		state.Status = common.EventSubscriptionState_SUBSCRIBE_PENDING
+	case r.unsubscribed[req.GetRequestId()]:
+		state.Status = common.EventSubscriptionState_UNSUBSCRIBED
+	default:
+		state.Status = common.EventSubscriptionState_SUBSCRIBED
+	}
+	return state, nil
+}
+
+func (r *relayMock) GetEventStates(ctx context.Context, req *networks.GetStateMessage) (*common.EventStates, error) {
+	return &common.EventStates{
+		States: []*common.EventState{{EventId: "event-1", State: &common.RequestState{RequestId: req.GetRequestId()}}},
+	}, nil
+}
+
+func startRelayMock(t *testing.T) (string, *relayMock) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	mock := &relayMock{
+		subscriptions: map[string]*networks.NetworkEventSubscription{},
+		polled:        map[string]bool{},
+		unsubscribed:  map[string]bool{},
+	}
+	server := grpc.NewServer()
+	networks.RegisterNetworkServer(server, mock)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String(), mock
+}
+
+func TestParseEventType(t *testing.T) {
+	eventType, err := eventsmanager.ParseEventType("ASSET_CLAIM")
+	require.NoError(t, err)
+	require.Equal(t, common.EventType_ASSET_CLAIM, eventType)
+
+	_, err = eventsmanager.ParseEventType("asset_claim")
+	require.EqualError(t, err, "invalid event type: asset_claim")
+}
+
+func TestCreateEventPublicationSpec(t *testing.T) {
+	appSpec := eventsmanager.CreateEventPublicationSpecForApp("http://localhost:8080/simple-event-callback")
+	require.Equal(t, "http://localhost:8080/simple-event-callback", appSpec.GetAppUrl())
+	require.Nil(t, appSpec.GetCtx())
+
+	ctxSpec := eventsmanager.CreateEventPublicationSpecForContract("network1", "mychannel", "simplestate", "Create",
+		[]string{"keyType", ""}, 1, nil)
+	require.Equal(t, "", ctxSpec.GetAppUrl())
+	require.Equal(t, "mychannel", ctxSpec.GetCtx().GetLedgerId())
+	require.Equal(t, [][]byte{[]byte("keyType"), []byte("")}, ctxSpec.GetCtx().GetArgs())
+	require.Equal(t, uint64(1), ctxSpec.GetCtx().GetReplaceArgIndex())
+}
+
+func TestSubscribeAndUnsubscribeRemoteEvent(t *testing.T) {
+	relayEndpoint, mock := startRelayMock(t)
+	contract := gatewayContractMock{}
+	eventMatcher := eventsmanager.CreateEventMatcher(common.EventType_LEDGER_STATE, "", "mychannel", "simplestate", "Create")
+	eventPublicationSpec := eventsmanager.CreateEventPublicationSpecForApp("http://localhost:8080/simple-event-callback")
+	interopJSON := types.InteropJSON{
+		Address: "localhost:9083/network2/mychannel:simplestate:Read:a",
+		Sign:    true,
+	}
+
+	state, err := eventsmanager.SubscribeRemoteEvent(contract, eventMatcher, eventPublicationSpec, "network1", "Org1MSP",
+		relayEndpoint, interopJSON, signerMock{}, "cert", false)
+	require.NoError(t, err)
+	require.Equal(t, common.EventSubscriptionState_SUBSCRIBED, state.GetStatus())
+	require.Equal(t, "sub-1", state.GetRequestId())
+
+	query := mock.subscriptions["sub-1"].GetQuery()
+	require.Equal(t, interopJSON.Address, query.GetAddress())
+	require.Equal(t, []string{"Org1MSP"}, query.GetPolicy())
+	require.Equal(t, "network1", query.GetRequestingNetwork())
+	require.NotEmpty(t, query.GetRequestorSignature())
+
+	status, err := eventsmanager.GetSubscriptionStatus("sub-1", relayEndpoint)
+	require.NoError(t, err)
+	require.Equal(t, common.EventSubscriptionState_SUBSCRIBED, status.GetStatus())
+
+	eventStates, err := eventsmanager.GetAllReceivedEvents("sub-1", relayEndpoint)
+	require.NoError(t, err)
+	require.Len(t, eventStates.GetStates(), 1)
+	require.Equal(t, "event-1", eventStates.GetStates()[0].GetEventId())
+
+	state, err = eventsmanager.UnsubscribeRemoteEvent(contract, eventMatcher, eventPublicationSpec, "sub-1", "network1",
+		"Org1MSP", relayEndpoint, interopJSON, signerMock{}, "cert", false)
+	require.NoError(t, err)
+	require.Equal(t, common.EventSubscriptionState_UNSUBSCRIBED, state.GetStatus())
+}
+
+func TestSubscribeRemoteEventNegativeAck(t *testing.T) {
+	relayEndpoint, _ := startRelayMock(t)
+	eventMatcher := eventsmanager.CreateEventMatcher(common.EventType_LEDGER_STATE, "", "mychannel", "", "Create")
+	eventPublicationSpec := eventsmanager.CreateEventPublicationSpecForApp("http://localhost:8080/simple-event-callback")
+	interopJSON := types.InteropJSON{
+		Address: "localhost:9083/network2/mychannel:simplestate:Read:a",
+		Sign:    false,
+	}
+
+	_, err := eventsmanager.SubscribeRemoteEvent(gatewayContractMock{}, eventMatcher, eventPublicationSpec, "network1",
+		"Org1MSP", relayEndpoint, interopJSON, signerMock{}, "cert", false)
+	require.ErrorContains(t, err, "missing contract in event matcher")
+}
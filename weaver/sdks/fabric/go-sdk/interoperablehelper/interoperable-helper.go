@@ -12,14 +12,19 @@ import (
 	"errors"
 	"bytes"
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/besu"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/corda"
 	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	"github.com/hyperledger-cacti/cacti/weaver/core/network/fabric-interop-cc/libs/utils/v3/besuheader"
 	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/helpers"
 	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/relay"
 	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/types"
@@ -43,14 +48,34 @@ func logThenErrorf(format string, args ...interface{}) error {
 	return errors.New(errorMsg)
 }
 
+// UnknownViewAge is the age InteropFlowWithViewAges reports for a view that does not attest when it was produced
+const UnknownViewAge time.Duration = -1
+
+/**
+ * Fetch remote views through the relay and invoke the local chaincode with them (unless returnWithoutLocalInvocation is set).
+ * Returns the views and the result of the local invocation or, with returnWithoutLocalInvocation, the JSON-encoded arguments for it.
+ **/
 func InteropFlow(interopContract GatewayContract, networkId string, invokeObject types.Query, org, localRelayEndpoint string,
 	interopArgIndices []int, interopJSONs []types.InteropJSON, signer Signer, certUser string, returnWithoutLocalInvocation bool, confidential bool) ([]*common.View, []byte, error) {
+	views, _, result, err := InteropFlowWithViewAges(interopContract, networkId, invokeObject, org, localRelayEndpoint,
+		interopArgIndices, interopJSONs, signer, certUser, returnWithoutLocalInvocation, confidential, nil)
+	return views, result, err
+}
+
+/**
+ * Same as InteropFlow, but also returns the age of each view when it was received (see GetViewAge, or UnknownViewAge).
+ * If viewStore is not nil, the views are stored in it as serialized for the local invocation, before it is submitted.
+ **/
+func InteropFlowWithViewAges(interopContract GatewayContract, networkId string, invokeObject types.Query, org, localRelayEndpoint string,
+	interopArgIndices []int, interopJSONs []types.InteropJSON, signer Signer, certUser string, returnWithoutLocalInvocation bool, confidential bool,
+	viewStore ViewStore) ([]*common.View, []time.Duration, []byte, error) {
 	if len(interopArgIndices) != len(interopJSONs) {
 		logThenErrorf("number of argument indices %d does not match number of view addresses %d", len(interopArgIndices), len(interopJSONs))
 	}
 
 	// Step 1: Iterate through the view addresses, and send remote requests and get views in response for each
 	var views []*common.View
+	var viewAges []time.Duration
 	var viewsSerializedBase64 []string
 	var computedAddresses []string
 	var viewContentsBase64 []string
@@ -58,15 +83,27 @@ func InteropFlow(interopContract GatewayContract, networkId string, invokeObject
 	for i := 0; i < len(interopJSONs); i++ {
 		requestResponseView, requestResponseAddress, err := getRemoteView(interopContract, networkId, org, localRelayEndpoint, interopJSONs[i], signer, certUser)
 		if err != nil {
-			return views, nil, logThenErrorf("InteropFlow remote view request error: %s", err.Error())
+			return views, viewAges, nil, logThenErrorf("InteropFlow remote view request error: %s", err.Error())
 		}
 
 		viewBytes, err := protoV2.Marshal(requestResponseView)
 		if err != nil {
-			return views, nil, logThenErrorf("failed to marshal view with error: %s", err.Error())
+			return views, viewAges, nil, logThenErrorf("failed to marshal view with error: %s", err.Error())
+		}
+		if viewStore != nil {
+			_, err = StoreView(viewStore, viewBytes)
+			if err != nil {
+				return views, viewAges, nil, err
+			}
 		}
 
+		viewAge, err := GetViewAge(requestResponseView)
+		if err != nil {
+			log.Warnf("unable to determine age of view for address %s: %s", requestResponseAddress, err.Error())
+			viewAge = UnknownViewAge
+		}
 		views = append(views, requestResponseView)
+		viewAges = append(viewAges, viewAge)
 		computedAddresses = append(computedAddresses, requestResponseAddress)
 		viewsSerializedBase64 = append(viewsSerializedBase64, base64.StdEncoding.EncodeToString(viewBytes))
 
@@ -83,22 +120,22 @@ func InteropFlow(interopContract GatewayContract, networkId string, invokeObject
 	if returnWithoutLocalInvocation {
 		ccArgs, err := getCCArgsForProofVerification(invokeObject, interopArgIndices, computedAddresses, viewsSerializedBase64, viewContentsBase64)
 		if err != nil {
-			return views, nil, logThenErrorf("InteropFlow getCCArgsForProofVerification error: %s", err.Error())
+			return views, viewAges, nil, logThenErrorf("InteropFlow getCCArgsForProofVerification error: %s", err.Error())
 		}
 		ccArgsBytes, err := json.Marshal(ccArgs)
 		if err != nil {
-			return views, nil, logThenErrorf("InteropFlow failed Marshal with error: %s", ccArgsBytes)
+			return views, viewAges, nil, logThenErrorf("InteropFlow failed Marshal with error: %s", ccArgsBytes)
 		}
-		return views, ccArgsBytes, nil
+		return views, viewAges, ccArgsBytes, nil
 	}
 
 	// Step 2
 	result, err := submitTransactionWithRemoteViews(interopContract, invokeObject, interopArgIndices, computedAddresses, viewsSerializedBase64, viewContentsBase64)
 	if err != nil {
-		return views, nil, logThenErrorf("InteropFlow submit transaction with remote view error: %s", err.Error())
+		return views, viewAges, nil, logThenErrorf("InteropFlow submit transaction with remote view error: %s", err.Error())
 	}
 
-	return views, result, nil
+	return views, viewAges, result, nil
 }
 
 /**
@@ -119,9 +156,122 @@ func submitTransactionWithRemoteViews(interopContract GatewayContract, invokeObj
 	return result, nil
 }
 
+// ViewSigner identifies the party (Fabric endorser or Corda notary) that produced a payload in a view
+type ViewSigner struct {
+	Org string `json:"org"`
+	Id  string `json:"id"`
+}
+
+// ViewReport is the outcome of verifying one of a batch of views in the interop chaincode
+type ViewReport struct {
+	Address         string       `json:"address"`
+	Signers         []ViewSigner `json:"signers"`
+	PolicySatisfied bool         `json:"policySatisfied"`
+	Verified        bool         `json:"verified"`
+	DataHash        string       `json:"dataHash,omitempty"`
+	Reason          string       `json:"reason,omitempty"`
+}
+
+/**
+ * Verify a batch of remote views in a single transaction of the interop chaincode.
+ * - Views failing verification do not fail the transaction; their reports give the reason instead.
+ * - If keySpace is not empty, the data of each verified view is also written under the submitter's key space of that
+ *   name in the interop chaincode, unless it already holds a newer view of the address.
+ * - If viewStore is not nil, the views are stored in it as serialized for the transaction, before it is submitted.
+ **/
+func VerifyViews(interopContract GatewayContract, viewAddresses []string, views []*common.View, viewContents [][]string, keySpace string, viewStore ViewStore) ([]ViewReport, error) {
+	viewsSerializedBase64 := make([]string, len(views))
+	for i, view := range views {
+		viewBytes, err := protoV2.Marshal(view)
+		if err != nil {
+			return nil, logThenErrorf("failed to Marshal view %d: %s", i, err.Error())
+		}
+		if viewStore != nil {
+			_, err = StoreView(viewStore, viewBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+		viewsSerializedBase64[i] = base64.StdEncoding.EncodeToString(viewBytes)
+	}
+	if viewContents == nil {
+		viewContents = make([][]string, len(views))
+	}
+	for i := range viewContents {
+		if viewContents[i] == nil {
+			viewContents[i] = []string{}
+		}
+	}
+	viewAddressesBytes, err := json.Marshal(viewAddresses)
+	if err != nil {
+		return nil, logThenErrorf("failed to Marshal viewAddresses: %s", viewAddresses)
+	}
+	viewsSerializedBase64Bytes, err := json.Marshal(viewsSerializedBase64)
+	if err != nil {
+		return nil, logThenErrorf("failed to Marshal viewsSerializedBase64: %s", viewsSerializedBase64)
+	}
+	viewContentsBytes, err := json.Marshal(viewContents)
+	if err != nil {
+		return nil, logThenErrorf("failed to Marshal viewContents: %s", viewContents)
+	}
+
+	result, err := interopContract.SubmitTransaction("VerifyViews", string(viewAddressesBytes), string(viewsSerializedBase64Bytes), string(viewContentsBytes), keySpace)
+	if err != nil {
+		return nil, logThenErrorf("submitTransaction Error: %s", err.Error())
+	}
+	var reports []ViewReport
+	err = json.Unmarshal(result, &reports)
+	if err != nil {
+		return nil, logThenErrorf("failed to Unmarshal view reports: %s", err.Error())
+	}
+	return reports, nil
+}
+
+// ViewAuditRecord is the record kept by the interop chaincode of a view accepted from a remote network
+type ViewAuditRecord struct {
+	TxId          string       `json:"txId"`
+	Timestamp     int64        `json:"timestamp"`
+	Address       string       `json:"address"`
+	Network       string       `json:"network"`
+	ViewHash      string       `json:"viewHash"`
+	DataHash      string       `json:"dataHash"`
+	Signers       []ViewSigner `json:"signers"`
+	PolicyVersion string       `json:"policyVersion"`
+}
+
+/**
+ * Lookup the audit records of the views accepted by the interop chaincode from a remote network.
+ * The proof of each view can be fetched from a ViewStore by the ViewHash of its record.
+ **/
+func GetViewAuditRecordsByNetwork(interopContract GatewayContract, networkId string) ([]ViewAuditRecord, error) {
+	return getViewAuditRecords(interopContract, "GetViewAuditRecordsByNetwork", networkId)
+}
+
+/**
+ * Lookup the audit records of the views accepted by the interop chaincode for a view address.
+ **/
+func GetViewAuditRecordsByAddress(interopContract GatewayContract, address string) ([]ViewAuditRecord, error) {
+	return getViewAuditRecords(interopContract, "GetViewAuditRecordsByAddress", address)
+}
+
+func getViewAuditRecords(interopContract GatewayContract, function string, arg string) ([]ViewAuditRecord, error) {
+	result, err := interopContract.EvaluateTransaction(function, arg)
+	if err != nil {
+		return nil, logThenErrorf("failed to evaluate transaction %s with error: %s", function, err.Error())
+	}
+	var records []ViewAuditRecord
+	err = json.Unmarshal(result, &records)
+	if err != nil {
+		return nil, logThenErrorf("failed to Unmarshal view audit records: %s", err.Error())
+	}
+	return records, nil
+}
+
 type IdentifierAccessPolicy struct {
-	Type     string   `json:"type"`
-	Criteria []string `json:"criteria"`
+	Type      string   `json:"type"`
+	Criteria  []string `json:"criteria"`
+	MaxAge    uint64   `json:"maxAge,omitempty"`
+	ClockSkew uint64   `json:"clockSkew,omitempty"`
 }
 
 type Identifier struct {
@@ -137,7 +287,7 @@ type VerificationPolicy struct {
 /**
  * Lookup verification policy in the interop chaincode and get the criteria related to query
  **/
-func getPolicyCriteriaForAddress(contract GatewayContract, address string) ([]string, error) {
+func GetPolicyCriteriaForAddress(contract GatewayContract, address string) ([]string, error) {
 	emptyCriteria := []string{}
 
 	parsedAddress, err := helpers.ParseAddress(address)
@@ -159,41 +309,79 @@ func getPolicyCriteriaForAddress(contract GatewayContract, address string) ([]st
 		return emptyCriteria, logThenErrorf("failed to unmarshal verification policy with error: %s", err.Error())
 	}
 
-	// Get policy criteria matching the requested information in the address
-	matchingIdentifier := Identifier{
-		Pattern: "",
-		Policy: IdentifierAccessPolicy{
-			Type:     "",
-			Criteria: []string{},
-		},
-	}
+	// Get policy criteria matching the requested information in the address, preferring an exact match and
+	// otherwise the most specific matching pattern (see the interop chaincode for the pattern syntax)
+	var matchingIdentifier *Identifier
 	for i := 0; i < len(verificationPolicy.Identifiers); i++ {
-		item := verificationPolicy.Identifiers[i]
+		item := &verificationPolicy.Identifiers[i]
 		if item.Pattern == parsedAddress.ViewSegment {
 			matchingIdentifier = item
 			break
 		}
-		if ValidPatternString(item.Pattern) && isPatternAndAddressMatch(item.Pattern, parsedAddress.ViewSegment) &&
-			(matchingIdentifier.Pattern == "") || (len(item.Pattern) > len(matchingIdentifier.Pattern)) {
+		if isPatternAndAddressMatch(item.Pattern, parsedAddress.ViewSegment) &&
+			(matchingIdentifier == nil || isMoreSpecificPattern(item.Pattern, matchingIdentifier.Pattern)) {
 			matchingIdentifier = item
-			break
 		}
 	}
+	if matchingIdentifier == nil {
+		return emptyCriteria, nil
+	}
 
 	return matchingIdentifier.Policy.Criteria, nil
 }
 
-func ValidPatternString(pattern string) bool {
-	// count number of stars in pattern
-	numStars := strings.Count(pattern, "*")
+// Kinds of view address pattern segments, in increasing order of specificity
+const (
+	trailingWildcardSegment = iota
+	wildcardSegment
+	regexSegment
+	globSegment
+	literalSegment
+)
+
+const patternSegmentSeparator = ":"
+
+func patternSegmentKind(segment string, last bool) int {
+	if segment == "*" {
+		if last {
+			return trailingWildcardSegment
+		}
+		return wildcardSegment
+	}
+	if len(segment) >= 2 && strings.HasPrefix(segment, "/") && strings.HasSuffix(segment, "/") {
+		return regexSegment
+	}
+	if strings.ContainsAny(segment, "*?[") {
+		return globSegment
+	}
+	return literalSegment
+}
 
-	// check if 0 or 1 stars
-	if numStars <= 1 {
-		// if 0 stars, return true, if 1 star, make sure its at the end
-		return (numStars == 0) || (strings.Index(pattern, "*")+1 == len(pattern))
+func patternSegmentKinds(pattern string) []int {
+	segments := strings.Split(pattern, patternSegmentSeparator)
+	kinds := make([]int, len(segments))
+	for i, segment := range segments {
+		kinds[i] = patternSegmentKind(segment, i == len(segments)-1)
 	}
+	return kinds
+}
 
-	return false
+// ValidPatternString checks that every glob and regular expression segment of a view address pattern is well formed
+func ValidPatternString(pattern string) bool {
+	segments := strings.Split(pattern, patternSegmentSeparator)
+	for i, segment := range segments {
+		switch patternSegmentKind(segment, i == len(segments)-1) {
+		case regexSegment:
+			if _, err := regexp.Compile("^(?:" + segment[1:len(segment)-1] + ")$"); err != nil {
+				return false
+			}
+		case globSegment:
+			if _, err := path.Match(segment, ""); err != nil {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func isPatternAndAddressMatch(pattern string, address string) bool {
@@ -202,20 +390,45 @@ func isPatternAndAddressMatch(pattern string, address string) bool {
 		return false
 	}
 
-	// count number of stars in pattern
-	numStars := strings.Count(pattern, "*")
-
-	// if 0 stars, and exact match, return true
-	if numStars == 0 && pattern == address {
-		return true
+	patternSegments := strings.Split(pattern, patternSegmentSeparator)
+	addressSegments := strings.Split(address, patternSegmentSeparator)
+	for i, segment := range patternSegments {
+		if i >= len(addressSegments) {
+			return false
+		}
+		switch patternSegmentKind(segment, i == len(patternSegments)-1) {
+		case trailingWildcardSegment:
+			return true
+		case wildcardSegment:
+		case regexSegment:
+			if !regexp.MustCompile("^(?:" + segment[1:len(segment)-1] + ")$").MatchString(addressSegments[i]) {
+				return false
+			}
+		case globSegment:
+			if matched, _ := path.Match(segment, addressSegments[i]); !matched {
+				return false
+			}
+		default:
+			if segment != addressSegments[i] {
+				return false
+			}
+		}
 	}
+	return len(patternSegments) == len(addressSegments)
+}
 
-	// if 1 star and pattern is a substring of address, return true
-	if numStars == 1 && strings.Contains(address, pattern) {
-		return true
+// isMoreSpecificPattern tells whether pattern a takes precedence over pattern b when both match an address
+func isMoreSpecificPattern(a string, b string) bool {
+	kindsA, kindsB := patternSegmentKinds(a), patternSegmentKinds(b)
+	for i := 0; i < len(kindsA) && i < len(kindsB); i++ {
+		if kindsA[i] != kindsB[i] {
+			return kindsA[i] > kindsB[i]
+		}
 	}
-
-	return false
+	if len(kindsA) != len(kindsB) {
+		return len(kindsA) > len(kindsB)
+	}
+	return a < b
 }
 
 /**
@@ -292,6 +505,82 @@ func GetResponseDataFromView(view *common.View) ([]byte, error) {
 	return viewPayload, nil
 }
 
+/**
+ * Returns the time at which the remote network produced a view, as attested by the proof in the view.
+ * For views carrying several payloads (e.g., one per endorser), the oldest timestamp is returned.
+ **/
+func GetViewTimestamp(view *common.View) (time.Time, error) {
+	var payloads [][]byte
+	if view.Meta.Protocol == common.Meta_FABRIC {
+		var fabricViewData fabric.FabricView
+		err := protoV2.Unmarshal(view.Data, &fabricViewData)
+		if err != nil {
+			return time.Time{}, logThenErrorf("fabricView unmarshal error: %s", err.Error())
+		}
+		for _, endorsedProposalResponse := range fabricViewData.EndorsedProposalResponses {
+			var ccAction peer.ChaincodeAction
+			err = proto.Unmarshal(endorsedProposalResponse.GetPayload().GetExtension(), &ccAction)
+			if err != nil {
+				return time.Time{}, logThenErrorf("unable to unmarshal chaincodeAction: %s", err.Error())
+			}
+			payloads = append(payloads, ccAction.GetResponse().GetPayload())
+		}
+	} else if view.Meta.Protocol == common.Meta_CORDA {
+		var cordaViewData corda.ViewData
+		err := protoV2.Unmarshal(view.Data, &cordaViewData)
+		if err != nil {
+			return time.Time{}, logThenErrorf("cordaView unmarshal error: %s", err.Error())
+		}
+		for _, notarizedPayload := range cordaViewData.NotarizedPayloads {
+			payloads = append(payloads, notarizedPayload.Payload)
+		}
+	} else if view.Meta.Protocol == common.Meta_ETHEREUM {
+		// Besu views are dated by the block whose header (sealed by the validators) carries the proven state
+		var besuViewData besu.BesuView
+		err := protoV2.Unmarshal(view.Data, &besuViewData)
+		if err != nil {
+			return time.Time{}, logThenErrorf("besuView unmarshal error: %s", err.Error())
+		}
+		timestamp, err := besuheader.Timestamp(besuViewData.BlockHeaderRlp)
+		if err != nil {
+			return time.Time{}, logThenErrorf("unable to get timestamp of besu block: %s", err.Error())
+		}
+		return time.Unix(timestamp, 0), nil
+	} else {
+		return time.Time{}, logThenErrorf("cannot get timestamp of view; unsupported DLT type: %+v", view.Meta.Protocol)
+	}
+	if len(payloads) == 0 {
+		return time.Time{}, logThenErrorf("view does not carry any interop payloads")
+	}
+
+	var timestamp int64
+	for i, payload := range payloads {
+		var interopPayload common.InteropPayload
+		err := protoV2.Unmarshal(payload, &interopPayload)
+		if err != nil {
+			return time.Time{}, logThenErrorf("unable to unmarshal interopPayload: %s", err.Error())
+		}
+		if interopPayload.GetTimestamp() == 0 {
+			return time.Time{}, logThenErrorf("interop payload %d does not carry a timestamp", i)
+		}
+		if i == 0 || interopPayload.GetTimestamp() < timestamp {
+			timestamp = interopPayload.GetTimestamp()
+		}
+	}
+	return time.Unix(timestamp, 0), nil
+}
+
+/**
+ * Returns how long ago the remote network produced a view (see GetViewTimestamp).
+ **/
+func GetViewAge(view *common.View) (time.Duration, error) {
+	timestamp, err := GetViewTimestamp(view)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(timestamp), nil
+}
+
 func verifyView(contract GatewayContract, b64ViewProto string, address string) error {
 	_, err := contract.EvaluateTransaction("VerifyView", b64ViewProto, address)
 	if err != nil {
@@ -347,7 +636,7 @@ func getCCArgsForProofVerification(invokeObject types.Query, interopArgIndices [
 /**
  * Creates an address string based on a query object, networkid and remote url.
  **/
-func createAddress(query types.Query, networkId, remoteURL string) string {
+func CreateAddress(query types.Query, networkId, remoteURL string) string {
 	addressString := remoteURL + "/" + networkId + "/" + query.Channel + ":" + query.ContractName + ":" + query.CcFunc + ":" + query.CcArgs[0]
 	return addressString
 }
@@ -361,7 +650,7 @@ func createFlowAddress(flow types.Flow, networkId string, remoteURL string) stri
 	return addressString
 }
 
-func signMessage(computedAddress string, uuidStr string, signer Signer) (string, error) {
+func SignMessage(computedAddress string, uuidStr string, signer Signer) (string, error) {
 	message := computedAddress + uuidStr
 	signature, err := signer.Sign([]byte(message))
 	if err != nil {
@@ -390,13 +679,13 @@ func getRemoteView(interopContract GatewayContract, networkId, org, localRelayEn
 	}
 	var computedAddress string
 	if interopJSON.Address == "" {
-		computedAddress = createAddress(query, interopJSON.NetworkId, interopJSON.RemoteEndPoint)
+		computedAddress = CreateAddress(query, interopJSON.NetworkId, interopJSON.RemoteEndPoint)
 	} else {
 		computedAddress = interopJSON.Address
 	}
 
 	// Step 2
-	policyCriteria, err := getPolicyCriteriaForAddress(interopContract, computedAddress)
+	policyCriteria, err := GetPolicyCriteriaForAddress(interopContract, computedAddress)
 	if err != nil {
 		return nil, "", logThenErrorf("InteropFlow failed to get policy criteria for address %s with error: %s", computedAddress, err.Error())
 	}
@@ -411,9 +700,9 @@ func getRemoteView(interopContract GatewayContract, networkId, org, localRelayEn
 	log.Infof("localRelayEndPoint: %s, computedAddress: %s, policyCriteria: %s, networkId: %s, certUser: %s, uuidStr: %s, org: %s",
 		localRelayEndPoint, computedAddress, policyCriteria, networkId, certUser, uuidStr, org)
 
-	signatureBase64, err := signMessage(computedAddress, uuidStr, signer)
+	signatureBase64, err := SignMessage(computedAddress, uuidStr, signer)
 	if err != nil {
-		return nil, "", logThenErrorf("failed signMessage with error: %s", err.Error())
+		return nil, "", logThenErrorf("failed SignMessage with error: %s", err.Error())
 	}
 
 	relayObj := relay.NewRelay(localRelayEndPoint, 600)
@@ -7,11 +7,26 @@ SPDX-License-Identifier: Apache-2.0
 package interoperablehelper_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/besu"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/corda"
 	"github.com/stretchr/testify/require"
 	interoperablehelper "github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/interoperablehelper"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/relaytest"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/types"
+	protoV2 "google.golang.org/protobuf/proto"
 )
 
 func TestValidPatternString(t *testing.T) {
@@ -28,15 +43,323 @@ func TestValidPatternString(t *testing.T) {
 	require.Equal(t, retValue, true)
 	fmt.Printf("Test success as with the pattern passed in the correct format with no stars\n")
 
-	// Test failure with the pattern containing more then one "*" character
-	pattern = "ab*cd*"
+	// Test success with globs and a regular expression in several segments
+	pattern = "mychannel:ab*cd*:Read:/asset[0-9]+/"
+	retValue = interoperablehelper.ValidPatternString(pattern)
+	require.Equal(t, retValue, true)
+	fmt.Printf("Test success with globs and a regular expression in several segments\n")
+
+	// Test failure with a malformed glob
+	pattern = "mychannel:ab[cd:*"
 	retValue = interoperablehelper.ValidPatternString(pattern)
 	require.Equal(t, retValue, false)
-	fmt.Printf("Test failed as expected with pattern containing more than one star\n")
+	fmt.Printf("Test failed as expected with a malformed glob\n")
 
-	// Test failure with the pattern containing one "*" character NOT at the end
-	pattern = "ab*cd"
+	// Test failure with a malformed regular expression
+	pattern = "mychannel:/(/:*"
 	retValue = interoperablehelper.ValidPatternString(pattern)
 	require.Equal(t, retValue, false)
-	fmt.Printf("Test failed as expected with pattern containing one star but NOT at the end\n")
+	fmt.Printf("Test failed as expected with a malformed regular expression\n")
+}
+
+func TestGetPolicyCriteriaForAddress(t *testing.T) {
+	contract := &gatewayContractMock{
+		verificationPolicy: `{"securityDomain":"network2","identifiers":[` +
+			`{"pattern":"*","policy":{"type":"Signature","criteria":["AnyMSP"]}},` +
+			`{"pattern":"mychannel:simplestate:*","policy":{"type":"Signature","criteria":["SimpleStateMSP"]}},` +
+			`{"pattern":"mychannel:simplestate:Read:/bond[0-9]+/","policy":{"type":"Signature","criteria":["BondMSP"]}},` +
+			`{"pattern":"mychannel:simplestate:Read:a","policy":{"type":"Signature","criteria":["ExactMSP"]}}]}`,
+	}
+	for viewSegment, criteria := range map[string]string{
+		"mychannel:simplestate:Read:a":      "ExactMSP",
+		"mychannel:simplestate:Read:bond42": "BondMSP",
+		"mychannel:simplestate:Read:b":      "SimpleStateMSP",
+		"otherchannel:simplestate:Read:a":   "AnyMSP",
+	} {
+		policyCriteria, err := interoperablehelper.GetPolicyCriteriaForAddress(contract, "localhost:9083/network2/"+viewSegment)
+		require.NoError(t, err)
+		require.Equal(t, []string{criteria}, policyCriteria, viewSegment)
+	}
+}
+
+// gatewayContractMock stands in for the local interop chaincode, verifying views against the remote network's membership
+type gatewayContractMock struct {
+	verificationPolicy string
+	membership         *common.Membership
+	submittedArgs      []string
+	viewReports        string
+	viewAuditRecords   string
+}
+
+func (c *gatewayContractMock) EvaluateTransaction(name string, args ...string) ([]byte, error) {
+	switch name {
+	case "GetVerificationPolicyBySecurityDomain":
+		return []byte(c.verificationPolicy), nil
+	case "VerifyView":
+		viewBytes, err := base64.StdEncoding.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		view := &common.View{}
+		err = protoV2.Unmarshal(viewBytes, view)
+		if err != nil {
+			return nil, err
+		}
+		return nil, relaytest.VerifyFabricView(view, args[1], c.membership)
+	case "GetViewAuditRecordsByAddress", "GetViewAuditRecordsByNetwork":
+		return []byte(c.viewAuditRecords), nil
+	}
+	return nil, fmt.Errorf("unexpected transaction %s", name)
+}
+
+func (c *gatewayContractMock) SubmitTransaction(name string, args ...string) ([]byte, error) {
+	switch name {
+	case "WriteExternalState":
+		c.submittedArgs = args
+		return []byte("ok"), nil
+	case "VerifyViews":
+		c.submittedArgs = args
+		return []byte(c.viewReports), nil
+	}
+	return nil, fmt.Errorf("unexpected transaction %s", name)
+}
+
+func TestInteropFlow(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	requestor, err := ca.NewIdentity("user1")
+	require.NoError(t, err)
+	address := "localhost:9083/network2/mychannel:simplestate:Read:a"
+	view, err := relaytest.NewFabricView(address, []byte("Arcturus"), peer0)
+	require.NoError(t, err)
+
+	relay, err := relaytest.StartRelay()
+	require.NoError(t, err)
+	defer relay.Stop()
+	contract := &gatewayContractMock{
+		verificationPolicy: `{"securityDomain":"network2","identifiers":[{"pattern":"mychannel:simplestate:Read:*","policy":{"type":"Signature","criteria":["Org2MSP"]}}]}`,
+		membership:         relaytest.NewMembership("network2", ca),
+	}
+	invokeObject := types.Query{ContractName: "simplestate", Channel: "mychannel", CcFunc: "Create", CcArgs: []string{"a", ""}}
+	interopJSONs := []types.InteropJSON{{Address: address}}
+
+	// the view is fetched through the relay, verified, stored, and written with the local invocation
+	relay.Respond(address, relaytest.PendingThenView(2, view))
+	store := &interoperablehelper.DirectoryViewStore{Dir: filepath.Join(t.TempDir(), "views")}
+	views, viewAges, result, err := interoperablehelper.InteropFlowWithViewAges(contract, "network1", invokeObject, "Org1MSP", relay.Address,
+		[]int{1}, interopJSONs, requestor, requestor.CertificatePEM, false, false, store)
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(result))
+	require.Len(t, views, 1)
+	require.True(t, protoV2.Equal(view, views[0]))
+	require.Len(t, viewAges, 1)
+	require.GreaterOrEqual(t, viewAges[0], time.Duration(0))
+	require.Less(t, viewAges[0], time.Minute)
+	require.Equal(t, []string{"Org2MSP"}, relay.NetworkQueries()[0].Policy)
+	require.Equal(t, requestor.CertificatePEM, relay.NetworkQueries()[0].Certificate)
+	require.Equal(t, "simplestate", contract.submittedArgs[0])
+	require.Equal(t, `["`+address+`"]`, contract.submittedArgs[5])
+	var viewsSerializedBase64 []string
+	require.NoError(t, json.Unmarshal([]byte(contract.submittedArgs[6]), &viewsSerializedBase64))
+	viewBytes, err := base64.StdEncoding.DecodeString(viewsSerializedBase64[0])
+	require.NoError(t, err)
+	storedViewBytes, err := store.Get(interoperablehelper.GetViewHash(viewBytes))
+	require.NoError(t, err)
+	require.Equal(t, viewBytes, storedViewBytes)
+
+	// a view endorsed by an unknown organization fails verification
+	otherCA, err := relaytest.NewCA("Org3MSP")
+	require.NoError(t, err)
+	otherPeer, err := otherCA.NewIdentity("peer0.org3.network3.com")
+	require.NoError(t, err)
+	otherView, err := relaytest.NewFabricView(address, []byte("Arcturus"), otherPeer)
+	require.NoError(t, err)
+	relay.Respond(address, relaytest.PendingThenView(0, otherView))
+	_, _, err = interoperablehelper.InteropFlow(contract, "network1", invokeObject, "Org1MSP", relay.Address,
+		[]int{1}, interopJSONs, requestor, requestor.CertificatePEM, false, false)
+	require.ErrorContains(t, err, "member does not exist for org: Org3MSP")
+
+	// errors from the remote network are surfaced
+	relay.Respond(address, relaytest.ErrorResponse("access denied"))
+	_, _, err = interoperablehelper.InteropFlow(contract, "network1", invokeObject, "Org1MSP", relay.Address,
+		[]int{1}, interopJSONs, requestor, requestor.CertificatePEM, false, false)
+	require.ErrorContains(t, err, "access denied")
+
+	// malformed views are rejected before the local invocation
+	relay.Respond(address, relaytest.MalformedViewResponse())
+	contract.submittedArgs = nil
+	_, _, err = interoperablehelper.InteropFlow(contract, "network1", invokeObject, "Org1MSP", relay.Address,
+		[]int{1}, interopJSONs, requestor, requestor.CertificatePEM, false, false)
+	require.ErrorContains(t, err, "view verification failed")
+	require.Nil(t, contract.submittedArgs)
+}
+
+func TestVerifyViews(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	addresses := []string{"localhost:9083/network2/mychannel:simplestate:Read:a", "localhost:9083/network2/mychannel:simplestate:Read:b"}
+	viewA, err := relaytest.NewFabricView(addresses[0], []byte("Arcturus"), peer0)
+	require.NoError(t, err)
+	viewB, err := relaytest.NewFabricView(addresses[1], []byte("Betelgeuse"), peer0)
+	require.NoError(t, err)
+	contract := &gatewayContractMock{
+		viewReports: `[{"address":"` + addresses[0] + `","signers":[{"org":"Org2MSP","id":"peer0.org2.network2.com"}],"policySatisfied":true,"verified":true,"dataHash":"abcd"},` +
+			`{"address":"` + addresses[1] + `","signers":[{"org":"Org2MSP","id":"peer0.org2.network2.com"}],"policySatisfied":false,"verified":false,"reason":"VerifyView error"}]`,
+	}
+
+	reports, err := interoperablehelper.VerifyViews(contract, addresses, []*common.View{viewA, viewB}, nil, "reconciliation", nil)
+	require.NoError(t, err)
+	require.Equal(t, []interoperablehelper.ViewReport{
+		{Address: addresses[0], Signers: []interoperablehelper.ViewSigner{{Org: "Org2MSP", Id: "peer0.org2.network2.com"}}, PolicySatisfied: true, Verified: true, DataHash: "abcd"},
+		{Address: addresses[1], Signers: []interoperablehelper.ViewSigner{{Org: "Org2MSP", Id: "peer0.org2.network2.com"}}, Reason: "VerifyView error"},
+	}, reports)
+
+	// the views are passed serialized, with empty contents for unencrypted views
+	require.Len(t, contract.submittedArgs, 4)
+	require.Equal(t, `["`+addresses[0]+`","`+addresses[1]+`"]`, contract.submittedArgs[0])
+	var viewsSerializedBase64 []string
+	require.NoError(t, json.Unmarshal([]byte(contract.submittedArgs[1]), &viewsSerializedBase64))
+	viewBytes, err := base64.StdEncoding.DecodeString(viewsSerializedBase64[1])
+	require.NoError(t, err)
+	view := &common.View{}
+	require.NoError(t, protoV2.Unmarshal(viewBytes, view))
+	require.True(t, protoV2.Equal(viewB, view))
+	require.Equal(t, `[[],[]]`, contract.submittedArgs[2])
+	require.Equal(t, "reconciliation", contract.submittedArgs[3])
+}
+
+func TestViewAudit(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	address := "localhost:9083/network2/mychannel:simplestate:Read:a"
+	view, err := relaytest.NewFabricView(address, []byte("Arcturus"), peer0)
+	require.NoError(t, err)
+
+	// views are stored exactly as submitted to the interop chaincode, under the hash of those bytes
+	store := &interoperablehelper.DirectoryViewStore{Dir: filepath.Join(t.TempDir(), "views")}
+	contract := &gatewayContractMock{viewReports: "[]"}
+	_, err = interoperablehelper.VerifyViews(contract, []string{address}, []*common.View{view}, nil, "", store)
+	require.NoError(t, err)
+	var viewsSerializedBase64 []string
+	require.NoError(t, json.Unmarshal([]byte(contract.submittedArgs[1]), &viewsSerializedBase64))
+	viewBytes, err := base64.StdEncoding.DecodeString(viewsSerializedBase64[0])
+	require.NoError(t, err)
+	submittedHash := sha256.Sum256(viewBytes)
+	viewHash := hex.EncodeToString(submittedHash[:])
+	require.Equal(t, viewHash, interoperablehelper.GetViewHash(viewBytes))
+	storedViewBytes, err := store.Get(viewHash)
+	require.NoError(t, err)
+	require.Equal(t, viewBytes, storedViewBytes)
+
+	// views can also be stored by the application, given the bytes it submitted
+	otherStore := &interoperablehelper.DirectoryViewStore{Dir: filepath.Join(t.TempDir(), "other-views")}
+	storedHash, err := interoperablehelper.StoreView(otherStore, viewBytes)
+	require.NoError(t, err)
+	require.Equal(t, viewHash, storedHash)
+
+	// the proof of an audited view is fetched by the hash in its record
+	contract.viewAuditRecords = `[{"txId":"tx1","timestamp":1760000000,"address":"` + address + `","network":"network2","viewHash":"` + viewHash +
+		`","dataHash":"abcd","signers":[{"org":"Org2MSP","id":"peer0.org2.network2.com"}],"policyVersion":"ef01"}]`
+	records, err := interoperablehelper.GetViewAuditRecordsByAddress(contract, address)
+	require.NoError(t, err)
+	require.Equal(t, []interoperablehelper.ViewAuditRecord{{
+		TxId:          "tx1",
+		Timestamp:     1760000000,
+		Address:       address,
+		Network:       "network2",
+		ViewHash:      viewHash,
+		DataHash:      "abcd",
+		Signers:       []interoperablehelper.ViewSigner{{Org: "Org2MSP", Id: "peer0.org2.network2.com"}},
+		PolicyVersion: "ef01",
+	}}, records)
+	fetchedView, err := interoperablehelper.FetchViewProof(store, records[0].ViewHash)
+	require.NoError(t, err)
+	require.True(t, protoV2.Equal(view, fetchedView))
+
+	// tampered or missing views are rejected
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir, viewHash), []byte("tampered"), 0600))
+	_, err = interoperablehelper.FetchViewProof(store, viewHash)
+	require.ErrorContains(t, err, "stored view does not match hash")
+	_, err = interoperablehelper.FetchViewProof(store, "0000")
+	require.ErrorContains(t, err, "failed to fetch view 0000")
+}
+
+func TestGetViewTimestamp(t *testing.T) {
+	newView := func(timestamps ...int64) *common.View {
+		var viewData corda.ViewData
+		for _, timestamp := range timestamps {
+			payload, err := protoV2.Marshal(&common.InteropPayload{Payload: []byte("Arcturus"), Timestamp: timestamp})
+			require.NoError(t, err)
+			viewData.NotarizedPayloads = append(viewData.NotarizedPayloads, &corda.ViewData_NotarizedPayload{Payload: payload})
+		}
+		data, err := protoV2.Marshal(&viewData)
+		require.NoError(t, err)
+		return &common.View{Meta: &common.Meta{Protocol: common.Meta_CORDA}, Data: data}
+	}
+
+	// the oldest payload dates the view
+	timestamp, err := interoperablehelper.GetViewTimestamp(newView(1760000300, 1760000000))
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1760000000, 0), timestamp)
+
+	_, err = interoperablehelper.GetViewTimestamp(newView(1760000000, 0))
+	require.EqualError(t, err, "interop payload 1 does not carry a timestamp")
+
+	_, err = interoperablehelper.GetViewTimestamp(newView())
+	require.EqualError(t, err, "view does not carry any interop payloads")
+
+	_, err = interoperablehelper.GetViewTimestamp(&common.View{Meta: &common.Meta{Protocol: common.Meta_BITCOIN}})
+	require.EqualError(t, err, "cannot get timestamp of view; unsupported DLT type: BITCOIN")
+}
+
+// rlpEncode encodes a byte string, or a list of already encoded items if isList is set
+func rlpEncode(isList bool, items ...[]byte) []byte {
+	content := bytes.Join(items, nil)
+	if !isList && len(content) == 1 && content[0] < 0x80 {
+		return content
+	}
+	offset := byte(0x80)
+	if isList {
+		offset = 0xc0
+	}
+	if len(content) < 56 {
+		return append([]byte{offset + byte(len(content))}, content...)
+	}
+	size := new(big.Int).SetInt64(int64(len(content))).Bytes()
+	return append(append([]byte{offset + 55 + byte(len(size))}, size...), content...)
+}
+
+func TestGetBesuViewTimestamp(t *testing.T) {
+	newView := func(timestamp []byte, fields int) *common.View {
+		// the fields of a block header, up to the nonce, with the timestamp at position 11
+		sizes := []int{32, 32, 20, 32, 32, 32, 256, 1, 2, 4, 0, -1, 97, 32, 8}
+		var header [][]byte
+		for i, size := range sizes[:fields] {
+			if size < 0 {
+				header = append(header, rlpEncode(false, timestamp))
+			} else {
+				header = append(header, rlpEncode(false, bytes.Repeat([]byte{byte(i + 1)}, size)))
+			}
+		}
+		data, err := protoV2.Marshal(&besu.BesuView{BlockHeaderRlp: rlpEncode(true, header...)})
+		require.NoError(t, err)
+		return &common.View{Meta: &common.Meta{Protocol: common.Meta_ETHEREUM}, Data: data}
+	}
+
+	// the block header dates the view
+	timestamp, err := interoperablehelper.GetViewTimestamp(newView([]byte{0x68, 0xe8, 0xe6, 0x00}, 15))
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0x68e8e600, 0), timestamp)
+
+	_, err = interoperablehelper.GetViewTimestamp(newView([]byte{0x68, 0xe8, 0xe6, 0x00}, 11))
+	require.ErrorContains(t, err, "unable to get timestamp of besu block: block header has 11 fields, expected at least 15")
+
+	_, err = interoperablehelper.GetViewTimestamp(newView([]byte{0x00, 0x68, 0xe8, 0xe6, 0x00}, 15))
+	require.ErrorContains(t, err, "unable to get timestamp of besu block: invalid block timestamp")
 }
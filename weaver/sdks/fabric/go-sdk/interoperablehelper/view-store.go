@@ -0,0 +1,83 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interoperablehelper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// ViewStore keeps serialized view protos off-chain, addressed by the SHA-256 hash (in hex) that the
+// interop chaincode records in its audit log for every view it accepts
+type ViewStore interface {
+	Put(viewHash string, viewBytes []byte) error
+	Get(viewHash string) ([]byte, error)
+}
+
+// DirectoryViewStore is a ViewStore keeping each view in a file of a directory, named by its hash
+type DirectoryViewStore struct {
+	Dir string
+}
+
+func (store *DirectoryViewStore) Put(viewHash string, viewBytes []byte) error {
+	err := os.MkdirAll(store.Dir, 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(store.Dir, viewHash), viewBytes, 0600)
+}
+
+func (store *DirectoryViewStore) Get(viewHash string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(store.Dir, viewHash))
+}
+
+// GetViewHash returns the hash under which the interop chaincode audits a view, given the view as serialized
+// in the transaction that submitted it
+func GetViewHash(viewBytes []byte) string {
+	viewHash := sha256.Sum256(viewBytes)
+	return hex.EncodeToString(viewHash[:])
+}
+
+/**
+ * Store the proof of a view off-chain and return its hash. The view must be given exactly as serialized in the
+ * transaction that submitted it, as the audit log records the hash of those bytes; InteropFlow and VerifyViews
+ * store the views they submit when given a ViewStore.
+ * Views must be stored to reconstruct the remote state that local transactions relied upon from the audit log.
+ **/
+func StoreView(store ViewStore, viewBytes []byte) (string, error) {
+	viewHash := GetViewHash(viewBytes)
+	err := store.Put(viewHash, viewBytes)
+	if err != nil {
+		return "", logThenErrorf("failed to store view %s: %s", viewHash, err.Error())
+	}
+	return viewHash, nil
+}
+
+/**
+ * Fetch the proof of a view from off-chain storage by the hash recorded in the audit log of the interop chaincode,
+ * checking that the stored view matches the hash.
+ **/
+func FetchViewProof(store ViewStore, viewHash string) (*common.View, error) {
+	viewBytes, err := store.Get(viewHash)
+	if err != nil {
+		return nil, logThenErrorf("failed to fetch view %s: %s", viewHash, err.Error())
+	}
+	if GetViewHash(viewBytes) != viewHash {
+		return nil, logThenErrorf("stored view does not match hash %s", viewHash)
+	}
+	view := &common.View{}
+	err = protoV2.Unmarshal(viewBytes, view)
+	if err != nil {
+		return nil, logThenErrorf("failed to Unmarshal view %s: %s", viewHash, err.Error())
+	}
+	return view, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package membershipmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Keychain is a store of wallet identities, e.g. a keychain of the keychain Go module of cactus-core-api
+type Keychain interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// KeychainWalletPrefix marks the wallet paths naming a keychain registered with RegisterKeychain
+const KeychainWalletPrefix = "keychain:"
+
+var (
+	keychains     = map[string]Keychain{}
+	keychainsLock sync.RWMutex
+)
+
+// RegisterKeychain makes a keychain usable as the wallet "keychain:<name>" by every function taking a wallet path.
+// The identities are the entries of the keychain under the user names, in the JSON format of the identity files of
+// file system wallets.
+func RegisterKeychain(name string, keychain Keychain) {
+	keychainsLock.Lock()
+	defer keychainsLock.Unlock()
+	if keychain == nil {
+		delete(keychains, name)
+	} else {
+		keychains[name] = keychain
+	}
+}
+
+// GetIdentityFromWallet returns the MSP ID, certificate and private key of a user of a wallet
+func GetIdentityFromWallet(walletPath, userName string) (string, string, string, error) {
+	return getInfoFromWallet(walletPath, userName)
+}
+
+func readKeychainIdentity(name, userName string) ([]byte, error) {
+	keychainsLock.RLock()
+	keychain, ok := keychains[name]
+	keychainsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("No keychain registered as '%s'", name)
+	}
+	identity, err := keychain.Get(context.Background(), userName)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read identity '%s' from keychain '%s': %s", userName, name, err.Error())
+	}
+	return []byte(identity), nil
+}
@@ -462,7 +462,13 @@ func newSign(privateKeyPEM string) (cidentity.Sign, error) {
 }
 
 func getInfoFromWallet(walletPath, userName string) (string, string, string, error) {
-	walletUserId, err := os.ReadFile(walletPath + "/" + userName + ".id")
+	var walletUserId []byte
+	var err error
+	if strings.HasPrefix(walletPath, KeychainWalletPrefix) {
+		walletUserId, err = readKeychainIdentity(strings.TrimPrefix(walletPath, KeychainWalletPrefix), userName)
+	} else {
+		walletUserId, err = os.ReadFile(walletPath + "/" + userName + ".id")
+	}
 	if err != nil {
 		return "", "", "", err
 	}
@@ -7,8 +7,10 @@
 package membershipmanager_test
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +60,35 @@ func TestMembershipManager(t *testing.T) {
 	err = mmsdk.DeleteLocalMembership(walletPath, userName, connectionProfilePath, "mychannel", "interop", []string{"Org1MSP"})
 	require.NoError(t, err)
 }
+
+type mapKeychain map[string]string
+
+func (k mapKeychain) Get(ctx context.Context, key string) (string, error) {
+	value, ok := k[key]
+	if !ok {
+		return "", fmt.Errorf("keychain entry not found")
+	}
+	return value, nil
+}
+
+func TestKeychainWallet(t *testing.T) {
+	identity := `{"credentials":{"certificate":"cert","privateKey":"key"},"mspId":"Org1MSP","type":"X.509","version":1}`
+
+	walletPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(walletPath, "user1.id"), []byte(identity), 0600)
+	require.NoError(t, err)
+	mspId, cert, key, err := mmsdk.GetIdentityFromWallet(walletPath, "user1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Org1MSP", "cert", "key"}, []string{mspId, cert, key})
+
+	mmsdk.RegisterKeychain("network1", mapKeychain{"user1": identity})
+	defer mmsdk.RegisterKeychain("network1", nil)
+	mspId, cert, key, err = mmsdk.GetIdentityFromWallet(mmsdk.KeychainWalletPrefix+"network1", "user1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Org1MSP", "cert", "key"}, []string{mspId, cert, key})
+
+	_, _, _, err = mmsdk.GetIdentityFromWallet("keychain:network1", "user2")
+	require.Error(t, err)
+	_, _, _, err = mmsdk.GetIdentityFromWallet("keychain:network2", "user1")
+	require.Error(t, err)
+}
@@ -25,6 +25,9 @@ func logThenErrorf(format string, args ...interface{}) error {
 	return errors.New(errorMsg)
 }
 
+// interval between two consecutive polls of the local relay for the state of an event subscription
+const eventStatePollInterval = time.Second
+
 type Relay struct {
 	endPoint    string
 	timeoutSecs uint64
@@ -146,3 +149,226 @@ func (r *Relay) getRequest(requestId string) (*common.RequestState, error) {
 
 	return requestState, nil
 }
+
+/**
+ * sendEventSubscribeRequest sends an event subscription request to a remote network using gRPC and the relay.
+ * @returns {string} The ID of the subscription request
+ */
+func (r *Relay) sendEventSubscribeRequest(eventMatcher *common.EventMatcher, eventPublicationSpec *common.EventPublication,
+	address string, policy []string, requestingNetwork string, certificate string, signature string, nonce string, org string,
+	confidential bool) (string, error) {
+
+	// set up a connection to the server
+	conn, err := grpc.Dial(r.endPoint, grpc.WithInsecure())
+	if err != nil {
+		return "", logThenErrorf("grpc Dial() failed to connect in sendEventSubscribeRequest: %v", err)
+	}
+	defer conn.Close()
+
+	networkClient := networks.NewNetworkClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	eventSubscription := &networks.NetworkEventSubscription{
+		EventMatcher:         eventMatcher,
+		Query:                createNetworkQuery(address, policy, requestingNetwork, certificate, signature, nonce, org, confidential),
+		EventPublicationSpec: eventPublicationSpec,
+	}
+	resp, err := networkClient.SubscribeEvent(ctx, eventSubscription)
+	if err != nil {
+		return "", logThenErrorf("error in grpc SubscribeEvent(): %v", err)
+	}
+	if resp.GetStatus() == common.Ack_ERROR {
+		return "", logThenErrorf("event subscription request received negative Ack error: %s", resp.GetMessage())
+	}
+
+	return resp.RequestId, nil
+}
+
+/**
+ * sendEventUnsubscribeRequest sends a request to a remote network using gRPC and the relay to cancel an event subscription.
+ * @returns {string} The ID of the unsubscription request
+ */
+func (r *Relay) sendEventUnsubscribeRequest(eventMatcher *common.EventMatcher, eventPublicationSpec *common.EventPublication,
+	requestId string, address string, policy []string, requestingNetwork string, certificate string, signature string,
+	nonce string, org string, confidential bool) (string, error) {
+
+	// set up a connection to the server
+	conn, err := grpc.Dial(r.endPoint, grpc.WithInsecure())
+	if err != nil {
+		return "", logThenErrorf("grpc Dial() failed to connect in sendEventUnsubscribeRequest: %v", err)
+	}
+	defer conn.Close()
+
+	networkClient := networks.NewNetworkClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	eventUnsubscription := &networks.NetworkEventUnsubscription{
+		Request: &networks.NetworkEventSubscription{
+			EventMatcher:         eventMatcher,
+			Query:                createNetworkQuery(address, policy, requestingNetwork, certificate, signature, nonce, org, confidential),
+			EventPublicationSpec: eventPublicationSpec,
+		},
+		RequestId: requestId,
+	}
+	resp, err := networkClient.UnsubscribeEvent(ctx, eventUnsubscription)
+	if err != nil {
+		return "", logThenErrorf("error in grpc UnsubscribeEvent(): %v", err)
+	}
+	if resp.GetStatus() == common.Ack_ERROR {
+		return "", logThenErrorf("event unsubscription request received negative Ack error: %s", resp.GetMessage())
+	}
+
+	return resp.RequestId, nil
+}
+
+/**
+ * ProcessSubscribeEventRequest sends an event subscription request to a remote network using gRPC and the relay
+ * and polls the local relay until the subscription is no longer pending.
+ * Uses the timeout provided by the class.
+ * @returns {EventSubscriptionState} The final subscription state returned by the relay
+ */
+func (r *Relay) ProcessSubscribeEventRequest(eventMatcher *common.EventMatcher, eventPublicationSpec *common.EventPublication,
+	address string, policy []string, requestingNetwork string, certificate string, signature string, nonce string, org string,
+	confidential bool) (*common.EventSubscriptionState, error) {
+
+	requestId, err := r.sendEventSubscribeRequest(eventMatcher, eventPublicationSpec, address, policy, requestingNetwork,
+		certificate, signature, nonce, org, confidential)
+	if err != nil {
+		return nil, logThenErrorf("sendEventSubscribeRequest() error: %s", err.Error())
+	}
+	// Adds timout time to current time.
+	currentTimeSecs := uint64(time.Now().Unix())
+	endTime := currentTimeSecs + r.timeoutSecs
+	finalState, err := r.recursiveEventSubscriptionState(requestId, endTime)
+	if err != nil {
+		return nil, logThenErrorf("event subscription error: %s", err.Error())
+	}
+	if finalState.GetStatus() == common.EventSubscriptionState_ERROR {
+		return nil, logThenErrorf("error during event subscription: %s", finalState.GetMessage())
+	}
+	return finalState, nil
+}
+
+/**
+ * ProcessUnsubscribeEventRequest sends an event unsubscription request to a remote network using gRPC and the relay
+ * and polls the local relay until the unsubscription is no longer pending.
+ * Uses the timeout provided by the class.
+ * @returns {EventSubscriptionState} The final subscription state returned by the relay
+ */
+func (r *Relay) ProcessUnsubscribeEventRequest(eventMatcher *common.EventMatcher, eventPublicationSpec *common.EventPublication,
+	requestId string, address string, policy []string, requestingNetwork string, certificate string, signature string,
+	nonce string, org string, confidential bool) (*common.EventSubscriptionState, error) {
+
+	unsubscribeRequestId, err := r.sendEventUnsubscribeRequest(eventMatcher, eventPublicationSpec, requestId, address, policy,
+		requestingNetwork, certificate, signature, nonce, org, confidential)
+	if err != nil {
+		return nil, logThenErrorf("sendEventUnsubscribeRequest() error: %s", err.Error())
+	}
+	// Adds timout time to current time.
+	currentTimeSecs := uint64(time.Now().Unix())
+	endTime := currentTimeSecs + r.timeoutSecs
+	finalState, err := r.recursiveEventSubscriptionState(unsubscribeRequestId, endTime)
+	if err != nil {
+		return nil, logThenErrorf("event unsubscription error: %s", err.Error())
+	}
+	if finalState.GetStatus() == common.EventSubscriptionState_ERROR {
+		return nil, logThenErrorf("error during event unsubscription: %s", finalState.GetMessage())
+	}
+	return finalState, nil
+}
+
+func (r *Relay) recursiveEventSubscriptionState(requestID string, endTime uint64) (*common.EventSubscriptionState, error) {
+	state, err := r.GetEventSubscriptionState(requestID)
+	if err != nil {
+		return nil, logThenErrorf("GetEventSubscriptionState() error: %s", err.Error())
+	}
+	switch state.GetStatus() {
+	case common.EventSubscriptionState_SUBSCRIBE_PENDING_ACK, common.EventSubscriptionState_SUBSCRIBE_PENDING,
+		common.EventSubscriptionState_UNSUBSCRIBE_PENDING_ACK, common.EventSubscriptionState_UNSUBSCRIBE_PENDING:
+		// return error if the waiting time is elapsed
+		currentTimeSecs := uint64(time.Now().Unix())
+		if endTime <= currentTimeSecs {
+			return nil, logThenErrorf("timeout: event subscription state is still pending")
+		}
+		time.Sleep(eventStatePollInterval)
+		return r.recursiveEventSubscriptionState(requestID, endTime)
+	default:
+		return state, nil
+	}
+}
+
+/**
+ * GetEventSubscriptionState is used to get the state of an event subscription from the local relay
+ * @returns {EventSubscriptionState} The subscription state recorded by the relay
+ */
+func (r *Relay) GetEventSubscriptionState(requestId string) (*common.EventSubscriptionState, error) {
+
+	// set up a connection to the server
+	conn, err := grpc.Dial(r.endPoint, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, logThenErrorf("grpc Dial() failed to connect in GetEventSubscriptionState: %v", err)
+	}
+	defer conn.Close()
+
+	networkClient := networks.NewNetworkClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	getStateMessage := &networks.GetStateMessage{
+		RequestId: requestId,
+	}
+	subscriptionState, err := networkClient.GetEventSubscriptionState(ctx, getStateMessage)
+	if err != nil {
+		return nil, logThenErrorf("error in grpc GetEventSubscriptionState(): %s", err.Error())
+	}
+	log.Debugf("subscriptionState: %v", subscriptionState)
+
+	return subscriptionState, nil
+}
+
+/**
+ * GetEventStates is used to fetch the events received by the local relay for a subscription.
+ * Note that the relay marks the events as deleted as soon as they are fetched.
+ * @returns {EventStates} The list of event states received for the subscription
+ */
+func (r *Relay) GetEventStates(requestId string) (*common.EventStates, error) {
+
+	// set up a connection to the server
+	conn, err := grpc.Dial(r.endPoint, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, logThenErrorf("grpc Dial() failed to connect in GetEventStates: %v", err)
+	}
+	defer conn.Close()
+
+	networkClient := networks.NewNetworkClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	getStateMessage := &networks.GetStateMessage{
+		RequestId: requestId,
+	}
+	eventStates, err := networkClient.GetEventStates(ctx, getStateMessage)
+	if err != nil {
+		return nil, logThenErrorf("error in grpc GetEventStates(): %s", err.Error())
+	}
+	log.Debugf("eventStates: %v", eventStates)
+
+	return eventStates, nil
+}
+
+func createNetworkQuery(address string, policy []string, requestingNetwork string, certificate string, signature string,
+	nonce string, org string, confidential bool) *networks.NetworkQuery {
+	return &networks.NetworkQuery{
+		Policy:             policy,
+		Address:            address,
+		RequestingRelay:    "",
+		RequestingNetwork:  requestingNetwork,
+		Certificate:        certificate,
+		RequestorSignature: signature,
+		Nonce:              nonce,
+		RequestingOrg:      org,
+		Confidential:       confidential,
+	}
+}
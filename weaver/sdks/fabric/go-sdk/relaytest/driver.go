@@ -0,0 +1,103 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package relaytest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Driver serves the DriverCommunication service, answering the queries of its relay with scripted responses
+type Driver struct {
+	driver.UnimplementedDriverCommunicationServer
+	// Endpoint the driver listens on
+	Address       string
+	relayEndpoint string
+	server        *grpc.Server
+	script        script
+	mutex         sync.Mutex
+	queries       []*common.Query
+}
+
+// StartDriver starts a driver listening on a free local port, which sends its responses to the relay at relayEndpoint
+func StartDriver(relayEndpoint string) (*Driver, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, err
+	}
+	d := &Driver{
+		Address:       listener.Addr().String(),
+		relayEndpoint: relayEndpoint,
+		server:        grpc.NewServer(),
+	}
+	driver.RegisterDriverCommunicationServer(d.server, d)
+	go d.server.Serve(listener)
+	return d, nil
+}
+
+func (d *Driver) Stop() {
+	d.server.Stop()
+}
+
+// Respond scripts the response to the queries for an address
+func (d *Driver) Respond(address string, response Response) {
+	d.script.respond(address, response)
+}
+
+// RespondToAll scripts the response to the queries for addresses that have no response of their own
+func (d *Driver) RespondToAll(response Response) {
+	d.script.respondToAll(response)
+}
+
+// Queries returns the queries the driver received
+func (d *Driver) Queries() []*common.Query {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]*common.Query{}, d.queries...)
+}
+
+func (d *Driver) sendDriverState(viewPayload *common.ViewPayload) {
+	conn, err := dial(d.relayEndpoint)
+	if err != nil {
+		log.Errorf("failed to connect to %s: %v", d.relayEndpoint, err)
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	_, err = relay.NewDataTransferClient(conn).SendDriverState(ctx, viewPayload)
+	if err != nil {
+		log.Errorf("failed to send state of request %s to %s: %v", viewPayload.RequestId, d.relayEndpoint, err)
+	}
+}
+
+// RequestDriverState acknowledges a query and sends its scripted response to the relay
+func (d *Driver) RequestDriverState(ctx context.Context, query *common.Query) (*common.Ack, error) {
+	d.mutex.Lock()
+	d.queries = append(d.queries, query)
+	d.mutex.Unlock()
+	response, scripted := d.script.responseFor(query.Address)
+	if !scripted {
+		return &common.Ack{
+			Status:    common.Ack_ERROR,
+			RequestId: query.RequestId,
+			Message:   fmt.Sprintf("no response scripted for address %s", query.Address),
+		}, nil
+	}
+	if !response.Timeout {
+		go d.sendDriverState(response.viewPayload(query.RequestId))
+	}
+	return &common.Ack{Status: common.Ack_OK, RequestId: query.RequestId}, nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package relaytest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// CA is a throwaway certificate authority for an organization
+type CA struct {
+	MspId          string
+	Certificate    *x509.Certificate
+	CertificatePEM string
+	privateKey     *ecdsa.PrivateKey
+	serial         int64
+}
+
+// Identity is a throwaway identity issued by a CA, which can endorse views and sign requests
+type Identity struct {
+	MspId          string
+	Certificate    *x509.Certificate
+	CertificatePEM string
+	PrivateKey     *ecdsa.PrivateKey
+}
+
+func newCertificate(template, parent *x509.Certificate, publicKey *ecdsa.PublicKey, signingKey *ecdsa.PrivateKey) (*x509.Certificate, string, error) {
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey, signingKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	return cert, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})), nil
+}
+
+// NewCA creates a self-signed ECDSA P-256 CA for an organization, valid for a day
+func NewCA(mspId string) (*CA, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca." + mspId, Organization: []string{mspId}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	cert, certPEM, err := newCertificate(template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{MspId: mspId, Certificate: cert, CertificatePEM: certPEM, privateKey: privateKey, serial: 1}, nil
+}
+
+// NewIdentity issues an ECDSA P-256 identity with the given common name
+func (ca *CA) NewIdentity(commonName string) (*Identity, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate identity key: %v", err)
+	}
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{ca.MspId}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	cert, certPEM, err := newCertificate(template, ca.Certificate, &privateKey.PublicKey, ca.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{MspId: ca.MspId, Certificate: cert, CertificatePEM: certPEM, PrivateKey: privateKey}, nil
+}
+
+// Sign signs the SHA-256 hash of a message, with an ASN.1 encoded signature as Fabric does
+func (id *Identity) Sign(msg []byte) ([]byte, error) {
+	hash := sha256.Sum256(msg)
+	return ecdsa.SignASN1(rand.Reader, id.PrivateKey, hash[:])
+}
+
+// SerializedIdentity returns the identity as it appears in the endorsements of a Fabric network
+func (id *Identity) SerializedIdentity() ([]byte, error) {
+	return proto.Marshal(&msp.SerializedIdentity{Mspid: id.MspId, IdBytes: []byte(id.CertificatePEM)})
+}
+
+/**
+ * NewFabricView creates a view of the interop payload for an address, as the Fabric driver generates it from the
+ * responses of the endorsers to HandleExternalRequest. Each endorser signs the proposal response payload.
+ **/
+func NewFabricView(address string, payload []byte, endorsers ...*Identity) (*common.View, error) {
+	interopPayloadBytes, err := protoV2.Marshal(&common.InteropPayload{Address: address, Payload: payload, Timestamp: time.Now().Unix()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal interop payload: %v", err)
+	}
+	ccActionBytes, err := proto.Marshal(&peer.ChaincodeAction{
+		Response:    &peer.Response{Status: 200, Payload: interopPayloadBytes},
+		ChaincodeId: &peer.ChaincodeID{Name: "interop"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chaincode action: %v", err)
+	}
+	proposalHash := sha256.Sum256([]byte(address))
+	proposalResponsePayload := &peer.ProposalResponsePayload{ProposalHash: proposalHash[:], Extension: ccActionBytes}
+	proposalResponsePayloadBytes, err := proto.Marshal(proposalResponsePayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proposal response payload: %v", err)
+	}
+
+	fabricView := &fabric.FabricView{}
+	for _, endorser := range endorsers {
+		endorserBytes, err := endorser.SerializedIdentity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal endorser identity: %v", err)
+		}
+		signature, err := endorser.Sign(append(append([]byte{}, proposalResponsePayloadBytes...), endorserBytes...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign proposal response payload: %v", err)
+		}
+		fabricView.EndorsedProposalResponses = append(fabricView.EndorsedProposalResponses, &fabric.FabricView_EndorsedProposalResponse{
+			Payload:     proposalResponsePayload,
+			Endorsement: &peer.Endorsement{Endorser: endorserBytes, Signature: signature},
+		})
+	}
+	viewData, err := protoV2.Marshal(fabricView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fabric view: %v", err)
+	}
+	return &common.View{
+		Meta: &common.Meta{
+			Protocol:            common.Meta_FABRIC,
+			Timestamp:           time.Now().UTC().Format(time.RFC3339),
+			ProofType:           "Notarization",
+			SerializationFormat: "STRING",
+		},
+		Data: viewData,
+	}, nil
+}
+
+// NewMembership creates the membership of a security domain whose members are the organizations of the given CAs
+func NewMembership(securityDomain string, cas ...*CA) *common.Membership {
+	membership := &common.Membership{SecurityDomain: securityDomain, Members: map[string]*common.Member{}}
+	for _, ca := range cas {
+		membership.Members[ca.MspId] = &common.Member{Value: ca.CertificatePEM, Type: "ca"}
+	}
+	return membership
+}
+
+/**
+ * VerifyFabricView checks a Fabric view as the interop chaincode does before accepting it: every endorsement must
+ * be for the given address, signed by its endorser, and the endorser must belong to one of the organizations of the
+ * membership. Mocks of the interop chaincode can use it to answer VerifyView.
+ **/
+func VerifyFabricView(view *common.View, address string, membership *common.Membership) error {
+	fabricView := &fabric.FabricView{}
+	err := protoV2.Unmarshal(view.GetData(), fabricView)
+	if err != nil {
+		return fmt.Errorf("unable to decode fabric view data: %v", err)
+	}
+	if len(fabricView.EndorsedProposalResponses) == 0 {
+		return fmt.Errorf("fabric view has no endorsements")
+	}
+	for _, response := range fabricView.EndorsedProposalResponses {
+		ccAction := &peer.ChaincodeAction{}
+		err = proto.Unmarshal(response.GetPayload().GetExtension(), ccAction)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal chaincode action: %v", err)
+		}
+		interopPayload := &common.InteropPayload{}
+		err = protoV2.Unmarshal(ccAction.GetResponse().GetPayload(), interopPayload)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal interop payload: %v", err)
+		}
+		if interopPayload.Address != address {
+			return fmt.Errorf("address in response does not match original address: original: %s response: %s", address, interopPayload.Address)
+		}
+
+		endorser := &msp.SerializedIdentity{}
+		err = proto.Unmarshal(response.GetEndorsement().GetEndorser(), endorser)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal endorser identity: %v", err)
+		}
+		certBlock, _ := pem.Decode(endorser.IdBytes)
+		if certBlock == nil {
+			return fmt.Errorf("endorser certificate not in PEM format")
+		}
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse endorser certificate: %v", err)
+		}
+		member, exists := membership.GetMembers()[endorser.Mspid]
+		if !exists {
+			return fmt.Errorf("member does not exist for org: %s", endorser.Mspid)
+		}
+		caBlock, _ := pem.Decode([]byte(member.Value))
+		if caBlock == nil {
+			return fmt.Errorf("CA certificate of org %s not in PEM format", endorser.Mspid)
+		}
+		caCert, err := x509.ParseCertificate(caBlock.Bytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse CA certificate of org %s: %v", endorser.Mspid, err)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(caCert)
+		_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+		if err != nil {
+			return fmt.Errorf("endorser is not a member of org %s: %v", endorser.Mspid, err)
+		}
+
+		payloadBytes, err := proto.Marshal(response.Payload)
+		if err != nil {
+			return fmt.Errorf("unable to marshal proposal response payload: %v", err)
+		}
+		publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("endorser key of org %s is not an ECDSA key", endorser.Mspid)
+		}
+		hash := sha256.Sum256(append(payloadBytes, response.Endorsement.Endorser...))
+		if !ecdsa.VerifyASN1(publicKey, hash[:], response.Endorsement.Signature) {
+			return fmt.Errorf("invalid signature of endorser from org %s", endorser.Mspid)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// relaytest provides in-process relays and drivers with scripted responses, and signed view fixtures,
+// so that the SDK's request and verification flows can be tested without deploying networks.
+package relaytest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/networks"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// timeout of the calls a relay or driver makes to other relays and drivers
+const callTimeout = 10 * time.Second
+
+// Response scripts how a relay or driver answers the queries for an address
+type Response struct {
+	// Number of times a client polling the relay is told the request is still pending before getting the outcome
+	PendingPolls int
+	// View sent in response; ignored if Error is set
+	View *common.View
+	// Error sent in response
+	Error string
+	// If set, the request stays pending forever
+	Timeout bool
+}
+
+// PendingThenView scripts a response that stays pending for a number of polls and then completes with a view
+func PendingThenView(pendingPolls int, view *common.View) Response {
+	return Response{PendingPolls: pendingPolls, View: view}
+}
+
+// ErrorResponse scripts a response with an error
+func ErrorResponse(message string) Response {
+	return Response{Error: message}
+}
+
+// TimeoutResponse scripts a response that never comes
+func TimeoutResponse() Response {
+	return Response{Timeout: true}
+}
+
+// MalformedViewResponse scripts a response with a Fabric view whose data cannot be decoded
+func MalformedViewResponse() Response {
+	return Response{View: &common.View{
+		Meta: &common.Meta{
+			Protocol:            common.Meta_FABRIC,
+			Timestamp:           time.Now().UTC().Format(time.RFC3339),
+			ProofType:           "Notarization",
+			SerializationFormat: "STRING",
+		},
+		Data: []byte("malformed view data"),
+	}}
+}
+
+func (r Response) viewPayload(requestId string) *common.ViewPayload {
+	if r.Error != "" {
+		return &common.ViewPayload{RequestId: requestId, State: &common.ViewPayload_Error{Error: r.Error}}
+	}
+	return &common.ViewPayload{RequestId: requestId, State: &common.ViewPayload_View{View: r.View}}
+}
+
+// scripted responses by address, with an optional response for all other addresses
+type script struct {
+	mutex           sync.Mutex
+	responses       map[string]Response
+	defaultResponse *Response
+}
+
+func (s *script) respond(address string, response Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.responses == nil {
+		s.responses = map[string]Response{}
+	}
+	s.responses[address] = response
+}
+
+func (s *script) respondToAll(response Response) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.defaultResponse = &response
+}
+
+func (s *script) responseFor(address string) (*Response, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if response, exists := s.responses[address]; exists {
+		return &response, true
+	}
+	if s.defaultResponse != nil {
+		response := *s.defaultResponse
+		return &response, true
+	}
+	return nil, false
+}
+
+func dial(endpoint string) (*grpc.ClientConn, error) {
+	return grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+type request struct {
+	query    *common.Query
+	response *Response
+	polls    int
+	state    *common.RequestState
+	// endpoint of the relay the query came from, to which the outcome is sent back
+	requestingRelay string
+}
+
+/**
+ * Relay serves the Network service used by SDK clients and the DataTransfer service used by other relays and drivers.
+ * A query is answered with the response scripted for its address; if there is none, it is forwarded to the remote
+ * relay or the driver set on the relay, whose answer is relayed back to the client or the requesting relay.
+ **/
+type Relay struct {
+	networks.UnimplementedNetworkServer
+	relay.UnimplementedDataTransferServer
+	// Endpoint the relay listens on
+	Address        string
+	server         *grpc.Server
+	script         script
+	mutex          sync.Mutex
+	remoteEndpoint string
+	driverEndpoint string
+	requests       map[string]*request
+	networkQueries []*networks.NetworkQuery
+	queries        []*common.Query
+}
+
+// StartRelay starts a relay listening on a free local port
+func StartRelay() (*Relay, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, err
+	}
+	r := &Relay{
+		Address:  listener.Addr().String(),
+		server:   grpc.NewServer(),
+		requests: map[string]*request{},
+	}
+	networks.RegisterNetworkServer(r.server, r)
+	relay.RegisterDataTransferServer(r.server, dataTransferServer{r})
+	go r.server.Serve(listener)
+	return r, nil
+}
+
+func (r *Relay) Stop() {
+	r.server.Stop()
+}
+
+// Respond scripts the response to the queries for an address
+func (r *Relay) Respond(address string, response Response) {
+	r.script.respond(address, response)
+}
+
+// RespondToAll scripts the response to the queries for addresses that have no response of their own
+func (r *Relay) RespondToAll(response Response) {
+	r.script.respondToAll(response)
+}
+
+// ForwardToRelay makes the relay forward queries without a scripted response to a remote relay
+func (r *Relay) ForwardToRelay(remoteEndpoint string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.remoteEndpoint = remoteEndpoint
+}
+
+// ForwardToDriver makes the relay forward queries without a scripted response to a driver
+func (r *Relay) ForwardToDriver(driverEndpoint string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.driverEndpoint = driverEndpoint
+}
+
+// NetworkQueries returns the queries the relay received from clients
+func (r *Relay) NetworkQueries() []*networks.NetworkQuery {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*networks.NetworkQuery{}, r.networkQueries...)
+}
+
+// Queries returns the queries the relay received from other relays
+func (r *Relay) Queries() []*common.Query {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*common.Query{}, r.queries...)
+}
+
+// forward a query to the remote relay or the driver, returning an error if the relay has neither
+func (r *Relay) forward(query *common.Query, toRemoteRelay bool) error {
+	r.mutex.Lock()
+	remoteEndpoint, driverEndpoint := r.remoteEndpoint, r.driverEndpoint
+	r.mutex.Unlock()
+	if !toRemoteRelay {
+		remoteEndpoint = ""
+	}
+	if remoteEndpoint == "" && driverEndpoint == "" {
+		return fmt.Errorf("no response scripted for address %s", query.Address)
+	}
+	go func() {
+		endpoint := remoteEndpoint
+		if endpoint == "" {
+			endpoint = driverEndpoint
+		}
+		conn, err := dial(endpoint)
+		if err != nil {
+			log.Errorf("failed to connect to %s: %v", endpoint, err)
+			return
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+		defer cancel()
+		var ack *common.Ack
+		if remoteEndpoint != "" {
+			ack, err = relay.NewDataTransferClient(conn).RequestState(ctx, query)
+		} else {
+			ack, err = driver.NewDriverCommunicationClient(conn).RequestDriverState(ctx, query)
+		}
+		if err == nil && ack.Status == common.Ack_ERROR {
+			err = fmt.Errorf("%s", ack.Message)
+		}
+		if err != nil {
+			r.deliver(&common.ViewPayload{RequestId: query.RequestId, State: &common.ViewPayload_Error{Error: err.Error()}})
+		}
+	}()
+	return nil
+}
+
+// sendState sends the outcome of a query back to the relay it came from
+func sendState(requestingRelay string, viewPayload *common.ViewPayload) {
+	conn, err := dial(requestingRelay)
+	if err != nil {
+		log.Errorf("failed to connect to %s: %v", requestingRelay, err)
+		return
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	_, err = relay.NewDataTransferClient(conn).SendState(ctx, viewPayload)
+	if err != nil {
+		log.Errorf("failed to send state of request %s to %s: %v", viewPayload.RequestId, requestingRelay, err)
+	}
+}
+
+// deliver records the outcome of a query, or passes it on to the relay the query came from
+func (r *Relay) deliver(viewPayload *common.ViewPayload) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	req, exists := r.requests[viewPayload.RequestId]
+	if !exists {
+		return fmt.Errorf("no request with ID %s", viewPayload.RequestId)
+	}
+	if req.requestingRelay != "" {
+		go sendState(req.requestingRelay, viewPayload)
+		return nil
+	}
+	req.state = &common.RequestState{RequestId: viewPayload.RequestId}
+	if viewPayload.GetError() != "" {
+		req.state.Status = common.RequestState_ERROR
+		req.state.State = &common.RequestState_Error{Error: viewPayload.GetError()}
+	} else {
+		req.state.Status = common.RequestState_COMPLETED
+		req.state.State = &common.RequestState_View{View: viewPayload.GetView()}
+	}
+	return nil
+}
+
+// RequestState accepts a query from a client
+func (r *Relay) RequestState(ctx context.Context, networkQuery *networks.NetworkQuery) (*common.Ack, error) {
+	query := &common.Query{
+		Policy:             networkQuery.Policy,
+		Address:            networkQuery.Address,
+		RequestingRelay:    r.Address,
+		RequestingNetwork:  networkQuery.RequestingNetwork,
+		Certificate:        networkQuery.Certificate,
+		RequestorSignature: networkQuery.RequestorSignature,
+		Nonce:              networkQuery.Nonce,
+		RequestId:          uuid.New().String(),
+		RequestingOrg:      networkQuery.RequestingOrg,
+		Confidential:       networkQuery.Confidential,
+	}
+	req := &request{query: query}
+	req.response, _ = r.script.responseFor(query.Address)
+	r.mutex.Lock()
+	r.networkQueries = append(r.networkQueries, networkQuery)
+	r.requests[query.RequestId] = req
+	r.mutex.Unlock()
+	if req.response == nil {
+		err := r.forward(query, true)
+		if err != nil {
+			// the request fails for clients that ignore the acknowledgement and poll for its state
+			r.deliver(&common.ViewPayload{RequestId: query.RequestId, State: &common.ViewPayload_Error{Error: err.Error()}})
+			return &common.Ack{Status: common.Ack_ERROR, RequestId: query.RequestId, Message: err.Error()}, nil
+		}
+	}
+	return &common.Ack{Status: common.Ack_OK, RequestId: query.RequestId}, nil
+}
+
+// GetState returns the state of a client's query
+func (r *Relay) GetState(ctx context.Context, getStateMessage *networks.GetStateMessage) (*common.RequestState, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	req, exists := r.requests[getStateMessage.RequestId]
+	if !exists {
+		return nil, fmt.Errorf("no request with ID %s", getStateMessage.RequestId)
+	}
+	pending := &common.RequestState{RequestId: req.query.RequestId, Status: common.RequestState_PENDING}
+	if req.response == nil {
+		if req.state == nil {
+			return pending, nil
+		}
+		return req.state, nil
+	}
+	req.polls++
+	if req.response.Timeout || req.polls <= req.response.PendingPolls {
+		return pending, nil
+	}
+	if req.response.Error != "" {
+		return &common.RequestState{
+			RequestId: req.query.RequestId,
+			Status:    common.RequestState_ERROR,
+			State:     &common.RequestState_Error{Error: req.response.Error},
+		}, nil
+	}
+	return &common.RequestState{
+		RequestId: req.query.RequestId,
+		Status:    common.RequestState_COMPLETED,
+		State:     &common.RequestState_View{View: req.response.View},
+	}, nil
+}
+
+// requestStateFromRelay accepts a query from another relay, whose outcome is sent back to that relay
+func (r *Relay) requestStateFromRelay(query *common.Query) (*common.Ack, error) {
+	req := &request{query: query, requestingRelay: query.RequestingRelay}
+	response, scripted := r.script.responseFor(query.Address)
+	r.mutex.Lock()
+	r.queries = append(r.queries, query)
+	r.requests[query.RequestId] = req
+	r.mutex.Unlock()
+	if !scripted {
+		err := r.forward(query, false)
+		if err != nil {
+			return &common.Ack{Status: common.Ack_ERROR, RequestId: query.RequestId, Message: err.Error()}, nil
+		}
+	} else if !response.Timeout {
+		go sendState(query.RequestingRelay, response.viewPayload(query.RequestId))
+	}
+	return &common.Ack{Status: common.Ack_OK, RequestId: query.RequestId}, nil
+}
+
+// SendState accepts the outcome of a query from the remote relay it was forwarded to
+func (r *Relay) SendState(ctx context.Context, viewPayload *common.ViewPayload) (*common.Ack, error) {
+	err := r.deliver(viewPayload)
+	if err != nil {
+		return &common.Ack{Status: common.Ack_ERROR, RequestId: viewPayload.RequestId, Message: err.Error()}, nil
+	}
+	return &common.Ack{Status: common.Ack_OK, RequestId: viewPayload.RequestId}, nil
+}
+
+// SendDriverState accepts the outcome of a query from the driver it was forwarded to
+func (r *Relay) SendDriverState(ctx context.Context, viewPayload *common.ViewPayload) (*common.Ack, error) {
+	return r.SendState(ctx, viewPayload)
+}
+
+// dataTransferServer exposes the DataTransfer service of a relay, whose RequestState clashes with the Network service's
+type dataTransferServer struct {
+	*Relay
+}
+
+func (d dataTransferServer) RequestState(ctx context.Context, query *common.Query) (*common.Ack, error) {
+	return d.requestStateFromRelay(query)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package relaytest_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/fabric"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/interoperablehelper"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/relay"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/relaytest"
+	"github.com/stretchr/testify/require"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+const address = "localhost:9083/network2/mychannel:simplestate:Read:a"
+
+func startRelay(t *testing.T) *relaytest.Relay {
+	r, err := relaytest.StartRelay()
+	require.NoError(t, err)
+	t.Cleanup(r.Stop)
+	return r
+}
+
+func TestFabricView(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	peer1, err := ca.NewIdentity("peer1.org2.network2.com")
+	require.NoError(t, err)
+
+	view, err := relaytest.NewFabricView(address, []byte("Arcturus"), peer0, peer1)
+	require.NoError(t, err)
+	data, err := interoperablehelper.GetResponseDataFromView(view)
+	require.NoError(t, err)
+	require.Equal(t, "Arcturus", string(data))
+
+	// the view is endorsed by members of the CA's organization, for the address of the query
+	membership := relaytest.NewMembership("network2", ca)
+	require.NoError(t, relaytest.VerifyFabricView(view, address, membership))
+	require.ErrorContains(t, relaytest.VerifyFabricView(view, address+"b", membership), "does not match")
+	otherCA, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	require.ErrorContains(t, relaytest.VerifyFabricView(view, address, relaytest.NewMembership("network2", otherCA)), "not a member")
+
+	// tampering with an endorsement invalidates its signature
+	fabricView := &fabric.FabricView{}
+	require.NoError(t, protoV2.Unmarshal(view.Data, fabricView))
+	require.Len(t, fabricView.EndorsedProposalResponses, 2)
+	fabricView.EndorsedProposalResponses[1].Endorsement.Signature = fabricView.EndorsedProposalResponses[0].Endorsement.Signature
+	view.Data, err = protoV2.Marshal(fabricView)
+	require.NoError(t, err)
+	require.ErrorContains(t, relaytest.VerifyFabricView(view, address, membership), "invalid signature")
+
+	require.Equal(t, ca.CertificatePEM, membership.Members["Org2MSP"].Value)
+	require.Equal(t, "ca", membership.Members["Org2MSP"].Type)
+}
+
+func TestScriptedResponses(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	view, err := relaytest.NewFabricView(address, []byte("Arcturus"), peer0)
+	require.NoError(t, err)
+
+	r := startRelay(t)
+	// the client's deadline is in whole seconds, so a one second timeout may expire right away
+	client := relay.NewRelay(r.Address, 5)
+
+	// pending, then view
+	r.Respond(address, relaytest.PendingThenView(3, view))
+	state, err := client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.NoError(t, err)
+	require.True(t, protoV2.Equal(view, state.GetView()))
+	queries := r.NetworkQueries()
+	require.Len(t, queries, 1)
+	require.Equal(t, address, queries[0].Address)
+	require.Equal(t, []string{"Org2MSP"}, queries[0].Policy)
+	require.Equal(t, "Org1MSP", queries[0].RequestingOrg)
+
+	// error
+	r.Respond(address, relaytest.ErrorResponse("access denied"))
+	_, err = client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.ErrorContains(t, err, "access denied")
+
+	// timeout
+	r.Respond(address, relaytest.TimeoutResponse())
+	_, err = relay.NewRelay(r.Address, 1).ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.ErrorContains(t, err, "timeout")
+
+	// malformed view
+	r.Respond(address, relaytest.MalformedViewResponse())
+	state, err = client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.NoError(t, err)
+	_, err = interoperablehelper.GetResponseDataFromView(state.GetView())
+	require.Error(t, err)
+
+	// no response scripted
+	_, err = client.ProcessRequest("localhost:9083/network2/mychannel:simplestate:Read:b", []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.ErrorContains(t, err, "no response scripted")
+}
+
+func TestForwarding(t *testing.T) {
+	ca, err := relaytest.NewCA("Org2MSP")
+	require.NoError(t, err)
+	peer0, err := ca.NewIdentity("peer0.org2.network2.com")
+	require.NoError(t, err)
+	view, err := relaytest.NewFabricView(address, []byte("Arcturus"), peer0)
+	require.NoError(t, err)
+
+	localRelay := startRelay(t)
+	remoteRelay := startRelay(t)
+	remoteDriver, err := relaytest.StartDriver(remoteRelay.Address)
+	require.NoError(t, err)
+	t.Cleanup(remoteDriver.Stop)
+	localRelay.ForwardToRelay(remoteRelay.Address)
+	remoteRelay.ForwardToDriver(remoteDriver.Address)
+	client := relay.NewRelay(localRelay.Address, 5)
+
+	remoteDriver.Respond(address, relaytest.PendingThenView(0, view))
+	state, err := client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.NoError(t, err)
+	require.True(t, protoV2.Equal(view, state.GetView()))
+	require.Len(t, remoteRelay.Queries(), 1)
+	require.Equal(t, localRelay.Address, remoteRelay.Queries()[0].RequestingRelay)
+	require.Len(t, remoteDriver.Queries(), 1)
+	require.Equal(t, "network1", remoteDriver.Queries()[0].RequestingNetwork)
+
+	remoteDriver.Respond(address, relaytest.ErrorResponse("chaincode function not found"))
+	_, err = client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.ErrorContains(t, err, "chaincode function not found")
+
+	// a remote relay answers with its own script before forwarding to its driver
+	remoteRelay.Respond(address, relaytest.ErrorResponse("remote relay unavailable"))
+	_, err = client.ProcessRequest(address, []string{"Org2MSP"}, "network1", "cert", "signature", "nonce", "Org1MSP")
+	require.ErrorContains(t, err, "remote relay unavailable")
+	require.Len(t, remoteDriver.Queries(), 2)
+}
@@ -0,0 +1,956 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package satp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// timeout of every call made by a gateway to the peer gateway or to its driver
+const requestTimeout = 5 * time.Second
+
+// default validity of a lock assertion, if not configured
+const defaultLockAssertionTimeoutSecs = 3600
+
+// format of the lock assertion claims made by the sender gateway
+const lockAssertionClaimFormat = "json"
+
+// helper functions to log and return errors
+func logThenErrorf(format string, args ...interface{}) error {
+	errorMsg := fmt.Sprintf(format, args...)
+	log.Error(errorMsg)
+	return errors.New(errorMsg)
+}
+
+// PeerGateway identifies a gateway of another network with which assets can be transferred
+type PeerGateway struct {
+	Address   string
+	PublicKey *ecdsa.PublicKey
+}
+
+// GatewayConfig holds what a gateway needs to take part in SATP sessions
+type GatewayConfig struct {
+	NetworkId     string
+	PrivateKey    *ecdsa.PrivateKey
+	DriverAddress string
+	// public key of the driver; asset status reports are only accepted when signed with the driver's key
+	DriverPublicKey *ecdsa.PublicKey
+	// gateways of other networks, keyed by network ID; messages are only accepted from these gateways
+	Peers                    map[string]PeerGateway
+	Store                    SessionStore
+	LockAssertionTimeoutSecs uint64
+	// AssignmentConsent supplies the consent of the original owner of the asset of an incoming transfer (the
+	// OriginatorPubkey of the transfer) to its assignment to the beneficiary, as a base64 encoded consent and
+	// signature created by the owner's application; incoming transfers can not be finalized without it
+	AssignmentConsent func(session *Session) (consent string, consentSignature string, err error)
+}
+
+// TransferRequest describes an asset transfer initiated by a sender gateway
+type TransferRequest struct {
+	AssetType     string
+	AssetId       string
+	OriginatorId  string
+	BeneficiaryId string
+	// base64 encoded ECert of the owner of the asset; the recipient network records it when creating the asset,
+	// and only assigns the asset to the beneficiary with the consent of this owner
+	OriginatorPubkey   string
+	BeneficiaryPubkey  string
+	RecipientNetworkId string
+	// consent of the asset owner to the transfer, created and signed by the owner's application, in base64
+	Consent          string
+	ConsentSignature string
+}
+
+// the claim conveyed in a lock assertion
+type lockAssertionClaim struct {
+	NetworkId string `json:"networkId"`
+	AssetType string `json:"assetType"`
+	AssetId   string `json:"assetId"`
+	SessionId string `json:"sessionId"`
+}
+
+/**
+ * Gateway implements both the sender and the receiver sides of SATP on top of the relay SATP gRPC service.
+ * Register it with a gRPC server (relay.RegisterSATPServer) listening at the address known to the peer gateways
+ * and to the local driver, which reports the outcome of PerformLock/CreateAsset/Extinguish/AssignAsset through SendAssetStatus.
+ **/
+type Gateway struct {
+	relay.UnimplementedSATPServer
+	config    GatewayConfig
+	publicKey string
+	// serializes the updates of sessions by concurrent requests
+	mutex sync.Mutex
+}
+
+func NewGateway(config GatewayConfig) (*Gateway, error) {
+	if config.NetworkId == "" {
+		return nil, logThenErrorf("gateway network ID not supplied")
+	}
+	if config.PrivateKey == nil {
+		return nil, logThenErrorf("gateway private key not supplied")
+	}
+	if config.DriverPublicKey == nil {
+		return nil, logThenErrorf("driver public key not supplied")
+	}
+	if config.Store == nil {
+		config.Store = NewMemorySessionStore()
+	}
+	if config.LockAssertionTimeoutSecs == 0 {
+		config.LockAssertionTimeoutSecs = defaultLockAssertionTimeoutSecs
+	}
+	publicKey, err := EncodePublicKey(&config.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, logThenErrorf("failed to encode gateway public key: %s", err.Error())
+	}
+	return &Gateway{
+		config:    config,
+		publicKey: publicKey,
+	}, nil
+}
+
+// GetSession returns the state of the session with a transfer context ID, or nil if there is no such session
+func (g *Gateway) GetSession(transferContextId string) (*Session, error) {
+	return g.config.Store.Get(transferContextId)
+}
+
+// the gateway of the other network taking part in a session
+func (g *Gateway) peerOf(session *Session) (PeerGateway, error) {
+	peerNetworkId := session.RecipientNetworkId
+	if session.Role == ReceiverRole {
+		peerNetworkId = session.SenderNetworkId
+	}
+	peer, exists := g.config.Peers[peerNetworkId]
+	if !exists {
+		return peer, fmt.Errorf("no gateway configured for network %s", peerNetworkId)
+	}
+	return peer, nil
+}
+
+func ackOk(requestId string) *common.Ack {
+	return &common.Ack{Status: common.Ack_OK, RequestId: requestId}
+}
+
+func ackError(requestId string, err error) *common.Ack {
+	return &common.Ack{Status: common.Ack_ERROR, RequestId: requestId, Message: err.Error()}
+}
+
+// get the signature of an incoming message, either from the message itself or from the request metadata
+func incomingSignature(ctx context.Context, msg proto.Message) string {
+	if signature, hasField := messageSignature(msg); hasField {
+		return signature
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	signatures := md.Get(signatureMetadataKey)
+	if len(signatures) == 0 {
+		return ""
+	}
+	return signatures[0]
+}
+
+// mark a session as failed; a failed session accepts no further messages
+func (g *Gateway) failSession(transferContextId string, cause error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	log.Errorf("SATP session with transfer context %s failed: %s", transferContextId, cause.Error())
+	session, err := g.config.Store.Get(transferContextId)
+	if err != nil || session == nil {
+		return
+	}
+	session.Error = cause.Error()
+	err = g.config.Store.Put(session)
+	if err != nil {
+		log.Errorf("failed to record failure of SATP session with transfer context %s: %s", transferContextId, err.Error())
+	}
+}
+
+// run the next step of a session in the background, as the gateway must acknowledge the current message first
+func (g *Gateway) proceed(transferContextId string, next func() error) {
+	go func() {
+		err := next()
+		if err != nil {
+			g.failSession(transferContextId, err)
+		}
+	}()
+}
+
+// load a session that can still make progress
+func (g *Gateway) loadActiveSession(transferContextId string) (*Session, error) {
+	session, err := g.config.Store.Get(transferContextId)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, fmt.Errorf("no SATP session found for transfer context %s", transferContextId)
+	}
+	if session.IsFailed() {
+		return nil, fmt.Errorf("SATP session with transfer context %s has failed: %s", transferContextId, session.Error)
+	}
+	return session, nil
+}
+
+/**
+ * Validate an incoming message against its session: the message type, the sequence of steps, the session ID,
+ * the hash of the previous message and the signature of the peer gateway. The session then advances to the step.
+ **/
+func (g *Gateway) acceptMessage(ctx context.Context, step, messageType, transferContextId, sessionId string, msg proto.Message) (*Session, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	session, err := g.loadActiveSession(transferContextId)
+	if err != nil {
+		return nil, err
+	}
+	if messageType != step {
+		return nil, fmt.Errorf("unexpected message type %s for %s", messageType, step)
+	}
+	if step == StepTransferCommence && session.SessionId == "" {
+		// the session ID is chosen by the sender gateway when it commences the transfer
+		session.SessionId = sessionId
+	}
+	if sessionId != session.SessionId {
+		return nil, fmt.Errorf("session ID %s does not match session ID %s of transfer context %s", sessionId, session.SessionId, transferContextId)
+	}
+	err = session.checkNextStep(step)
+	if err != nil {
+		return nil, err
+	}
+	if hashPrev, hasField := prevMessageHash(msg); hasField && hashPrev != session.LastMessageHash {
+		return nil, fmt.Errorf("hash of previous message in %s does not match the last message of session %s", step, session.SessionId)
+	}
+	peer, err := g.peerOf(session)
+	if err != nil {
+		return nil, err
+	}
+	err = verifyMessageSignature(peer.PublicKey, msg, incomingSignature(ctx, msg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signature of %s: %v", step, err)
+	}
+
+	msgHash, err := hashMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+	session.Step = step
+	session.LastMessageHash = msgHash
+	err = g.config.Store.Put(session)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("SATP %s gateway accepted %s for session %s", session.Role, step, session.SessionId)
+	return session, nil
+}
+
+// send a message to the peer gateway
+func invokePeer(ctx context.Context, client relay.SATPClient, msg proto.Message) (*common.Ack, error) {
+	switch m := msg.(type) {
+	case *relay.TransferProposalClaimsRequest:
+		return client.TransferProposalClaims(ctx, m)
+	case *relay.TransferProposalReceiptRequest:
+		return client.TransferProposalReceipt(ctx, m)
+	case *relay.TransferCommenceRequest:
+		return client.TransferCommence(ctx, m)
+	case *relay.AckCommenceRequest:
+		return client.AckCommence(ctx, m)
+	case *relay.LockAssertionRequest:
+		return client.LockAssertion(ctx, m)
+	case *relay.LockAssertionReceiptRequest:
+		return client.LockAssertionReceipt(ctx, m)
+	case *relay.CommitPrepareRequest:
+		return client.CommitPrepare(ctx, m)
+	case *relay.CommitReadyRequest:
+		return client.CommitReady(ctx, m)
+	case *relay.CommitFinalAssertionRequest:
+		return client.CommitFinalAssertion(ctx, m)
+	case *relay.AckFinalReceiptRequest:
+		return client.AckFinalReceipt(ctx, m)
+	case *relay.TransferCompletedRequest:
+		return client.TransferCompleted(ctx, m)
+	default:
+		return nil, fmt.Errorf("unsupported SATP message %T", msg)
+	}
+}
+
+func (g *Gateway) sendToPeer(peer PeerGateway, step string, msg proto.Message, signature string) error {
+	conn, err := grpc.Dial(peer.Address, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("grpc Dial() failed to connect to gateway %s: %v", peer.Address, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, signatureMetadataKey, signature)
+	ack, err := invokePeer(ctx, relay.NewSATPClient(conn), msg)
+	if err != nil {
+		return fmt.Errorf("error in grpc %s(): %v", step, err)
+	}
+	if ack == nil {
+		return fmt.Errorf("no acknowledgement of %s from gateway %s", step, peer.Address)
+	}
+	if ack.GetStatus() == common.Ack_ERROR {
+		return fmt.Errorf("%s rejected by gateway %s: %s", step, peer.Address, ack.GetMessage())
+	}
+	return nil
+}
+
+/**
+ * Build, sign and send the message of the next step of a session to the peer gateway.
+ * The session advances to the step before the message is sent, as the peer may reply before the call returns.
+ **/
+func (g *Gateway) sendMessage(transferContextId, step string, build func(session *Session) (proto.Message, error)) error {
+	g.mutex.Lock()
+	session, err := g.loadActiveSession(transferContextId)
+	if err == nil {
+		err = session.checkNextStep(step)
+	}
+	var msg proto.Message
+	if err == nil {
+		session.TransferNumber++
+		msg, err = build(session)
+	}
+	var signature, msgHash string
+	if err == nil {
+		signature, err = signMessage(g.config.PrivateKey, msg)
+	}
+	if err == nil {
+		msgHash, err = hashMessage(msg)
+	}
+	var peer PeerGateway
+	if err == nil {
+		peer, err = g.peerOf(session)
+	}
+	if err == nil {
+		session.Step = step
+		session.LastMessageHash = msgHash
+		err = g.config.Store.Put(session)
+	}
+	g.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("SATP %s gateway sending %s for session %s", session.Role, step, session.SessionId)
+	return g.sendToPeer(peer, step, msg, signature)
+}
+
+// request the local driver to act on the ledger in the next step of a session
+func (g *Gateway) requestDriver(transferContextId, step string) error {
+	g.mutex.Lock()
+	session, err := g.loadActiveSession(transferContextId)
+	if err == nil {
+		err = session.checkNextStep(step)
+	}
+	if err == nil {
+		session.Step = step
+		err = g.config.Store.Put(session)
+	}
+	g.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(g.config.DriverAddress, grpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("grpc Dial() failed to connect to driver %s: %v", g.config.DriverAddress, err)
+	}
+	defer conn.Close()
+	client := driver.NewDriverCommunicationClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	log.Infof("SATP %s gateway requesting %s from driver for session %s", session.Role, step, session.SessionId)
+	var ack *common.Ack
+	switch step {
+	case StepPerformLock:
+		ack, err = client.PerformLock(ctx, &driver.PerformLockRequest{
+			SessionId:        session.SessionId,
+			Consent:          session.Consent,
+			ConsentSignature: session.ConsentSignature,
+		})
+	case StepCreateAsset:
+		ack, err = client.CreateAsset(ctx, &driver.CreateAssetRequest{
+			SessionId:     session.SessionId,
+			AssetType:     session.AssetType,
+			AssetId:       session.AssetId,
+			OriginalOwner: session.OriginatorPubkey,
+		})
+	case StepExtinguish:
+		ack, err = client.Extinguish(ctx, &driver.ExtinguishRequest{
+			SessionId: session.SessionId,
+			AssetType: session.AssetType,
+			AssetId:   session.AssetId,
+		})
+	case StepAssignAsset:
+		if g.config.AssignmentConsent == nil {
+			return fmt.Errorf("no assignment consent configured for session %s", session.SessionId)
+		}
+		var consent, consentSignature string
+		consent, consentSignature, err = g.config.AssignmentConsent(session)
+		if err != nil {
+			return fmt.Errorf("failed to get assignment consent for session %s: %v", session.SessionId, err)
+		}
+		ack, err = client.AssignAsset(ctx, &driver.AssignAssetRequest{
+			SessionId:        session.SessionId,
+			Consent:          consent,
+			ConsentSignature: consentSignature,
+		})
+	default:
+		return fmt.Errorf("%s is not a driver request", step)
+	}
+	if err != nil {
+		return fmt.Errorf("error in grpc %s(): %v", step, err)
+	}
+	if ack == nil {
+		return fmt.Errorf("no acknowledgement of %s from driver", step)
+	}
+	if ack.GetStatus() == common.Ack_ERROR {
+		return fmt.Errorf("%s rejected by driver: %s", step, ack.GetMessage())
+	}
+	return nil
+}
+
+/**
+ * StartTransfer initiates an asset transfer as the sender gateway by sending the transfer proposal claims to the
+ * recipient network's gateway. The rest of the session proceeds in the background; its progress can be followed
+ * with GetSession using the transfer context ID of the returned session.
+ **/
+func (g *Gateway) StartTransfer(request TransferRequest) (*Session, error) {
+	if request.AssetType == "" || request.AssetId == "" {
+		return nil, logThenErrorf("asset type and ID need to be supplied")
+	}
+	if request.Consent == "" || request.ConsentSignature == "" {
+		return nil, logThenErrorf("consent of the asset owner needs to be supplied")
+	}
+	if request.OriginatorPubkey == "" {
+		return nil, logThenErrorf("public key (ECert) of the asset owner needs to be supplied")
+	}
+	peer, exists := g.config.Peers[request.RecipientNetworkId]
+	if !exists {
+		return nil, logThenErrorf("no gateway configured for network %s", request.RecipientNetworkId)
+	}
+	peerPublicKey, err := EncodePublicKey(peer.PublicKey)
+	if err != nil {
+		return nil, logThenErrorf("failed to encode public key of gateway of %s: %s", request.RecipientNetworkId, err.Error())
+	}
+	claims := &relay.TransferProposalClaimsRequest{
+		MessageType:                 StepTransferProposalClaims,
+		AssetAssetId:                request.AssetId,
+		AssetProfileId:              request.AssetType,
+		VerifiedOriginatorEntityId:  request.OriginatorId,
+		VerifiedBeneficiaryEntityId: request.BeneficiaryId,
+		OriginatorPubkey:            request.OriginatorPubkey,
+		BeneficiaryPubkey:           request.BeneficiaryPubkey,
+		SenderGatewayNetworkId:      g.config.NetworkId,
+		RecipientGatewayNetworkId:   request.RecipientNetworkId,
+		ClientIdentityPubkey:        g.publicKey,
+		ServerIdentityPubkey:        peerPublicKey,
+		TransferNonce:               uuid.New().String(),
+	}
+	// the transfer context is identified by the hash of the claims, which both gateways can compute; the nonce
+	// makes it unique for every transfer, even of an asset which has been transferred before
+	transferContextId, err := hashMessage(claims)
+	if err != nil {
+		return nil, logThenErrorf("failed to hash transfer proposal claims: %s", err.Error())
+	}
+	session := &Session{
+		TransferContextId:  transferContextId,
+		Role:               SenderRole,
+		AssetType:          request.AssetType,
+		AssetId:            request.AssetId,
+		SenderNetworkId:    g.config.NetworkId,
+		RecipientNetworkId: request.RecipientNetworkId,
+		BeneficiaryId:      request.BeneficiaryId,
+		OriginatorPubkey:   request.OriginatorPubkey,
+		Consent:            request.Consent,
+		ConsentSignature:   request.ConsentSignature,
+		Step:               StepTransferProposalClaims,
+		LastMessageHash:    transferContextId,
+	}
+	signature, err := signMessage(g.config.PrivateKey, claims)
+	if err != nil {
+		return nil, logThenErrorf("failed to sign transfer proposal claims: %s", err.Error())
+	}
+
+	g.mutex.Lock()
+	existing, err := g.config.Store.Get(transferContextId)
+	if err == nil && existing != nil {
+		err = fmt.Errorf("a SATP session with transfer context %s already exists", transferContextId)
+	}
+	if err == nil {
+		err = g.config.Store.Put(session)
+	}
+	g.mutex.Unlock()
+	if err != nil {
+		return nil, logThenErrorf("%s", err.Error())
+	}
+
+	err = g.sendToPeer(peer, StepTransferProposalClaims, claims, signature)
+	if err != nil {
+		g.failSession(transferContextId, err)
+		return nil, logThenErrorf("%s", err.Error())
+	}
+	return session, nil
+}
+
+// Stage 1: transfer initiation
+
+// TransferProposalClaims is run on the receiver gateway to let a sender gateway initiate an asset transfer
+func (g *Gateway) TransferProposalClaims(ctx context.Context, request *relay.TransferProposalClaimsRequest) (*common.Ack, error) {
+	transferContextId, err := hashMessage(request)
+	if err != nil {
+		return ackError("", err), nil
+	}
+	err = g.acceptTransferProposalClaims(ctx, transferContextId, request)
+	if err != nil {
+		return ackError(transferContextId, logThenErrorf("transfer proposal claims rejected: %s", err.Error())), nil
+	}
+
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepTransferProposalReceipt, func(session *Session) (proto.Message, error) {
+			receipt := &relay.TransferProposalReceiptRequest{
+				MessageType:                 StepTransferProposalReceipt,
+				AssetAssetId:                request.AssetAssetId,
+				AssetProfileId:              request.AssetProfileId,
+				VerifiedOriginatorEntityId:  request.VerifiedOriginatorEntityId,
+				VerifiedBeneficiaryEntityId: request.VerifiedBeneficiaryEntityId,
+				OriginatorPubkey:            request.OriginatorPubkey,
+				BeneficiaryPubkey:           request.BeneficiaryPubkey,
+				SenderGatewayNetworkId:      request.SenderGatewayNetworkId,
+				RecipientGatewayNetworkId:   request.RecipientGatewayNetworkId,
+				ClientIdentityPubkey:        request.ClientIdentityPubkey,
+				ServerIdentityPubkey:        request.ServerIdentityPubkey,
+				SenderGatewayOwnerId:        request.SenderGatewayOwnerId,
+				ReceiverGatewayOwnerId:      request.ReceiverGatewayOwnerId,
+				TransferNonce:               request.TransferNonce,
+			}
+			return receipt, nil
+		})
+	})
+	return ackOk(transferContextId), nil
+}
+
+func (g *Gateway) acceptTransferProposalClaims(ctx context.Context, transferContextId string, request *relay.TransferProposalClaimsRequest) error {
+	if request.MessageType != StepTransferProposalClaims {
+		return fmt.Errorf("unexpected message type %s", request.MessageType)
+	}
+	if request.AssetAssetId == "" || request.AssetProfileId == "" {
+		return fmt.Errorf("asset not specified")
+	}
+	if request.TransferNonce == "" {
+		return fmt.Errorf("transfer nonce not specified")
+	}
+	if request.OriginatorPubkey == "" {
+		return fmt.Errorf("public key of the asset owner not specified")
+	}
+	if request.RecipientGatewayNetworkId != g.config.NetworkId {
+		return fmt.Errorf("transfer is meant for network %s and not for %s", request.RecipientGatewayNetworkId, g.config.NetworkId)
+	}
+	if request.ServerIdentityPubkey != g.publicKey {
+		return fmt.Errorf("server identity public key does not match the public key of this gateway")
+	}
+	peer, exists := g.config.Peers[request.SenderGatewayNetworkId]
+	if !exists {
+		return fmt.Errorf("no gateway configured for network %s", request.SenderGatewayNetworkId)
+	}
+	peerPublicKey, err := EncodePublicKey(peer.PublicKey)
+	if err != nil {
+		return err
+	}
+	if request.ClientIdentityPubkey != peerPublicKey {
+		return fmt.Errorf("client identity public key does not match the gateway of network %s", request.SenderGatewayNetworkId)
+	}
+	err = verifyMessageSignature(peer.PublicKey, request, incomingSignature(ctx, request))
+	if err != nil {
+		return fmt.Errorf("failed to verify signature of %s: %v", StepTransferProposalClaims, err)
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	existing, err := g.config.Store.Get(transferContextId)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("a SATP session with transfer context %s already exists", transferContextId)
+	}
+	return g.config.Store.Put(&Session{
+		TransferContextId:  transferContextId,
+		Role:               ReceiverRole,
+		AssetType:          request.AssetProfileId,
+		AssetId:            request.AssetAssetId,
+		SenderNetworkId:    request.SenderGatewayNetworkId,
+		RecipientNetworkId: request.RecipientGatewayNetworkId,
+		BeneficiaryId:      request.VerifiedBeneficiaryEntityId,
+		OriginatorPubkey:   request.OriginatorPubkey,
+		Step:               StepTransferProposalClaims,
+		LastMessageHash:    transferContextId,
+	})
+}
+
+// TransferProposalReceipt is run on the sender gateway when the receiver gateway accepts the transfer proposal
+func (g *Gateway) TransferProposalReceipt(ctx context.Context, request *relay.TransferProposalReceiptRequest) (*common.Ack, error) {
+	transferContextId, err := hashMessage(claimsFromReceipt(request))
+	if err != nil {
+		return ackError("", err), nil
+	}
+	_, err = g.acceptMessage(ctx, StepTransferProposalReceipt, request.MessageType, transferContextId, "", request)
+	if err != nil {
+		return ackError(transferContextId, logThenErrorf("transfer proposal receipt rejected: %s", err.Error())), nil
+	}
+
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepTransferCommence, func(session *Session) (proto.Message, error) {
+			session.SessionId = uuid.New().String()
+			peer, err := g.peerOf(session)
+			if err != nil {
+				return nil, err
+			}
+			peerPublicKey, err := EncodePublicKey(peer.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			return &relay.TransferCommenceRequest{
+				MessageType:            StepTransferCommence,
+				SessionId:              session.SessionId,
+				TransferContextId:      transferContextId,
+				ClientIdentityPubkey:   g.publicKey,
+				ServerIdentityPubkey:   peerPublicKey,
+				HashTransferInitClaims: transferContextId,
+				HashPrevMessage:        session.LastMessageHash,
+				ClientTransferNumber:   strconv.FormatUint(session.TransferNumber, 10),
+			}, nil
+		})
+	})
+	return ackOk(transferContextId), nil
+}
+
+// TransferCommence is run on the receiver gateway when the sender gateway is ready to start the transfer
+func (g *Gateway) TransferCommence(ctx context.Context, request *relay.TransferCommenceRequest) (*common.Ack, error) {
+	if request.HashTransferInitClaims != request.TransferContextId {
+		return ackError(request.SessionId, logThenErrorf("transfer commence rejected: hash of transfer proposal claims does not match transfer context %s", request.TransferContextId)), nil
+	}
+	_, err := g.acceptMessage(ctx, StepTransferCommence, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("transfer commence rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepAckCommence, func(session *Session) (proto.Message, error) {
+			return &relay.AckCommenceRequest{
+				MessageType:          StepAckCommence,
+				SessionId:            session.SessionId,
+				TransferContextId:    transferContextId,
+				ClientIdentityPubkey: request.ClientIdentityPubkey,
+				ServerIdentityPubkey: g.publicKey,
+				HashPrevMessage:      session.LastMessageHash,
+				ServerTransferNumber: strconv.FormatUint(session.TransferNumber, 10),
+			}, nil
+		})
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// AckCommence is run on the sender gateway when the receiver gateway agrees to proceed, upon which the asset is locked
+func (g *Gateway) AckCommence(ctx context.Context, request *relay.AckCommenceRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepAckCommence, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("ack commence rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.requestDriver(transferContextId, StepPerformLock)
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// Stage 2: lock assertion
+
+// SendAssetStatus is run on either gateway when its driver reports the outcome of a request made to it
+func (g *Gateway) SendAssetStatus(ctx context.Context, request *relay.SendAssetStatusRequest) (*common.Ack, error) {
+	err := verifyMessageSignature(g.config.DriverPublicKey, request, incomingSignature(ctx, request))
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("asset status rejected: driver signature check failed: %s", err.Error())), nil
+	}
+	session, err := g.acceptAssetStatus(request.SessionId, request.Status)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("asset status rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := session.TransferContextId
+	switch request.Status {
+	case StepLocked:
+		g.proceed(transferContextId, func() error {
+			return g.sendLockAssertion(transferContextId)
+		})
+	case StepCreated:
+		g.proceed(transferContextId, func() error {
+			return g.sendMessage(transferContextId, StepCommitReady, func(session *Session) (proto.Message, error) {
+				return &relay.CommitReadyRequest{
+					MessageType:       StepCommitReady,
+					SessionId:         session.SessionId,
+					TransferContextId: transferContextId,
+				}, nil
+			})
+		})
+	case StepExtinguished:
+		g.proceed(transferContextId, func() error {
+			return g.sendMessage(transferContextId, StepCommitFinalAssertion, func(session *Session) (proto.Message, error) {
+				return &relay.CommitFinalAssertionRequest{
+					MessageType:       StepCommitFinalAssertion,
+					SessionId:         session.SessionId,
+					TransferContextId: transferContextId,
+				}, nil
+			})
+		})
+	case StepFinalized:
+		g.proceed(transferContextId, func() error {
+			return g.sendMessage(transferContextId, StepAckFinalReceipt, func(session *Session) (proto.Message, error) {
+				return &relay.AckFinalReceiptRequest{
+					MessageType:       StepAckFinalReceipt,
+					SessionId:         session.SessionId,
+					TransferContextId: transferContextId,
+				}, nil
+			})
+		})
+	}
+	return ackOk(request.SessionId), nil
+}
+
+func (g *Gateway) acceptAssetStatus(sessionId, status string) (*Session, error) {
+	if status != StepLocked && status != StepCreated && status != StepExtinguished && status != StepFinalized {
+		return nil, fmt.Errorf("invalid asset status: %s", status)
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	session, err := g.config.Store.GetBySessionId(sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, fmt.Errorf("no SATP session found with session ID %s", sessionId)
+	}
+	if session.IsFailed() {
+		return nil, fmt.Errorf("SATP session %s has failed: %s", sessionId, session.Error)
+	}
+	err = session.checkNextStep(status)
+	if err != nil {
+		return nil, err
+	}
+	session.Step = status
+	err = g.config.Store.Put(session)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("SATP %s gateway received asset status %s for session %s", session.Role, status, sessionId)
+	return session, nil
+}
+
+func (g *Gateway) sendLockAssertion(transferContextId string) error {
+	return g.sendMessage(transferContextId, StepLockAssertion, func(session *Session) (proto.Message, error) {
+		claim, err := json.Marshal(lockAssertionClaim{
+			NetworkId: g.config.NetworkId,
+			AssetType: session.AssetType,
+			AssetId:   session.AssetId,
+			SessionId: session.SessionId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		peer, err := g.peerOf(session)
+		if err != nil {
+			return nil, err
+		}
+		peerPublicKey, err := EncodePublicKey(peer.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		session.LockExpirationSecs = uint64(time.Now().Unix()) + g.config.LockAssertionTimeoutSecs
+		return &relay.LockAssertionRequest{
+			MessageType:              StepLockAssertion,
+			SessionId:                session.SessionId,
+			TransferContextId:        transferContextId,
+			ClientIdentityPubkey:     g.publicKey,
+			ServerIdentityPubkey:     peerPublicKey,
+			LockAssertionClaim:       string(claim),
+			LockAssertionClaimFormat: lockAssertionClaimFormat,
+			LockAssertionExpiration:  strconv.FormatUint(session.LockExpirationSecs, 10),
+			HashPrevMessage:          session.LastMessageHash,
+			ClientTransferNumber:     strconv.FormatUint(session.TransferNumber, 10),
+		}, nil
+	})
+}
+
+// LockAssertion is run on the receiver gateway when the sender gateway asserts that the asset has been locked
+func (g *Gateway) LockAssertion(ctx context.Context, request *relay.LockAssertionRequest) (*common.Ack, error) {
+	err := g.checkLockAssertionClaim(request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("lock assertion rejected: %s", err.Error())), nil
+	}
+	_, err = g.acceptMessage(ctx, StepLockAssertion, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("lock assertion rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepLockAssertionReceipt, func(session *Session) (proto.Message, error) {
+			session.LockExpirationSecs, _ = strconv.ParseUint(request.LockAssertionExpiration, 10, 64)
+			return &relay.LockAssertionReceiptRequest{
+				MessageType:          StepLockAssertionReceipt,
+				SessionId:            session.SessionId,
+				TransferContextId:    transferContextId,
+				ClientIdentityPubkey: request.ClientIdentityPubkey,
+				ServerIdentityPubkey: g.publicKey,
+				HashPrevMessage:      session.LastMessageHash,
+				ServerTransferNumber: strconv.FormatUint(session.TransferNumber, 10),
+			}, nil
+		})
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// check that a lock assertion is about the asset of its session and has not expired
+func (g *Gateway) checkLockAssertionClaim(request *relay.LockAssertionRequest) error {
+	session, err := g.loadActiveSession(request.TransferContextId)
+	if err != nil {
+		return err
+	}
+	if request.LockAssertionClaimFormat != lockAssertionClaimFormat {
+		return fmt.Errorf("unsupported lock assertion claim format %s", request.LockAssertionClaimFormat)
+	}
+	claim := lockAssertionClaim{}
+	err = json.Unmarshal([]byte(request.LockAssertionClaim), &claim)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal lock assertion claim: %v", err)
+	}
+	if claim.NetworkId != session.SenderNetworkId || claim.AssetType != session.AssetType ||
+		claim.AssetId != session.AssetId || claim.SessionId != request.SessionId {
+		return fmt.Errorf("lock assertion claim %s does not match session %s", request.LockAssertionClaim, request.SessionId)
+	}
+	expirationSecs, err := strconv.ParseUint(request.LockAssertionExpiration, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid lock assertion expiration %s", request.LockAssertionExpiration)
+	}
+	if expirationSecs <= uint64(time.Now().Unix()) {
+		return fmt.Errorf("lock assertion for session %s has expired", request.SessionId)
+	}
+	return nil
+}
+
+// LockAssertionReceipt is run on the sender gateway when the receiver gateway accepts the lock assertion
+func (g *Gateway) LockAssertionReceipt(ctx context.Context, request *relay.LockAssertionReceiptRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepLockAssertionReceipt, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("lock assertion receipt rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepCommitPrepare, func(session *Session) (proto.Message, error) {
+			return &relay.CommitPrepareRequest{
+				MessageType:       StepCommitPrepare,
+				SessionId:         session.SessionId,
+				TransferContextId: transferContextId,
+			}, nil
+		})
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// Stage 3: commitment
+
+// CommitPrepare is run on the receiver gateway, which creates the asset in the recipient network
+func (g *Gateway) CommitPrepare(ctx context.Context, request *relay.CommitPrepareRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepCommitPrepare, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("commit prepare rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.requestDriver(transferContextId, StepCreateAsset)
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// CommitReady is run on the sender gateway, which extinguishes the asset in the origin network
+func (g *Gateway) CommitReady(ctx context.Context, request *relay.CommitReadyRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepCommitReady, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("commit ready rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.requestDriver(transferContextId, StepExtinguish)
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// CommitFinalAssertion is run on the receiver gateway, which assigns the created asset to the beneficiary
+func (g *Gateway) CommitFinalAssertion(ctx context.Context, request *relay.CommitFinalAssertionRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepCommitFinalAssertion, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("commit final assertion rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.requestDriver(transferContextId, StepAssignAsset)
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// AckFinalReceipt is run on the sender gateway, which completes the transfer
+func (g *Gateway) AckFinalReceipt(ctx context.Context, request *relay.AckFinalReceiptRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepAckFinalReceipt, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("ack final receipt rejected: %s", err.Error())), nil
+	}
+
+	transferContextId := request.TransferContextId
+	g.proceed(transferContextId, func() error {
+		return g.sendMessage(transferContextId, StepTransferCompleted, func(session *Session) (proto.Message, error) {
+			return &relay.TransferCompletedRequest{
+				MessageType:       StepTransferCompleted,
+				SessionId:         session.SessionId,
+				TransferContextId: transferContextId,
+			}, nil
+		})
+	})
+	return ackOk(request.SessionId), nil
+}
+
+// TransferCompleted is run on the receiver gateway to close the session
+func (g *Gateway) TransferCompleted(ctx context.Context, request *relay.TransferCompletedRequest) (*common.Ack, error) {
+	_, err := g.acceptMessage(ctx, StepTransferCompleted, request.MessageType, request.TransferContextId, request.SessionId, request)
+	if err != nil {
+		return ackError(request.SessionId, logThenErrorf("transfer completed rejected: %s", err.Error())), nil
+	}
+	return ackOk(request.SessionId), nil
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package satp_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/common"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/driver"
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	"github.com/hyperledger-cacti/cacti/weaver/sdks/fabric/go-sdk/v3/satp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// driver mock which reports the requested asset operation as done to its gateway
+type driverMock struct {
+	driver.UnimplementedDriverCommunicationServer
+	gatewayAddress string
+	key            *ecdsa.PrivateKey
+	mutex          sync.Mutex
+	requests       []string
+	consents       []string
+	// when set, AssignAsset fails with this error
+	assignError error
+}
+
+func (d *driverMock) reportStatus(step, sessionId, status string) (*common.Ack, error) {
+	d.mutex.Lock()
+	d.requests = append(d.requests, step)
+	d.mutex.Unlock()
+	return d.sendStatus(sessionId, status)
+}
+
+// like reportStatus, also recording the consent to the operation passed to the driver
+func (d *driverMock) reportConsentedStatus(step, sessionId, status, consent, consentSignature string) (*common.Ack, error) {
+	d.mutex.Lock()
+	d.requests = append(d.requests, step)
+	d.consents = append(d.consents, consent+"/"+consentSignature)
+	d.mutex.Unlock()
+	return d.sendStatus(sessionId, status)
+}
+
+func (d *driverMock) sendStatus(sessionId, status string) (*common.Ack, error) {
+	go func() {
+		conn, err := grpc.Dial(d.gatewayAddress, grpc.WithInsecure())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		request := &relay.SendAssetStatusRequest{
+			SessionId: sessionId,
+			Status:    status,
+		}
+		ctx, err := satp.SignAssetStatus(context.Background(), d.key, request)
+		if err != nil {
+			return
+		}
+		relay.NewSATPClient(conn).SendAssetStatus(ctx, request)
+	}()
+	return &common.Ack{Status: common.Ack_OK, RequestId: sessionId}, nil
+}
+
+func (d *driverMock) PerformLock(ctx context.Context, req *driver.PerformLockRequest) (*common.Ack, error) {
+	return d.reportConsentedStatus(satp.StepPerformLock, req.SessionId, satp.StepLocked, req.Consent, req.ConsentSignature)
+}
+
+func (d *driverMock) CreateAsset(ctx context.Context, req *driver.CreateAssetRequest) (*common.Ack, error) {
+	return d.reportStatus(satp.StepCreateAsset+":"+req.AssetType+":"+req.AssetId+":"+req.OriginalOwner, req.SessionId, satp.StepCreated)
+}
+
+func (d *driverMock) Extinguish(ctx context.Context, req *driver.ExtinguishRequest) (*common.Ack, error) {
+	return d.reportStatus(satp.StepExtinguish+":"+req.AssetType+":"+req.AssetId, req.SessionId, satp.StepExtinguished)
+}
+
+func (d *driverMock) AssignAsset(ctx context.Context, req *driver.AssignAssetRequest) (*common.Ack, error) {
+	if d.assignError != nil {
+		return nil, d.assignError
+	}
+	return d.reportConsentedStatus(satp.StepAssignAsset, req.SessionId, satp.StepFinalized, req.Consent, req.ConsentSignature)
+}
+
+func (d *driverMock) getRequests() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]string{}, d.requests...)
+}
+
+func (d *driverMock) getConsents() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return append([]string{}, d.consents...)
+}
+
+type testNetwork struct {
+	networkId string
+	key       *ecdsa.PrivateKey
+	listener  net.Listener
+	driver    *driverMock
+	gateway   *satp.Gateway
+}
+
+// create the listeners and keys of a network first, as the gateways need to know each other
+func newTestNetwork(t *testing.T, networkId string) *testNetwork {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return &testNetwork{networkId: networkId, key: key, listener: listener}
+}
+
+func (n *testNetwork) start(t *testing.T, peer *testNetwork, store satp.SessionStore) {
+	driverListener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	driverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	n.driver = &driverMock{gatewayAddress: n.listener.Addr().String(), key: driverKey}
+	driverServer := grpc.NewServer()
+	driver.RegisterDriverCommunicationServer(driverServer, n.driver)
+	go driverServer.Serve(driverListener)
+	t.Cleanup(driverServer.Stop)
+
+	n.gateway, err = satp.NewGateway(satp.GatewayConfig{
+		NetworkId:       n.networkId,
+		PrivateKey:      n.key,
+		DriverAddress:   driverListener.Addr().String(),
+		DriverPublicKey: &driverKey.PublicKey,
+		Peers: map[string]satp.PeerGateway{
+			peer.networkId: {Address: peer.listener.Addr().String(), PublicKey: &peer.key.PublicKey},
+		},
+		Store: store,
+		AssignmentConsent: func(session *satp.Session) (string, string, error) {
+			return "assign-" + session.AssetId + "-to-" + session.BeneficiaryId, "alice-assignment-signature", nil
+		},
+	})
+	require.NoError(t, err)
+	gatewayServer := grpc.NewServer()
+	relay.RegisterSATPServer(gatewayServer, n.gateway)
+	go gatewayServer.Serve(n.listener)
+	t.Cleanup(gatewayServer.Stop)
+}
+
+func waitForSession(t *testing.T, gateway *satp.Gateway, transferContextId string) *satp.Session {
+	var session *satp.Session
+	require.Eventually(t, func() bool {
+		var err error
+		session, err = gateway.GetSession(transferContextId)
+		require.NoError(t, err)
+		return session != nil && (session.IsCompleted() || session.IsFailed())
+	}, 10*time.Second, 10*time.Millisecond)
+	return session
+}
+
+func TestAssetTransfer(t *testing.T) {
+	network1 := newTestNetwork(t, "network1")
+	network2 := newTestNetwork(t, "network2")
+	receiverStore, err := satp.NewFileSessionStore(t.TempDir())
+	require.NoError(t, err)
+	network1.start(t, network2, satp.NewMemorySessionStore())
+	network2.start(t, network1, receiverStore)
+
+	senderSession, err := network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorId:       "alice",
+		BeneficiaryId:      "bob",
+		RecipientNetworkId: "network2",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+	})
+	require.NoError(t, err)
+	require.Equal(t, satp.SenderRole, senderSession.Role)
+
+	senderSession = waitForSession(t, network1.gateway, senderSession.TransferContextId)
+	require.Empty(t, senderSession.Error)
+	require.Equal(t, satp.StepTransferCompleted, senderSession.Step)
+	require.NotEmpty(t, senderSession.SessionId)
+
+	receiverSession := waitForSession(t, network2.gateway, senderSession.TransferContextId)
+	require.Empty(t, receiverSession.Error)
+	require.Equal(t, satp.ReceiverRole, receiverSession.Role)
+	require.Equal(t, senderSession.SessionId, receiverSession.SessionId)
+	require.Equal(t, "bond01", receiverSession.AssetType)
+	require.Equal(t, "a05", receiverSession.AssetId)
+	require.NotZero(t, receiverSession.LockExpirationSecs)
+
+	// the drivers are told which asset to create and extinguish
+	require.Equal(t, []string{satp.StepPerformLock, satp.StepExtinguish + ":bond01:a05"}, network1.driver.getRequests())
+	require.Equal(t, []string{satp.StepCreateAsset + ":bond01:a05:alice-ecert", satp.StepAssignAsset}, network2.driver.getRequests())
+	// the drivers get the owner's consents to lock the asset and to assign it to the beneficiary
+	require.Equal(t, []string{"lock-a05/alice-signature"}, network1.driver.getConsents())
+	require.Equal(t, []string{"assign-a05-to-bob/alice-assignment-signature"}, network2.driver.getConsents())
+
+	// the session state is persisted by the receiver gateway's store
+	storedSession, err := receiverStore.GetBySessionId(senderSession.SessionId)
+	require.NoError(t, err)
+	require.Equal(t, satp.StepTransferCompleted, storedSession.Step)
+}
+
+func TestRepeatedAssetTransfer(t *testing.T) {
+	network1 := newTestNetwork(t, "network1")
+	network2 := newTestNetwork(t, "network2")
+	network1.start(t, network2, satp.NewMemorySessionStore())
+	network2.start(t, network1, satp.NewMemorySessionStore())
+
+	// identical transfer requests start distinct sessions, told apart by the nonce of their claims
+	request := satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorId:       "alice",
+		BeneficiaryId:      "bob",
+		RecipientNetworkId: "network2",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+	}
+	firstSession, err := network1.gateway.StartTransfer(request)
+	require.NoError(t, err)
+	firstSession = waitForSession(t, network1.gateway, firstSession.TransferContextId)
+	require.Equal(t, satp.StepTransferCompleted, firstSession.Step)
+
+	secondSession, err := network1.gateway.StartTransfer(request)
+	require.NoError(t, err)
+	require.NotEqual(t, firstSession.TransferContextId, secondSession.TransferContextId)
+	secondSession = waitForSession(t, network1.gateway, secondSession.TransferContextId)
+	require.Empty(t, secondSession.Error)
+	require.Equal(t, satp.StepTransferCompleted, secondSession.Step)
+	require.NotEqual(t, firstSession.SessionId, secondSession.SessionId)
+
+	// claims without a nonce are rejected
+	conn, err := grpc.Dial(network2.listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	ack, err := relay.NewSATPClient(conn).TransferProposalClaims(context.Background(), &relay.TransferProposalClaimsRequest{
+		MessageType:               satp.StepTransferProposalClaims,
+		AssetAssetId:              "a05",
+		AssetProfileId:            "bond01",
+		SenderGatewayNetworkId:    "network1",
+		RecipientGatewayNetworkId: "network2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "transfer nonce not specified")
+}
+
+func TestAssetTransferUnknownSender(t *testing.T) {
+	network1 := newTestNetwork(t, "network1")
+	network2 := newTestNetwork(t, "network2")
+	impostor := newTestNetwork(t, "network1")
+	network1.start(t, network2, satp.NewMemorySessionStore())
+	// the receiver gateway trusts a different key for network1
+	network2.start(t, impostor, satp.NewMemorySessionStore())
+
+	_, err := network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+		RecipientNetworkId: "network2",
+	})
+	require.ErrorContains(t, err, "client identity public key does not match")
+
+	_, err = network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+		RecipientNetworkId: "network3",
+	})
+	require.EqualError(t, err, "no gateway configured for network network3")
+
+	_, err = network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		RecipientNetworkId: "network2",
+	})
+	require.EqualError(t, err, "consent of the asset owner needs to be supplied")
+
+	_, err = network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+		RecipientNetworkId: "network2",
+	})
+	require.EqualError(t, err, "public key (ECert) of the asset owner needs to be supplied")
+}
+
+func TestAssetTransferAssignmentFailure(t *testing.T) {
+	network1 := newTestNetwork(t, "network1")
+	network2 := newTestNetwork(t, "network2")
+	network1.start(t, network2, satp.NewMemorySessionStore())
+	network2.start(t, network1, satp.NewMemorySessionStore())
+	network2.driver.assignError = status.Error(codes.Internal, "AssignAssetForSATP failed")
+
+	senderSession, err := network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorId:       "alice",
+		BeneficiaryId:      "bob",
+		RecipientNetworkId: "network2",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+	})
+	require.NoError(t, err)
+
+	// the receiver session fails at the assignment instead of completing
+	receiverSession := waitForSession(t, network2.gateway, senderSession.TransferContextId)
+	require.True(t, receiverSession.IsFailed())
+	require.False(t, receiverSession.IsCompleted())
+	require.Contains(t, receiverSession.Error, "AssignAssetForSATP failed")
+	require.Equal(t, []string{satp.StepCreateAsset + ":bond01:a05:alice-ecert"}, network2.driver.getRequests())
+}
+
+func TestOutOfSequenceMessages(t *testing.T) {
+	network1 := newTestNetwork(t, "network1")
+	network2 := newTestNetwork(t, "network2")
+	network1.start(t, network2, satp.NewMemorySessionStore())
+	network2.start(t, network1, satp.NewMemorySessionStore())
+
+	conn, err := grpc.Dial(network2.listener.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := relay.NewSATPClient(conn)
+
+	// messages of unknown sessions are rejected
+	ack, err := client.CommitPrepare(context.Background(), &relay.CommitPrepareRequest{
+		MessageType:       satp.StepCommitPrepare,
+		SessionId:         "session",
+		TransferContextId: "context",
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "no SATP session found for transfer context context")
+
+	// driver status reports of unknown sessions are rejected
+	statusRequest := &relay.SendAssetStatusRequest{
+		SessionId: "session",
+		Status:    satp.StepCreated,
+	}
+	driverCtx, err := satp.SignAssetStatus(context.Background(), network2.driver.key, statusRequest)
+	require.NoError(t, err)
+	ack, err = client.SendAssetStatus(driverCtx, statusRequest)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "no SATP session found with session ID session")
+
+	// status reports which are not signed by the gateway's driver are rejected
+	ack, err = client.SendAssetStatus(context.Background(), statusRequest)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "driver signature check failed")
+	impostorCtx, err := satp.SignAssetStatus(context.Background(), network1.driver.key, statusRequest)
+	require.NoError(t, err)
+	ack, err = client.SendAssetStatus(impostorCtx, statusRequest)
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "driver signature check failed: invalid signature")
+
+	// a completed session accepts no further messages
+	senderSession, err := network1.gateway.StartTransfer(satp.TransferRequest{
+		AssetType:          "bond01",
+		AssetId:            "a05",
+		OriginatorPubkey:   "alice-ecert",
+		Consent:            "lock-a05",
+		ConsentSignature:   "alice-signature",
+		RecipientNetworkId: "network2",
+	})
+	require.NoError(t, err)
+	senderSession = waitForSession(t, network1.gateway, senderSession.TransferContextId)
+	waitForSession(t, network2.gateway, senderSession.TransferContextId)
+	ack, err = client.CommitPrepare(context.Background(), &relay.CommitPrepareRequest{
+		MessageType:       satp.StepCommitPrepare,
+		SessionId:         senderSession.SessionId,
+		TransferContextId: senderSession.TransferContextId,
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.Ack_ERROR, ack.GetStatus())
+	require.Contains(t, ack.GetMessage(), "out of sequence step CommitPrepare")
+}
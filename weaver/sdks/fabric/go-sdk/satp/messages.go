@@ -0,0 +1,168 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package satp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger-cacti/cacti/weaver/common/protos-go/v3/relay"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// gRPC metadata key carrying the signature of the messages that have no signature field of their own
+const signatureMetadataKey = "satp-signature"
+
+// EncodePublicKey encodes a gateway's public key in the form exchanged in the SATP identity fields
+func EncodePublicKey(publicKey *ecdsa.PublicKey) (string, error) {
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(publicKeyBytes), nil
+}
+
+func marshalMessage(msg proto.Message) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+}
+
+// hashMessage returns the SHA-256 hash of a message in hex form, as used in the hash_prev_message fields
+func hashMessage(msg proto.Message) (string, error) {
+	msgBytes, err := marshalMessage(msg)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(msgBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// messageSignature returns the signature carried in a message, and false if the message has no signature field
+func messageSignature(msg proto.Message) (string, bool) {
+	switch m := msg.(type) {
+	case *relay.TransferCommenceRequest:
+		return m.ClientSignature, true
+	case *relay.AckCommenceRequest:
+		return m.ServerSignature, true
+	case *relay.LockAssertionRequest:
+		return m.ClientSignature, true
+	case *relay.LockAssertionReceiptRequest:
+		return m.ServerSignature, true
+	default:
+		return "", false
+	}
+}
+
+func setMessageSignature(msg proto.Message, signature string) {
+	switch m := msg.(type) {
+	case *relay.TransferCommenceRequest:
+		m.ClientSignature = signature
+	case *relay.AckCommenceRequest:
+		m.ServerSignature = signature
+	case *relay.LockAssertionRequest:
+		m.ClientSignature = signature
+	case *relay.LockAssertionReceiptRequest:
+		m.ServerSignature = signature
+	}
+}
+
+// prevMessageHash returns the hash of the previous message carried in a message, and false if the message has no such field
+func prevMessageHash(msg proto.Message) (string, bool) {
+	switch m := msg.(type) {
+	case *relay.TransferCommenceRequest:
+		return m.HashPrevMessage, true
+	case *relay.AckCommenceRequest:
+		return m.HashPrevMessage, true
+	case *relay.LockAssertionRequest:
+		return m.HashPrevMessage, true
+	case *relay.LockAssertionReceiptRequest:
+		return m.HashPrevMessage, true
+	default:
+		return "", false
+	}
+}
+
+// the bytes that are signed: the message serialized without its signature
+func signedBytes(msg proto.Message) ([]byte, error) {
+	unsignedMsg := proto.Clone(msg)
+	setMessageSignature(unsignedMsg, "")
+	return marshalMessage(unsignedMsg)
+}
+
+/**
+ * Sign a message with the gateway's private key.
+ * The signature is set in the message's signature field if it has one, and returned in base64 form in any case.
+ **/
+func signMessage(privateKey *ecdsa.PrivateKey, msg proto.Message) (string, error) {
+	msgBytes, err := signedBytes(msg)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(msgBytes)
+	signature, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	if err != nil {
+		return "", err
+	}
+	signatureBase64 := base64.StdEncoding.EncodeToString(signature)
+	setMessageSignature(msg, signatureBase64)
+	return signatureBase64, nil
+}
+
+/**
+ * SignAssetStatus signs an asset status report with the driver's private key, returning a context for the
+ * SendAssetStatus call which carries the signature that the gateway checks against its driver public key.
+ **/
+func SignAssetStatus(ctx context.Context, driverKey *ecdsa.PrivateKey, request *relay.SendAssetStatusRequest) (context.Context, error) {
+	signature, err := signMessage(driverKey, request)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, signatureMetadataKey, signature), nil
+}
+
+// verifyMessageSignature checks a base64 encoded signature over a message against a gateway's or driver's public key
+func verifyMessageSignature(publicKey *ecdsa.PublicKey, msg proto.Message, signatureBase64 string) error {
+	signature, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %v", err)
+	}
+	msgBytes, err := signedBytes(msg)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(msgBytes)
+	if !ecdsa.VerifyASN1(publicKey, hash[:], signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// the transfer proposal claims echoed in a receipt, used by the sender gateway to find the session of the receipt
+func claimsFromReceipt(receipt *relay.TransferProposalReceiptRequest) *relay.TransferProposalClaimsRequest {
+	return &relay.TransferProposalClaimsRequest{
+		MessageType:                 StepTransferProposalClaims,
+		AssetAssetId:                receipt.AssetAssetId,
+		AssetProfileId:              receipt.AssetProfileId,
+		VerifiedOriginatorEntityId:  receipt.VerifiedOriginatorEntityId,
+		VerifiedBeneficiaryEntityId: receipt.VerifiedBeneficiaryEntityId,
+		OriginatorPubkey:            receipt.OriginatorPubkey,
+		BeneficiaryPubkey:           receipt.BeneficiaryPubkey,
+		SenderGatewayNetworkId:      receipt.SenderGatewayNetworkId,
+		RecipientGatewayNetworkId:   receipt.RecipientGatewayNetworkId,
+		ClientIdentityPubkey:        receipt.ClientIdentityPubkey,
+		ServerIdentityPubkey:        receipt.ServerIdentityPubkey,
+		SenderGatewayOwnerId:        receipt.SenderGatewayOwnerId,
+		ReceiverGatewayOwnerId:      receipt.ReceiverGatewayOwnerId,
+		TransferNonce:               receipt.TransferNonce,
+	}
+}
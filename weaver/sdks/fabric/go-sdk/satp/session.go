@@ -0,0 +1,234 @@
+/*
+Copyright 2020 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package satp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Role of a gateway in a SATP session
+type Role string
+
+const (
+	SenderRole   Role = "sender"
+	ReceiverRole Role = "receiver"
+)
+
+// Steps of a SATP session. Apart from the gateway-to-gateway messages, a session also records the requests
+// made by a gateway to its driver and the asset status reported back by the driver.
+const (
+	StepTransferProposalClaims  = "TransferProposalClaims"
+	StepTransferProposalReceipt = "TransferProposalReceipt"
+	StepTransferCommence        = "TransferCommence"
+	StepAckCommence             = "AckCommence"
+	StepPerformLock             = "PerformLock"
+	StepLocked                  = "Locked"
+	StepLockAssertion           = "LockAssertion"
+	StepLockAssertionReceipt    = "LockAssertionReceipt"
+	StepCommitPrepare           = "CommitPrepare"
+	StepCreateAsset             = "CreateAsset"
+	StepCreated                 = "Created"
+	StepCommitReady             = "CommitReady"
+	StepExtinguish              = "Extinguish"
+	StepExtinguished            = "Extinguished"
+	StepCommitFinalAssertion    = "CommitFinalAssertion"
+	StepAssignAsset             = "AssignAsset"
+	StepFinalized               = "Finalized"
+	StepAckFinalReceipt         = "AckFinalReceipt"
+	StepTransferCompleted       = "TransferCompleted"
+)
+
+// the order in which the steps of a session happen at each of the two gateways
+var stepSequences = map[Role][]string{
+	SenderRole: {
+		StepTransferProposalClaims, StepTransferProposalReceipt, StepTransferCommence, StepAckCommence,
+		StepPerformLock, StepLocked, StepLockAssertion, StepLockAssertionReceipt,
+		StepCommitPrepare, StepCommitReady, StepExtinguish, StepExtinguished,
+		StepCommitFinalAssertion, StepAckFinalReceipt, StepTransferCompleted,
+	},
+	ReceiverRole: {
+		StepTransferProposalClaims, StepTransferProposalReceipt, StepTransferCommence, StepAckCommence,
+		StepLockAssertion, StepLockAssertionReceipt,
+		StepCommitPrepare, StepCreateAsset, StepCreated, StepCommitReady,
+		StepCommitFinalAssertion, StepAssignAsset, StepFinalized, StepAckFinalReceipt, StepTransferCompleted,
+	},
+}
+
+// Session is the state of an asset transfer kept by each of the two gateways
+type Session struct {
+	TransferContextId  string `json:"transferContextId"`
+	SessionId          string `json:"sessionId"`
+	Role               Role   `json:"role"`
+	AssetType          string `json:"assetType"`
+	AssetId            string `json:"assetId"`
+	SenderNetworkId    string `json:"senderNetworkId"`
+	RecipientNetworkId string `json:"recipientNetworkId"`
+	BeneficiaryId      string `json:"beneficiaryId"`
+	// base64 encoded ECert of the owner of the asset in the origin network, whose consent its assignment requires
+	OriginatorPubkey string `json:"originatorPubkey,omitempty"`
+	// consent of the asset owner to the transfer, passed to the driver of the sender gateway to lock the asset
+	Consent            string `json:"consent,omitempty"`
+	ConsentSignature   string `json:"consentSignature,omitempty"`
+	Step               string `json:"step"`
+	LastMessageHash    string `json:"lastMessageHash"`
+	TransferNumber     uint64 `json:"transferNumber"`
+	LockExpirationSecs uint64 `json:"lockExpirationSecs"`
+	Error              string `json:"error,omitempty"`
+}
+
+// IsCompleted returns true once the last step of the session has happened
+func (s *Session) IsCompleted() bool {
+	return s.Step == StepTransferCompleted
+}
+
+// IsFailed returns true if the session was aborted due to an error
+func (s *Session) IsFailed() bool {
+	return s.Error != ""
+}
+
+// check that a step is the one that follows the current step of the session
+func (s *Session) checkNextStep(step string) error {
+	sequence := stepSequences[s.Role]
+	for i, seqStep := range sequence {
+		if seqStep == s.Step {
+			if i+1 < len(sequence) && sequence[i+1] == step {
+				return nil
+			}
+			break
+		}
+	}
+	return fmt.Errorf("out of sequence step %s in session %s of %s gateway at step %s", step, s.TransferContextId, s.Role, s.Step)
+}
+
+// SessionStore persists the state of SATP sessions
+type SessionStore interface {
+	// Put creates or updates a session, keyed by its transfer context ID
+	Put(session *Session) error
+	// Get returns the session with a transfer context ID, or nil if there is no such session
+	Get(transferContextId string) (*Session, error)
+	// GetBySessionId returns the session with a session ID, or nil if there is no such session
+	GetBySessionId(sessionId string) (*Session, error)
+}
+
+// MemorySessionStore keeps sessions in memory
+type MemorySessionStore struct {
+	mutex    sync.RWMutex
+	sessions map[string]Session
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		sessions: map[string]Session{},
+	}
+}
+
+func (m *MemorySessionStore) Put(session *Session) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sessions[session.TransferContextId] = *session
+	return nil
+}
+
+func (m *MemorySessionStore) Get(transferContextId string) (*Session, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	session, exists := m.sessions[transferContextId]
+	if !exists {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (m *MemorySessionStore) GetBySessionId(sessionId string) (*Session, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	for _, session := range m.sessions {
+		if session.SessionId == sessionId {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+// FileSessionStore keeps every session as a JSON file in a directory, so that sessions survive gateway restarts
+type FileSessionStore struct {
+	mutex sync.RWMutex
+	dir   string
+}
+
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session directory %s: %v", dir, err)
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (f *FileSessionStore) sessionPath(transferContextId string) string {
+	return filepath.Join(f.dir, transferContextId+".json")
+}
+
+func (f *FileSessionStore) Put(session *Session) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first so that a crash never leaves a partially written session behind
+	tmpPath := f.sessionPath(session.TransferContextId) + ".tmp"
+	err = os.WriteFile(tmpPath, sessionJSON, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, f.sessionPath(session.TransferContextId))
+}
+
+func (f *FileSessionStore) readSession(path string) (*Session, error) {
+	sessionJSON, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	session := &Session{}
+	err = json.Unmarshal(sessionJSON, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session in %s: %v", path, err)
+	}
+	return session, nil
+}
+
+func (f *FileSessionStore) Get(transferContextId string) (*Session, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.readSession(f.sessionPath(transferContextId))
+}
+
+func (f *FileSessionStore) GetBySessionId(sessionId string) (*Session, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	paths, err := filepath.Glob(filepath.Join(f.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		session, err := f.readSession(path)
+		if err != nil {
+			return nil, err
+		}
+		if session != nil && session.SessionId == sessionId {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
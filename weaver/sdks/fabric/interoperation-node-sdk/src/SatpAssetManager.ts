@@ -10,14 +10,51 @@
 /** End file docs */
 
 import log4js from "log4js";
+import crypto from "crypto";
 import * as helpers from "./helpers";
 import { Contract } from "fabric-network";
 const logger = log4js.getLogger("InteroperableHelper");
 
+/**
+ * Create a SATP transfer consent, by which an asset owner lets a gateway take custody of an asset in a SATP session
+ * for assignment to a recipient. The consent is created and signed by the owner's application with the owner's
+ * private key (PEM), and both consent and signature are returned in base64, to be passed on to the gateway.
+ **/
+const createTransferConsent = (
+  assetType: string,
+  assetID: string,
+  recipientGatewayECert: string,
+  recipientECert: string,
+  sessionID: string,
+  expiryTimeSecs: number,
+  ownerPrivateKeyPEM: string,
+): { consent: string; signature: string } => {
+  const consentBytes = Buffer.from(
+    JSON.stringify({
+      assetType: assetType,
+      assetId: assetID,
+      recipientGateway: recipientGatewayECert,
+      recipient: recipientECert,
+      sessionId: sessionID,
+      expiryTimeSecs: expiryTimeSecs,
+    }),
+  );
+  const signer = crypto.createSign("SHA256");
+  signer.update(consentBytes);
+  const signature = signer.sign(ownerPrivateKeyPEM);
+  return {
+    consent: consentBytes.toString("base64"),
+    signature: signature.toString("base64"),
+  };
+};
+
 const lockAsset = async (
   contract: Contract,
   assetType: string,
   assetID: string,
+  recipientECert: string,
+  consent: string,
+  consentSignature: string,
   endorsingOrgs: Array<string> = [],
 ): Promise<any> => {
   if (!contract) {
@@ -32,10 +69,25 @@ const lockAsset = async (
     logger.error("Asset ID not supplied");
     return false;
   }
+  if (!recipientECert) {
+    logger.error("Recipient ECert not supplied");
+    return false;
+  }
+
+  if (!consent || !consentSignature) {
+    logger.error("Owner consent not supplied");
+    return false;
+  }
 
   // Normal invoke function
   const tx = contract.createTransaction("LockAssetForSATP");
-  const ccArgs = [assetType, assetID];
+  const ccArgs = [
+    assetType,
+    assetID,
+    recipientECert,
+    consent,
+    consentSignature,
+  ];
   if (endorsingOrgs && endorsingOrgs.length > 0) {
     tx.setEndorsingOrganizations(...endorsingOrgs);
   }
@@ -87,6 +139,8 @@ const assignAsset = async (
   assetType: string,
   assetID: string,
   recipientECert: string,
+  consent: string,
+  consentSignature: string,
   endorsingOrgs: Array<string> = [],
 ): Promise<any> => {
   if (!contract) {
@@ -105,10 +159,20 @@ const assignAsset = async (
     logger.error("Recipient ECert not supplied");
     return false;
   }
+  if (!consent || !consentSignature) {
+    logger.error("Owner consent not supplied");
+    return false;
+  }
 
   // Normal invoke function
   const tx = contract.createTransaction("AssignAssetForSATP");
-  const ccArgs = [assetType, assetID, recipientECert];
+  const ccArgs = [
+    assetType,
+    assetID,
+    recipientECert,
+    consent,
+    consentSignature,
+  ];
   if (endorsingOrgs && endorsingOrgs.length > 0) {
     tx.setEndorsingOrganizations(...endorsingOrgs);
   }
@@ -123,4 +187,4 @@ const assignAsset = async (
   return result;
 };
 
-export { lockAsset, assignAsset, extinguishAsset };
+export { createTransferConsent, lockAsset, assignAsset, extinguishAsset };